@@ -0,0 +1,262 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+package extract
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// the formats of a DATE or TIMESTAMP column that count seconds or
+// milliseconds since the unix epoch instead of using a layout
+const (
+	FormatUnix      = "unix"
+	FormatUnixMilli = "unixmilli"
+)
+
+// ColumnTypes are the column types an extract rule can use
+var ColumnTypes = []string{
+	COLTYPE_TEXT,
+	COLTYPE_VARCHAR,
+	COLTYPE_INT,
+	COLTYPE_BIGINT,
+	COLTYPE_DECIMAL,
+	COLTYPE_DOUBLE,
+	COLTYPE_BOOLEAN,
+	COLTYPE_DATE,
+	COLTYPE_TIMESTAMP,
+	COLTYPE_JSONB,
+}
+
+// ColumnFormats are the suggested formats of a DATE or TIMESTAMP
+// column, any Go time layout holding a year is accepted.  A blank
+// format tries each of the DefaultTimeFormats.
+var ColumnFormats = []string{
+	"2006-01-02",
+	"01/02/2006",
+	"02/01/2006",
+	"20060102",
+	"Jan 2, 2006",
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+	"01/02/2006 15:04:05",
+	FormatUnix,
+	FormatUnixMilli,
+}
+
+// DefaultTimeFormats are tried in order for a DATE or TIMESTAMP column
+// without a format
+var DefaultTimeFormats = []string{
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// the booleans a BOOLEAN column accepts, compared without case
+var booleanValues = map[string]bool{
+	"true": true, "t": true, "yes": true, "y": true, "1": true,
+	"false": false, "f": false, "no": false, "n": false, "0": false,
+}
+
+var decimalRegex = regexp.MustCompile(`^[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+
+var varcharRegex = regexp.MustCompile(`^VARCHAR\(([0-9]+)\)$`)
+
+var parameterRegex = regexp.MustCompile(`^(.*[A-Z])\s*\(\s*[0-9]+\s*(,\s*[0-9]+\s*)?\)$`)
+
+// BaseColumnType returns the upper cased column type without its
+// parameters, DECIMAL(10,2) gives DECIMAL and VARCHAR(32) gives VARCHAR
+func BaseColumnType(columnType string) string {
+	colType := strings.ToUpper(strings.TrimSpace(columnType))
+	if m := parameterRegex.FindStringSubmatch(colType); m != nil {
+		return m[1]
+	}
+	return colType
+}
+
+// legacyColumnTypes are types extract rules were given before column
+// types were checked, by the column type each loads as
+var legacyColumnTypes = map[string]string{
+	"STRING":      COLTYPE_TEXT,
+	"INTEGER":     COLTYPE_INT,
+	"INT4":        COLTYPE_INT,
+	"INT8":        COLTYPE_BIGINT,
+	"NUMERIC":     COLTYPE_DECIMAL,
+	"FLOAT":       COLTYPE_DOUBLE,
+	"FLOAT8":      COLTYPE_DOUBLE,
+	"DOUBLE":      COLTYPE_DOUBLE,
+	"REAL":        COLTYPE_DOUBLE,
+	"BOOL":        COLTYPE_BOOLEAN,
+	"DATETIME":    COLTYPE_TIMESTAMP,
+	"JSON":        COLTYPE_JSONB,
+	"TIMESTAMPTZ": COLTYPE_TIMESTAMP,
+}
+
+// NormalizeColumnType returns the column type a legacy type loads as,
+// NUMERIC(10,2) gives DECIMAL(10,2), other types are returned as given
+func NormalizeColumnType(columnType string) string {
+	colType := strings.ToUpper(strings.TrimSpace(columnType))
+	base := BaseColumnType(colType)
+	normal, ok := legacyColumnTypes[base]
+	if !ok {
+		return columnType
+	}
+	return normal + strings.TrimSpace(colType[len(base):])
+}
+
+// ValidateColumnType returns an error unless columnType is one of the
+// ColumnTypes and format suits it, only DATE and TIMESTAMP columns
+// have a format
+func ValidateColumnType(columnType, format string) error {
+	var found bool
+	for _, t := range ColumnTypes {
+		if BaseColumnType(t) == BaseColumnType(columnType) {
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("column type %q is not one of %s", columnType, strings.Join(ColumnTypes, ", "))
+	}
+	if format == "" {
+		return nil
+	}
+
+	switch BaseColumnType(columnType) {
+	case COLTYPE_DATE, COLTYPE_TIMESTAMP:
+	default:
+		return fmt.Errorf("a %s column does not have a format", columnType)
+	}
+	if format == FormatUnix || format == FormatUnixMilli {
+		return nil
+	}
+	// a layout has to hold the year, and a value written with the
+	// layout has to parse back
+	if !strings.Contains(format, "06") {
+		return fmt.Errorf("format %q does not hold a year, use a Go time layout such as 2006-01-02", format)
+	}
+	ref := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+	if _, err := time.Parse(format, ref.Format(format)); err != nil {
+		return fmt.Errorf("format %q is not a valid time layout %v", format, err)
+	}
+	return nil
+}
+
+// ConvertValue converts an extracted value to the Go type of its
+// column type so it is checked before loading.  INT and BIGINT give an
+// int64, DOUBLE PRECISION a float64, BOOLEAN a bool, DATE and TIMESTAMP
+// a time.Time, and DECIMAL and JSONB a validated string.  A nil, blank
+// or null value of a non text column converts to nil without an error.
+func ConvertValue(columnType, format string, v interface{}) (interface{}, error) {
+	colType := strings.ToUpper(columnType)
+	if v == nil {
+		return nil, nil
+	}
+	if colType == COLTYPE_TEXT {
+		return fmt.Sprintf("%v", v), nil
+	}
+	if m := varcharRegex.FindStringSubmatch(colType); m != nil {
+		s := fmt.Sprintf("%v", v)
+		n, _ := strconv.Atoi(m[1])
+		if utf8.RuneCountInString(s) > n {
+			return nil, fmt.Errorf("value is longer than %s", columnType)
+		}
+		return s, nil
+	}
+
+	s := strings.TrimSpace(fmt.Sprintf("%v", v))
+	if s == "" || s == "null" {
+		return nil, nil
+	}
+
+	// DECIMAL(10,2) converts as a DECIMAL
+	colType = BaseColumnType(colType)
+	switch colType {
+	case COLTYPE_INT, COLTYPE_BIGINT:
+		bits := 64
+		if colType == COLTYPE_INT {
+			bits = 32
+		}
+		// numbers decoded from JSON are float64
+		if f, ok := v.(float64); ok && f == float64(int64(f)) {
+			s = strconv.FormatInt(int64(f), 10)
+		}
+		n, err := strconv.ParseInt(s, 10, bits)
+		if err != nil {
+			return nil, err
+		}
+		return n, nil
+	case COLTYPE_DOUBLE:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, err
+		}
+		return f, nil
+	case COLTYPE_DECIMAL:
+		if !decimalRegex.MatchString(s) {
+			return nil, fmt.Errorf("%q is not a decimal", s)
+		}
+		return s, nil
+	case COLTYPE_BOOLEAN:
+		b, ok := booleanValues[strings.ToLower(s)]
+		if !ok {
+			return nil, fmt.Errorf("%q is not a boolean", s)
+		}
+		return b, nil
+	case COLTYPE_DATE, COLTYPE_TIMESTAMP:
+		if t, ok := v.(time.Time); ok {
+			return t, nil
+		}
+		t, err := parseTime(format, s)
+		if err != nil {
+			return nil, err
+		}
+		if colType == COLTYPE_DATE {
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		}
+		return t, nil
+	case COLTYPE_JSONB:
+		if !json.Valid([]byte(s)) {
+			return nil, fmt.Errorf("value is not valid JSON")
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("column type %q is not supported", columnType)
+}
+
+// parseTime parses a DATE or TIMESTAMP value with its format, each of
+// the DefaultTimeFormats is tried when the format is blank
+func parseTime(format, s string) (t time.Time, err error) {
+	switch format {
+	case FormatUnix, FormatUnixMilli:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return t, err
+		}
+		if format == FormatUnix {
+			return time.Unix(n, 0).UTC(), nil
+		}
+		return time.Unix(0, n*int64(time.Millisecond)).UTC(), nil
+	case "":
+		for _, layout := range DefaultTimeFormats {
+			t, err = time.Parse(layout, s)
+			if err == nil {
+				return t, nil
+			}
+		}
+		return t, err
+	}
+	return time.Parse(format, s)
+}
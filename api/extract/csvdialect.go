@@ -0,0 +1,117 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+package extract
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// character encodings supported for CSV files
+const (
+	CharsetUTF8        = "utf-8"
+	CharsetLatin1      = "latin-1"
+	CharsetUTF16       = "utf-16"
+	CharsetUTF16LE     = "utf-16le"
+	CharsetUTF16BE     = "utf-16be"
+	CharsetWindows1252 = "windows-1252"
+)
+
+// CSVDialect holds the parsed CSV settings of an extract source
+type CSVDialect struct {
+	Delimiter  rune
+	Quote      rune
+	Comment    rune
+	LazyQuotes bool
+	// Charset is nil when the file is already UTF-8
+	Charset encoding.Encoding
+}
+
+// GetCSVDialect parses and validates the CSV settings of an extract
+// source, blank values fall back to the encoding/csv defaults
+func GetCSVDialect(delimiter, quote, comment string, lazyQuotes bool, charset string) (d CSVDialect, err error) {
+	d = CSVDialect{
+		Delimiter:  ',',
+		Quote:      '"',
+		LazyQuotes: lazyQuotes,
+	}
+
+	if delimiter != "" {
+		d.Delimiter, err = parseCSVRune("delimiter", delimiter)
+		if err != nil {
+			return d, err
+		}
+	}
+	if quote != "" {
+		d.Quote, err = parseCSVRune("quote", quote)
+		if err != nil {
+			return d, err
+		}
+	}
+	if comment != "" {
+		d.Comment, err = parseCSVRune("comment", comment)
+		if err != nil {
+			return d, err
+		}
+	}
+
+	if d.Delimiter == d.Quote {
+		return d, fmt.Errorf("csv delimiter and quote can not be the same character")
+	}
+	if d.Comment != 0 && (d.Comment == d.Delimiter || d.Comment == d.Quote) {
+		return d, fmt.Errorf("csv comment can not be the same character as the delimiter or quote")
+	}
+
+	d.Charset, err = GetCharset(charset)
+	return d, err
+}
+
+// GetCharset returns the decoder for a character encoding name, nil
+// is returned for UTF-8 which needs no decoding
+func GetCharset(name string) (encoding.Encoding, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", CharsetUTF8, "utf8":
+		return nil, nil
+	case CharsetLatin1, "latin1", "iso-8859-1":
+		return charmap.ISO8859_1, nil
+	case CharsetWindows1252, "cp1252":
+		return charmap.Windows1252, nil
+	case CharsetUTF16:
+		// a byte order mark decides the endianness, little endian
+		// is assumed when there is none
+		return unicode.UTF16(unicode.LittleEndian, unicode.UseBOM), nil
+	case CharsetUTF16LE:
+		return unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM), nil
+	case CharsetUTF16BE:
+		return unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM), nil
+	}
+	return nil, fmt.Errorf("character encoding %s is not supported", name)
+}
+
+// parseCSVRune accepts a single character, "tab" and \t are accepted
+// as names for the tab character
+func parseCSVRune(field, value string) (rune, error) {
+	if value == "tab" || value == `\t` {
+		return '\t', nil
+	}
+	if utf8.RuneCountInString(value) != 1 {
+		return 0, fmt.Errorf("csv %s must be a single character", field)
+	}
+	r, _ := utf8.DecodeRuneInString(value)
+	if r == utf8.RuneError || r == '\r' || r == '\n' {
+		return 0, fmt.Errorf("csv %s is not a valid character", field)
+	}
+	return r, nil
+}
@@ -10,18 +10,27 @@
 package extract
 
 const (
-	XLSXScheme      = "xlsx"
-	CSVScheme       = "csv"
-	XMLScheme       = "xml"
-	JSONScheme      = "json"
-	JSONPathScheme  = "jsonpath"
-	FinnHubScheme   = "finnhub-stocks"
-	APIScheme       = "api"
-	HTTPPostScheme  = "httppost"
-	COLTYPE_TEXT    = "TEXT"
-	COLTYPE_VARCHAR = "VARCHAR(32)"
-	COLTYPE_INT     = "INT"
-	COLTYPE_DECIMAL = "DECIMAL"
+	XLSXScheme        = "xlsx"
+	CSVScheme         = "csv"
+	XMLScheme         = "xml"
+	JSONScheme        = "json"
+	JSONPathScheme    = "jsonpath"
+	FinnHubScheme     = "finnhub-stocks"
+	APIScheme         = "api"
+	HTTPPostScheme    = "httppost"
+	ParquetScheme     = "parquet"
+	NDJSONScheme      = "ndjson"
+	FixedWidthScheme  = "fixedwidth"
+	COLTYPE_TEXT      = "TEXT"
+	COLTYPE_VARCHAR   = "VARCHAR(32)"
+	COLTYPE_INT       = "INT"
+	COLTYPE_BIGINT    = "BIGINT"
+	COLTYPE_DECIMAL   = "DECIMAL"
+	COLTYPE_DOUBLE    = "DOUBLE PRECISION"
+	COLTYPE_BOOLEAN   = "BOOLEAN"
+	COLTYPE_DATE      = "DATE"
+	COLTYPE_TIMESTAMP = "TIMESTAMP"
+	COLTYPE_JSONB     = "JSONB"
 )
 
 type LoaderMessage struct {
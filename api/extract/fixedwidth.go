@@ -0,0 +1,40 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+package extract
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseFixedWidthRange parses a fixedwidth column path such as 10-24,
+// the positions are 1-based and inclusive of both ends
+func ParseFixedWidthRange(path string) (start, end int, err error) {
+	parts := strings.Split(strings.TrimSpace(path), "-")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("fixedwidth path %q must be a range like 10-24", path)
+	}
+	start, err = strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("fixedwidth path %q has an invalid start %v", path, err)
+	}
+	end, err = strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("fixedwidth path %q has an invalid end %v", path, err)
+	}
+	if start < 1 {
+		return 0, 0, fmt.Errorf("fixedwidth path %q must start at position 1 or greater", path)
+	}
+	if end < start {
+		return 0, 0, fmt.Errorf("fixedwidth path %q ends before it starts", path)
+	}
+	return start, end, nil
+}
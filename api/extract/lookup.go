@@ -0,0 +1,47 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+package extract
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ColumnPair pairs a column of an extracted row with a column of the
+// reference data of a lookup
+type ColumnPair struct {
+	Column    string
+	Reference string
+}
+
+// ParseColumnPairs parses a comma separated list of column=reference
+// pairs, a name alone pairs columns of the same name
+func ParseColumnPairs(s string) ([]ColumnPair, error) {
+	pairs := make([]ColumnPair, 0)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		p := ColumnPair{Column: item, Reference: item}
+		if i := strings.Index(item, "="); i >= 0 {
+			p.Column = strings.TrimSpace(item[:i])
+			p.Reference = strings.TrimSpace(item[i+1:])
+		}
+		if p.Column == "" || p.Reference == "" {
+			return nil, fmt.Errorf("column pair %q must be column=reference", item)
+		}
+		pairs = append(pairs, p)
+	}
+	if len(pairs) == 0 {
+		return nil, fmt.Errorf("at least one column is required")
+	}
+	return pairs, nil
+}
@@ -20,6 +20,9 @@ type Source struct {
 	Password  string `json:"password"`
 	Database  string `json:"database"`
 	Tablename string `json:"tablename"`
+	// Externaldatabase is the database to connect to on an externally
+	// provided postgres server, Database then names a schema within it
+	Externaldatabase string `json:"externaldatabase,omitempty"`
 }
 
 type DBCreds struct {
@@ -54,12 +57,21 @@ type ExtractRuleDefinition struct {
 	ColumnType            string `json:"columntype"`
 	MatchValues           string `json:"matchvalues"`
 	TransformFunctionName string `json:"transformfunctionname"`
+	Keycolumn             bool   `json:"keycolumn,omitempty"`
+	ColumnFormat          string `json:"columnformat,omitempty"`
+	Matchregex            string `json:"matchregex,omitempty"`
+	Notnull               bool   `json:"notnull,omitempty"`
+	Minvalue              string `json:"minvalue,omitempty"`
+	Maxvalue              string `json:"maxvalue,omitempty"`
+	Unique                bool   `json:"unique,omitempty"`
+	Privacypolicy         string `json:"privacypolicy,omitempty"`
 }
 
 type TransformFunction struct {
 	ID     string `json:"id"`
 	Name   string `json:"name"`
 	Source string `json:"source"`
+	Shape  string `json:"shape,omitempty"`
 }
 
 type ExtensionDefinition struct {
@@ -69,6 +81,26 @@ type ExtensionDefinition struct {
 	Extensionpath   string `json:"extensionpath"`
 }
 
+type LookupDefinition struct {
+	ID              string `json:"id"`
+	Extractsourceid string `json:"extractsourceid"`
+	Lookupname      string `json:"lookupname"`
+	Referencetable  string `json:"referencetable,omitempty"`
+	Referencefile   string `json:"referencefile,omitempty"`
+	Matchcolumns    string `json:"matchcolumns"`
+	Columns         string `json:"columns"`
+}
+
+type RouteDefinition struct {
+	ID              string `json:"id"`
+	Extractsourceid string `json:"extractsourceid"`
+	Routename       string `json:"routename"`
+	Position        int    `json:"position"`
+	Predicate       string `json:"predicate"`
+	Targettable     string `json:"targettable,omitempty"`
+	Drop            bool   `json:"drop,omitempty"`
+}
+
 type ExtractSourceDefinition struct {
 	ID             string `json:"id"`
 	Name           string `json:"name"`
@@ -84,6 +116,20 @@ type ExtractSourceDefinition struct {
 	Encoding       string `json:"encoding"`
 	Transport      string `json:"transport"`
 	Servicetype    string `json:"servicetype"`
+	Delimiter      string `json:"delimiter,omitempty"`
+	Quote          string `json:"quote,omitempty"`
+	Comment        string `json:"comment,omitempty"`
+	Lazyquotes     bool   `json:"lazyquotes,omitempty"`
+	Charset        string `json:"charset,omitempty"`
+	Batchrows      int    `json:"batchrows,omitempty"`
+	Batchbytes     int    `json:"batchbytes,omitempty"`
+	Copythreshold  int    `json:"copythreshold,omitempty"`
+	Loadmode       string `json:"loadmode,omitempty"`
+	// Reloadduplicates, Rowhash and Dedupwindow control the
+	// duplicate files and rows that are loaded
+	Reloadduplicates bool `json:"reloadduplicates,omitempty"`
+	Rowhash          bool `json:"rowhash,omitempty"`
+	Dedupwindow      int  `json:"dedupwindow,omitempty"`
 }
 
 // PipelineSpec defines the desired state of Pipeline
@@ -123,6 +169,8 @@ type PipelineSpec struct {
 	Extractsources      []ExtractSourceDefinition `json:"extractsources,omitempty"`
 	Extensions          []ExtensionDefinition     `json:"extensions,omitempty"`
 	Extractrules        []ExtractRuleDefinition   `json:"extractrules,omitempty"`
+	Lookups             []LookupDefinition        `json:"lookups,omitempty"`
+	Routes              []RouteDefinition         `json:"routes,omitempty"`
 }
 
 // PipelineStatus defines the observed state of Pipeline
@@ -89,6 +89,16 @@ func (in *PipelineSpec) DeepCopyInto(out *PipelineSpec) {
 		*out = make([]ExtractSourceDefinition, len(*in))
 		copy(*out, *in)
 	}
+	if in.Lookups != nil {
+		in, out := &in.Lookups, &out.Lookups
+		*out = make([]LookupDefinition, len(*in))
+		copy(*out, *in)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]RouteDefinition, len(*in))
+		copy(*out, *in)
+	}
 
 }
 
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/churrodata/churro/internal/ctl"
+	"github.com/churrodata/churro/internal/transform"
 	"github.com/churrodata/churro/pkg"
 	pb "github.com/churrodata/churro/rpc/ctl"
 	_ "github.com/go-sql-driver/mysql"
@@ -31,6 +32,10 @@ import (
 )
 
 func main() {
+	// a dry run of a transform function runs this executable as its
+	// sandbox
+	transform.ServeSandbox()
+
 	zerolog.TimeFieldFormat = time.RFC822
 	log.Logger = log.With().Caller().Logger()
 
@@ -32,7 +32,7 @@ func main() {
 	log.Logger = log.With().Caller().Logger()
 
 	log.Info().Msg("testdatabases")
-	dbTypeFlag := flag.String("dbtype", domain.DatabaseMysql, "either mysql, singlestore, or cockroachdb")
+	dbTypeFlag := flag.String("dbtype", domain.DatabaseMysql, "either mysql, singlestore, postgres, sqlite, clickhouse or cockroachdb")
 
 	flag.Parse()
 
@@ -76,6 +76,39 @@ func main() {
 			Username: "admin",
 			Password: "secretpass",
 		}
+	} else if *dbTypeFlag == domain.DatabasePostgres {
+		creds = config.DBCredentials{
+			Username: "postgres",
+			// base64 of not-so-secure
+			Password: "bm90LXNvLXNlY3VyZQ==",
+		}
+		source = v1alpha1.Source{
+			Host:             "127.0.0.1",
+			Port:             5432,
+			Database:         "public",
+			Externaldatabase: "postgres",
+			Username:         "postgres",
+			Password:         "bm90LXNvLXNlY3VyZQ==",
+		}
+	} else if *dbTypeFlag == domain.DatabaseClickhouse {
+		creds = config.DBCredentials{
+			Username: "default",
+			// base64 of not-so-secure
+			Password: "bm90LXNvLXNlY3VyZQ==",
+		}
+		source = v1alpha1.Source{
+			Host:     "127.0.0.1",
+			Port:     9000,
+			Database: "default",
+			Username: "default",
+			Password: "bm90LXNvLXNlY3VyZQ==",
+		}
+	} else if *dbTypeFlag == domain.DatabaseSqlite {
+		source = v1alpha1.Source{
+			Path:     "/tmp/churro-test-databases",
+			Database: "testadmin",
+			Username: "sqlite",
+		}
 	} else if *dbTypeFlag == domain.DatabaseCockroach {
 		creds = config.DBCredentials{
 			//Username: "root",
@@ -4,9 +4,11 @@ go 1.16
 
 require (
 	aqwari.net/xml v0.0.0-20210331023308-d9421b293817
+	github.com/ClickHouse/clickhouse-go v1.5.4
 	github.com/go-logr/logr v0.4.0
 	github.com/go-sql-driver/mysql v1.6.0
 	github.com/gorilla/mux v1.8.0
+	github.com/klauspost/compress v1.13.1
 	github.com/lib/pq v1.3.0
 	github.com/mattn/go-sqlite3 v1.14.5
 	github.com/ohler55/ojg v1.12.4
@@ -17,8 +19,11 @@ require (
 	github.com/rs/zerolog v1.23.0
 	github.com/santhosh-tekuri/jsonschema/v3 v3.0.1
 	github.com/traefik/yaegi v0.9.21
+	github.com/xitongsys/parquet-go v1.6.2
+	github.com/xitongsys/parquet-go-source v0.0.0-20220315005136-aec0fe3e777c
 	github.com/xuri/excelize/v2 v2.4.1
 	golang.org/x/sys v0.0.0-20210630005230-0f9fa26af87c
+	golang.org/x/text v0.3.6
 	google.golang.org/genproto v0.0.0-20201119123407-9b1e624d6bc4 // indirect
 	google.golang.org/grpc v1.33.2
 	google.golang.org/protobuf v1.27.1
@@ -0,0 +1,296 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package archive reads compressed files and archive members so that
+// extract sources can process vendor drops without unpacking them
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/bzip2"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// compression suffixes that are decompressed on the fly
+const (
+	GzipSuffix  = ".gz"
+	ZstdSuffix  = ".zst"
+	Bzip2Suffix = ".bz2"
+)
+
+// archive suffixes that fan out one extract job per member
+const (
+	ZipSuffix   = ".zip"
+	TarSuffix   = ".tar"
+	TarGzSuffix = ".tar.gz"
+	TgzSuffix   = ".tgz"
+)
+
+// ProcessedSuffix is appended to an archive once its members are
+// queued, the members are still read from the renamed archive
+const ProcessedSuffix = ".churro-processed"
+
+// IsArchive returns true when the file name is a zip or tar archive
+func IsArchive(name string) bool {
+	n := strings.ToLower(name)
+	return strings.HasSuffix(n, ZipSuffix) ||
+		strings.HasSuffix(n, TarSuffix) ||
+		strings.HasSuffix(n, TarGzSuffix) ||
+		strings.HasSuffix(n, TgzSuffix)
+}
+
+// IsCompressed returns true when the file name is a single compressed
+// file such as data.csv.gz
+func IsCompressed(name string) bool {
+	return !IsArchive(name) && StripCompression(name) != name
+}
+
+// StripCompression removes a compression suffix from a file name so
+// that data.csv.gz can be matched against an extract source regex
+// written for data.csv
+func StripCompression(name string) string {
+	if IsArchive(name) {
+		return name
+	}
+	n := strings.ToLower(name)
+	for _, suffix := range []string{GzipSuffix, ZstdSuffix, Bzip2Suffix} {
+		if strings.HasSuffix(n, suffix) {
+			return name[:len(name)-len(suffix)]
+		}
+	}
+	return name
+}
+
+// Members returns the regular files within an archive whose base
+// name matches the regex
+func Members(archivePath, regex string) (members []string, err error) {
+	re, err := regexp.Compile(regex)
+	if err != nil {
+		return members, err
+	}
+
+	match := func(name string) bool {
+		return re.MatchString(StripCompression(path.Base(name)))
+	}
+
+	if isZip(archivePath) {
+		zr, err := zip.OpenReader(archivePath)
+		if err != nil {
+			return members, err
+		}
+		defer zr.Close()
+		for _, f := range zr.File {
+			if !f.FileInfo().IsDir() && match(f.Name) {
+				members = append(members, f.Name)
+			}
+		}
+		return members, nil
+	}
+
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return members, err
+	}
+	defer f.Close()
+
+	tr, closer, err := newTarReader(archivePath, f)
+	if err != nil {
+		return members, err
+	}
+	defer closer.Close()
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return members, err
+		}
+		if hdr.Typeflag == tar.TypeReg && match(hdr.Name) {
+			members = append(members, hdr.Name)
+		}
+	}
+	return members, nil
+}
+
+// Open opens a file for reading, when member is not blank the member
+// of the archive is read instead of the archive itself.  The content
+// is decompressed on the fly based on the file or member name.
+func Open(filePath, member string) (io.ReadCloser, error) {
+	if member == "" {
+		f, err := os.Open(filePath)
+		if err != nil {
+			return nil, err
+		}
+		return decompress(filePath, &multiCloser{Reader: f, closers: []io.Closer{f}})
+	}
+
+	if isZip(filePath) {
+		zr, err := zip.OpenReader(filePath)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range zr.File {
+			if f.Name == member {
+				rc, err := f.Open()
+				if err != nil {
+					zr.Close()
+					return nil, err
+				}
+				return decompress(member, &multiCloser{Reader: rc, closers: []io.Closer{rc, zr}})
+			}
+		}
+		zr.Close()
+		return nil, fmt.Errorf("member %s not found in archive %s", member, filePath)
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	tr, closer, err := newTarReader(filePath, f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			closer.Close()
+			f.Close()
+			return nil, err
+		}
+		if hdr.Name == member {
+			return decompress(member, &multiCloser{Reader: tr, closers: []io.Closer{closer, f}})
+		}
+	}
+	closer.Close()
+	f.Close()
+	return nil, fmt.Errorf("member %s not found in archive %s", member, filePath)
+}
+
+// Spool returns a local path holding the decompressed content for
+// readers that need random access, such as parquet and xlsx.  The
+// original path is returned when no decompression is needed.  The
+// returned cleanup function removes any temporary file.
+func Spool(filePath, member string) (localPath string, cleanup func(), err error) {
+	cleanup = func() {}
+	if member == "" && !IsCompressed(filePath) {
+		return filePath, cleanup, nil
+	}
+
+	name := filePath
+	if member != "" {
+		name = member
+	}
+
+	rc, err := Open(filePath, member)
+	if err != nil {
+		return "", cleanup, err
+	}
+	defer rc.Close()
+
+	// the temp file keeps the original extension, some readers
+	// use it to pick a format
+	tmp, err := ioutil.TempFile("", "churro-*-"+path.Base(StripCompression(name)))
+	if err != nil {
+		return "", cleanup, err
+	}
+	_, err = io.Copy(tmp, rc)
+	closeErr := tmp.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return "", cleanup, err
+	}
+
+	return tmp.Name(), func() { os.Remove(tmp.Name()) }, nil
+}
+
+func decompress(name string, rc *multiCloser) (io.ReadCloser, error) {
+	n := strings.ToLower(name)
+	switch {
+	case IsArchive(n):
+		return rc, nil
+	case strings.HasSuffix(n, GzipSuffix):
+		gz, err := gzip.NewReader(rc.Reader)
+		if err != nil {
+			rc.Close()
+			return nil, err
+		}
+		rc.Reader = gz
+		rc.closers = append([]io.Closer{gz}, rc.closers...)
+	case strings.HasSuffix(n, ZstdSuffix):
+		zr, err := zstd.NewReader(rc.Reader)
+		if err != nil {
+			rc.Close()
+			return nil, err
+		}
+		rc.Reader = zr
+		rc.closers = append([]io.Closer{zr.IOReadCloser()}, rc.closers...)
+	case strings.HasSuffix(n, Bzip2Suffix):
+		rc.Reader = bzip2.NewReader(rc.Reader)
+	}
+	return rc, nil
+}
+
+// archiveFormat returns the lower cased archive name without the
+// processed suffix, which picks the archive format
+func archiveFormat(archivePath string) string {
+	return strings.TrimSuffix(strings.ToLower(archivePath), ProcessedSuffix)
+}
+
+func isZip(archivePath string) bool {
+	return strings.HasSuffix(archiveFormat(archivePath), ZipSuffix)
+}
+
+func newTarReader(archivePath string, r io.Reader) (*tar.Reader, io.Closer, error) {
+	n := archiveFormat(archivePath)
+	if strings.HasSuffix(n, TarGzSuffix) || strings.HasSuffix(n, TgzSuffix) {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		return tar.NewReader(gz), gz, nil
+	}
+	return tar.NewReader(r), ioutil.NopCloser(nil), nil
+}
+
+// multiCloser reads from the outermost reader and closes every layer
+// beneath it
+type multiCloser struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (m *multiCloser) Close() (err error) {
+	for _, c := range m.closers {
+		if cerr := c.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
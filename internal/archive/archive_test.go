@@ -0,0 +1,197 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const archiveTestData = "num,city\n2,boerne\n"
+
+// bzip2 compressed archiveTestData, the standard library has no
+// bzip2 writer
+var archiveTestBzip2 = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0x79, 0x2d,
+	0x91, 0x58, 0x00, 0x00, 0x06, 0x59, 0x80, 0x00, 0x10, 0x00, 0x04, 0x10,
+	0x00, 0x1a, 0x23, 0x96, 0x20, 0x20, 0x00, 0x31, 0x00, 0x00, 0x0a, 0x60,
+	0x01, 0xa0, 0x75, 0x95, 0x6f, 0x55, 0xd0, 0xd7, 0xcb, 0xd9, 0x1c, 0x2e,
+	0xe4, 0x8a, 0x70, 0xa1, 0x20, 0xf2, 0x5b, 0x22, 0xb0,
+}
+
+func TestCompressedFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "churroarchivetest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	gw.Write([]byte(archiveTestData))
+	gw.Close()
+
+	var zs bytes.Buffer
+	zw, err := zstd.NewWriter(&zs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw.Write([]byte(archiveTestData))
+	zw.Close()
+
+	files := map[string][]byte{
+		"data.csv":     []byte(archiveTestData),
+		"data.csv.gz":  gz.Bytes(),
+		"data.csv.zst": zs.Bytes(),
+		"data.csv.bz2": archiveTestBzip2,
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(p, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		rc, err := Open(p, "")
+		if err != nil {
+			t.Fatalf("archive.Open %s Error: %v", name, err)
+		}
+		b, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("archive.Open %s read Error: %v", name, err)
+		}
+		if string(b) != archiveTestData {
+			t.Fatalf("archive.Open %s got %q", name, string(b))
+		}
+
+		local, cleanup, err := Spool(p, "")
+		if err != nil {
+			t.Fatalf("archive.Spool %s Error: %v", name, err)
+		}
+		b, err = ioutil.ReadFile(local)
+		if err != nil || string(b) != archiveTestData {
+			t.Fatalf("archive.Spool %s got %q %v", name, string(b), err)
+		}
+		cleanup()
+		if local != p {
+			if _, err := os.Stat(local); !os.IsNotExist(err) {
+				t.Fatalf("archive.Spool %s temp file was not removed", name)
+			}
+		}
+
+		if StripCompression(name) != "data.csv" {
+			t.Fatalf("archive.StripCompression %s got %s", name, StripCompression(name))
+		}
+	}
+}
+
+func TestArchiveMembers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "churroarchivetest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	gw.Write([]byte(archiveTestData))
+	gw.Close()
+
+	members := map[string][]byte{
+		"drop/one.csv":    []byte(archiveTestData),
+		"drop/two.csv.gz": gz.Bytes(),
+		"drop/readme.txt": []byte("not data"),
+	}
+
+	zipPath := filepath.Join(dir, "drop.zip")
+	zf, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(zf)
+	for name, content := range members {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.Write(content)
+	}
+	zw.Close()
+	zf.Close()
+
+	tarPath := filepath.Join(dir, "drop.tar.gz")
+	tf, err := os.Create(tarPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tgz := gzip.NewWriter(tf)
+	tw := tar.NewWriter(tgz)
+	for name, content := range members {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		tw.Write(content)
+	}
+	tw.Close()
+	tgz.Close()
+	tf.Close()
+
+	for _, p := range []string{zipPath, tarPath} {
+		if !IsArchive(p) {
+			t.Fatalf("archive.IsArchive %s expected true", p)
+		}
+
+		found, err := Members(p, "[a-z,0-9].(csv)$")
+		if err != nil {
+			t.Fatalf("archive.Members %s Error: %v", p, err)
+		}
+		if len(found) != 2 {
+			t.Fatalf("archive.Members %s expected 2 members got %v", p, found)
+		}
+
+		for _, m := range found {
+			rc, err := Open(p, m)
+			if err != nil {
+				t.Fatalf("archive.Open %s %s Error: %v", p, m, err)
+			}
+			b, err := ioutil.ReadAll(rc)
+			rc.Close()
+			if err != nil || string(b) != archiveTestData {
+				t.Fatalf("archive.Open %s %s got %q %v", p, m, string(b), err)
+			}
+		}
+
+		_, err = Open(p, "drop/missing.csv")
+		if err == nil {
+			t.Fatalf("archive.Open %s expected an error for a missing member", p)
+		}
+
+		// members are queued after the archive is renamed as processed
+		processed := p + ProcessedSuffix
+		if err := os.Rename(p, processed); err != nil {
+			t.Fatal(err)
+		}
+		found, err = Members(processed, "[a-z,0-9].(csv)$")
+		if err != nil || len(found) != 2 {
+			t.Fatalf("archive.Members %s got %v %v", processed, found, err)
+		}
+		for _, m := range found {
+			rc, err := Open(processed, m)
+			if err != nil {
+				t.Fatalf("archive.Open %s %s Error: %v", processed, m, err)
+			}
+			b, err := ioutil.ReadAll(rc)
+			rc.Close()
+			if err != nil || string(b) != archiveTestData {
+				t.Fatalf("archive.Open %s %s got %q %v", processed, m, string(b), err)
+			}
+		}
+	}
+}
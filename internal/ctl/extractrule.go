@@ -15,11 +15,17 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 
 	extractapi "github.com/churrodata/churro/api/extract"
 	"github.com/churrodata/churro/api/v1alpha1"
+	"github.com/churrodata/churro/internal/db"
 	"github.com/churrodata/churro/internal/domain"
+	"github.com/churrodata/churro/internal/privacy"
+	"github.com/churrodata/churro/internal/transform"
 	"github.com/churrodata/churro/pkg"
 	pb "github.com/churrodata/churro/rpc/ctl"
 	"github.com/ohler55/ojg/jp"
@@ -41,23 +47,11 @@ func (s *Server) CreateExtractRule(ctx context.Context, request *pb.CreateExtrac
 		return nil, status.Errorf(codes.InvalidArgument,
 			err.Error())
 	}
+	rule.ColumnType = extractapi.NormalizeColumnType(rule.ColumnType)
 
-	if rule.ExtractSourceID == "" {
-		return nil, status.Errorf(codes.InvalidArgument,
-			"extract rule extract source ID is required")
-
-	}
-	if rule.ColumnName == "" {
-		return nil, status.Errorf(codes.InvalidArgument,
-			"extract rule column name is required")
-	}
-	if rule.ColumnPath == "" {
-		return nil, status.Errorf(codes.InvalidArgument,
-			"extract rule source is required")
-	}
-	if rule.ColumnType == "" {
-		return nil, status.Errorf(codes.InvalidArgument,
-			"extract rule type is required")
+	err = checkExtractRule(rule)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
 
 	_, config, err := pkg.GetKubeClient()
@@ -87,7 +81,16 @@ func (s *Server) CreateExtractRule(ctx context.Context, request *pb.CreateExtrac
 		}
 	}
 
-	err = validateRulePath(rule.ColumnPath, wdir.Scheme)
+	err = validateRulePath(rule.ColumnPath, wdir.Scheme, rule.ColumnType, rule.ColumnFormat, "")
+	if err == nil {
+		err = validateQualityRules(rule)
+	}
+	if err == nil {
+		err = transform.ValidateBuiltin(rule.TransformFunction)
+	}
+	if err == nil {
+		err = validatePrivacyPolicy(rule, rule.ColumnType)
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
@@ -106,6 +109,14 @@ func (s *Server) CreateExtractRule(ctx context.Context, request *pb.CreateExtrac
 		ColumnType:            rule.ColumnType,
 		MatchValues:           rule.MatchValues,
 		TransformFunctionName: rule.TransformFunction,
+		Keycolumn:             rule.KeyColumn,
+		ColumnFormat:          rule.ColumnFormat,
+		Matchregex:            rule.MatchRegex,
+		Notnull:               rule.NotNull,
+		Minvalue:              rule.MinValue,
+		Maxvalue:              rule.MaxValue,
+		Unique:                rule.Unique,
+		Privacypolicy:         rule.PrivacyPolicy,
 	}
 	pipelineToUpdate.Spec.Extractrules = append(pipelineToUpdate.Spec.Extractrules, x)
 
@@ -115,6 +126,8 @@ func (s *Server) CreateExtractRule(ctx context.Context, request *pb.CreateExtrac
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
 
+	s.recordSchemaVersion(pipelineToUpdate, rule.ExtractSourceID)
+
 	response.ID = rule.ID
 	return response, nil
 }
@@ -147,8 +160,10 @@ func (s *Server) DeleteExtractRule(ctx context.Context, request *pb.DeleteExtrac
 		return nil, status.Errorf(codes.InvalidArgument, "no extract rules exist")
 	}
 
+	var extractSourceID string
 	for i := 0; i < len(pipelineToUpdate.Spec.Extractrules); i++ {
 		if pipelineToUpdate.Spec.Extractrules[i].ID == request.ExtractRuleID {
+			extractSourceID = pipelineToUpdate.Spec.Extractrules[i].Extractsourceid
 			// removes the extract rule from the array
 			pipelineToUpdate.Spec.Extractrules = append(pipelineToUpdate.Spec.Extractrules[:i], pipelineToUpdate.Spec.Extractrules[i+1:]...)
 		}
@@ -160,6 +175,8 @@ func (s *Server) DeleteExtractRule(ctx context.Context, request *pb.DeleteExtrac
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
 
+	s.recordSchemaVersion(pipelineToUpdate, extractSourceID)
+
 	return response, nil
 }
 
@@ -175,6 +192,7 @@ func (s *Server) UpdateExtractRule(ctx context.Context, request *pb.UpdateExtrac
 		return nil, status.Errorf(codes.InvalidArgument,
 			err.Error())
 	}
+	rule.ColumnType = extractapi.NormalizeColumnType(rule.ColumnType)
 
 	log.Info().Msg(fmt.Sprintf("in updateextractrule got rule %+v\n", rule))
 
@@ -204,7 +222,28 @@ func (s *Server) UpdateExtractRule(ctx context.Context, request *pb.UpdateExtrac
 		}
 	}
 
-	err = validateRulePath(rule.ColumnPath, wdir.Scheme)
+	// a rule updated without a column type keeps its type
+	columnType := rule.ColumnType
+	var storedType string
+	for i := 0; i < len(pipelineToUpdate.Spec.Extractrules); i++ {
+		if pipelineToUpdate.Spec.Extractrules[i].ID == rule.ID {
+			storedType = pipelineToUpdate.Spec.Extractrules[i].ColumnType
+		}
+	}
+	if columnType == "" {
+		columnType = extractapi.NormalizeColumnType(storedType)
+	}
+
+	err = validateRulePath(rule.ColumnPath, wdir.Scheme, columnType, rule.ColumnFormat, extractapi.NormalizeColumnType(storedType))
+	if err == nil {
+		err = validateQualityRules(rule)
+	}
+	if err == nil {
+		err = transform.ValidateBuiltin(rule.TransformFunction)
+	}
+	if err == nil {
+		err = validatePrivacyPolicy(rule, columnType)
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
@@ -218,13 +257,25 @@ func (s *Server) UpdateExtractRule(ctx context.Context, request *pb.UpdateExtrac
 		if pipelineToUpdate.Spec.Extractrules[i].ID == rule.ID {
 			pipelineToUpdate.Spec.Extractrules[i].ColumnName = rule.ColumnName
 			pipelineToUpdate.Spec.Extractrules[i].ColumnPath = rule.ColumnPath
+			if rule.ColumnType != "" {
+				pipelineToUpdate.Spec.Extractrules[i].ColumnType = rule.ColumnType
+			}
 			pipelineToUpdate.Spec.Extractrules[i].MatchValues = rule.MatchValues
 			pipelineToUpdate.Spec.Extractrules[i].TransformFunctionName = rule.TransformFunction
+			pipelineToUpdate.Spec.Extractrules[i].Keycolumn = rule.KeyColumn
+			pipelineToUpdate.Spec.Extractrules[i].ColumnFormat = rule.ColumnFormat
+			pipelineToUpdate.Spec.Extractrules[i].Matchregex = rule.MatchRegex
+			pipelineToUpdate.Spec.Extractrules[i].Notnull = rule.NotNull
+			pipelineToUpdate.Spec.Extractrules[i].Minvalue = rule.MinValue
+			pipelineToUpdate.Spec.Extractrules[i].Maxvalue = rule.MaxValue
+			pipelineToUpdate.Spec.Extractrules[i].Unique = rule.Unique
+			pipelineToUpdate.Spec.Extractrules[i].Privacypolicy = rule.PrivacyPolicy
 			_, err = pipelineClient.Update(pipelineToUpdate)
 			if err != nil {
 				log.Error().Stack().Err(err).Msg("some error")
 				return nil, status.Errorf(codes.InvalidArgument, err.Error())
 			}
+			s.recordSchemaVersion(pipelineToUpdate, rule.ExtractSourceID)
 		}
 	}
 
@@ -265,6 +316,14 @@ func (s *Server) GetExtractRule(ctx context.Context, request *pb.GetExtractRuleR
 				ColumnType:        pipelineToUpdate.Spec.Extractrules[i].ColumnType,
 				MatchValues:       pipelineToUpdate.Spec.Extractrules[i].MatchValues,
 				TransformFunction: pipelineToUpdate.Spec.Extractrules[i].TransformFunctionName,
+				KeyColumn:         pipelineToUpdate.Spec.Extractrules[i].Keycolumn,
+				ColumnFormat:      pipelineToUpdate.Spec.Extractrules[i].ColumnFormat,
+				MatchRegex:        pipelineToUpdate.Spec.Extractrules[i].Matchregex,
+				NotNull:           pipelineToUpdate.Spec.Extractrules[i].Notnull,
+				MinValue:          pipelineToUpdate.Spec.Extractrules[i].Minvalue,
+				MaxValue:          pipelineToUpdate.Spec.Extractrules[i].Maxvalue,
+				Unique:            pipelineToUpdate.Spec.Extractrules[i].Unique,
+				PrivacyPolicy:     pipelineToUpdate.Spec.Extractrules[i].Privacypolicy,
 			}
 
 			b, err := json.Marshal(rule)
@@ -313,6 +372,14 @@ func (s *Server) GetExtractRules(ctx context.Context, request *pb.GetExtractRule
 			ColumnType:        pipelineToUpdate.Spec.Extractrules[i].ColumnType,
 			MatchValues:       pipelineToUpdate.Spec.Extractrules[i].MatchValues,
 			TransformFunction: pipelineToUpdate.Spec.Extractrules[i].TransformFunctionName,
+			KeyColumn:         pipelineToUpdate.Spec.Extractrules[i].Keycolumn,
+			ColumnFormat:      pipelineToUpdate.Spec.Extractrules[i].ColumnFormat,
+			MatchRegex:        pipelineToUpdate.Spec.Extractrules[i].Matchregex,
+			NotNull:           pipelineToUpdate.Spec.Extractrules[i].Notnull,
+			MinValue:          pipelineToUpdate.Spec.Extractrules[i].Minvalue,
+			MaxValue:          pipelineToUpdate.Spec.Extractrules[i].Maxvalue,
+			Unique:            pipelineToUpdate.Spec.Extractrules[i].Unique,
+			PrivacyPolicy:     pipelineToUpdate.Spec.Extractrules[i].Privacypolicy,
 		}
 		rules = append(rules, rule)
 	}
@@ -329,7 +396,30 @@ func (s *Server) GetExtractRules(ctx context.Context, request *pb.GetExtractRule
 	return response, nil
 }
 
-func validateRulePath(path, scheme string) (err error) {
+// checkExtractRule returns an error when a required field of an
+// extract rule is blank
+func checkExtractRule(rule domain.ExtractRule) error {
+	if rule.ExtractSourceID == "" {
+		return errors.New("extract rule extract source ID is required")
+	}
+	if rule.ColumnName == "" {
+		return errors.New("extract rule column name is required")
+	}
+	if rule.ColumnPath == "" {
+		return errors.New("extract rule source is required")
+	}
+	if rule.ColumnType == "" {
+		return errors.New("extract rule type is required")
+	}
+	return nil
+}
+
+// validateRulePath checks the path of an extract rule suits the scheme
+// of its extract source, and that the column type and format are ones
+// the extract can convert values to.  storedType is the type the rule
+// has, a rule keeping a type given before column types were checked is
+// accepted and its values load without conversion.
+func validateRulePath(path, scheme, columnType, columnFormat, storedType string) (err error) {
 	switch scheme {
 	case extractapi.XMLScheme:
 		_, err = xmlpath.Compile(path)
@@ -343,8 +433,145 @@ func validateRulePath(path, scheme string) (err error) {
 		if v < 0 {
 			err = errors.New("CSV path can not be a negative number")
 		}
-	case extractapi.JSONPathScheme:
+	case extractapi.JSONPathScheme, extractapi.NDJSONScheme:
 		_, err = jp.ParseString(path)
+	case extractapi.FixedWidthScheme:
+		// a 1-based inclusive character range such as 10-24
+		_, _, err = extractapi.ParseFixedWidthRange(path)
+	case extractapi.ParquetScheme:
+		// a parquet column name, nested fields use a dotted path
+		for _, field := range strings.Split(path, ".") {
+			if strings.TrimSpace(field) == "" {
+				return errors.New("parquet path can not contain an empty field name")
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if storedType != "" && strings.EqualFold(columnType, storedType) && extractapi.ValidateColumnType(storedType, "") != nil {
+		return nil
+	}
+	return extractapi.ValidateColumnType(columnType, columnFormat)
+}
+
+// validateQualityRules checks the data quality rules of an extract
+// rule, the regex must compile and a range must be numeric
+func validateQualityRules(rule domain.ExtractRule) error {
+	if rule.MatchRegex != "" {
+		if _, err := regexp.Compile(rule.MatchRegex); err != nil {
+			return fmt.Errorf("match regex is not valid %v", err)
+		}
+	}
+	var min, max float64
+	var err error
+	if rule.MinValue != "" {
+		min, err = strconv.ParseFloat(rule.MinValue, 64)
+		if err != nil {
+			return fmt.Errorf("minimum value %q is not a number", rule.MinValue)
+		}
+	}
+	if rule.MaxValue != "" {
+		max, err = strconv.ParseFloat(rule.MaxValue, 64)
+		if err != nil {
+			return fmt.Errorf("maximum value %q is not a number", rule.MaxValue)
+		}
+	}
+	if rule.MinValue != "" && rule.MaxValue != "" && min > max {
+		return errors.New("minimum value is greater than the maximum value")
+	}
+	return nil
+}
+
+// validatePrivacyPolicy checks the privacy policy of an extract rule,
+// only redact can be used on a column that is not TEXT and a key column
+// can only be hashed or tokenized so its values stay distinct
+func validatePrivacyPolicy(rule domain.ExtractRule, columnType string) error {
+	if strings.TrimSpace(rule.PrivacyPolicy) == "" {
+		return nil
+	}
+	p, err := privacy.Parse(rule.PrivacyPolicy)
+	if err != nil {
+		return err
+	}
+	if p.TextOnly() && extractapi.BaseColumnType(columnType) != extractapi.COLTYPE_TEXT {
+		return fmt.Errorf("privacy policy %s can only be used on a %s column", p.Name, extractapi.COLTYPE_TEXT)
+	}
+	if rule.KeyColumn && p.Name != privacy.PolicyHash && p.Name != privacy.PolicyTokenize {
+		return fmt.Errorf("privacy policy %s can not be used on a key column", p.Name)
+	}
+	return nil
+}
+
+// schemaColumn is a column of a recorded schema version
+type schemaColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// recordSchemaVersion records the columns the extract rules give the
+// table of an extract source as a new schema version when they differ
+// from the latest version.  The extract rule change is already saved
+// when this is called so errors are only logged.
+func (s *Server) recordSchemaVersion(p *v1alpha1.Pipeline, extractSourceID string) {
+	var tableName string
+	for i := 0; i < len(p.Spec.Extractsources); i++ {
+		if p.Spec.Extractsources[i].ID == extractSourceID {
+			tableName = p.Spec.Extractsources[i].Tablename
+		}
+	}
+	if tableName == "" {
+		return
+	}
+
+	columns := make([]schemaColumn, 0)
+	for i := 0; i < len(p.Spec.Extractrules); i++ {
+		r := p.Spec.Extractrules[i]
+		if r.Extractsourceid == extractSourceID {
+			columns = append(columns, schemaColumn{Name: r.ColumnName, Type: r.ColumnType})
+		}
+	}
+	sort.Slice(columns, func(i, j int) bool { return columns[i].Name < columns[j].Name })
+	b, err := json.Marshal(columns)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in recording schema version")
+		return
+	}
+
+	churroDB, err := db.NewChurroDB(s.Pi.Spec.DatabaseType)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in recording schema version")
+		return
+	}
+	err = churroDB.GetConnection(s.DBCreds, s.Pi.Spec.DataSource)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in recording schema version")
+		return
+	}
+
+	versions, err := churroDB.GetSchemaVersions(tableName)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in getting schema versions")
+		return
+	}
+	v := domain.SchemaVersion{
+		TableName:       tableName,
+		Version:         1,
+		ExtractSourceID: extractSourceID,
+		Columns:         string(b),
+	}
+	if len(versions) > 0 {
+		latest := versions[len(versions)-1]
+		if latest.Columns == v.Columns {
+			return
+		}
+		v.Version = latest.Version + 1
+	}
+
+	err = churroDB.CreateSchemaVersion(v)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in recording schema version")
+		return
 	}
-	return err
+	log.Info().Msg(fmt.Sprintf("recorded schema version %d of %s", v.Version, tableName))
 }
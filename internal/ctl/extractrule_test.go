@@ -0,0 +1,36 @@
+package ctl
+
+import (
+	"testing"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/domain"
+)
+
+func TestValidateRulePathLegacyType(t *testing.T) {
+	// a rule keeping a type given before column types were checked
+	if err := validateRulePath("1", extractapi.CSVScheme, "MONEY", "", "MONEY"); err != nil {
+		t.Fatalf("validateRulePath of a kept legacy type Error: %v", err)
+	}
+	if err := validateRulePath("1", extractapi.CSVScheme, "MONEY", "", ""); err == nil {
+		t.Fatal("validateRulePath expected an error for a new rule of an unknown type")
+	}
+	if err := validateRulePath("1", extractapi.CSVScheme, "MONEY", "", extractapi.COLTYPE_TEXT); err == nil {
+		t.Fatal("validateRulePath expected an error for a rule changed to an unknown type")
+	}
+	if err := validateRulePath("1", extractapi.CSVScheme, extractapi.NormalizeColumnType("integer"), "", ""); err != nil {
+		t.Fatalf("validateRulePath of a normalized type Error: %v", err)
+	}
+}
+
+func TestValidatePrivacyPolicyColumnType(t *testing.T) {
+	rule := domain.ExtractRule{ColumnName: "email", PrivacyPolicy: "hash"}
+	for _, columnType := range []string{extractapi.COLTYPE_TEXT, "text", " Text "} {
+		if err := validatePrivacyPolicy(rule, columnType); err != nil {
+			t.Fatalf("validatePrivacyPolicy of a %q column Error: %v", columnType, err)
+		}
+	}
+	if err := validatePrivacyPolicy(rule, "int"); err == nil {
+		t.Fatal("validatePrivacyPolicy expected an error for an int column")
+	}
+}
@@ -13,6 +13,7 @@ package ctl
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -79,10 +80,36 @@ func (s *Server) CreateExtractSource(ctx context.Context, request *pb.CreateExtr
 		return nil, status.Errorf(codes.InvalidArgument,
 			"extract source skipheaders is required to be >= 0")
 	}
+	if wdir.BatchRows < 0 || wdir.BatchBytes < 0 {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"extract source batchrows and batchbytes are required to be >= 0")
+	}
+	if wdir.CopyThreshold < 0 {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"extract source copythreshold is required to be >= 0")
+	}
+	if wdir.DedupWindow < 0 {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"extract source dedupwindow is required to be >= 0")
+	}
+	// the key columns are extract rules which are added after the
+	// extract source, they are checked when the source is updated
+	err = validateLoadMode(wdir.LoadMode, wdir.ID, nil)
+	if err != nil && err != errNoKeyColumns {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"extract source %s", err.Error())
+	}
 	if wdir.Sheetname == "" && (wdir.Scheme == extractapi.XLSXScheme) {
 		return nil, status.Errorf(codes.InvalidArgument,
 			"extract source sheetname is required for xlsx scheme")
 	}
+	if wdir.Scheme == extractapi.CSVScheme {
+		_, err = extractapi.GetCSVDialect(wdir.Delimiter, wdir.Quote, wdir.Comment, wdir.LazyQuotes, wdir.Charset)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"extract source %s", err.Error())
+		}
+	}
 	if wdir.Tablename == "" {
 		return nil, status.Errorf(codes.InvalidArgument,
 			"extract source tablename is required")
@@ -120,20 +147,32 @@ func (s *Server) CreateExtractSource(ctx context.Context, request *pb.CreateExtr
 		pipelineToUpdate.Spec.Extractsources = make([]v1alpha1.ExtractSourceDefinition, 0)
 	}
 	esrc := v1alpha1.ExtractSourceDefinition{
-		ID:             wdir.ID,
-		Name:           wdir.Name,
-		Path:           wdir.Path,
-		Scheme:         wdir.Scheme,
-		Regex:          wdir.Regex,
-		Tablename:      wdir.Tablename,
-		Cronexpression: wdir.Cronexpression,
-		Skipheaders:    wdir.Skipheaders,
-		Sheetname:      wdir.Sheetname,
-		Multiline:      strconv.FormatBool(wdir.Multiline),
-		Encoding:       wdir.Encoding,
-		Transport:      wdir.Transport,
-		Port:           wdir.Port,
-		Servicetype:    wdir.Servicetype,
+		ID:               wdir.ID,
+		Name:             wdir.Name,
+		Path:             wdir.Path,
+		Scheme:           wdir.Scheme,
+		Regex:            wdir.Regex,
+		Tablename:        wdir.Tablename,
+		Cronexpression:   wdir.Cronexpression,
+		Skipheaders:      wdir.Skipheaders,
+		Sheetname:        wdir.Sheetname,
+		Multiline:        strconv.FormatBool(wdir.Multiline),
+		Encoding:         wdir.Encoding,
+		Transport:        wdir.Transport,
+		Port:             wdir.Port,
+		Servicetype:      wdir.Servicetype,
+		Delimiter:        wdir.Delimiter,
+		Quote:            wdir.Quote,
+		Comment:          wdir.Comment,
+		Lazyquotes:       wdir.LazyQuotes,
+		Charset:          wdir.Charset,
+		Batchrows:        wdir.BatchRows,
+		Batchbytes:       wdir.BatchBytes,
+		Copythreshold:    wdir.CopyThreshold,
+		Loadmode:         wdir.LoadMode,
+		Reloadduplicates: wdir.ReloadDuplicates,
+		Rowhash:          wdir.RowHash,
+		Dedupwindow:      wdir.DedupWindow,
 	}
 
 	pipelineToUpdate.Spec.Extractsources = append(pipelineToUpdate.Spec.Extractsources, esrc)
@@ -248,6 +287,18 @@ func (s *Server) GetExtractSource(ctx context.Context, request *pb.GetExtractSou
 			wdir.Encoding = c.Encoding
 			wdir.Transport = c.Transport
 			wdir.Servicetype = c.Servicetype
+			wdir.Delimiter = c.Delimiter
+			wdir.Quote = c.Quote
+			wdir.Comment = c.Comment
+			wdir.LazyQuotes = c.Lazyquotes
+			wdir.Charset = c.Charset
+			wdir.BatchRows = c.Batchrows
+			wdir.BatchBytes = c.Batchbytes
+			wdir.CopyThreshold = c.Copythreshold
+			wdir.LoadMode = c.Loadmode
+			wdir.ReloadDuplicates = c.Reloadduplicates
+			wdir.RowHash = c.Rowhash
+			wdir.DedupWindow = c.Dedupwindow
 			wdir.Cronexpression = pipelineToUpdate.Spec.Extractsources[i].Cronexpression
 			// get the extract rules for this extract source
 			wdir.ExtractRules = make(map[string]domain.ExtractRule)
@@ -262,6 +313,14 @@ func (s *Server) GetExtractSource(ctx context.Context, request *pb.GetExtractSou
 					dom.ColumnType = a.ColumnType
 					dom.MatchValues = a.MatchValues
 					dom.TransformFunction = a.TransformFunctionName
+					dom.KeyColumn = a.Keycolumn
+					dom.ColumnFormat = a.ColumnFormat
+					dom.MatchRegex = a.Matchregex
+					dom.NotNull = a.Notnull
+					dom.MinValue = a.Minvalue
+					dom.MaxValue = a.Maxvalue
+					dom.Unique = a.Unique
+					dom.PrivacyPolicy = a.Privacypolicy
 					wdir.ExtractRules[a.ID] = dom
 				}
 			}
@@ -279,6 +338,22 @@ func (s *Server) GetExtractSource(ctx context.Context, request *pb.GetExtractSou
 					wdir.Extensions[a.ID] = dom
 				}
 			}
+
+			// get the lookups for this extract source
+			wdir.Lookups = make(map[string]domain.Lookup)
+			for y := 0; y < len(pipelineToUpdate.Spec.Lookups); y++ {
+				if pipelineToUpdate.Spec.Lookups[y].Extractsourceid == request.ExtractSourceID {
+					wdir.Lookups[pipelineToUpdate.Spec.Lookups[y].ID] = getLookup(pipelineToUpdate.Spec.Lookups[y])
+				}
+			}
+
+			// get the routes for this extract source
+			wdir.Routes = make(map[string]domain.Route)
+			for y := 0; y < len(pipelineToUpdate.Spec.Routes); y++ {
+				if pipelineToUpdate.Spec.Routes[y].Extractsourceid == request.ExtractSourceID {
+					wdir.Routes[pipelineToUpdate.Spec.Routes[y].ID] = getRoute(pipelineToUpdate.Spec.Routes[y])
+				}
+			}
 		}
 	}
 
@@ -414,6 +489,31 @@ func (s *Server) UpdateExtractSource(ctx context.Context, request *pb.UpdateExtr
 		return nil, status.Errorf(codes.InvalidArgument, "no extract sources exist")
 	}
 
+	if f.BatchRows < 0 || f.BatchBytes < 0 {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"extract source batchrows and batchbytes are required to be >= 0")
+	}
+	if f.CopyThreshold < 0 {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"extract source copythreshold is required to be >= 0")
+	}
+	if f.DedupWindow < 0 {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"extract source dedupwindow is required to be >= 0")
+	}
+	err = validateLoadMode(f.LoadMode, f.ID, pipelineToUpdate.Spec.Extractrules)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"extract source %s", err.Error())
+	}
+	if f.Scheme == extractapi.CSVScheme {
+		_, err = extractapi.GetCSVDialect(f.Delimiter, f.Quote, f.Comment, f.LazyQuotes, f.Charset)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"extract source %s", err.Error())
+		}
+	}
+
 	for i := 0; i < len(pipelineToUpdate.Spec.Extractsources); i++ {
 		if pipelineToUpdate.Spec.Extractsources[i].ID == f.ID {
 			pipelineToUpdate.Spec.Extractsources[i].Name = f.Name
@@ -428,6 +528,18 @@ func (s *Server) UpdateExtractSource(ctx context.Context, request *pb.UpdateExtr
 			pipelineToUpdate.Spec.Extractsources[i].Transport = f.Transport
 			pipelineToUpdate.Spec.Extractsources[i].Cronexpression = f.Cronexpression
 			pipelineToUpdate.Spec.Extractsources[i].Servicetype = f.Servicetype
+			pipelineToUpdate.Spec.Extractsources[i].Delimiter = f.Delimiter
+			pipelineToUpdate.Spec.Extractsources[i].Quote = f.Quote
+			pipelineToUpdate.Spec.Extractsources[i].Comment = f.Comment
+			pipelineToUpdate.Spec.Extractsources[i].Lazyquotes = f.LazyQuotes
+			pipelineToUpdate.Spec.Extractsources[i].Charset = f.Charset
+			pipelineToUpdate.Spec.Extractsources[i].Batchrows = f.BatchRows
+			pipelineToUpdate.Spec.Extractsources[i].Batchbytes = f.BatchBytes
+			pipelineToUpdate.Spec.Extractsources[i].Copythreshold = f.CopyThreshold
+			pipelineToUpdate.Spec.Extractsources[i].Loadmode = f.LoadMode
+			pipelineToUpdate.Spec.Extractsources[i].Reloadduplicates = f.ReloadDuplicates
+			pipelineToUpdate.Spec.Extractsources[i].Rowhash = f.RowHash
+			pipelineToUpdate.Spec.Extractsources[i].Dedupwindow = f.DedupWindow
 			_, err = pipelineClient.Update(pipelineToUpdate)
 			if err != nil {
 				log.Error().Stack().Err(err).Msg("some error")
@@ -445,6 +557,28 @@ func (s *Server) UpdateExtractSource(ctx context.Context, request *pb.UpdateExtr
 	return response, nil
 }
 
+var errNoKeyColumns = errors.New("load mode requires an extract rule marked as a key column")
+
+// validateLoadMode checks the load mode of an extract source, the
+// upsert and replace-partition modes need a key column among the
+// rules of the extract source
+func validateLoadMode(mode, extractSourceID string, rules []v1alpha1.ExtractRuleDefinition) error {
+	switch mode {
+	case "", domain.LoadModeAppend:
+		return nil
+	case domain.LoadModeUpsert, domain.LoadModeReplacePartition:
+	default:
+		return fmt.Errorf("load mode %q is not one of %s, %s or %s", mode,
+			domain.LoadModeAppend, domain.LoadModeUpsert, domain.LoadModeReplacePartition)
+	}
+	for _, r := range rules {
+		if r.Extractsourceid == extractSourceID && r.Keycolumn {
+			return nil
+		}
+	}
+	return errNoKeyColumns
+}
+
 func (s Server) triggerExtractSourceUpdate() error {
 	// here is where we would ping the extractsource service to let it update
 
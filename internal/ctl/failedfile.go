@@ -0,0 +1,69 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package ctl
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/churrodata/churro/internal/extractsource"
+	pb "github.com/churrodata/churro/rpc/ctl"
+	watchpb "github.com/churrodata/churro/rpc/extractsource"
+)
+
+// GetFailedFiles returns the files of an extract source that failed
+// extraction, the failed directories are only mounted by the
+// extractsource service so it is asked for them
+func (s *Server) GetFailedFiles(ctx context.Context, request *pb.GetFailedFilesRequest) (response *pb.GetFailedFilesResponse, err error) {
+
+	client, err := extractsource.GetExtractSourceServiceConnection(s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.Unavailable, err.Error())
+	}
+
+	resp, err := client.GetFailedFiles(ctx, &watchpb.GetFailedFilesRequest{
+		ExtractSourceID: request.ExtractSourceID,
+	})
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, err
+	}
+
+	return &pb.GetFailedFilesResponse{FailedFilesString: resp.FailedFilesString}, nil
+}
+
+// RequeueFailedFiles extracts failed files of an extract source again,
+// typically after its extract rules were fixed
+func (s *Server) RequeueFailedFiles(ctx context.Context, request *pb.RequeueFailedFilesRequest) (response *pb.RequeueFailedFilesResponse, err error) {
+
+	client, err := extractsource.GetExtractSourceServiceConnection(s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.Unavailable, err.Error())
+	}
+
+	resp, err := client.RequeueFailedFiles(ctx, &watchpb.RequeueFailedFilesRequest{
+		ExtractSourceID: request.ExtractSourceID,
+		IDs:             request.IDs,
+	})
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, err
+	}
+	log.Info().Msg(fmt.Sprintf("requeued failed files %v\n", resp.IDs))
+
+	return &pb.RequeueFailedFilesResponse{IDs: resp.IDs}, nil
+}
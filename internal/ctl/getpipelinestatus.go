@@ -128,8 +128,15 @@ func (s Server) getJobs() (jobs []*pb.PipelineJobStatus, err error) {
 			return jobs, err
 		}
 		m1.RecordsLoaded = int32(jp.RecordsLoaded)
+		m1.ConversionErrors = int32(jp.ConversionErrors)
+		m1.RecordsRejected = int32(jp.RecordsRejected)
 		m1.FileName = jp.FileName
 		m1.TableName = jp.TableName
+		// a job that failed extraction still exits cleanly, its
+		// extract log holds the real status
+		if jp.Status != "" {
+			m1.Status = jp.Status
+		}
 		log.Info().Msg(fmt.Sprintf("adding jobProfile of %s/%s", m1.FileName, m1.TableName))
 		jobs = append(jobs, &m1)
 	}
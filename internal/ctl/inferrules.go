@@ -0,0 +1,187 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package ctl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/churrodata/churro/api/v1alpha1"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/churrodata/churro/internal/infer"
+	"github.com/churrodata/churro/internal/transform"
+	"github.com/churrodata/churro/pkg"
+	pb "github.com/churrodata/churro/rpc/ctl"
+	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// InferExtractRules suggests the extract rules of an extract source
+// from a sample file, the rules are returned for review and are not
+// saved
+func (s *Server) InferExtractRules(ctx context.Context, request *pb.InferExtractRulesRequest) (response *pb.InferExtractRulesResponse, err error) {
+
+	response = &pb.InferExtractRulesResponse{}
+
+	if len(request.Sample) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "a sample file is required")
+	}
+
+	_, config, err := pkg.GetKubeClient()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipelineClient, err := pkg.NewClient(config, s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipeline, err := pipelineClient.Get(s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	var source *v1alpha1.ExtractSourceDefinition
+	for i := 0; i < len(pipeline.Spec.Extractsources); i++ {
+		if pipeline.Spec.Extractsources[i].ID == request.ExtractSourceID {
+			source = &pipeline.Spec.Extractsources[i]
+		}
+	}
+	if source == nil {
+		return nil, status.Errorf(codes.NotFound, "extract source %s not found", request.ExtractSourceID)
+	}
+
+	rules, err := infer.Rules(source.Scheme, source.Sheetname, request.Sample)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	for i := range rules {
+		rules[i].ExtractSourceID = request.ExtractSourceID
+	}
+	log.Info().Msg(fmt.Sprintf("inferred %d extract rules for %s", len(rules), source.Name))
+
+	b, _ := json.Marshal(rules)
+	response.ExtractRulesString = string(b)
+	return response, nil
+}
+
+// CreateExtractRules creates the extract rules of an extract source in
+// a single update of the pipeline, none are created when a rule is not
+// valid or has the column name of another rule of the extract source
+func (s *Server) CreateExtractRules(ctx context.Context, request *pb.CreateExtractRulesRequest) (response *pb.CreateExtractRulesResponse, err error) {
+
+	response = &pb.CreateExtractRulesResponse{}
+	var rules []domain.ExtractRule
+
+	err = json.Unmarshal([]byte(request.ExtractRulesString), &rules)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if len(rules) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "no extract rules to create")
+	}
+
+	_, config, err := pkg.GetKubeClient()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipelineClient, err := pkg.NewClient(config, s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipelineToUpdate, err := pipelineClient.Get(s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	var scheme string
+	for i := 0; i < len(pipelineToUpdate.Spec.Extractsources); i++ {
+		if pipelineToUpdate.Spec.Extractsources[i].ID == request.ExtractSourceID {
+			scheme = pipelineToUpdate.Spec.Extractsources[i].Scheme
+		}
+	}
+	if scheme == "" {
+		return nil, status.Errorf(codes.NotFound, "extract source %s not found", request.ExtractSourceID)
+	}
+
+	names := make(map[string]bool)
+	for i := 0; i < len(pipelineToUpdate.Spec.Extractrules); i++ {
+		if pipelineToUpdate.Spec.Extractrules[i].Extractsourceid == request.ExtractSourceID {
+			names[pipelineToUpdate.Spec.Extractrules[i].ColumnName] = true
+		}
+	}
+
+	for _, rule := range rules {
+		rule.ExtractSourceID = request.ExtractSourceID
+		err = checkExtractRule(rule)
+		if err == nil {
+			err = validateRulePath(rule.ColumnPath, scheme, rule.ColumnType, rule.ColumnFormat, "")
+		}
+		if err == nil {
+			err = validateQualityRules(rule)
+		}
+		if err == nil {
+			err = transform.ValidateBuiltin(rule.TransformFunction)
+		}
+		if err == nil {
+			err = validatePrivacyPolicy(rule, rule.ColumnType)
+		}
+		if err == nil && names[rule.ColumnName] {
+			err = fmt.Errorf("extract rule column name %s is already used", rule.ColumnName)
+		}
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "column %s: %s", rule.ColumnName, err.Error())
+		}
+		names[rule.ColumnName] = true
+
+		x := v1alpha1.ExtractRuleDefinition{
+			ID:                    xid.New().String(),
+			Extractsourceid:       rule.ExtractSourceID,
+			ColumnName:            rule.ColumnName,
+			ColumnPath:            rule.ColumnPath,
+			ColumnType:            rule.ColumnType,
+			MatchValues:           rule.MatchValues,
+			TransformFunctionName: rule.TransformFunction,
+			Keycolumn:             rule.KeyColumn,
+			ColumnFormat:          rule.ColumnFormat,
+			Matchregex:            rule.MatchRegex,
+			Notnull:               rule.NotNull,
+			Minvalue:              rule.MinValue,
+			Maxvalue:              rule.MaxValue,
+			Unique:                rule.Unique,
+			Privacypolicy:         rule.PrivacyPolicy,
+		}
+		pipelineToUpdate.Spec.Extractrules = append(pipelineToUpdate.Spec.Extractrules, x)
+		response.IDs = append(response.IDs, x.ID)
+	}
+
+	_, err = pipelineClient.Update(pipelineToUpdate)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	s.recordSchemaVersion(pipelineToUpdate, request.ExtractSourceID)
+
+	return response, nil
+}
@@ -0,0 +1,289 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package ctl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/api/v1alpha1"
+	"github.com/churrodata/churro/internal/db/sqlsafe"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/churrodata/churro/internal/extractsource"
+	"github.com/churrodata/churro/pkg"
+	pb "github.com/churrodata/churro/rpc/ctl"
+	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateLookup adds a lookup to an extract source
+func (s *Server) CreateLookup(ctx context.Context, request *pb.CreateLookupRequest) (response *pb.CreateLookupResponse, err error) {
+
+	response = &pb.CreateLookupResponse{}
+	var l domain.Lookup
+
+	err = json.Unmarshal([]byte(request.LookupString), &l)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			err.Error())
+	}
+
+	if l.ReferenceFile != "" {
+		l.ReferenceFile = filepath.Clean(l.ReferenceFile)
+	}
+	err = checkLookup(l)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if l.ID == "" {
+		l.ID = xid.New().String()
+	}
+
+	_, config, err := pkg.GetKubeClient()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipelineClient, err := pkg.NewClient(config, s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipelineToUpdate, err := pipelineClient.Get(s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	for _, x := range pipelineToUpdate.Spec.Lookups {
+		if x.Extractsourceid == l.ExtractSourceID && x.Lookupname == l.LookupName {
+			return nil, status.Errorf(codes.InvalidArgument, "lookup %s already exists", l.LookupName)
+		}
+	}
+
+	pipelineToUpdate.Spec.Lookups = append(pipelineToUpdate.Spec.Lookups, getLookupDefinition(l))
+	_, err = pipelineClient.Update(pipelineToUpdate)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	response.ID = l.ID
+	return response, nil
+}
+
+// DeleteLookup removes a lookup from an extract source
+func (s *Server) DeleteLookup(ctx context.Context, request *pb.DeleteLookupRequest) (response *pb.DeleteLookupResponse, err error) {
+
+	response = &pb.DeleteLookupResponse{}
+
+	_, config, err := pkg.GetKubeClient()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipelineClient, err := pkg.NewClient(config, s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipelineToUpdate, err := pipelineClient.Get(s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	for i := 0; i < len(pipelineToUpdate.Spec.Lookups); i++ {
+		if pipelineToUpdate.Spec.Lookups[i].ID == request.LookupID {
+			// removes the lookup from the array
+			pipelineToUpdate.Spec.Lookups = append(pipelineToUpdate.Spec.Lookups[:i], pipelineToUpdate.Spec.Lookups[i+1:]...)
+			_, err = pipelineClient.Update(pipelineToUpdate)
+			if err != nil {
+				log.Error().Stack().Err(err).Msg("some error")
+				return nil, status.Errorf(codes.InvalidArgument, err.Error())
+			}
+			return response, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.InvalidArgument, "lookup not found")
+}
+
+// UpdateLookup changes a lookup of an extract source
+func (s *Server) UpdateLookup(ctx context.Context, request *pb.UpdateLookupRequest) (response *pb.UpdateLookupResponse, err error) {
+
+	response = &pb.UpdateLookupResponse{}
+
+	var l domain.Lookup
+	err = json.Unmarshal([]byte(request.LookupString), &l)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			err.Error())
+	}
+
+	if l.ReferenceFile != "" {
+		l.ReferenceFile = filepath.Clean(l.ReferenceFile)
+	}
+	err = checkLookup(l)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	_, config, err := pkg.GetKubeClient()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipelineClient, err := pkg.NewClient(config, s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipelineToUpdate, err := pipelineClient.Get(s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	for i := 0; i < len(pipelineToUpdate.Spec.Lookups); i++ {
+		if pipelineToUpdate.Spec.Lookups[i].ID == l.ID {
+			pipelineToUpdate.Spec.Lookups[i] = getLookupDefinition(l)
+			_, err = pipelineClient.Update(pipelineToUpdate)
+			if err != nil {
+				log.Error().Stack().Err(err).Msg("some error")
+				return nil, status.Errorf(codes.InvalidArgument, err.Error())
+			}
+			return response, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.InvalidArgument, "lookup not found")
+}
+
+// GetLookup fetches a single lookup
+func (s *Server) GetLookup(ctx context.Context, request *pb.GetLookupRequest) (response *pb.GetLookupResponse, err error) {
+
+	response = &pb.GetLookupResponse{}
+
+	_, config, err := pkg.GetKubeClient()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipelineClient, err := pkg.NewClient(config, s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipelineToUpdate, err := pipelineClient.Get(s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	for i := 0; i < len(pipelineToUpdate.Spec.Lookups); i++ {
+		if pipelineToUpdate.Spec.Lookups[i].ID == request.LookupID {
+			b, err := json.Marshal(getLookup(pipelineToUpdate.Spec.Lookups[i]))
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, err.Error())
+			}
+			response.LookupString = string(b)
+			return response, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.InvalidArgument, "lookup not found")
+}
+
+// checkLookup checks a lookup names one reference table or file and
+// its match and appended columns parse
+func checkLookup(l domain.Lookup) error {
+	if l.ExtractSourceID == "" {
+		return errors.New("lookup extract source ID is required")
+	}
+	if l.LookupName == "" {
+		return errors.New("lookup name is required")
+	}
+	switch {
+	case l.ReferenceTable == "" && l.ReferenceFile == "":
+		return errors.New("lookup reference table or reference file is required")
+	case l.ReferenceTable != "" && l.ReferenceFile != "":
+		return errors.New("lookup can not have both a reference table and a reference file")
+	case l.ReferenceTable != "":
+		if err := sqlsafe.ValidIdentifier(l.ReferenceTable); err != nil {
+			return err
+		}
+	case !inDataVolume(l.ReferenceFile):
+		return fmt.Errorf("lookup reference file %s must be an absolute path in the pipeline volume %s", l.ReferenceFile, extractsource.DataVolumePath)
+	}
+
+	if _, err := extractapi.ParseColumnPairs(l.MatchColumns); err != nil {
+		return fmt.Errorf("lookup match columns %v", err)
+	}
+	columns, err := extractapi.ParseColumnPairs(l.Columns)
+	if err != nil {
+		return fmt.Errorf("lookup columns %v", err)
+	}
+	for _, c := range columns {
+		if err := sqlsafe.ValidIdentifier(c.Column); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inDataVolume reports whether path, once cleaned, is a file under the
+// data volume extract pods mount
+func inDataVolume(path string) bool {
+	if !filepath.IsAbs(path) {
+		return false
+	}
+	return strings.HasPrefix(filepath.Clean(path), extractsource.DataVolumePath+"/")
+}
+
+func getLookupDefinition(l domain.Lookup) v1alpha1.LookupDefinition {
+	return v1alpha1.LookupDefinition{
+		ID:              l.ID,
+		Extractsourceid: l.ExtractSourceID,
+		Lookupname:      l.LookupName,
+		Referencetable:  l.ReferenceTable,
+		Referencefile:   l.ReferenceFile,
+		Matchcolumns:    l.MatchColumns,
+		Columns:         l.Columns,
+	}
+}
+
+func getLookup(x v1alpha1.LookupDefinition) domain.Lookup {
+	return domain.Lookup{
+		ID:              x.ID,
+		ExtractSourceID: x.Extractsourceid,
+		LookupName:      x.Lookupname,
+		ReferenceTable:  x.Referencetable,
+		ReferenceFile:   x.Referencefile,
+		MatchColumns:    x.Matchcolumns,
+		Columns:         x.Columns,
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package ctl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/churrodata/churro/api/v1alpha1"
+	"github.com/churrodata/churro/internal/db/sqlsafe"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/churrodata/churro/internal/predicate"
+	"github.com/churrodata/churro/pkg"
+	pb "github.com/churrodata/churro/rpc/ctl"
+	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateRoute adds a route to an extract source
+func (s *Server) CreateRoute(ctx context.Context, request *pb.CreateRouteRequest) (response *pb.CreateRouteResponse, err error) {
+
+	response = &pb.CreateRouteResponse{}
+	var r domain.Route
+
+	err = json.Unmarshal([]byte(request.RouteString), &r)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			err.Error())
+	}
+
+	err = checkRoute(r)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if r.ID == "" {
+		r.ID = xid.New().String()
+	}
+
+	_, config, err := pkg.GetKubeClient()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipelineClient, err := pkg.NewClient(config, s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipelineToUpdate, err := pipelineClient.Get(s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	for _, x := range pipelineToUpdate.Spec.Routes {
+		if x.Extractsourceid == r.ExtractSourceID && x.Routename == r.RouteName {
+			return nil, status.Errorf(codes.InvalidArgument, "route %s already exists", r.RouteName)
+		}
+	}
+
+	pipelineToUpdate.Spec.Routes = append(pipelineToUpdate.Spec.Routes, getRouteDefinition(r))
+	_, err = pipelineClient.Update(pipelineToUpdate)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	response.ID = r.ID
+	return response, nil
+}
+
+// DeleteRoute removes a route from an extract source
+func (s *Server) DeleteRoute(ctx context.Context, request *pb.DeleteRouteRequest) (response *pb.DeleteRouteResponse, err error) {
+
+	response = &pb.DeleteRouteResponse{}
+
+	_, config, err := pkg.GetKubeClient()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipelineClient, err := pkg.NewClient(config, s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipelineToUpdate, err := pipelineClient.Get(s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	for i := 0; i < len(pipelineToUpdate.Spec.Routes); i++ {
+		if pipelineToUpdate.Spec.Routes[i].ID == request.RouteID {
+			// removes the route from the array
+			pipelineToUpdate.Spec.Routes = append(pipelineToUpdate.Spec.Routes[:i], pipelineToUpdate.Spec.Routes[i+1:]...)
+			_, err = pipelineClient.Update(pipelineToUpdate)
+			if err != nil {
+				log.Error().Stack().Err(err).Msg("some error")
+				return nil, status.Errorf(codes.InvalidArgument, err.Error())
+			}
+			return response, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.InvalidArgument, "route not found")
+}
+
+// UpdateRoute changes a route of an extract source
+func (s *Server) UpdateRoute(ctx context.Context, request *pb.UpdateRouteRequest) (response *pb.UpdateRouteResponse, err error) {
+
+	response = &pb.UpdateRouteResponse{}
+
+	var r domain.Route
+	err = json.Unmarshal([]byte(request.RouteString), &r)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument,
+			err.Error())
+	}
+
+	err = checkRoute(r)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	_, config, err := pkg.GetKubeClient()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipelineClient, err := pkg.NewClient(config, s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipelineToUpdate, err := pipelineClient.Get(s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	for i := 0; i < len(pipelineToUpdate.Spec.Routes); i++ {
+		if pipelineToUpdate.Spec.Routes[i].ID == r.ID {
+			pipelineToUpdate.Spec.Routes[i] = getRouteDefinition(r)
+			_, err = pipelineClient.Update(pipelineToUpdate)
+			if err != nil {
+				log.Error().Stack().Err(err).Msg("some error")
+				return nil, status.Errorf(codes.InvalidArgument, err.Error())
+			}
+			return response, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.InvalidArgument, "route not found")
+}
+
+// GetRoute fetches a single route
+func (s *Server) GetRoute(ctx context.Context, request *pb.GetRouteRequest) (response *pb.GetRouteResponse, err error) {
+
+	response = &pb.GetRouteResponse{}
+
+	_, config, err := pkg.GetKubeClient()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipelineClient, err := pkg.NewClient(config, s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipelineToUpdate, err := pipelineClient.Get(s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	for i := 0; i < len(pipelineToUpdate.Spec.Routes); i++ {
+		if pipelineToUpdate.Spec.Routes[i].ID == request.RouteID {
+			b, err := json.Marshal(getRoute(pipelineToUpdate.Spec.Routes[i]))
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, err.Error())
+			}
+			response.RouteString = string(b)
+			return response, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.InvalidArgument, "route not found")
+}
+
+// checkRoute checks a route has a predicate that compiles and either a
+// valid target table or drops the rows it matches
+func checkRoute(r domain.Route) error {
+	if r.ExtractSourceID == "" {
+		return errors.New("route extract source ID is required")
+	}
+	if r.RouteName == "" {
+		return errors.New("route name is required")
+	}
+	if r.Position < 0 {
+		return errors.New("route position can not be negative")
+	}
+	if _, err := predicate.Compile(r.Predicate); err != nil {
+		return fmt.Errorf("route %v", err)
+	}
+	switch {
+	case r.Drop && r.TargetTable != "":
+		return errors.New("route can not both drop rows and have a target table")
+	case !r.Drop && r.TargetTable == "":
+		return errors.New("route target table is required when rows are not dropped")
+	case r.TargetTable != "":
+		if err := sqlsafe.ValidIdentifier(r.TargetTable); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getRouteDefinition(r domain.Route) v1alpha1.RouteDefinition {
+	return v1alpha1.RouteDefinition{
+		ID:              r.ID,
+		Extractsourceid: r.ExtractSourceID,
+		Routename:       r.RouteName,
+		Position:        r.Position,
+		Predicate:       r.Predicate,
+		Targettable:     r.TargetTable,
+		Drop:            r.Drop,
+	}
+}
+
+func getRoute(x v1alpha1.RouteDefinition) domain.Route {
+	return domain.Route{
+		ID:              x.ID,
+		ExtractSourceID: x.Extractsourceid,
+		RouteName:       x.Routename,
+		Position:        x.Position,
+		Predicate:       x.Predicate,
+		TargetTable:     x.Targettable,
+		Drop:            x.Drop,
+	}
+}
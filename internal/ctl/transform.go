@@ -18,6 +18,7 @@ import (
 	"github.com/churrodata/churro/api/v1alpha1"
 	"github.com/churrodata/churro/internal/db"
 	"github.com/churrodata/churro/internal/domain"
+	"github.com/churrodata/churro/internal/transform"
 	"github.com/churrodata/churro/pkg"
 	pb "github.com/churrodata/churro/rpc/ctl"
 	"github.com/rs/xid"
@@ -47,6 +48,10 @@ func (s *Server) CreateTransformFunction(ctx context.Context, request *pb.Create
 		return nil, status.Errorf(codes.InvalidArgument,
 			"transform source is required")
 	}
+	shape, err := transform.Check(p)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
 
 	var churroDB db.ChurroDatabase
 	churroDB, err = db.NewChurroDB(s.Pi.Spec.DatabaseType)
@@ -63,6 +68,7 @@ func (s *Server) CreateTransformFunction(ctx context.Context, request *pb.Create
 	tf := domain.TransformFunction{
 		Name:   p.Name,
 		Source: p.Source,
+		Shape:  shape,
 		ID:     xid.New().String(),
 	}
 	/**
@@ -94,6 +100,7 @@ func (s *Server) CreateTransformFunction(ctx context.Context, request *pb.Create
 		ID:     tf.ID,
 		Name:   tf.Name,
 		Source: tf.Source,
+		Shape:  tf.Shape,
 	}
 	log.Info().Msg(fmt.Sprintf("adding transform %v", x))
 
@@ -126,6 +133,10 @@ func (s *Server) UpdateTransformFunction(ctx context.Context, request *pb.Update
 		return nil, status.Errorf(codes.InvalidArgument,
 			err.Error())
 	}
+	shape, err := transform.Check(o)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
 	_, config, err := pkg.GetKubeClient()
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("some error")
@@ -153,6 +164,7 @@ func (s *Server) UpdateTransformFunction(ctx context.Context, request *pb.Update
 		if pipelineToUpdate.Spec.Functions[i].ID == o.ID {
 			pipelineToUpdate.Spec.Functions[i].Name = o.Name
 			pipelineToUpdate.Spec.Functions[i].Source = o.Source
+			pipelineToUpdate.Spec.Functions[i].Shape = shape
 
 			_, err = pipelineClient.Update(pipelineToUpdate)
 			if err != nil {
@@ -194,6 +206,7 @@ func (s *Server) GetTransformFunctions(ctx context.Context, request *pb.GetTrans
 			ID:     pipelineToUpdate.Spec.Functions[i].ID,
 			Name:   pipelineToUpdate.Spec.Functions[i].Name,
 			Source: pipelineToUpdate.Spec.Functions[i].Source,
+			Shape:  pipelineToUpdate.Spec.Functions[i].Shape,
 		}
 		functions = append(functions, fn)
 	}
@@ -282,6 +295,7 @@ func (s *Server) GetTransformFunction(ctx context.Context, request *pb.GetTransf
 			tf.ID = pipelineToUpdate.Spec.Functions[i].ID
 			tf.Name = pipelineToUpdate.Spec.Functions[i].Name
 			tf.Source = pipelineToUpdate.Spec.Functions[i].Source
+			tf.Shape = pipelineToUpdate.Spec.Functions[i].Shape
 		}
 	}
 
@@ -290,3 +304,36 @@ func (s *Server) GetTransformFunction(ctx context.Context, request *pb.GetTransf
 
 	return response, nil
 }
+
+// TestTransformFunction compiles a transform function in a sandboxed
+// interpreter and runs it on the sample values, the function is not
+// saved
+func (s *Server) TestTransformFunction(ctx context.Context, request *pb.TestTransformFunctionRequest) (response *pb.TestTransformFunctionResponse, err error) {
+
+	response = &pb.TestTransformFunctionResponse{}
+
+	var fn domain.TransformFunction
+	err = json.Unmarshal([]byte(request.FunctionString), &fn)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+	if fn.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"transform name is required")
+	}
+	if fn.Source == "" {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"transform source is required")
+	}
+
+	shape, results, err := transform.DryRun(fn, request.Samples)
+	if err != nil {
+		response.CompileError = err.Error()
+		return response, nil
+	}
+
+	b, _ := json.Marshal(results)
+	response.Shape = shape
+	response.ResultsString = string(b)
+	return response, nil
+}
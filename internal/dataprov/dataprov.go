@@ -15,6 +15,12 @@
 package dataprov
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
 	"time"
 
 	"github.com/churrodata/churro/api/v1alpha1"
@@ -24,12 +30,23 @@ import (
 	"github.com/rs/xid"
 )
 
+// ErrDuplicate is returned by Register when a file with the same
+// fingerprint was registered before
+var ErrDuplicate = errors.New("duplicate file")
+
 // Register a new data provenance instance, return an error
-// if it can not be registered with churro
-func Register(dp *domain.DataProvenance, pipeline v1alpha1.Pipeline, dbCreds config.DBCredentials) (err error) {
+// if it can not be registered with churro.  The fingerprint of the
+// file at the path of the data provenance is registered with it, when
+// a file with the same fingerprint was registered before ErrDuplicate
+// is returned unless force is set.
+func Register(dp *domain.DataProvenance, pipeline v1alpha1.Pipeline, dbCreds config.DBCredentials, member string, force bool) (err error) {
 
 	dp.LastUpdated = time.Now()
 	dp.ID = xid.New().String()
+	dp.Fingerprint, err = Fingerprint(dp.Path, member)
+	if err != nil {
+		return err
+	}
 	// register the id with the churro data store
 
 	var churroDB db.ChurroDatabase
@@ -43,7 +60,42 @@ func Register(dp *domain.DataProvenance, pipeline v1alpha1.Pipeline, dbCreds con
 		return err
 	}
 
+	if dp.Fingerprint != "" {
+		earlier, err := churroDB.GetDataprovsByFingerprint(dp.Fingerprint)
+		if err != nil {
+			return err
+		}
+		if len(earlier) > 0 && !force {
+			return fmt.Errorf("%w, %s has the fingerprint of %s registered as %s at %s", ErrDuplicate, dp.Name, earlier[0].Name, earlier[0].ID, earlier[0].LastUpdated.Format(time.RFC3339))
+		}
+	}
+
 	err = churroDB.CreateDataprov(*dp)
 
 	return err
 }
+
+// Fingerprint returns the SHA-256 of the file at path, the name of the
+// member is added for a member of an archive.  It is blank when path is
+// not a regular file, like the URL of an API extract source.
+func Fingerprint(path, member string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return "", nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	if member != "" {
+		io.WriteString(h, "!"+member)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
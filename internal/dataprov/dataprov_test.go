@@ -1,6 +1,9 @@
 package dataprov
 
 import (
+	"os"
+	"path/filepath"
+
 	"github.com/churrodata/churro/api/v1alpha1"
 	"github.com/churrodata/churro/internal/domain"
 	"github.com/churrodata/churro/pkg/config"
@@ -17,9 +20,41 @@ func TestRegister(t *testing.T) {
 		},
 	}
 	dbcreds := config.DBCredentials{}
-	err := Register(dp, pipeline, dbcreds)
+	err := Register(dp, pipeline, dbcreds, "", false)
 	if err != nil {
 		t.Fatalf("dataprov.Register Error: %v", err)
 	}
 
 }
+
+func TestFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	a := filepath.Join(dir, "a.csv")
+	b := filepath.Join(dir, "b.csv")
+	c := filepath.Join(dir, "c.csv")
+	for path, contents := range map[string]string{a: "id,name\n1,x\n", b: "id,name\n1,x\n", c: "id,name\n2,y\n"} {
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fa, err := Fingerprint(a, "")
+	if err != nil {
+		t.Fatalf("Fingerprint Error: %v", err)
+	}
+	if len(fa) != 64 {
+		t.Fatalf("Fingerprint got %q", fa)
+	}
+	if fb, _ := Fingerprint(b, ""); fb != fa {
+		t.Errorf("Fingerprint of identical files differ %s %s", fa, fb)
+	}
+	if fc, _ := Fingerprint(c, ""); fc == fa {
+		t.Error("Fingerprint of different files are the same")
+	}
+	if fm, _ := Fingerprint(a, "member.csv"); fm == fa {
+		t.Error("Fingerprint of an archive member should include the member name")
+	}
+	if f, err := Fingerprint("https://example.com/api", ""); err != nil || f != "" {
+		t.Errorf("Fingerprint of a URL got %q %v", f, err)
+	}
+}
@@ -15,10 +15,13 @@ import (
 
 	extractapi "github.com/churrodata/churro/api/extract"
 	"github.com/churrodata/churro/api/v1alpha1"
+	"github.com/churrodata/churro/internal/db/clickhouse"
 	"github.com/churrodata/churro/internal/db/cockroachdb"
 	"github.com/churrodata/churro/internal/db/mockdb"
 	"github.com/churrodata/churro/internal/db/mysql"
+	"github.com/churrodata/churro/internal/db/postgres"
 	"github.com/churrodata/churro/internal/db/singlestore"
+	"github.com/churrodata/churro/internal/db/sqlite"
 	"github.com/churrodata/churro/internal/domain"
 	"github.com/churrodata/churro/internal/stats"
 
@@ -48,10 +51,20 @@ type ChurroDatabase interface {
 	CreateTable(userid, dbname, tableName string, columnNames, columnTypes []string) error
 	GetInsertStatement(scheme, database, tablename string, cols []string, vals []interface{}, key int64) error
 	GetBulkInsertStatement(scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string) error
+	GetUpsertStatement(scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string) error
+	GetReplacePartitionStatement(scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string, keyCols []string, partitions [][]interface{}) error
+	GetTableColumns(database, tableName string) (map[string]string, error)
+	GetColumnType(columnType string) (string, error)
+	AddColumn(database, tableName, columnName, columnType string) error
+	GetReferenceRows(database, tableName string, columns []string) ([][]interface{}, error)
 
 	UpdatePipelineStats(t stats.PipelineStats) error
 
 	CreateDataprov(d domain.DataProvenance) error
+	GetDataprovsByFingerprint(fingerprint string) ([]domain.DataProvenance, error)
+	ClearDataprovFingerprint(id string) error
+	CreatePrivacyAudit(a domain.PrivacyAudit) error
+	GetPrivacyAudits(dataprovID string) ([]domain.PrivacyAudit, error)
 
 	CreateAuthenticatedUser(u domain.AuthenticatedUser) error
 	DeleteAuthenticatedUser(id string) error
@@ -72,8 +85,18 @@ type ChurroDatabase interface {
 	Bootstrap() error
 	CreateExtractLog(p domain.JobProfile) error
 	UpdateExtractLog(p domain.JobProfile) error
+	UpdateExtractLogStatus(id, status, errorText string) error
 	GetExtractLog(jobName string) (domain.JobProfile, error)
 	GetExtractLogById(id string) (domain.JobProfile, error)
+	CreateSchemaVersion(v domain.SchemaVersion) error
+	GetSchemaVersions(tableName string) ([]domain.SchemaVersion, error)
+}
+
+// BulkCopier is implemented by the databases able to load a large
+// batch of records with a bulk copy protocol, the number of records
+// copied is returned
+type BulkCopier interface {
+	CopyRecords(scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string) (int64, error)
 }
 
 // NewChurroDB ...
@@ -87,6 +110,15 @@ func NewChurroDB(dbType string) (ChurroDatabase, error) {
 	if dbType == domain.DatabaseSinglestore {
 		return &singlestore.SinglestoreChurroDatabase{}, nil
 	}
+	if dbType == domain.DatabasePostgres {
+		return &postgres.PostgresChurroDatabase{}, nil
+	}
+	if dbType == domain.DatabaseSqlite {
+		return &sqlite.SqliteChurroDatabase{}, nil
+	}
+	if dbType == domain.DatabaseClickhouse {
+		return &clickhouse.ClickhouseChurroDatabase{}, nil
+	}
 	if dbType == domain.DatabaseMock {
 		return &mockdb.MockChurroDatabase{}, nil
 	}
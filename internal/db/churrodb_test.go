@@ -0,0 +1,390 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db/clickhouse"
+	"github.com/churrodata/churro/internal/db/cockroachdb"
+	"github.com/churrodata/churro/internal/db/mysql"
+	"github.com/churrodata/churro/internal/db/postgres"
+	"github.com/churrodata/churro/internal/db/singlestore"
+	"github.com/churrodata/churro/internal/db/sqlite"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/churrodata/churro/internal/stats"
+)
+
+// values a CSV cell or httppost body could carry
+var hostileValues = []string{
+	`O'Brien`,
+	`'); DROP TABLE churro; --`,
+	`\'; select 1; --`,
+	`" or "1"="1`,
+	"`; drop database churro; `",
+	"line\nbreak\x00nul",
+}
+
+// table, column and database names that must be refused
+var hostileIdentifiers = []string{
+	"",
+	"1table",
+	"my table",
+	"mytable; drop table churro",
+	`my"table`,
+	"my`table",
+	"mytable--",
+	strings.Repeat("a", 64),
+}
+
+// the calls a driver does not implement, they send no statement
+var unimplemented = map[string]bool{
+	domain.DatabaseSinglestore + " UpdatePipelineStats": true,
+}
+
+// recorder is a database/sql driver standing in for a real database,
+// it records every statement and its arguments
+type recorder struct {
+	mu         sync.Mutex
+	statements []recordedStatement
+}
+
+type recordedStatement struct {
+	query string
+	args  []driver.Value
+}
+
+func (r *recorder) Connect(ctx context.Context) (driver.Conn, error) {
+	return &recorderConn{r: r}, nil
+}
+
+func (r *recorder) Driver() driver.Driver {
+	return recorderDriver{r: r}
+}
+
+func (r *recorder) record(query string, args []driver.Value) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statements = append(r.statements, recordedStatement{query: query, args: args})
+}
+
+func (r *recorder) reset() []recordedStatement {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := r.statements
+	r.statements = nil
+	return s
+}
+
+type recorderDriver struct {
+	r *recorder
+}
+
+func (d recorderDriver) Open(name string) (driver.Conn, error) {
+	return &recorderConn{r: d.r}, nil
+}
+
+type recorderConn struct {
+	r *recorder
+}
+
+func (c *recorderConn) Prepare(query string) (driver.Stmt, error) {
+	return &recorderStmt{r: c.r, query: query}, nil
+}
+
+func (c *recorderConn) Close() error { return nil }
+
+func (c *recorderConn) Begin() (driver.Tx, error) { return recorderTx{}, nil }
+
+type recorderTx struct{}
+
+func (t recorderTx) Commit() error   { return nil }
+func (t recorderTx) Rollback() error { return nil }
+
+type recorderStmt struct {
+	r     *recorder
+	query string
+}
+
+func (s *recorderStmt) Close() error  { return nil }
+func (s *recorderStmt) NumInput() int { return -1 }
+
+func (s *recorderStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.r.record(s.query, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *recorderStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.r.record(s.query, args)
+	return emptyRows{}, nil
+}
+
+// emptyRows makes every QueryRow return sql.ErrNoRows
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string              { return nil }
+func (emptyRows) Close() error                   { return nil }
+func (emptyRows) Next(dest []driver.Value) error { return io.EOF }
+
+type driverUnderTest struct {
+	name string
+	db   ChurroDatabase
+}
+
+func getDriversUnderTest(conn *sql.DB) []driverUnderTest {
+	return []driverUnderTest{
+		{domain.DatabaseCockroach, &cockroachdb.CockroachChurroDatabase{Connection: conn}},
+		{domain.DatabaseMysql, &mysql.MysqlChurroDatabase{Connection: conn}},
+		{domain.DatabaseSinglestore, &singlestore.SinglestoreChurroDatabase{Connection: conn}},
+		{domain.DatabasePostgres, &postgres.PostgresChurroDatabase{Connection: conn}},
+		{domain.DatabaseSqlite, &sqlite.SqliteChurroDatabase{Connection: conn}},
+		{domain.DatabaseClickhouse, &clickhouse.ClickhouseChurroDatabase{Connection: conn}},
+	}
+}
+
+func getHostileRecords(value string, count int) []extractapi.GenericRow {
+	records := make([]extractapi.GenericRow, 0, count)
+	for i := 0; i < count; i++ {
+		records = append(records, extractapi.GenericRow{
+			Key:  int64(i),
+			Cols: []interface{}{value, "null"},
+		})
+	}
+	return records
+}
+
+func TestHostileValues(t *testing.T) {
+	rec := &recorder{}
+	conn := sql.OpenDB(rec)
+	defer conn.Close()
+
+	cols := []string{"city", "zip"}
+	colTypes := []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_INT}
+
+	for _, d := range getDriversUnderTest(conn) {
+		for _, h := range hostileValues {
+			// the returned errors come from the stand-in having no
+			// rows, only the statements sent are of interest here
+			calls := map[string]func() error{
+				"GetInsertStatement": func() error {
+					return d.db.GetInsertStatement(extractapi.CSVScheme, "pipeline1", "mytable", []string{"metadata"}, []interface{}{h}, 1)
+				},
+				"GetBulkInsertStatement": func() error {
+					return d.db.GetBulkInsertStatement(extractapi.CSVScheme, "pipeline1", "mytable", cols, getHostileRecords(h, 3), colTypes)
+				},
+				"GetBulkInsertStatement large batch": func() error {
+					return d.db.GetBulkInsertStatement(extractapi.CSVScheme, "pipeline1", "mytable", cols, getHostileRecords(h, 600), colTypes)
+				},
+				"GetUpsertStatement": func() error {
+					return d.db.GetUpsertStatement(extractapi.CSVScheme, "pipeline1", "mytable", cols, getHostileRecords(h, 3), colTypes)
+				},
+				"GetReplacePartitionStatement": func() error {
+					return d.db.GetReplacePartitionStatement(extractapi.CSVScheme, "pipeline1", "mytable", cols, getHostileRecords(h, 3), colTypes, []string{"city"}, [][]interface{}{{h}})
+				},
+				"UpdatePipelineMetric": func() error {
+					return d.db.UpdatePipelineMetric(domain.PipelineMetric{Name: h, Value: h})
+				},
+				"UpdateExtractSourceMetric": func() error {
+					return d.db.UpdateExtractSourceMetric(domain.ExtractSourceMetric{ExtractSourceID: h, Name: h, Value: h})
+				},
+				"GetExtractSourceMetrics": func() error {
+					_, err := d.db.GetExtractSourceMetrics(h)
+					return err
+				},
+				"CreateExtractLog": func() error {
+					return d.db.CreateExtractLog(domain.JobProfile{ID: h, JobName: h, FileName: h, TableName: h})
+				},
+				"UpdateExtractLog": func() error {
+					return d.db.UpdateExtractLog(domain.JobProfile{ID: h, RecordsLoaded: 5})
+				},
+				"CreateSchemaVersion": func() error {
+					return d.db.CreateSchemaVersion(domain.SchemaVersion{TableName: h, Version: 1, ExtractSourceID: h, Columns: h})
+				},
+				"GetSchemaVersions": func() error {
+					_, err := d.db.GetSchemaVersions(h)
+					return err
+				},
+				"UpdatePipelineStats": func() error {
+					return d.db.UpdatePipelineStats(stats.PipelineStats{Pipeline: "pipeline1", DataprovID: h, FileName: h, RecordsIn: 5})
+				},
+				"CreateDataprov": func() error {
+					return d.db.CreateDataprov(domain.DataProvenance{ID: h, Name: h, Path: h})
+				},
+				"UpdateUserProfile": func() error {
+					return d.db.UpdateUserProfile(domain.UserProfile{ID: h, FirstName: h, LastName: h, Email: h, Password: h, Access: h})
+				},
+				"DeleteUserProfile": func() error {
+					return d.db.DeleteUserProfile(h)
+				},
+				"DeleteAuthenticatedUser": func() error {
+					return d.db.DeleteAuthenticatedUser(h)
+				},
+				"UpdateUserPipelineAccess": func() error {
+					return d.db.UpdateUserPipelineAccess(domain.UserPipelineAccess{UserProfileID: h, PipelineID: h, Access: h})
+				},
+				"DeleteUserPipelineAccess": func() error {
+					return d.db.DeleteUserPipelineAccess(h, h)
+				},
+				"DeleteAllUserPipelineAccess": func() error {
+					return d.db.DeleteAllUserPipelineAccess(h)
+				},
+			}
+
+			if copier, ok := d.db.(BulkCopier); ok {
+				calls["CopyRecords"] = func() error {
+					_, err := copier.CopyRecords(extractapi.CSVScheme, "pipeline1", "mytable", cols, getHostileRecords(h, 3), colTypes)
+					return err
+				}
+			}
+
+			for name, call := range calls {
+				rec.reset()
+				call()
+				statements := rec.reset()
+				if len(statements) == 0 {
+					if unimplemented[d.name+" "+name] {
+						continue
+					}
+					t.Fatalf("%s %s sent no statement for the value %q", d.name, name, h)
+				}
+				var bound bool
+				for _, s := range statements {
+					if strings.Contains(s.query, h) {
+						t.Fatalf("%s %s wrote the value %q into the statement %s", d.name, name, h, s.query)
+					}
+					for _, a := range s.args {
+						if fmt.Sprintf("%v", a) == h {
+							bound = true
+						}
+					}
+				}
+				if !bound {
+					t.Fatalf("%s %s did not bind the value %q as an argument", d.name, name, h)
+				}
+			}
+		}
+	}
+}
+
+func TestHostileIdentifiers(t *testing.T) {
+	rec := &recorder{}
+	conn := sql.OpenDB(rec)
+	defer conn.Close()
+
+	records := getHostileRecords("boerne", 1)
+
+	for _, d := range getDriversUnderTest(conn) {
+		for _, h := range hostileIdentifiers {
+			calls := map[string]func() error{
+				"CreateTable table": func() error {
+					return d.db.CreateTable("pipeline1", "pipeline1", h, []string{"city"}, []string{extractapi.COLTYPE_TEXT})
+				},
+				"CreateTable column": func() error {
+					return d.db.CreateTable("pipeline1", "pipeline1", "mytable", []string{h}, []string{extractapi.COLTYPE_TEXT})
+				},
+				"CreateTable database": func() error {
+					return d.db.CreateTable("pipeline1", h, "mytable", []string{"city"}, []string{extractapi.COLTYPE_TEXT})
+				},
+				"GetInsertStatement table": func() error {
+					return d.db.GetInsertStatement(extractapi.CSVScheme, "pipeline1", h, []string{"metadata"}, []interface{}{"x"}, 1)
+				},
+				"GetInsertStatement column": func() error {
+					return d.db.GetInsertStatement(extractapi.CSVScheme, "pipeline1", "mytable", []string{h}, []interface{}{"x"}, 1)
+				},
+				"GetBulkInsertStatement table": func() error {
+					return d.db.GetBulkInsertStatement(extractapi.CSVScheme, "pipeline1", h, []string{"city", "zip"}, records, []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_INT})
+				},
+				"GetBulkInsertStatement column": func() error {
+					return d.db.GetBulkInsertStatement(extractapi.CSVScheme, "pipeline1", "mytable", []string{"city", h}, records, []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_INT})
+				},
+				"GetUpsertStatement table": func() error {
+					return d.db.GetUpsertStatement(extractapi.CSVScheme, "pipeline1", h, []string{"city", "zip"}, records, []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_INT})
+				},
+				"GetUpsertStatement column": func() error {
+					return d.db.GetUpsertStatement(extractapi.CSVScheme, "pipeline1", "mytable", []string{"city", h}, records, []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_INT})
+				},
+				"GetReplacePartitionStatement table": func() error {
+					return d.db.GetReplacePartitionStatement(extractapi.CSVScheme, "pipeline1", h, []string{"city", "zip"}, records, []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_INT}, []string{"city"}, [][]interface{}{{"boerne"}})
+				},
+				"GetReplacePartitionStatement key column": func() error {
+					return d.db.GetReplacePartitionStatement(extractapi.CSVScheme, "pipeline1", "mytable", []string{"city", "zip"}, records, []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_INT}, []string{h}, [][]interface{}{{"boerne"}})
+				},
+				"GetTableColumns table": func() error {
+					_, err := d.db.GetTableColumns("pipeline1", h)
+					return err
+				},
+				"GetTableColumns database": func() error {
+					_, err := d.db.GetTableColumns(h, "mytable")
+					return err
+				},
+				"AddColumn table": func() error {
+					return d.db.AddColumn("pipeline1", h, "city", extractapi.COLTYPE_TEXT)
+				},
+				"AddColumn column": func() error {
+					return d.db.AddColumn("pipeline1", "mytable", h, extractapi.COLTYPE_TEXT)
+				},
+				"AddColumn database": func() error {
+					return d.db.AddColumn(h, "mytable", "city", extractapi.COLTYPE_TEXT)
+				},
+				"CreatePipelineDatabase": func() error {
+					return d.db.CreatePipelineDatabase(h)
+				},
+				"CreatePipelineObjects": func() error {
+					return d.db.CreatePipelineObjects(h, "pipeline1")
+				},
+				"CreateUser": func() error {
+					return d.db.CreateUser(h, "secret")
+				},
+				"UpdatePipelineStats": func() error {
+					if d.name == domain.DatabaseMysql || d.name == domain.DatabaseSinglestore {
+						// mysql and singlestore do not qualify the
+						// stats table with the pipeline database
+						return fmt.Errorf("skipped")
+					}
+					return d.db.UpdatePipelineStats(stats.PipelineStats{Pipeline: h, FileName: "a.csv"})
+				},
+			}
+
+			if copier, ok := d.db.(BulkCopier); ok {
+				calls["CopyRecords table"] = func() error {
+					_, err := copier.CopyRecords(extractapi.CSVScheme, "pipeline1", h, []string{"city", "zip"}, records, []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_INT})
+					return err
+				}
+				calls["CopyRecords column"] = func() error {
+					_, err := copier.CopyRecords(extractapi.CSVScheme, "pipeline1", "mytable", []string{"city", h}, records, []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_INT})
+					return err
+				}
+			}
+
+			for name, call := range calls {
+				rec.reset()
+				err := call()
+				statements := rec.reset()
+				if err == nil {
+					t.Fatalf("%s %s accepted the identifier %q", d.name, name, h)
+				}
+				if len(statements) > 0 {
+					t.Fatalf("%s %s sent %q for the identifier %q", d.name, name, statements[0].query, h)
+				}
+			}
+		}
+
+		rec.reset()
+		err := d.db.CreateTable("pipeline1", "pipeline1", "mytable", []string{"city"}, []string{"TEXT); DROP TABLE churro; --"})
+		if err == nil || len(rec.reset()) > 0 {
+			t.Fatalf("%s CreateTable accepted a hostile column type", d.name)
+		}
+
+		rec.reset()
+		err = d.db.AddColumn("pipeline1", "mytable", "city", "TEXT; DROP TABLE churro; --")
+		if err == nil || len(rec.reset()) > 0 {
+			t.Fatalf("%s AddColumn accepted a hostile column type", d.name)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package clickhouse
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/churrodata/churro/internal/db/sqlsafe"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/rs/xid"
+)
+
+// the auth tables are small and rarely change, rows are deleted with
+// a mutation which clickhouse applies in the background
+
+func (d ClickhouseChurroDatabase) CreateAuthenticatedUser(u domain.AuthenticatedUser) error {
+	u.ID = xid.New().String()
+	INSERT := "INSERT INTO authenticateduser (id, token, locked, lastupdated) VALUES (?, ?, ?, ?)"
+
+	err := d.insert(INSERT, []interface{}{u.ID, u.Token, u.Locked, time.Now()})
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d ClickhouseChurroDatabase) DeleteAuthenticatedUser(id string) (err error) {
+	_, err = d.Connection.Exec("ALTER TABLE authenticateduser DELETE where id=?", id)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d ClickhouseChurroDatabase) GetUserPipelineAccess(pipeline, id string) (a domain.UserPipelineAccess, err error) {
+	a.PipelineID = pipeline
+	a.UserProfileID = id
+
+	row := d.Connection.QueryRow("SELECT access, lastupdated FROM userpipelineaccess FINAL where pipelineid=? and userprofileid=?", pipeline, id)
+	switch err := row.Scan(&a.Access, &a.LastUpdated); err {
+	case sql.ErrNoRows:
+		log.Error().Stack().Err(err).Msg("userpipelineaccess id was not found")
+		return a, err
+	case nil:
+		log.Info().Msg("userpipelineaccess id was found")
+		return a, nil
+	default:
+		return a, err
+	}
+}
+
+func (d ClickhouseChurroDatabase) CreateUserPipelineAccess(a domain.UserPipelineAccess) error {
+	var INSERT = "INSERT INTO userpipelineaccess (userprofileid, pipelineid, access, lastupdated) VALUES (?, ?, ?, ?)"
+
+	err := d.insert(INSERT, []interface{}{a.UserProfileID, a.PipelineID, a.Access, time.Now()})
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateUserPipelineAccess inserts the new version of the access
+func (d ClickhouseChurroDatabase) UpdateUserPipelineAccess(a domain.UserPipelineAccess) error {
+	var INSERT = "INSERT INTO userpipelineaccess (userprofileid, pipelineid, access, lastupdated) VALUES (?, ?, ?, ?)"
+
+	err := d.insert(INSERT, []interface{}{a.UserProfileID, a.PipelineID, a.Access, time.Now()})
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d ClickhouseChurroDatabase) DeleteAllUserPipelineAccess(pipeline string) error {
+	_, err := d.Connection.Exec("ALTER TABLE userpipelineaccess DELETE where pipelineid = ?", pipeline)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d ClickhouseChurroDatabase) DeleteUserPipelineAccess(pipeline, id string) error {
+	_, err := d.Connection.Exec("ALTER TABLE userpipelineaccess DELETE where pipelineid = ? and userprofileid = ?", pipeline, id)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d ClickhouseChurroDatabase) CreateUserProfile(u domain.UserProfile) error {
+	u.ID = xid.New().String()
+	return d.UpdateUserProfile(u)
+}
+
+// UpdateUserProfile inserts the new version of the user profile
+func (d ClickhouseChurroDatabase) UpdateUserProfile(u domain.UserProfile) error {
+	INSERT := "INSERT INTO userprofile (id, firstname, lastname, password, access, email, lastupdated) VALUES (?, ?, ?, ?, ?, ?, ?)"
+
+	err := d.insert(INSERT, []interface{}{u.ID, u.FirstName, u.LastName, u.Password, u.Access, u.Email, time.Now()})
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d ClickhouseChurroDatabase) DeleteUserProfile(id string) (err error) {
+	_, err = d.Connection.Exec("ALTER TABLE userprofile DELETE where id=?", id)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d ClickhouseChurroDatabase) Authenticate(email, password string) (u domain.UserProfile, err error) {
+	return u, nil
+}
+
+func (d ClickhouseChurroDatabase) GetAllUserProfile() (users []domain.UserProfile, err error) {
+	users = make([]domain.UserProfile, 0)
+
+	rows, err := d.Connection.Query("SELECT id, firstname, lastname, password, access, email, lastupdated FROM userprofile FINAL")
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return users, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p := domain.UserProfile{}
+		err = rows.Scan(&p.ID, &p.FirstName, &p.LastName, &p.Password, &p.Access, &p.Email, &p.LastUpdated)
+		if err != nil {
+			log.Error().Stack().Err(err)
+			return users, err
+		}
+		users = append(users, p)
+	}
+
+	return users, nil
+}
+
+func (d ClickhouseChurroDatabase) GetAllUserProfileForPipeline(pipelineid string) (users []domain.UserProfile, err error) {
+	users = make([]domain.UserProfile, 0)
+
+	rows, err := d.Connection.Query("SELECT a.id, a.firstname, a.lastname, a.password, a.access, a.email, a.lastupdated FROM userprofile AS a FINAL INNER JOIN (SELECT userprofileid FROM userpipelineaccess FINAL where pipelineid = ?) AS b ON a.id = b.userprofileid", pipelineid)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return users, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p := domain.UserProfile{}
+		err = rows.Scan(&p.ID, &p.FirstName, &p.LastName, &p.Password, &p.Access, &p.Email, &p.LastUpdated)
+		if err != nil {
+			log.Error().Stack().Err(err)
+			return users, err
+		}
+		users = append(users, p)
+	}
+	return users, nil
+}
+
+func (d ClickhouseChurroDatabase) GetUserProfileByEmail(email string) (u domain.UserProfile, err error) {
+	row := d.Connection.QueryRow("SELECT id, firstname, lastname, password, access, email, lastupdated FROM userprofile FINAL where email=?", email)
+	switch err := row.Scan(&u.ID, &u.FirstName, &u.LastName, &u.Password, &u.Access, &u.Email, &u.LastUpdated); err {
+	case sql.ErrNoRows:
+		log.Error().Stack().Err(err).Msg("userprofile email was not found" + email)
+		return u, err
+	case nil:
+		return u, nil
+	default:
+		return u, err
+	}
+}
+
+func (d ClickhouseChurroDatabase) GetUserProfile(id string) (u domain.UserProfile, err error) {
+	row := d.Connection.QueryRow("SELECT id, firstname, lastname, password, access, email, lastupdated FROM userprofile FINAL where id=?", id)
+	switch err := row.Scan(&u.ID, &u.FirstName, &u.LastName, &u.Password, &u.Access, &u.Email, &u.LastUpdated); err {
+	case sql.ErrNoRows:
+		log.Error().Stack().Err(err).Msg("userprofile id was not found")
+		return u, err
+	case nil:
+		log.Info().Msg("userprofile id was found")
+		return u, nil
+	default:
+		return u, err
+	}
+}
+
+func (d ClickhouseChurroDatabase) Bootstrap() (err error) {
+	var id string
+	bootstrapID := "0000"
+	row := d.Connection.QueryRow("SELECT id FROM userprofile FINAL where id=?", bootstrapID)
+	switch err := row.Scan(&id); err {
+	case sql.ErrNoRows:
+	case nil:
+		return nil
+	default:
+		return err
+	}
+
+	return d.UpdateUserProfile(domain.UserProfile{
+		ID:        bootstrapID,
+		FirstName: "admin",
+		LastName:  "admin",
+		Password:  "admin",
+		Access:    "Admin",
+		Email:     "admin@admin.org",
+	})
+}
+
+func (d ClickhouseChurroDatabase) CreateChurroDatabase(dbName string) (err error) {
+	database, err := sqlsafe.QuoteClickHouse(dbName)
+	if err != nil {
+		return err
+	}
+
+	// make sure churro admin database is created
+	sqlStr := fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s", database)
+	_, err = d.Connection.Exec(sqlStr)
+	log.Info().Msg(sqlStr)
+	if err != nil {
+		return err
+	}
+	log.Info().Msg("Successfully created database " + dbName)
+	return nil
+
+}
+
+func (d ClickhouseChurroDatabase) CreateAuthObjects() (err error) {
+
+	// create AuthenticatedUser
+	_, err = d.Connection.Exec("CREATE TABLE IF NOT EXISTS authenticateduser (id String, token String, locked UInt8, lastupdated DateTime) ENGINE = ReplacingMergeTree(lastupdated) ORDER BY id")
+	if err != nil {
+		return err
+	}
+
+	// create UserProfile
+	_, err = d.Connection.Exec("CREATE TABLE IF NOT EXISTS userprofile (id String, firstname String, lastname String, password String, access String, email String, lastupdated DateTime) ENGINE = ReplacingMergeTree(lastupdated) ORDER BY id")
+	if err != nil {
+		return err
+	}
+	// create UserPipelineAccess
+	_, err = d.Connection.Exec("CREATE TABLE IF NOT EXISTS userpipelineaccess (userprofileid String, pipelineid String, access String, lastupdated DateTime) ENGINE = ReplacingMergeTree(lastupdated) ORDER BY (pipelineid, userprofileid)")
+	if err != nil {
+		return err
+	}
+	return nil
+}
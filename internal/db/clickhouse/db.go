@@ -0,0 +1,273 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package clickhouse implements the churro database on an externally
+// provided clickhouse server for append heavy, analytical pipelines.
+// Each churro database (churro, pipeline1, ...) is a clickhouse
+// database.  Clickhouse does not update rows in place, so the churro
+// bookkeeping tables are ReplacingMergeTree tables versioned by
+// lastupdated, an update inserts the new version of a row and reads
+// select FINAL to see only the latest version.
+package clickhouse
+
+import (
+	"database/sql"
+	b64 "encoding/base64"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	_ "github.com/ClickHouse/clickhouse-go"
+
+	"github.com/churrodata/churro/api/v1alpha1"
+	"github.com/churrodata/churro/internal/db/sqlsafe"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/churrodata/churro/internal/stats"
+	"github.com/churrodata/churro/pkg/config"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	DB_CLICKHOUSE = "clickhouse"
+)
+
+// the native protocol port used when the source does not specify one
+const defaultPort = 9000
+
+type ClickhouseChurroDatabase struct {
+	Connection *sql.DB
+	namespace  string
+}
+
+func (d ClickhouseChurroDatabase) GetVersion() (string, error) {
+	var version string
+	err := d.Connection.QueryRow("SELECT version()").Scan(&version)
+	if err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+func (d ClickhouseChurroDatabase) CreateObjects(dbName string) error {
+
+	database, err := sqlsafe.QuoteClickHouse(dbName)
+	if err != nil {
+		return err
+	}
+
+	// make sure churro admin database is created
+	sqlStr := fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s", database)
+	_, err = d.Connection.Exec(sqlStr)
+	log.Info().Msg(sqlStr)
+	if err != nil {
+		return err
+	}
+	log.Info().Msg("Successfully created database " + dbName)
+
+	sqlStr = fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.pipelinemetric ( name String, value String, lastupdated DateTime ) ENGINE = ReplacingMergeTree(lastupdated) ORDER BY name", database)
+	log.Info().Msg(sqlStr)
+	_, err = d.Connection.Exec(sqlStr)
+	if err != nil {
+		return err
+	}
+	log.Info().Msg("pipelinemetric Table created successfully..")
+
+	// seed the metric table unless it already is
+	sqlStr = fmt.Sprintf("INSERT INTO %s.pipelinemetric ( name, value, lastupdated ) SELECT ?, '0', now() WHERE (SELECT count() FROM %s.pipelinemetric WHERE name = ?) = 0", database, database)
+	log.Info().Msg(sqlStr)
+	_, err = d.Connection.Exec(sqlStr, domain.MetricFilesProcessed, domain.MetricFilesProcessed)
+	if err != nil {
+		return err
+	}
+
+	sqlStr = fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.extractsourcemetric ( extractsourceid String, name String, value String, lastupdated DateTime ) ENGINE = ReplacingMergeTree(lastupdated) ORDER BY (extractsourceid, name)", database)
+	log.Info().Msg(sqlStr)
+	_, err = d.Connection.Exec(sqlStr)
+	if err != nil {
+		return err
+	}
+	log.Info().Msg("extractsourcemetric Table created successfully..")
+
+	return nil
+}
+
+func (d ClickhouseChurroDatabase) GetDatabaseType() string {
+	return DB_CLICKHOUSE
+}
+
+// GetConnection connects to the clickhouse server with the native
+// protocol, the source Database is the one unqualified table names are
+// found in.  Set CLICKHOUSE_SECURE to true to connect with TLS.
+func (d *ClickhouseChurroDatabase) GetConnection(dbCreds config.DBCredentials, source v1alpha1.Source) (err error) {
+	d.namespace = os.Getenv("CHURRO_NAMESPACE")
+	if d.namespace == "" {
+		log.Error().Stack().Msg("error CHURRO_NAMESPACE is empty")
+		return fmt.Errorf("CHURRO_NAMESPACE env var required")
+	}
+
+	connectString, err := getConnectString(source, os.Getenv("CLICKHOUSE_SECURE"))
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in GetConnection")
+		return err
+	}
+	d.Connection, err = sql.Open("clickhouse", connectString)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in GetConnection")
+		return err
+	}
+
+	return nil
+}
+
+func getConnectString(source v1alpha1.Source, secure string) (string, error) {
+	if source.Host == "" {
+		return "", fmt.Errorf("clickhouse host is required")
+	}
+	password, err := getPassword(source.Password)
+	if err != nil {
+		return "", err
+	}
+
+	port := source.Port
+	if port == 0 {
+		port = defaultPort
+	}
+	q := url.Values{}
+	q.Set("username", source.Username)
+	q.Set("password", password)
+	if source.Database != "" {
+		if err := sqlsafe.ValidIdentifier(source.Database); err != nil {
+			return "", err
+		}
+		q.Set("database", source.Database)
+	}
+	if secure != "" {
+		if _, err := strconv.ParseBool(secure); err != nil {
+			return "", fmt.Errorf("CLICKHOUSE_SECURE %q is not true or false", secure)
+		}
+		q.Set("secure", secure)
+	}
+
+	u := url.URL{
+		Scheme:   "tcp",
+		Host:     net.JoinHostPort(source.Host, strconv.Itoa(port)),
+		RawQuery: q.Encode(),
+	}
+	return u.String(), nil
+}
+
+// getPassword decodes a password, the pipeline CR holds passwords
+// base64 encoded
+func getPassword(encoded string) (string, error) {
+	b, err := b64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("clickhouse password is not base64 encoded %v", err)
+	}
+	return string(b), nil
+}
+
+// insert inserts rows using the native protocol, clickhouse-go only
+// sends an INSERT ... VALUES within a transaction, the rows of which
+// are sent as a block when the transaction commits
+func (d ClickhouseChurroDatabase) insert(query string, rows ...[]interface{}) error {
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for _, r := range rows {
+		if _, err := stmt.Exec(r...); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (d ClickhouseChurroDatabase) CreateDataprov(data domain.DataProvenance) error {
+	return d.insert("INSERT INTO dataprov (id, name, path, fingerprint, lastupdated) VALUES (?, ?, ?, ?, ?)",
+		[]interface{}{data.ID, data.Name, data.Path, data.Fingerprint, data.LastUpdated})
+}
+
+// GetDataprovsByFingerprint returns the data provenance of the files
+// registered with a fingerprint, oldest first
+func (d ClickhouseChurroDatabase) GetDataprovsByFingerprint(fingerprint string) ([]domain.DataProvenance, error) {
+	rows, err := d.Connection.Query("SELECT id, name, path, fingerprint, lastupdated FROM dataprov FINAL WHERE fingerprint = ? ORDER BY lastupdated", fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dps := make([]domain.DataProvenance, 0)
+	for rows.Next() {
+		dp := domain.DataProvenance{}
+		if err := rows.Scan(&dp.ID, &dp.Name, &dp.Path, &dp.Fingerprint, &dp.LastUpdated); err != nil {
+			return nil, err
+		}
+		dps = append(dps, dp)
+	}
+	return dps, rows.Err()
+}
+
+// ClearDataprovFingerprint inserts the new version of a dataprov whose
+// extraction failed with a blank fingerprint, so the file is not
+// skipped when it is requeued
+func (d ClickhouseChurroDatabase) ClearDataprovFingerprint(id string) error {
+	_, err := d.Connection.Exec("INSERT INTO dataprov (id, name, path, fingerprint, lastupdated) SELECT id, name, path, '', now() FROM dataprov FINAL WHERE id = ?", id)
+	return err
+}
+
+// CreatePrivacyAudit records a privacy policy applied to a column
+func (d ClickhouseChurroDatabase) CreatePrivacyAudit(a domain.PrivacyAudit) error {
+	return d.insert("INSERT INTO privacyaudit (id, dataprov_id, tablename, columnname, policy, lastupdated) VALUES (?, ?, ?, ?, ?, ?)",
+		[]interface{}{a.ID, a.DataprovID, a.TableName, a.ColumnName, a.Policy, a.LastUpdated})
+}
+
+// GetPrivacyAudits returns the privacy policies applied to the columns
+// of a dataprov
+func (d ClickhouseChurroDatabase) GetPrivacyAudits(dataprovID string) ([]domain.PrivacyAudit, error) {
+	rows, err := d.Connection.Query("SELECT id, dataprov_id, tablename, columnname, policy, lastupdated FROM privacyaudit FINAL WHERE dataprov_id = ? ORDER BY tablename, columnname", dataprovID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	audits := make([]domain.PrivacyAudit, 0)
+	for rows.Next() {
+		a := domain.PrivacyAudit{}
+		if err := rows.Scan(&a.ID, &a.DataprovID, &a.TableName, &a.ColumnName, &a.Policy, &a.LastUpdated); err != nil {
+			return nil, err
+		}
+		audits = append(audits, a)
+	}
+	return audits, rows.Err()
+}
+
+// UpdatePipelineStats adds a row of the records read, pipeline_stats is
+// a SummingMergeTree so the rows of a file are summed as they merge
+func (d *ClickhouseChurroDatabase) UpdatePipelineStats(data stats.PipelineStats) error {
+	database, err := sqlsafe.QuoteClickHouse(data.Pipeline)
+	if err != nil {
+		return err
+	}
+
+	sqlstr := fmt.Sprintf("INSERT INTO %s.pipeline_stats (dataprov_id, file_name, records_in, lastupdated) VALUES (?, ?, ?, ?)", database)
+	log.Info().Msg("stats insert " + sqlstr)
+	return d.insert(sqlstr, []interface{}{data.DataprovID, data.FileName, data.RecordsIn, time.Now()})
+}
@@ -0,0 +1,90 @@
+package clickhouse
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/api/v1alpha1"
+)
+
+func TestGetConnectString(t *testing.T) {
+	src := v1alpha1.Source{
+		Host:     "ch.example.com",
+		Username: "pipeline1",
+		Password: "cEBzcy93b3JkOg==",
+		Database: "pipeline1",
+	}
+
+	s, err := getConnectString(src, "true")
+	if err != nil {
+		t.Fatalf("getConnectString Error: %v", err)
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		t.Fatalf("url.Parse %s Error: %v", s, err)
+	}
+	q := u.Query()
+	if u.Scheme != "tcp" || u.Host != "ch.example.com:9000" || q.Get("username") != "pipeline1" || q.Get("password") != "p@ss/word:" {
+		t.Fatalf("getConnectString got %s", s)
+	}
+	if q.Get("database") != "pipeline1" || q.Get("secure") != "true" {
+		t.Fatalf("getConnectString database or secure got %s", s)
+	}
+
+	bad := src
+	bad.Host = ""
+	if _, err := getConnectString(bad, ""); err == nil {
+		t.Fatal("getConnectString expected an error without a host")
+	}
+	bad = src
+	bad.Database = "pipeline1&database=churro"
+	if _, err := getConnectString(bad, ""); err == nil {
+		t.Fatal("getConnectString expected an error for an invalid database")
+	}
+	if _, err := getConnectString(src, "yes please"); err == nil {
+		t.Fatal("getConnectString expected an error for an invalid secure value")
+	}
+}
+
+func TestGetRowValues(t *testing.T) {
+	colTypes := []string{
+		extractapi.COLTYPE_TEXT,
+		extractapi.COLTYPE_INT,
+		extractapi.COLTYPE_BIGINT,
+		extractapi.COLTYPE_DOUBLE,
+		extractapi.COLTYPE_BOOLEAN,
+		extractapi.COLTYPE_TIMESTAMP,
+		extractapi.COLTYPE_INT,
+	}
+	now := time.Now()
+	r := extractapi.GenericRow{Key: 7, Cols: []interface{}{"null", "42", 9000000000, "1.5", "true", "2021-03-04 05:06:07", "forty two"}}
+
+	values := getRowValues(extractapi.CSVScheme, r, colTypes, now)
+	if len(values) != len(colTypes)+3 {
+		t.Fatalf("getRowValues got %d values", len(values))
+	}
+	if values[0] != int64(7) || values[1] != extractapi.CSVScheme || values[len(values)-1] != now {
+		t.Fatalf("getRowValues key, format or load time got %v", values)
+	}
+	want := []interface{}{"null", int32(42), int64(9000000000), 1.5, true, time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC), nil}
+	for i, w := range want {
+		if values[i+2] != w {
+			t.Fatalf("getRowValues column %d got %#v expected %#v", i, values[i+2], w)
+		}
+	}
+
+	if v := getValue("DECIMAL(10,2)", "12.34"); v != 12.34 {
+		t.Fatalf("getValue DECIMAL(10,2) got %#v", v)
+	}
+	if v := getValue("VARCHAR(64)", 12); v != "12" {
+		t.Fatalf("getValue VARCHAR(64) got %#v", v)
+	}
+	if ct, err := getColumnType("DECIMAL(10,2)"); err != nil || ct != "Decimal(10, 2)" {
+		t.Fatalf("getColumnType DECIMAL(10,2) got %q %v", ct, err)
+	}
+	if _, err := getColumnType("money"); err == nil {
+		t.Fatal("getColumnType expected an error for an unsupported type")
+	}
+}
@@ -0,0 +1,454 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package clickhouse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db/sqlsafe"
+	"github.com/rs/zerolog/log"
+)
+
+// the clickhouse types churro column types are created as, every
+// column is Nullable since a value that does not convert is loaded
+// as a NULL
+var clickhouseTypes = map[string]string{
+	extractapi.COLTYPE_TEXT:      "String",
+	extractapi.COLTYPE_VARCHAR:   "String",
+	extractapi.COLTYPE_INT:       "Int32",
+	extractapi.COLTYPE_BIGINT:    "Int64",
+	extractapi.COLTYPE_DECIMAL:   "Decimal(18, 4)",
+	extractapi.COLTYPE_DOUBLE:    "Float64",
+	extractapi.COLTYPE_BOOLEAN:   "UInt8",
+	extractapi.COLTYPE_DATE:      "Date",
+	extractapi.COLTYPE_TIMESTAMP: "DateTime",
+	extractapi.COLTYPE_JSONB:     "String",
+}
+
+var decimalRegex = regexp.MustCompile(`^DECIMAL\s*\(\s*([0-9]+)\s*(?:,\s*([0-9]+)\s*)?\)$`)
+
+// the layouts a date or timestamp column value is parsed with
+var timeLayouts = []string{
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+	"2006-01-02",
+}
+
+// CreateTable creates a MergeTree table ordered by the primary key and
+// load time, the pipeline user was granted its whole database so there
+// is no grant on the table
+func (d ClickhouseChurroDatabase) CreateTable(userid, dbname, tableName string, columnNames []string, columnTypes []string) (err error) {
+
+	table, err := sqlsafe.QuoteClickHouse(dbname, tableName)
+	if err != nil {
+		return err
+	}
+	tableColumns, err := getTableColumns(columnNames, columnTypes)
+	if err != nil {
+		return err
+	}
+	if err := sqlsafe.ValidIdentifier(userid); err != nil {
+		return err
+	}
+
+	sqlStr := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s ( primarykey Int64, dataformat String, %s lastupdated DateTime ) ENGINE = MergeTree ORDER BY (primarykey, lastupdated)", table, tableColumns)
+	log.Info().Msg(sqlStr)
+
+	_, err = d.Connection.Exec(sqlStr)
+	if err != nil {
+		return err
+	}
+
+	log.Debug().Msg("Table created successfully.." + tableName)
+
+	return nil
+}
+
+func getTableColumns(columnNames, columnTypes []string) (string, error) {
+	var result string
+	for i, v := range columnNames {
+		name, err := sqlsafe.QuoteClickHouse(v)
+		if err != nil {
+			return "", err
+		}
+		colType, err := getColumnType(columnTypes[i])
+		if err != nil {
+			return "", err
+		}
+		result = result + fmt.Sprintf("%s Nullable(%s),", name, colType)
+	}
+	log.Debug().Msg("getTableColumns " + result)
+	return result, nil
+}
+
+// getColumnType returns the clickhouse type of a churro column type
+func getColumnType(columnType string) (string, error) {
+	if err := sqlsafe.ValidColumnType(columnType); err != nil {
+		return "", err
+	}
+	// DECIMAL(10,2) keeps its precision and scale
+	if m := decimalRegex.FindStringSubmatch(strings.ToUpper(columnType)); m != nil {
+		scale := m[2]
+		if scale == "" {
+			scale = "0"
+		}
+		return fmt.Sprintf("Decimal(%s, %s)", m[1], scale), nil
+	}
+	t, ok := clickhouseTypes[strings.ToUpper(columnType)]
+	if !ok {
+		return "", fmt.Errorf("column type %q is not supported by clickhouse", columnType)
+	}
+	return t, nil
+}
+
+// getInsertColumns returns the quoted column list of an insert,
+// surrounded by the columns churro adds to every table
+func getInsertColumns(cols []string) (string, error) {
+	quoted, err := sqlsafe.QuoteList(sqlsafe.QuoteClickHouse, cols)
+	if err != nil {
+		return "", err
+	}
+	if quoted == "" {
+		return "primarykey, dataformat, lastupdated", nil
+	}
+	return "primarykey, dataformat, " + quoted + ", lastupdated", nil
+}
+
+// getInsertStatement returns an insert of one row of the columns
+func getInsertStatement(table string, cols []string) (string, error) {
+	colNames, err := getInsertColumns(cols)
+	if err != nil {
+		return "", err
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(cols)+3), ", ")
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, colNames, placeholders), nil
+}
+
+func (d ClickhouseChurroDatabase) GetInsertStatement(scheme, database, tablename string, cols []string, vals []interface{}, primarykey int64) error {
+
+	table, err := sqlsafe.QuoteClickHouse(database, tablename)
+	if err != nil {
+		return err
+	}
+	sqlString, err := getInsertStatement(table, cols)
+	if err != nil {
+		return err
+	}
+
+	args := append([]interface{}{primarykey, scheme}, vals...)
+	args = append(args, time.Now())
+	err = d.insert(sqlString, args)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg(sqlString)
+		return err
+	}
+
+	return nil
+}
+
+// GetBulkInsertStatement loads the records with the clickhouse native
+// protocol, the rows are sent to the server as column blocks when the
+// transaction commits rather than as the text of a statement
+func (d ClickhouseChurroDatabase) GetBulkInsertStatement(scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string) error {
+
+	table, err := sqlsafe.QuoteClickHouse(database, tableName)
+	if err != nil {
+		return err
+	}
+	sqlString, err := getInsertStatement(table, cols)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	rows := make([][]interface{}, 0, len(records))
+	for _, r := range records {
+		rows = append(rows, getRowValues(scheme, r, colTypes, now))
+	}
+
+	err = d.insert(sqlString, rows...)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in bulk insert")
+		return err
+	}
+
+	return nil
+}
+
+// GetUpsertStatement deletes the rows with the primary keys of the
+// records and then loads the records.  Clickhouse has no update in
+// place, a delete mutation only applies to the parts that exist when
+// it is submitted so the rows inserted after it are kept.
+func (d ClickhouseChurroDatabase) GetUpsertStatement(scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string) error {
+
+	table, err := sqlsafe.QuoteClickHouse(database, tableName)
+	if err != nil {
+		return err
+	}
+	if _, err := getInsertColumns(cols); err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	keys := make([]interface{}, 0, len(records))
+	for _, r := range records {
+		keys = append(keys, r.Key)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(keys)), ", ")
+	sqlString := fmt.Sprintf("ALTER TABLE %s DELETE WHERE primarykey IN (%s)", table, placeholders)
+	_, err = d.Connection.Exec(sqlString, keys...)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in upsert delete")
+		return err
+	}
+
+	return d.GetBulkInsertStatement(scheme, database, tableName, cols, records, colTypes)
+}
+
+// GetReplacePartitionStatement deletes the rows of the partitions with
+// a single mutation and then loads the records, a partition holds the
+// values of the key columns
+func (d ClickhouseChurroDatabase) GetReplacePartitionStatement(scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string, keyCols []string, partitions [][]interface{}) error {
+
+	table, err := sqlsafe.QuoteClickHouse(database, tableName)
+	if err != nil {
+		return err
+	}
+	if _, err := getInsertColumns(cols); err != nil {
+		return err
+	}
+
+	if len(partitions) > 0 {
+		filters := make([]string, 0, len(partitions))
+		args := make([]interface{}, 0, len(partitions)*len(keyCols))
+		for _, partition := range partitions {
+			filter, values, err := getPartitionFilter(cols, colTypes, keyCols, partition)
+			if err != nil {
+				return err
+			}
+			filters = append(filters, filter)
+			args = append(args, values...)
+		}
+		sqlString := fmt.Sprintf("ALTER TABLE %s DELETE WHERE %s", table, strings.Join(filters, " OR "))
+		_, err = d.Connection.Exec(sqlString, args...)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("error in partition delete")
+			return err
+		}
+	}
+
+	return d.GetBulkInsertStatement(scheme, database, tableName, cols, records, colTypes)
+}
+
+// getPartitionFilter returns the condition matching the rows whose key
+// columns hold the values of the partition
+func getPartitionFilter(cols, colTypes, keyCols []string, partition []interface{}) (string, []interface{}, error) {
+	if len(keyCols) == 0 || len(keyCols) != len(partition) {
+		return "", nil, fmt.Errorf("partition has %d values for %d key columns", len(partition), len(keyCols))
+	}
+
+	conditions := make([]string, 0, len(keyCols))
+	args := make([]interface{}, 0, len(keyCols))
+	for i, k := range keyCols {
+		c := columnIndex(cols, k)
+		if c < 0 {
+			return "", nil, fmt.Errorf("key column %s is not a loaded column", k)
+		}
+		name, err := sqlsafe.QuoteClickHouse(k)
+		if err != nil {
+			return "", nil, err
+		}
+		v := getValue(colTypes[c], partition[i])
+		if v == nil {
+			conditions = append(conditions, name+" IS NULL")
+			continue
+		}
+		conditions = append(conditions, name+" = ?")
+		args = append(args, v)
+	}
+	return "(" + strings.Join(conditions, " AND ") + ")", args, nil
+}
+
+func columnIndex(cols []string, name string) int {
+	for i := range cols {
+		if cols[i] == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// getRowValues returns the key, data format, column values and load
+// time of a record.  The native protocol needs a value of the column's
+// Go type, a value that does not convert is sent as a NULL.
+func getRowValues(scheme string, r extractapi.GenericRow, colTypes []string, now time.Time) []interface{} {
+	values := make([]interface{}, 0, len(r.Cols)+3)
+	values = append(values, r.Key, scheme)
+	for i := 0; i < len(r.Cols); i++ {
+		values = append(values, getValue(colTypes[i], r.Cols[i]))
+	}
+	return append(values, now)
+}
+
+func getValue(colType string, v interface{}) interface{} {
+	colType = extractapi.BaseColumnType(colType)
+	if v == nil {
+		return nil
+	}
+	switch colType {
+	case extractapi.COLTYPE_TEXT, extractapi.BaseColumnType(extractapi.COLTYPE_VARCHAR):
+		return fmt.Sprintf("%v", v)
+	}
+	if v == "null" {
+		return nil
+	}
+	// converted to the column type by the extract
+	switch t := v.(type) {
+	case float64, bool, time.Time:
+		return v
+	case int64:
+		if colType == extractapi.COLTYPE_INT {
+			return int32(t)
+		}
+		return t
+	}
+	s := strings.TrimSpace(fmt.Sprintf("%v", v))
+
+	var value interface{}
+	var err error
+	switch colType {
+	case extractapi.COLTYPE_INT:
+		var i int64
+		i, err = strconv.ParseInt(s, 10, 32)
+		value = int32(i)
+	case extractapi.COLTYPE_BIGINT:
+		value, err = strconv.ParseInt(s, 10, 64)
+	case extractapi.COLTYPE_DECIMAL, extractapi.COLTYPE_DOUBLE:
+		value, err = strconv.ParseFloat(s, 64)
+	case extractapi.COLTYPE_BOOLEAN:
+		value, err = strconv.ParseBool(s)
+	case extractapi.COLTYPE_DATE, extractapi.COLTYPE_TIMESTAMP:
+		value, err = parseTime(s)
+	default:
+		return s
+	}
+	if err != nil {
+		log.Debug().Msg(fmt.Sprintf("%s value %q loaded as null %v", colType, s, err))
+		return nil
+	}
+	return value
+}
+
+func parseTime(s string) (t time.Time, err error) {
+	for _, layout := range timeLayouts {
+		t, err = time.Parse(layout, s)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return t, err
+}
+
+// GetTableColumns returns the type of each column of a table, keyed by
+// column name
+func (d ClickhouseChurroDatabase) GetTableColumns(database, tableName string) (map[string]string, error) {
+	if _, err := sqlsafe.QuoteClickHouse(database, tableName); err != nil {
+		return nil, err
+	}
+
+	rows, err := d.Connection.Query("SELECT name, type FROM system.columns WHERE database = ? AND table = ?", database, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := make(map[string]string)
+	for rows.Next() {
+		var name, colType string
+		if err := rows.Scan(&name, &colType); err != nil {
+			return nil, err
+		}
+		columns[name] = colType
+	}
+	return columns, rows.Err()
+}
+
+// GetColumnType returns the type GetTableColumns reports for an extract
+// rule column type
+func (d ClickhouseChurroDatabase) GetColumnType(columnType string) (string, error) {
+	t, err := getColumnType(columnType)
+	if err != nil {
+		return "", err
+	}
+	return "Nullable(" + t + ")", nil
+}
+
+// AddColumn adds a column to an existing table
+func (d ClickhouseChurroDatabase) AddColumn(database, tableName, columnName, columnType string) error {
+	table, err := sqlsafe.QuoteClickHouse(database, tableName)
+	if err != nil {
+		return err
+	}
+	column, err := getTableColumns([]string{columnName}, []string{columnType})
+	if err != nil {
+		return err
+	}
+
+	sqlStr := fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s", table, strings.TrimSuffix(column, ","))
+	log.Info().Msg(sqlStr)
+	_, err = d.Connection.Exec(sqlStr)
+	return err
+}
+
+// GetReferenceRows returns the values of the columns of every row of a
+// table, for the lookups of an extract source
+func (d ClickhouseChurroDatabase) GetReferenceRows(database, tableName string, columns []string) ([][]interface{}, error) {
+	table, err := sqlsafe.QuoteClickHouse(database, tableName)
+	if err != nil {
+		return nil, err
+	}
+	quoted, err := sqlsafe.QuoteList(sqlsafe.QuoteClickHouse, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.Connection.Query(fmt.Sprintf("SELECT %s FROM %s", quoted, table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make([][]interface{}, 0)
+	for rows.Next() {
+		row := make([]interface{}, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range row {
+			dest[i] = &row[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		for i, v := range row {
+			if b, ok := v.([]byte); ok {
+				row[i] = string(b)
+			}
+		}
+		values = append(values, row)
+	}
+	return values, rows.Err()
+}
@@ -0,0 +1,84 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package clickhouse
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/churrodata/churro/internal/db/sqlsafe"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+// UpdateExtractSourceMetric inserts the new version of the metric
+func (d ClickhouseChurroDatabase) UpdateExtractSourceMetric(a domain.ExtractSourceMetric) (err error) {
+	var INSERT = "INSERT INTO extractsourcemetric (extractsourceid, name, value, lastupdated) VALUES (?, ?, ?, ?)"
+	log.Info().Msg(INSERT)
+
+	err = d.insert(INSERT, []interface{}{a.ExtractSourceID, a.Name, a.Value, time.Now()})
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d ClickhouseChurroDatabase) CreateExtractSourceMetric(a domain.ExtractSourceMetric) (err error) {
+	var INSERT = "INSERT INTO extractsourcemetric (extractsourceid, name, value, lastupdated) VALUES (?, ?, ?, ?)"
+
+	err = d.insert(INSERT, []interface{}{a.ExtractSourceID, a.Name, a.Value, time.Now()})
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d ClickhouseChurroDatabase) GetExtractSourceMetrics(id string) (wdirs []domain.ExtractSourceMetric, err error) {
+	rows, err := d.Connection.Query("SELECT extractsourceid, name, value from extractsourcemetric FINAL where extractsourceid = ?", id)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return wdirs, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p := domain.ExtractSourceMetric{}
+		err = rows.Scan(&p.ExtractSourceID, &p.Name, &p.Value)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("some error")
+			return wdirs, err
+		}
+		wdirs = append(wdirs, p)
+	}
+
+	return wdirs, nil
+}
+
+func (d ClickhouseChurroDatabase) IsInitialized(tablename string) bool {
+	table, err := sqlsafe.QuoteClickHouse(d.namespace, tablename)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in isInitialized ")
+		return false
+	}
+	sqlString := fmt.Sprintf("select count() from %s", table)
+	row := d.Connection.QueryRow(sqlString)
+	var t uint64
+	err = row.Scan(&t)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in isInitialized ")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,287 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package clickhouse
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/churrodata/churro/internal/db/migrate"
+	"github.com/churrodata/churro/internal/db/sqlsafe"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+func (s ClickhouseChurroDatabase) CreatePipelineDatabase(dbName string) error {
+	database, err := sqlsafe.QuoteClickHouse(dbName)
+	if err != nil {
+		return err
+	}
+
+	// make sure the pipeline database is created
+	sqlStr := fmt.Sprintf("CREATE DATABASE IF NOT EXISTS %s", database)
+	_, err = s.Connection.Exec(sqlStr)
+	log.Info().Msg(sqlStr)
+	if err != nil {
+		return err
+	}
+	log.Info().Msg("Successfully created database " + dbName)
+
+	// the pipeline user is named after the pipeline, the grant covers
+	// the tables it creates later on within its database
+	sqlStr = fmt.Sprintf("GRANT SELECT, INSERT, CREATE TABLE, ALTER ON %s.* TO %s", database, database)
+	_, err = s.Connection.Exec(sqlStr)
+	log.Info().Msg(sqlStr)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// pipelineColumns are the columns added to the pipeline tables after
+// they were first created, CreatePipelineObjects adds them to the
+// tables of an existing pipeline
+var pipelineColumns = []migrate.Column{
+	{Table: "extractlog", Version: 1, Name: "conversion_errors", Definition: "Int64"},
+	{Table: "extractlog", Version: 2, Name: "records_rejected", Definition: "Int64"},
+	{Table: "dataprov", Version: 1, Name: "fingerprint", Definition: "String"},
+	{Table: "extractlog", Version: 3, Name: "status", Definition: "String"},
+	{Table: "extractlog", Version: 3, Name: "error_text", Definition: "String"},
+}
+
+func (s ClickhouseChurroDatabase) CreatePipelineObjects(dbName, username string) error {
+	database, err := sqlsafe.QuoteClickHouse(dbName)
+	if err != nil {
+		return err
+	}
+	// the pipeline user was granted the whole pipeline database
+	if err := sqlsafe.ValidIdentifier(username); err != nil {
+		return err
+	}
+
+	statements := []string{
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.dataprov ( id String, name String, path String, fingerprint String, lastupdated DateTime ) ENGINE = ReplacingMergeTree(lastupdated) ORDER BY id", database),
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.pipeline_stats ( dataprov_id String, file_name String, records_in Int64, lastupdated DateTime ) ENGINE = SummingMergeTree(records_in) ORDER BY file_name", database),
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.extractlog ( tablename String, id String, dataprov_id String, podname String, poddate DateTime, records_loaded Int64, conversion_errors Int64, records_rejected Int64, file_name String, status String, error_text String, lastupdated DateTime ) ENGINE = ReplacingMergeTree(lastupdated) ORDER BY id", database),
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.schemaversion ( tablename String, version Int64, extractsource_id String, column_list String, lastupdated DateTime ) ENGINE = ReplacingMergeTree(lastupdated) ORDER BY (tablename, version)", database),
+		fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.privacyaudit ( id String, dataprov_id String, tablename String, columnname String, policy String, lastupdated DateTime ) ENGINE = ReplacingMergeTree(lastupdated) ORDER BY id", database),
+	}
+
+	for _, sqlStr := range statements {
+		_, err = s.Connection.Exec(sqlStr)
+		if err != nil {
+			return err
+		}
+		log.Info().Msg(sqlStr)
+	}
+
+	// add the columns of later releases to the tables of an existing
+	// pipeline
+	return migrate.Run(s, dbName, pipelineColumns, func(table, column, definition string) error {
+		sqlStr := fmt.Sprintf("ALTER TABLE %s.%s ADD COLUMN IF NOT EXISTS %s %s", database, table, column, definition)
+		log.Info().Msg(sqlStr)
+		_, err := s.Connection.Exec(sqlStr)
+		return err
+	})
+}
+
+func (s ClickhouseChurroDatabase) GetAllPipelineMetrics() (metrics []domain.PipelineMetric, err error) {
+	metrics = make([]domain.PipelineMetric, 0)
+
+	rows, err := s.Connection.Query("SELECT name, value, lastupdated FROM pipelinemetric FINAL order by name")
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return metrics, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p := domain.PipelineMetric{}
+		err = rows.Scan(&p.Name, &p.Value, &p.LastUpdated)
+		if err != nil {
+			log.Error().Stack().Err(err)
+			return metrics, err
+		}
+		metrics = append(metrics, p)
+	}
+
+	return metrics, nil
+}
+
+// UpdatePipelineMetric inserts the new version of the metric
+func (s ClickhouseChurroDatabase) UpdatePipelineMetric(m domain.PipelineMetric) error {
+	var INSERT = "INSERT INTO pipelinemetric (name, value, lastupdated) VALUES (?, ?, ?)"
+	log.Info().Msg(INSERT)
+
+	err := s.insert(INSERT, []interface{}{m.Name, m.Value, time.Now()})
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s ClickhouseChurroDatabase) CreatePipelineMetric(m domain.PipelineMetric) error {
+	INSERT := "INSERT INTO pipelinemetric (name, value, lastupdated) VALUES (?, ?, ?)"
+
+	err := s.insert(INSERT, []interface{}{m.Name, m.Value, time.Now()})
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+// CreateUser creates the pipeline user unless it exists, the admin
+// user needs clickhouse access management enabled to create users
+func (s ClickhouseChurroDatabase) CreateUser(username, password string) (err error) {
+	user, err := sqlsafe.QuoteClickHouse(username)
+	if err != nil {
+		return err
+	}
+	pw, err := getPassword(password)
+	if err != nil {
+		return err
+	}
+
+	// clickhouse-go binds the password into the statement as a
+	// quoted string literal
+	_, err = s.Connection.Exec(fmt.Sprintf("CREATE USER IF NOT EXISTS %s IDENTIFIED WITH sha256_password BY ?", user), pw)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s ClickhouseChurroDatabase) CreateExtractLog(p domain.JobProfile) error {
+	var INSERT = "INSERT INTO extractlog ( tablename, id, dataprov_id, podname, poddate, records_loaded, file_name, lastupdated ) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+
+	err := s.insert(INSERT, []interface{}{p.TableName, p.ID, p.DataProvenanceID, p.JobName, p.StartDate, int64(p.RecordsLoaded), p.FileName, time.Now()})
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateExtractLog inserts the new version of the extract log row,
+// copying the columns that do not change from the latest version
+func (s ClickhouseChurroDatabase) UpdateExtractLog(p domain.JobProfile) error {
+	var UPDATE = "INSERT INTO extractlog ( tablename, id, dataprov_id, podname, poddate, records_loaded, conversion_errors, records_rejected, file_name, status, error_text, lastupdated ) SELECT tablename, id, dataprov_id, podname, poddate, ?, ?, ?, file_name, status, error_text, now() FROM extractlog FINAL where id = ?"
+	log.Info().Msg(UPDATE)
+
+	_, err := s.Connection.Exec(UPDATE, int64(p.RecordsLoaded), int64(p.ConversionErrors), int64(p.RecordsRejected), p.ID)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateExtractLogStatus inserts the new version of the extract log row
+// with the status of the job and the error it failed with
+func (s ClickhouseChurroDatabase) UpdateExtractLogStatus(id, status, errorText string) error {
+	var UPDATE = "INSERT INTO extractlog ( tablename, id, dataprov_id, podname, poddate, records_loaded, conversion_errors, records_rejected, file_name, status, error_text, lastupdated ) SELECT tablename, id, dataprov_id, podname, poddate, records_loaded, conversion_errors, records_rejected, file_name, ?, ?, now() FROM extractlog FINAL where id = ?"
+	log.Info().Msg(UPDATE)
+
+	_, err := s.Connection.Exec(UPDATE, status, errorText, id)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s ClickhouseChurroDatabase) GetExtractLog(jobName string) (p domain.JobProfile, err error) {
+
+	row := s.Connection.QueryRow("SELECT tablename, id, dataprov_id, podname, poddate, records_loaded, conversion_errors, records_rejected, file_name, status, error_text FROM extractlog FINAL where podname=?", jobName)
+	p, err = scanExtractLog(row)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error getting job from extract log " + jobName)
+		return p, err
+	}
+
+	log.Info().Msg(fmt.Sprintf("returning extract log values of %+v", p))
+	return p, nil
+}
+
+func (s ClickhouseChurroDatabase) GetExtractLogById(id string) (p domain.JobProfile, err error) {
+
+	row := s.Connection.QueryRow("SELECT tablename, id, dataprov_id, podname, poddate, records_loaded, conversion_errors, records_rejected, file_name, status, error_text FROM extractlog FINAL where id=?", id)
+	p, err = scanExtractLog(row)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error getting job by id from extract log " + id)
+		return p, err
+	}
+	log.Info().Msg(fmt.Sprintf("returning extract log values of %+v", p))
+
+	return p, nil
+}
+
+// scanExtractLog scans an extract log row, clickhouse returns the pod
+// date as a time and the records loaded as an Int64
+func scanExtractLog(row *sql.Row) (p domain.JobProfile, err error) {
+	var podDate time.Time
+	var recordsLoaded, conversionErrors, recordsRejected int64
+	err = row.Scan(&p.TableName, &p.ID, &p.DataProvenanceID, &p.JobName, &podDate, &recordsLoaded, &conversionErrors, &recordsRejected, &p.FileName, &p.Status, &p.ErrorText)
+	if err != nil {
+		return p, err
+	}
+	p.StartDate = podDate.Format("2006-01-02 15:04:05")
+	p.RecordsLoaded = int(recordsLoaded)
+	p.ConversionErrors = int(conversionErrors)
+	p.RecordsRejected = int(recordsRejected)
+	return p, nil
+}
+
+func (s ClickhouseChurroDatabase) CreateSchemaVersion(v domain.SchemaVersion) error {
+	var INSERT = "INSERT INTO schemaversion ( tablename, version, extractsource_id, column_list, lastupdated ) VALUES (?, ?, ?, ?, ?)"
+
+	err := s.insert(INSERT, []interface{}{v.TableName, int64(v.Version), v.ExtractSourceID, v.Columns, time.Now()})
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetSchemaVersions returns the schema versions of a table, oldest first
+func (s ClickhouseChurroDatabase) GetSchemaVersions(tableName string) (versions []domain.SchemaVersion, err error) {
+	versions = make([]domain.SchemaVersion, 0)
+	rows, err := s.Connection.Query("SELECT tablename, version, extractsource_id, column_list, lastupdated FROM schemaversion FINAL where tablename=? order by version", tableName)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error getting schema versions of " + tableName)
+		return versions, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		v := domain.SchemaVersion{}
+		var version int64
+		err = rows.Scan(&v.TableName, &version, &v.ExtractSourceID, &v.Columns, &v.LastUpdated)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("error getting schema versions of " + tableName)
+			return versions, err
+		}
+		v.Version = int(version)
+		versions = append(versions, v)
+	}
+
+	return versions, rows.Err()
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/rs/zerolog/log"
 
+	"github.com/churrodata/churro/internal/db/sqlsafe"
 	"github.com/churrodata/churro/internal/domain"
 	"github.com/rs/xid"
 )
@@ -35,7 +36,7 @@ func (d CockroachChurroDatabase) CreateAuthenticatedUser(u domain.AuthenticatedU
 }
 
 func (d CockroachChurroDatabase) DeleteAuthenticatedUser(id string) (err error) {
-	_, err = d.Connection.Exec(fmt.Sprintf("DELETE FROM authenticateduser where id='%s'", id))
+	_, err = d.Connection.Exec("DELETE FROM authenticateduser where id=$1", id)
 	if err != nil {
 		log.Error().Stack().Err(err)
 		return err
@@ -77,12 +78,11 @@ func (d CockroachChurroDatabase) CreateUserPipelineAccess(a domain.UserPipelineA
 func (d CockroachChurroDatabase) UpdateUserPipelineAccess(a domain.UserPipelineAccess) error {
 	datetime := time.Now()
 
-	_, err := d.Connection.Exec(
-		fmt.Sprintf("UPDATE userpipelineaccess set (access, lastupdated) = ('%s','%v') where userprofileid = '%s' and pipelineid = '%s'",
-			a.Access,
-			datetime.Format("2006-01-02T15:04:05.999999999"),
-			a.UserProfileID,
-			a.PipelineID))
+	_, err := d.Connection.Exec("UPDATE userpipelineaccess set (access, lastupdated) = ($1, $2) where userprofileid = $3 and pipelineid = $4",
+		a.Access,
+		datetime,
+		a.UserProfileID,
+		a.PipelineID)
 	if err != nil {
 		log.Error().Stack().Err(err)
 		return err
@@ -92,7 +92,7 @@ func (d CockroachChurroDatabase) UpdateUserPipelineAccess(a domain.UserPipelineA
 }
 
 func (d CockroachChurroDatabase) DeleteAllUserPipelineAccess(pipeline string) error {
-	_, err := d.Connection.Exec(fmt.Sprintf("DELETE FROM UserPipelineAccess where pipelineid = '%s'", pipeline))
+	_, err := d.Connection.Exec("DELETE FROM UserPipelineAccess where pipelineid = $1", pipeline)
 	if err != nil {
 		log.Error().Stack().Err(err)
 		return err
@@ -102,7 +102,7 @@ func (d CockroachChurroDatabase) DeleteAllUserPipelineAccess(pipeline string) er
 }
 
 func (d CockroachChurroDatabase) DeleteUserPipelineAccess(pipeline, id string) error {
-	_, err := d.Connection.Exec(fmt.Sprintf("DELETE FROM UserPipelineAccess where pipelineid = '%s' and userprofileid = '%s'", pipeline, id))
+	_, err := d.Connection.Exec("DELETE FROM UserPipelineAccess where pipelineid = $1 and userprofileid = $2", pipeline, id)
 	if err != nil {
 		log.Error().Stack().Err(err)
 		return err
@@ -126,9 +126,8 @@ func (d CockroachChurroDatabase) CreateUserProfile(u domain.UserProfile) error {
 func (d CockroachChurroDatabase) UpdateUserProfile(u domain.UserProfile) error {
 	datetime := time.Now()
 
-	sqlStr := fmt.Sprintf("UPDATE userprofile set (password, lastname, firstname, email, access, lastupdated) = ('%s','%s','%s','%s','%s','%v') where id = '%s'", u.Password, u.LastName, u.FirstName, u.Email, u.Access, datetime.Format("2006-01-02T15:04:05.999999999"), u.ID)
-	log.Info().Msg("sqlStr is " + sqlStr)
-	_, err := d.Connection.Exec(sqlStr)
+	sqlStr := "UPDATE userprofile set (password, lastname, firstname, email, access, lastupdated) = ($1, $2, $3, $4, $5, $6) where id = $7"
+	_, err := d.Connection.Exec(sqlStr, u.Password, u.LastName, u.FirstName, u.Email, u.Access, datetime, u.ID)
 	if err != nil {
 		log.Error().Stack().Err(err)
 		return err
@@ -138,7 +137,7 @@ func (d CockroachChurroDatabase) UpdateUserProfile(u domain.UserProfile) error {
 }
 
 func (d CockroachChurroDatabase) DeleteUserProfile(id string) (err error) {
-	_, err = d.Connection.Exec(fmt.Sprintf("DELETE FROM userprofile where id='%s'", id))
+	_, err = d.Connection.Exec("DELETE FROM userprofile where id=$1", id)
 	if err != nil {
 		log.Error().Stack().Err(err)
 		return err
@@ -245,8 +244,13 @@ func (d CockroachChurroDatabase) Bootstrap() (err error) {
 }
 
 func (d CockroachChurroDatabase) CreateChurroDatabase(dbName string) (err error) {
+	database, err := sqlsafe.QuotePostgres(dbName)
+	if err != nil {
+		return err
+	}
+
 	// make sure churro admin database is created
-	sqlStr := fmt.Sprintf("CREATE DATABASE if not exists %s", dbName)
+	sqlStr := fmt.Sprintf("CREATE DATABASE if not exists %s", database)
 	_, err = d.Connection.Exec(sqlStr)
 	log.Info().Msg(sqlStr)
 	if err != nil {
@@ -17,6 +17,7 @@ import (
 	_ "github.com/lib/pq"
 
 	"github.com/churrodata/churro/api/v1alpha1"
+	"github.com/churrodata/churro/internal/db/sqlsafe"
 	"github.com/churrodata/churro/internal/domain"
 	"github.com/churrodata/churro/internal/stats"
 	"github.com/churrodata/churro/pkg/config"
@@ -49,9 +50,14 @@ func (d CockroachChurroDatabase) GetVersion() (string, error) {
 
 func (d CockroachChurroDatabase) CreateObjects(dbName string) error {
 
+	database, err := sqlsafe.QuotePostgres(dbName)
+	if err != nil {
+		return err
+	}
+
 	// make sure churro admin database is created
-	sqlStr := fmt.Sprintf("CREATE DATABASE if not exists %s", dbName)
-	_, err := d.Connection.Exec(sqlStr)
+	sqlStr := fmt.Sprintf("CREATE DATABASE if not exists %s", database)
+	_, err = d.Connection.Exec(sqlStr)
 	log.Info().Msg(sqlStr)
 	if err != nil {
 		return err
@@ -111,7 +117,7 @@ func (d CockroachChurroDatabase) CreateObjects(dbName string) error {
 	}
 	log.Info().Msg("transformfunction Table created successfully..")
 	*/
-	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.pipelinemetric ( name STRING PRIMARY KEY, value STRING NOT NULL, lastupdated TIMESTAMP);", dbName)
+	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.pipelinemetric ( name STRING PRIMARY KEY, value STRING NOT NULL, lastupdated TIMESTAMP);", database)
 	log.Info().Msg(sqlStr)
 	stmt, err := d.Connection.Prepare(sqlStr)
 	if err != nil {
@@ -123,7 +129,7 @@ func (d CockroachChurroDatabase) CreateObjects(dbName string) error {
 	}
 	log.Info().Msg("pipelinemetric Table created successfully..")
 	// select to see if we have already seeded the metric table
-	sqlStr = fmt.Sprintf("SELECT name from %s.pipelinemetric where name = '%s'", dbName, domain.MetricFilesProcessed)
+	sqlStr = fmt.Sprintf("SELECT name from %s.pipelinemetric where name = $1", database)
 	log.Info().Msg(sqlStr)
 	stmt, err = d.Connection.Prepare(sqlStr)
 	if err != nil {
@@ -131,15 +137,15 @@ func (d CockroachChurroDatabase) CreateObjects(dbName string) error {
 	}
 
 	var xname string
-	err = stmt.QueryRow().Scan(&xname)
+	err = stmt.QueryRow(domain.MetricFilesProcessed).Scan(&xname)
 	if err == sql.ErrNoRows {
-		sqlStr = fmt.Sprintf("INSERT into %s.pipelinemetric ( name, value, lastupdated) values ('%s', '0', now());", dbName, domain.MetricFilesProcessed)
+		sqlStr = fmt.Sprintf("INSERT into %s.pipelinemetric ( name, value, lastupdated) values ($1, '0', now());", database)
 		log.Info().Msg(sqlStr)
 		stmt, err = d.Connection.Prepare(sqlStr)
 		if err != nil {
 			return err
 		}
-		_, err = stmt.Exec()
+		_, err = stmt.Exec(domain.MetricFilesProcessed)
 		if err != nil {
 			return err
 		}
@@ -147,7 +153,7 @@ func (d CockroachChurroDatabase) CreateObjects(dbName string) error {
 	} else {
 		log.Info().Msg("pipelinemetric Table already seeded..")
 	}
-	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.extractsourcemetric ( extractsourceid STRING, name STRING NOT NULL, value STRING NOT NULL, lastupdated TIMESTAMP);", dbName)
+	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.extractsourcemetric ( extractsourceid STRING, name STRING NOT NULL, value STRING NOT NULL, lastupdated TIMESTAMP);", database)
 	log.Info().Msg(sqlStr)
 	stmt, err = d.Connection.Prepare(sqlStr)
 	if err != nil {
@@ -184,23 +190,87 @@ func (d *CockroachChurroDatabase) GetConnection(dbCreds config.DBCredentials, so
 }
 
 func (d CockroachChurroDatabase) CreateDataprov(data domain.DataProvenance) error {
-	insertStmt, err := d.Connection.Prepare("INSERT into DATAPROV (id, name, path, lastupdated) values ($1, $2, $3, $4)")
+	insertStmt, err := d.Connection.Prepare("INSERT into DATAPROV (id, name, path, fingerprint, lastupdated) values ($1, $2, $3, $4, $5)")
+	if err != nil {
+		return err
+	}
+	if _, err := insertStmt.Exec(data.ID, data.Name, data.Path, data.Fingerprint, data.LastUpdated); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetDataprovsByFingerprint returns the data provenance of the files
+// registered with a fingerprint, oldest first
+func (d CockroachChurroDatabase) GetDataprovsByFingerprint(fingerprint string) ([]domain.DataProvenance, error) {
+	rows, err := d.Connection.Query("SELECT id, name, path, fingerprint, lastupdated FROM dataprov WHERE fingerprint = $1 ORDER BY lastupdated", fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dps := make([]domain.DataProvenance, 0)
+	for rows.Next() {
+		dp := domain.DataProvenance{}
+		if err := rows.Scan(&dp.ID, &dp.Name, &dp.Path, &dp.Fingerprint, &dp.LastUpdated); err != nil {
+			return nil, err
+		}
+		dps = append(dps, dp)
+	}
+	return dps, rows.Err()
+}
+
+// ClearDataprovFingerprint blanks the fingerprint of a dataprov whose
+// extraction failed, so the file is not skipped when it is requeued
+func (d CockroachChurroDatabase) ClearDataprovFingerprint(id string) error {
+	_, err := d.Connection.Exec("UPDATE dataprov SET fingerprint = '' WHERE id = $1", id)
+	return err
+}
+
+// CreatePrivacyAudit records a privacy policy applied to a column
+func (d CockroachChurroDatabase) CreatePrivacyAudit(a domain.PrivacyAudit) error {
+	insertStmt, err := d.Connection.Prepare("INSERT into privacyaudit (id, dataprov_id, tablename, columnname, policy, lastupdated) values ($1, $2, $3, $4, $5, $6)")
 	if err != nil {
 		return err
 	}
-	if _, err := insertStmt.Exec(data.ID, data.Name, data.Path, data.LastUpdated); err != nil {
+	if _, err := insertStmt.Exec(a.ID, a.DataprovID, a.TableName, a.ColumnName, a.Policy, a.LastUpdated); err != nil {
 		return err
 	}
 	return nil
 }
 
+// GetPrivacyAudits returns the privacy policies applied to the columns
+// of a dataprov
+func (d CockroachChurroDatabase) GetPrivacyAudits(dataprovID string) ([]domain.PrivacyAudit, error) {
+	rows, err := d.Connection.Query("SELECT id, dataprov_id, tablename, columnname, policy, lastupdated FROM privacyaudit WHERE dataprov_id = $1 ORDER BY tablename, columnname", dataprovID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	audits := make([]domain.PrivacyAudit, 0)
+	for rows.Next() {
+		a := domain.PrivacyAudit{}
+		if err := rows.Scan(&a.ID, &a.DataprovID, &a.TableName, &a.ColumnName, &a.Policy, &a.LastUpdated); err != nil {
+			return nil, err
+		}
+		audits = append(audits, a)
+	}
+	return audits, rows.Err()
+}
+
 func (d *CockroachChurroDatabase) UpdatePipelineStats(data stats.PipelineStats) error {
+	database, err := sqlsafe.QuotePostgres(data.Pipeline)
+	if err != nil {
+		return err
+	}
+
 	var recordsIn int64
 	// get existing records count if a row exists
-	sqlstr := fmt.Sprintf("SELECT records_in from %s.pipeline_stats where file_name = '%s'", data.Pipeline, data.FileName)
+	sqlstr := fmt.Sprintf("SELECT records_in from %s.pipeline_stats where file_name = $1", database)
 	log.Info().Msg("stats sql query " + sqlstr)
-	row := d.Connection.QueryRow(sqlstr)
-	err := row.Scan(&recordsIn)
+	row := d.Connection.QueryRow(sqlstr, data.FileName)
+	err = row.Scan(&recordsIn)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			log.Info().Msg("no rows in pipeline_stats yet")
@@ -210,7 +280,7 @@ func (d *CockroachChurroDatabase) UpdatePipelineStats(data stats.PipelineStats)
 	}
 
 	recordsIn += data.RecordsIn
-	sqlstr = fmt.Sprintf("UPSERT into %s.pipeline_stats (dataprov_id, file_name, records_in, lastupdated ) values ($1, $2, $3, 'now()')", data.Pipeline)
+	sqlstr = fmt.Sprintf("UPSERT into %s.pipeline_stats (dataprov_id, file_name, records_in, lastupdated ) values ($1, $2, $3, 'now()')", database)
 	log.Info().Msg("stats upsert " + sqlstr)
 	upsertStmt, err := d.Connection.Prepare(sqlstr)
 	if err != nil {
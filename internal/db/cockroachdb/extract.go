@@ -13,14 +13,33 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db/sqlsafe"
+	"github.com/lib/pq"
 	"github.com/rs/zerolog/log"
 )
 
+// the most placeholders a single statement can bind
+const maxPlaceholders = 65535
+
 func (d CockroachChurroDatabase) CreateTable(userid, dbname, tableName string, columnNames []string, columnTypes []string) (err error) {
 
-	sqlStr := fmt.Sprintf("CREATE TABLE if not exists %s.%s ( primarykey bigint PRIMARY KEY, dataformat text, %s lastupdated TIMESTAMP);", dbname, tableName, getTableColumns(columnNames, columnTypes))
+	table, err := sqlsafe.QuotePostgres(dbname, tableName)
+	if err != nil {
+		return err
+	}
+	tableColumns, err := getTableColumns(columnNames, columnTypes)
+	if err != nil {
+		return err
+	}
+	user, err := sqlsafe.QuotePostgres(userid)
+	if err != nil {
+		return err
+	}
+
+	sqlStr := fmt.Sprintf("CREATE TABLE if not exists %s ( primarykey bigint PRIMARY KEY, dataformat text, %s lastupdated TIMESTAMP);", table, tableColumns)
 	log.Info().Msg(sqlStr)
 
 	var stmt *sql.Stmt
@@ -35,8 +54,9 @@ func (d CockroachChurroDatabase) CreateTable(userid, dbname, tableName string, c
 
 	log.Debug().Msg("Table created successfully.." + tableName)
 	// grant privs to pipeline database user
-	// grant insert,select on foo.churro,foo.dataprov to foo
-	sqlStr = fmt.Sprintf("grant insert,select on %s.%s to %s;", dbname, tableName, userid)
+	// grant insert,select,update,delete on foo.churro to foo, the
+	// upsert and replace-partition load modes update and delete rows
+	sqlStr = fmt.Sprintf("grant insert,select,update,delete on %s to %s;", table, user)
 	stmt, err = d.Connection.Prepare(sqlStr)
 	if err != nil {
 		return err
@@ -50,32 +70,64 @@ func (d CockroachChurroDatabase) CreateTable(userid, dbname, tableName string, c
 	return nil
 }
 
-func getTableColumns(columnNames, columnTypes []string) string {
+func getTableColumns(columnNames, columnTypes []string) (string, error) {
 	var result string
 	for i, v := range columnNames {
-		col := fmt.Sprintf("%s %s,", v, columnTypes[i])
-		result = result + col
+		name, err := sqlsafe.QuotePostgres(v)
+		if err != nil {
+			return "", err
+		}
+		if err := sqlsafe.ValidColumnType(columnTypes[i]); err != nil {
+			return "", err
+		}
+		result = result + fmt.Sprintf("%s %s,", name, columnTypes[i])
 	}
 	log.Debug().Msg("getTableColumns " + result)
-	return result
+	return result, nil
+}
+
+// getInsertColumns returns the quoted column list of an insert,
+// surrounded by the columns churro adds to every table
+func getInsertColumns(cols []string) (string, error) {
+	quoted, err := sqlsafe.QuoteList(sqlsafe.QuotePostgres, cols)
+	if err != nil {
+		return "", err
+	}
+	if quoted == "" {
+		return "primarykey, dataformat, lastupdated", nil
+	}
+	return "primarykey, dataformat, " + quoted + ", lastupdated", nil
+}
+
+// writePlaceholders writes the numbered placeholders of one row,
+// starting after the first n already used by the statement
+func writePlaceholders(sb *strings.Builder, n, count int) {
+	sb.WriteString("(")
+	for i := 1; i <= count; i++ {
+		fmt.Fprintf(sb, "$%d, ", n+i)
+	}
+	sb.WriteString("now())")
 }
 
 func (d CockroachChurroDatabase) GetInsertStatement(scheme, database, tablename string, cols []string, vals []interface{}, primarykey int64) error {
 
-	var b string
-	for _, v := range cols {
-		b = b + fmt.Sprintf("%s,", v)
+	table, err := sqlsafe.QuotePostgres(database, tablename)
+	if err != nil {
+		return err
 	}
-	var c string
-	for _, v := range vals {
-		c = c + fmt.Sprintf("'%s',", v)
+	colNames, err := getInsertColumns(cols)
+	if err != nil {
+		return err
 	}
 
-	csvsql := fmt.Sprintf("insert into %s.%s (primarykey, dataformat, %s lastupdated) values (%d, '%s', %s now())", database, tablename, b, primarykey, scheme, c)
+	var sqlString strings.Builder
+	fmt.Fprintf(&sqlString, "insert into %s (%s) values ", table, colNames)
+	writePlaceholders(&sqlString, 0, len(vals)+2)
 
-	_, err := d.Connection.Query(csvsql)
+	args := append([]interface{}{primarykey, scheme}, vals...)
+	_, err = d.Connection.Exec(sqlString.String(), args...)
 	if err != nil {
-		log.Error().Stack().Err(err).Msg(csvsql)
+		log.Error().Stack().Err(err).Msg(sqlString.String())
 		return err
 	}
 
@@ -84,61 +136,387 @@ func (d CockroachChurroDatabase) GetInsertStatement(scheme, database, tablename
 
 func (d CockroachChurroDatabase) GetBulkInsertStatement(scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string) error {
 
+	table, err := sqlsafe.QuotePostgres(database, tableName)
+	if err != nil {
+		return err
+	}
+	colNames, err := getInsertColumns(cols)
+	if err != nil {
+		return err
+	}
+
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return err
+	}
+
+	err = insertRows(tx, fmt.Sprintf("insert into %s (%s) values ", table, colNames), "", scheme, cols, records, colTypes)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetUpsertStatement loads the records with UPSERT, a record replaces
+// the row that has the same primary key
+func (d CockroachChurroDatabase) GetUpsertStatement(scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string) error {
+
+	table, err := sqlsafe.QuotePostgres(database, tableName)
+	if err != nil {
+		return err
+	}
+	colNames, err := getInsertColumns(cols)
+	if err != nil {
+		return err
+	}
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return err
+	}
+
+	err = insertRows(tx, fmt.Sprintf("upsert into %s (%s) values ", table, colNames), "", scheme, cols, records, colTypes)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetReplacePartitionStatement deletes the rows of each partition and
+// then loads the records within one transaction, a partition holds
+// the values of the key columns
+func (d CockroachChurroDatabase) GetReplacePartitionStatement(scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string, keyCols []string, partitions [][]interface{}) error {
+
+	// the partitions are checked before the database is touched
+	filters := make([]string, 0, len(partitions))
+	args := make([][]interface{}, 0, len(partitions))
+	for _, partition := range partitions {
+		filter, values, err := getPartitionFilter(cols, colTypes, keyCols, partition)
+		if err != nil {
+			return err
+		}
+		filters = append(filters, filter)
+		args = append(args, values)
+	}
+
+	table, err := sqlsafe.QuotePostgres(database, tableName)
+	if err != nil {
+		return err
+	}
+	colNames, err := getInsertColumns(cols)
+	if err != nil {
+		return err
+	}
+
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return err
+	}
+
+	for i := range filters {
+		_, err = tx.Exec(fmt.Sprintf("delete from %s where %s", table, filters[i]), args[i]...)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("error in partition delete")
+			tx.Rollback()
+			return err
+		}
+	}
+
+	err = insertRows(tx, fmt.Sprintf("insert into %s (%s) values ", table, colNames), "", scheme, cols, records, colTypes)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insertRows writes the records with multi-row statements, wide tables
+// are split across statements to stay under the placeholder limit
+func insertRows(tx *sql.Tx, prefix, suffix, scheme string, cols []string, records []extractapi.GenericRow, colTypes []string) error {
+	perRow := len(cols) + 2
+	rowsPerStatement := maxPlaceholders / perRow
+
+	for start := 0; start < len(records); start += rowsPerStatement {
+		end := start + rowsPerStatement
+		if end > len(records) {
+			end = len(records)
+		}
+
+		var sqlString strings.Builder
+		sqlString.WriteString(prefix)
+		args := make([]interface{}, 0, (end-start)*perRow)
+		for i, r := range records[start:end] {
+			if i > 0 {
+				sqlString.WriteString(", ")
+			}
+			writePlaceholders(&sqlString, len(args), perRow)
+			args = append(args, getRowValues(scheme, r, colTypes)...)
+		}
+		sqlString.WriteString(suffix)
+
+		log.Debug().Msg(sqlString.String())
+
+		_, err := tx.Exec(sqlString.String(), args...)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("error in bulk insert")
+			return err
+		}
+	}
+	return nil
+}
+
+// getPartitionFilter returns the condition matching the rows whose key
+// columns hold the values of the partition
+func getPartitionFilter(cols, colTypes, keyCols []string, partition []interface{}) (string, []interface{}, error) {
+	if len(keyCols) == 0 || len(keyCols) != len(partition) {
+		return "", nil, fmt.Errorf("partition has %d values for %d key columns", len(partition), len(keyCols))
+	}
+
 	var sqlString strings.Builder
+	args := make([]interface{}, 0, len(keyCols))
+	for i, k := range keyCols {
+		c := columnIndex(cols, k)
+		if c < 0 {
+			return "", nil, fmt.Errorf("key column %s is not a loaded column", k)
+		}
+		name, err := sqlsafe.QuotePostgres(k)
+		if err != nil {
+			return "", nil, err
+		}
+		if i > 0 {
+			sqlString.WriteString(" and ")
+		}
+		v := getValue(colTypes[c], partition[i])
+		if v == nil {
+			fmt.Fprintf(&sqlString, "%s is null", name)
+			continue
+		}
+		args = append(args, v)
+		fmt.Fprintf(&sqlString, "%s = $%d", name, len(args))
+	}
+	return sqlString.String(), args, nil
+}
+
+func columnIndex(cols []string, name string) int {
+	for i := range cols {
+		if cols[i] == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// CopyRecords loads the records in a single transaction using the
+// postgres COPY FROM STDIN protocol, the count of records copied is
+// returned once the transaction commits
+func (d CockroachChurroDatabase) CopyRecords(scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string) (loaded int64, err error) {
+
+	copyStmt, err := getCopyStatement(database, tableName, cols)
+	if err != nil {
+		return 0, err
+	}
 
-	var colNames string
-	for i, v := range cols {
-		if i < len(cols)-1 {
-			colNames = colNames + fmt.Sprintf("%s,", v)
-		} else {
-			colNames = colNames + fmt.Sprintf("%s", v)
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
 		}
+	}()
+
+	stmt, err := tx.Prepare(copyStmt)
+	if err != nil {
+		return 0, err
 	}
-	tmp := fmt.Sprintf("insert into %s.%s (primarykey, dataformat, %s, lastupdated) values ", database, tableName, colNames)
-	sqlString.WriteString(tmp)
-	numRecords := len(records)
 
-	var currentRecord int
+	now := time.Now()
 	for _, r := range records {
-		//log.Info().Msg(fmt.Sprintf("colTypes %v\n", colTypes))
-		//log.Info().Msg(fmt.Sprintf("cols %v\n", cols))
-		//log.Info().Msg(fmt.Sprintf("r.Cols %v\n", r.Cols))
-		currentRecord++
-		var tmp string
-		var colValues string
-		for i := 0; i < len(r.Cols); i++ {
-			if i == len(r.Cols)-1 {
-				if colTypes[i] == extractapi.COLTYPE_TEXT ||
-					colTypes[i] == extractapi.COLTYPE_VARCHAR {
-					colValues = colValues + "'" + r.Cols[i].(string) + "'"
-				} else {
-					colValues = colValues + fmt.Sprintf("%v", r.Cols[i])
-				}
-			} else {
-				if colTypes[i] == extractapi.COLTYPE_TEXT ||
-					colTypes[i] == extractapi.COLTYPE_VARCHAR {
-					colValues = colValues + "'" + r.Cols[i].(string) + "'" + ","
-				} else {
-					colValues = colValues + fmt.Sprintf("%v", r.Cols[i]) + ","
-				}
-			}
+		_, err = stmt.Exec(append(getRowValues(scheme, r, colTypes), now)...)
+		if err != nil {
+			stmt.Close()
+			return 0, err
+		}
+		loaded++
+	}
+
+	// an Exec without arguments flushes the buffered rows
+	_, err = stmt.Exec()
+	if err != nil {
+		stmt.Close()
+		return 0, err
+	}
+	err = stmt.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+	log.Info().Msg(fmt.Sprintf("copied %d records into %s.%s", loaded, database, tableName))
+	return loaded, nil
+}
+
+// getCopyStatement returns the COPY FROM STDIN statement of the table,
+// the table was created with unquoted names which are folded to lower
+// case, COPY quotes every identifier
+func getCopyStatement(database, tableName string, cols []string) (string, error) {
+	if _, err := sqlsafe.QuotePostgres(database, tableName); err != nil {
+		return "", err
+	}
+
+	copyCols := []string{"primarykey", "dataformat"}
+	for _, v := range cols {
+		if err := sqlsafe.ValidIdentifier(v); err != nil {
+			return "", err
 		}
-		if currentRecord < numRecords {
-			tmp = fmt.Sprintf("(%d, '%s', %s, now()),", r.Key, scheme, colValues)
-		} else {
-			tmp = fmt.Sprintf("(%d, '%s', %s, now())", r.Key, scheme, colValues)
+		copyCols = append(copyCols, strings.ToLower(v))
+	}
+	copyCols = append(copyCols, "lastupdated")
+
+	return pq.CopyInSchema(strings.ToLower(database), strings.ToLower(tableName), copyCols...), nil
+}
+
+// getRowValues returns the key, data format and column values of a
+// record
+func getRowValues(scheme string, r extractapi.GenericRow, colTypes []string) []interface{} {
+	values := make([]interface{}, 0, len(r.Cols)+3)
+	values = append(values, r.Key, scheme)
+	for i := 0; i < len(r.Cols); i++ {
+		values = append(values, getValue(colTypes[i], r.Cols[i]))
+	}
+	return values
+}
+
+// getValue returns the value sent for a column, a nil value and a null
+// value in a non-text column are sent as a database NULL
+func getValue(colType string, v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	switch colType {
+	case extractapi.COLTYPE_TEXT, extractapi.COLTYPE_VARCHAR:
+		return fmt.Sprintf("%v", v)
+	}
+	if v == "null" {
+		return nil
+	}
+	switch v.(type) {
+	case int64, float64, bool, time.Time:
+		// converted to the column type by the extract
+		return v
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// cockroachTypes are the information_schema data types of the extract
+// rule column types, an INT is a 64 bit integer in cockroach
+var cockroachTypes = map[string]string{
+	extractapi.COLTYPE_TEXT:      "text",
+	extractapi.COLTYPE_VARCHAR:   "character varying",
+	extractapi.COLTYPE_INT:       "bigint",
+	extractapi.COLTYPE_BIGINT:    "bigint",
+	extractapi.COLTYPE_DECIMAL:   "numeric",
+	extractapi.COLTYPE_DOUBLE:    "double precision",
+	extractapi.COLTYPE_BOOLEAN:   "boolean",
+	extractapi.COLTYPE_DATE:      "date",
+	extractapi.COLTYPE_TIMESTAMP: "timestamp without time zone",
+	extractapi.COLTYPE_JSONB:     "jsonb",
+}
+
+// GetTableColumns returns the data type of each column of a table,
+// keyed by column name
+func (d CockroachChurroDatabase) GetTableColumns(database, tableName string) (map[string]string, error) {
+	schema, err := sqlsafe.QuotePostgres(database)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := sqlsafe.QuotePostgres(tableName); err != nil {
+		return nil, err
+	}
+
+	sqlStr := fmt.Sprintf("SELECT column_name, data_type FROM %s.information_schema.columns WHERE table_name = $1", schema)
+	rows, err := d.Connection.Query(sqlStr, strings.ToLower(tableName))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := make(map[string]string)
+	for rows.Next() {
+		var name, dataType string
+		if err := rows.Scan(&name, &dataType); err != nil {
+			return nil, err
 		}
-		sqlString.WriteString(tmp)
+		columns[name] = dataType
 	}
+	return columns, rows.Err()
+}
 
-	log.Info().Msg(sqlString.String())
+// GetColumnType returns the data type GetTableColumns reports for an
+// extract rule column type, blank when the type is not one churro knows
+func (d CockroachChurroDatabase) GetColumnType(columnType string) (string, error) {
+	return cockroachTypes[strings.ToUpper(columnType)], nil
+}
 
-	_, err := d.Connection.Exec(sqlString.String())
+// AddColumn adds a column to an existing table
+func (d CockroachChurroDatabase) AddColumn(database, tableName, columnName, columnType string) error {
+	table, err := sqlsafe.QuotePostgres(database, tableName)
+	if err != nil {
+		return err
+	}
+	column, err := getTableColumns([]string{columnName}, []string{columnType})
 	if err != nil {
-		log.Error().Stack().Err(err)
 		return err
 	}
 
-	return nil
+	sqlStr := fmt.Sprintf("ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s", table, strings.TrimSuffix(column, ","))
+	log.Info().Msg(sqlStr)
+	_, err = d.Connection.Exec(sqlStr)
+	return err
+}
+
+// GetReferenceRows returns the values of the columns of every row of a
+// table, for the lookups of an extract source
+func (d CockroachChurroDatabase) GetReferenceRows(database, tableName string, columns []string) ([][]interface{}, error) {
+	table, err := sqlsafe.QuotePostgres(database, tableName)
+	if err != nil {
+		return nil, err
+	}
+	quoted, err := sqlsafe.QuoteList(sqlsafe.QuotePostgres, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.Connection.Query(fmt.Sprintf("SELECT %s FROM %s", quoted, table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
+	values := make([][]interface{}, 0)
+	for rows.Next() {
+		row := make([]interface{}, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range row {
+			dest[i] = &row[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		for i, v := range row {
+			if b, ok := v.([]byte); ok {
+				row[i] = string(b)
+			}
+		}
+		values = append(values, row)
+	}
+	return values, rows.Err()
 }
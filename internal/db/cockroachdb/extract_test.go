@@ -0,0 +1,91 @@
+package cockroachdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+)
+
+// copyRecorder is a database/sql driver standing in for cockroach, it
+// records the statement prepared and the rows sent to it
+type copyRecorder struct {
+	query string
+	rows  [][]driver.Value
+}
+
+func (r *copyRecorder) Connect(ctx context.Context) (driver.Conn, error) { return r, nil }
+func (r *copyRecorder) Driver() driver.Driver                            { return nil }
+func (r *copyRecorder) Begin() (driver.Tx, error)                        { return r, nil }
+func (r *copyRecorder) Commit() error                                    { return nil }
+func (r *copyRecorder) Rollback() error                                  { return nil }
+func (r *copyRecorder) Close() error                                     { return nil }
+func (r *copyRecorder) NumInput() int                                    { return -1 }
+
+func (r *copyRecorder) Prepare(query string) (driver.Stmt, error) {
+	r.query = query
+	return r, nil
+}
+
+func (r *copyRecorder) Exec(args []driver.Value) (driver.Result, error) {
+	// the Exec without arguments flushes the rows
+	if len(args) > 0 {
+		r.rows = append(r.rows, args)
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (r *copyRecorder) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, driver.ErrSkip
+}
+
+func TestGetCopyStatement(t *testing.T) {
+	got, err := getCopyStatement("Pipeline1", "MyTable", []string{"City", "zip"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `COPY "pipeline1"."mytable" ("primarykey", "dataformat", "city", "zip", "lastupdated") FROM STDIN`
+	if got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	for _, c := range [][]string{{"pipeline1", "my table", "city"}, {"pipeline1", "mytable", "ci\"ty"}, {"pipe;line1", "mytable", "city"}} {
+		if _, err := getCopyStatement(c[0], c[1], c[2:]); err == nil {
+			t.Fatalf("accepted the identifiers %v", c)
+		}
+	}
+}
+
+func TestCopyRecords(t *testing.T) {
+	rec := &copyRecorder{}
+	conn := sql.OpenDB(rec)
+	defer conn.Close()
+
+	records := []extractapi.GenericRow{
+		{Key: 1, Cols: []interface{}{"boerne", "78006"}},
+		{Key: 2, Cols: []interface{}{"comfort", "78013"}},
+		{Key: 3, Cols: []interface{}{"kerrville", "null"}},
+	}
+	d := CockroachChurroDatabase{Connection: conn}
+	loaded, err := d.CopyRecords(extractapi.CSVScheme, "pipeline1", "mytable", []string{"city", "zip"}, records, []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_INT})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded != int64(len(records)) {
+		t.Fatalf("loaded %d, want %d", loaded, len(records))
+	}
+	if rec.query != `COPY "pipeline1"."mytable" ("primarykey", "dataformat", "city", "zip", "lastupdated") FROM STDIN` {
+		t.Fatalf("prepared %s", rec.query)
+	}
+	if len(rec.rows) != len(records) {
+		t.Fatalf("sent %d rows, want %d", len(rec.rows), len(records))
+	}
+	for i, r := range rec.rows {
+		// primarykey, dataformat, the columns and lastupdated
+		if len(r) != 5 || r[0] != records[i].Key || r[2] != records[i].Cols[0] {
+			t.Fatalf("row %d sent as %v", i, r)
+		}
+	}
+}
@@ -12,15 +12,16 @@ package cockroachdb
 import (
 	"fmt"
 
+	"github.com/churrodata/churro/internal/db/sqlsafe"
 	"github.com/churrodata/churro/internal/domain"
 	"github.com/rs/zerolog/log"
 )
 
 func (d CockroachChurroDatabase) UpdateExtractSourceMetric(a domain.ExtractSourceMetric) (err error) {
-	var UPDATE = fmt.Sprintf("UPDATE extractsourcemetric set (value, lastupdated) = ('%s',now()) where extractsourceid = '%s' and name = '%s'", a.Value, a.ExtractSourceID, a.Name)
+	var UPDATE = "UPDATE extractsourcemetric set (value, lastupdated) = ($1, now()) where extractsourceid = $2 and name = $3"
 	log.Info().Msg(UPDATE)
 
-	_, err = d.Connection.Exec(UPDATE)
+	_, err = d.Connection.Exec(UPDATE, a.Value, a.ExtractSourceID, a.Name)
 	if err != nil {
 		log.Error().Stack().Err(err)
 		return err
@@ -30,7 +31,7 @@ func (d CockroachChurroDatabase) UpdateExtractSourceMetric(a domain.ExtractSourc
 }
 
 func (d CockroachChurroDatabase) CreateExtractSourceMetric(a domain.ExtractSourceMetric) (err error) {
-	var INSERT = fmt.Sprintf("INSERT INTO extractsourcemetric(extractsourceid, name, value, lastupdated) values($1,$2,$3,now())")
+	var INSERT = "INSERT INTO extractsourcemetric(extractsourceid, name, value, lastupdated) values($1,$2,$3,now())"
 	stmt, err := d.Connection.Prepare(INSERT)
 	if err != nil {
 		log.Error().Stack().Err(err)
@@ -67,10 +68,15 @@ func (d CockroachChurroDatabase) GetExtractSourceMetrics(id string) (wdirs []dom
 }
 
 func (d CockroachChurroDatabase) IsInitialized(tablename string) bool {
-	sqlString := fmt.Sprintf("select count(*) from %s.%s", d.namespace, tablename)
+	table, err := sqlsafe.QuotePostgres(d.namespace, tablename)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in isInitialized ")
+		return false
+	}
+	sqlString := fmt.Sprintf("select count(*) from %s", table)
 	row := d.Connection.QueryRow(sqlString)
 	var t int
-	err := row.Scan(&t)
+	err = row.Scan(&t)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in isInitialized ")
 		return false
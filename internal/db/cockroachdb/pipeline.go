@@ -14,14 +14,21 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/churrodata/churro/internal/db/migrate"
+	"github.com/churrodata/churro/internal/db/sqlsafe"
 	"github.com/churrodata/churro/internal/domain"
 	"github.com/rs/zerolog/log"
 )
 
 func (s CockroachChurroDatabase) CreatePipelineDatabase(dbName string) error {
+	database, err := sqlsafe.QuotePostgres(dbName)
+	if err != nil {
+		return err
+	}
+
 	// make sure churro admin database is created
-	sqlStr := fmt.Sprintf("CREATE DATABASE if not exists %s", dbName)
-	_, err := s.Connection.Exec(sqlStr)
+	sqlStr := fmt.Sprintf("CREATE DATABASE if not exists %s", database)
+	_, err = s.Connection.Exec(sqlStr)
 	log.Info().Msg(sqlStr)
 	if err != nil {
 		return err
@@ -32,7 +39,7 @@ func (s CockroachChurroDatabase) CreatePipelineDatabase(dbName string) error {
 	// pipeline db
 
 	// grant all on database c1 to c1
-	sqlStr = fmt.Sprintf("grant all on database %s to %s", dbName, dbName)
+	sqlStr = fmt.Sprintf("grant all on database %s to %s", database, database)
 	_, err = s.Connection.Exec(sqlStr)
 	log.Info().Msg(sqlStr)
 	if err != nil {
@@ -43,9 +50,29 @@ func (s CockroachChurroDatabase) CreatePipelineDatabase(dbName string) error {
 	return nil
 }
 
+// pipelineColumns are the columns added to the pipeline tables after
+// they were first created, CreatePipelineObjects adds them to the
+// tables of an existing pipeline
+var pipelineColumns = []migrate.Column{
+	{Table: "extractlog", Version: 1, Name: "conversion_errors", Definition: "int not null default 0"},
+	{Table: "extractlog", Version: 2, Name: "records_rejected", Definition: "int not null default 0"},
+	{Table: "dataprov", Version: 1, Name: "fingerprint", Definition: "STRING"},
+	{Table: "extractlog", Version: 3, Name: "status", Definition: "string not null default ''"},
+	{Table: "extractlog", Version: 3, Name: "error_text", Definition: "string not null default ''"},
+}
+
 func (s CockroachChurroDatabase) CreatePipelineObjects(dbName, username string) error {
+	database, err := sqlsafe.QuotePostgres(dbName)
+	if err != nil {
+		return err
+	}
+	user, err := sqlsafe.QuotePostgres(username)
+	if err != nil {
+		return err
+	}
+
 	// make sure churro admin database is created
-	sqlStr := fmt.Sprintf("CREATE TABLE if not exists %s.dataprov ( id STRING PRIMARY KEY, name STRING, path STRING, lastupdated TIMESTAMP);", dbName)
+	sqlStr := fmt.Sprintf("CREATE TABLE if not exists %s.dataprov ( id STRING PRIMARY KEY, name STRING, path STRING, fingerprint STRING, lastupdated TIMESTAMP);", database)
 	stmt, err := s.Connection.Prepare(sqlStr)
 	if err != nil {
 		return err
@@ -59,7 +86,7 @@ func (s CockroachChurroDatabase) CreatePipelineObjects(dbName, username string)
 
 	// grant privs to pipeline database user
 	// grant insert,update,select on pipeline1.dataprov to someuser
-	sqlStr = fmt.Sprintf("grant insert,select on %s.dataprov to %s;", dbName, username)
+	sqlStr = fmt.Sprintf("grant insert,select on %s.dataprov to %s;", database, user)
 	stmt, err = s.Connection.Prepare(sqlStr)
 	if err != nil {
 		return err
@@ -72,7 +99,7 @@ func (s CockroachChurroDatabase) CreatePipelineObjects(dbName, username string)
 
 	// grant privs to pipeline database user
 	// grant create on pipelinedatabase to someuser
-	sqlStr = fmt.Sprintf("grant all on database %s to %s;", dbName, username)
+	sqlStr = fmt.Sprintf("grant all on database %s to %s;", database, user)
 	stmt, err = s.Connection.Prepare(sqlStr)
 	if err != nil {
 		return err
@@ -91,7 +118,7 @@ func (s CockroachChurroDatabase) CreatePipelineObjects(dbName, username string)
 	          records_in bigint,
 	          lastUpdated TIMESTAMP);
 	*/
-	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.pipeline_stats ( id serial PRIMARY KEY, dataprov_id text, file_name text, records_in bigint, lastupdated TIMESTAMP);", dbName)
+	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.pipeline_stats ( id serial PRIMARY KEY, dataprov_id text, file_name text, records_in bigint, lastupdated TIMESTAMP);", database)
 	stmt, err = s.Connection.Prepare(sqlStr)
 	if err != nil {
 		return err
@@ -104,7 +131,7 @@ func (s CockroachChurroDatabase) CreatePipelineObjects(dbName, username string)
 
 	// grant privs to pipeline database user
 	// grant insert,update,select on pipeline1.pipeline_stats to someuser
-	sqlStr = fmt.Sprintf("grant insert,select on %s.pipeline_stats to %s;", dbName, username)
+	sqlStr = fmt.Sprintf("grant insert,select on %s.pipeline_stats to %s;", database, user)
 	stmt, err = s.Connection.Prepare(sqlStr)
 	if err != nil {
 		return err
@@ -115,7 +142,7 @@ func (s CockroachChurroDatabase) CreatePipelineObjects(dbName, username string)
 	}
 	log.Info().Msg(sqlStr)
 
-	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.extractlog ( tablename string not null, id STRING PRIMARY KEY, dataprov_id STRING not null, podname STRING not null, poddate timestamp, records_loaded int not null, file_name string, lastupdated TIMESTAMP);", dbName)
+	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.extractlog ( tablename string not null, id STRING PRIMARY KEY, dataprov_id STRING not null, podname STRING not null, poddate timestamp, records_loaded int not null, conversion_errors int not null default 0, records_rejected int not null default 0, file_name string, status string not null default '', error_text string not null default '', lastupdated TIMESTAMP);", database)
 	stmt, err = s.Connection.Prepare(sqlStr)
 	if err != nil {
 		return err
@@ -129,7 +156,57 @@ func (s CockroachChurroDatabase) CreatePipelineObjects(dbName, username string)
 
 	// grant privs to pipeline database user
 	// grant insert,update,select on pipeline1.extractlog to someuser
-	sqlStr = fmt.Sprintf("grant insert,select on %s.extractlog to %s;", dbName, username)
+	sqlStr = fmt.Sprintf("grant insert,select on %s.extractlog to %s;", database, user)
+	stmt, err = s.Connection.Prepare(sqlStr)
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec()
+	if err != nil {
+		return err
+	}
+	log.Info().Msg(sqlStr)
+
+	// the columns of an extract source table after each extract rule
+	// change, one row per version
+	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.schemaversion ( tablename STRING not null, version int not null, extractsource_id STRING, column_list STRING, lastupdated TIMESTAMP, PRIMARY KEY (tablename, version));", database)
+	stmt, err = s.Connection.Prepare(sqlStr)
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec()
+	if err != nil {
+		return err
+	}
+
+	log.Info().Msg(sqlStr)
+
+	// grant privs to pipeline database user
+	// grant insert,select on pipeline1.schemaversion to someuser
+	sqlStr = fmt.Sprintf("grant insert,select on %s.schemaversion to %s;", database, user)
+	stmt, err = s.Connection.Prepare(sqlStr)
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec()
+	if err != nil {
+		return err
+	}
+	log.Info().Msg(sqlStr)
+
+	// the privacy policies applied to the columns of each dataprov
+	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.privacyaudit ( id STRING PRIMARY KEY, dataprov_id STRING not null, tablename STRING not null, columnname STRING not null, policy STRING not null, lastupdated TIMESTAMP);", database)
+	stmt, err = s.Connection.Prepare(sqlStr)
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec()
+	if err != nil {
+		return err
+	}
+	log.Info().Msg(sqlStr)
+
+	sqlStr = fmt.Sprintf("grant insert,select on %s.privacyaudit to %s;", database, user)
 	stmt, err = s.Connection.Prepare(sqlStr)
 	if err != nil {
 		return err
@@ -154,7 +231,14 @@ func (s CockroachChurroDatabase) CreatePipelineObjects(dbName, username string)
 	  }
 	*/
 
-	return nil
+	// add the columns of later releases to the tables of an existing
+	// pipeline
+	return migrate.Run(s, dbName, pipelineColumns, func(table, column, definition string) error {
+		sqlStr := fmt.Sprintf("ALTER TABLE %s.%s ADD COLUMN IF NOT EXISTS %s %s;", database, table, column, definition)
+		log.Info().Msg(sqlStr)
+		_, err := s.Connection.Exec(sqlStr)
+		return err
+	})
 }
 
 func (s CockroachChurroDatabase) GetAllPipelineMetrics() (metrics []domain.PipelineMetric, err error) {
@@ -181,10 +265,10 @@ func (s CockroachChurroDatabase) GetAllPipelineMetrics() (metrics []domain.Pipel
 
 func (s CockroachChurroDatabase) UpdatePipelineMetric(m domain.PipelineMetric) error {
 	datetime := time.Now()
-	var UPDATE = fmt.Sprintf("UPDATE pipelinemetric set (value, lastupdated) = ('%s', '%v') where name = '%s'", m.Value, datetime.Format("2006-01-02T15:04:05.999999999"), m.Name)
+	var UPDATE = "UPDATE pipelinemetric set (value, lastupdated) = ($1, $2) where name = $3"
 	log.Info().Msg(UPDATE)
 
-	_, err := s.Connection.Exec(UPDATE)
+	_, err := s.Connection.Exec(UPDATE, m.Value, datetime, m.Name)
 	if err != nil {
 		log.Error().Stack().Err(err)
 		return err
@@ -206,7 +290,11 @@ func (s CockroachChurroDatabase) CreatePipelineMetric(m domain.PipelineMetric) e
 }
 
 func (s CockroachChurroDatabase) CreateUser(username, password string) (err error) {
-	sqlStr := fmt.Sprintf("create user if not exists %s;", username)
+	user, err := sqlsafe.QuotePostgres(username)
+	if err != nil {
+		return err
+	}
+	sqlStr := fmt.Sprintf("create user if not exists %s;", user)
 	var stmt *sql.Stmt
 	stmt, err = s.Connection.Prepare(sqlStr)
 	if err != nil {
@@ -233,7 +321,7 @@ func (s CockroachChurroDatabase) CreateExtractLog(p domain.JobProfile) error {
 }
 func (s CockroachChurroDatabase) UpdateExtractLog(p domain.JobProfile) error {
 	//datetime := time.Now()
-	var UPDATE = fmt.Sprintf("UPDATE extractlog set (records_loaded, lastupdated) = (%d, now()) where id = '%s'", p.RecordsLoaded, p.ID)
+	var UPDATE = "UPDATE extractlog set (records_loaded, conversion_errors, records_rejected, lastupdated) = ($1, $2, $3, now()) where id = $4"
 	log.Info().Msg(UPDATE)
 	stmt, err := s.Connection.Prepare(UPDATE)
 	if err != nil {
@@ -241,7 +329,22 @@ func (s CockroachChurroDatabase) UpdateExtractLog(p domain.JobProfile) error {
 		return err
 	}
 
-	_, err = stmt.Exec()
+	_, err = stmt.Exec(p.RecordsLoaded, p.ConversionErrors, p.RecordsRejected, p.ID)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateExtractLogStatus sets the status of an extract job and the
+// error it failed with
+func (s CockroachChurroDatabase) UpdateExtractLogStatus(id, status, errorText string) error {
+	var UPDATE = "UPDATE extractlog set status = $1, error_text = $2, lastupdated = now() where id = $3"
+	log.Info().Msg(UPDATE)
+
+	_, err := s.Connection.Exec(UPDATE, status, errorText, id)
 	if err != nil {
 		log.Error().Stack().Err(err)
 		return err
@@ -252,8 +355,8 @@ func (s CockroachChurroDatabase) UpdateExtractLog(p domain.JobProfile) error {
 
 func (s CockroachChurroDatabase) GetExtractLog(jobName string) (p domain.JobProfile, err error) {
 
-	row := s.Connection.QueryRow("SELECT tablename, id,dataprov_id, podname, poddate, records_loaded, file_name FROM extractlog where podname=$1", jobName)
-	err = row.Scan(&p.TableName, &p.ID, &p.DataProvenanceID, &p.JobName, &p.StartDate, &p.RecordsLoaded, &p.FileName)
+	row := s.Connection.QueryRow("SELECT tablename, id,dataprov_id, podname, poddate, records_loaded, conversion_errors, records_rejected, file_name, status, error_text FROM extractlog where podname=$1", jobName)
+	err = row.Scan(&p.TableName, &p.ID, &p.DataProvenanceID, &p.JobName, &p.StartDate, &p.RecordsLoaded, &p.ConversionErrors, &p.RecordsRejected, &p.FileName, &p.Status, &p.ErrorText)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error getting job from extract log " + jobName)
 		return p, err
@@ -266,8 +369,8 @@ func (s CockroachChurroDatabase) GetExtractLog(jobName string) (p domain.JobProf
 
 func (s CockroachChurroDatabase) GetExtractLogById(id string) (p domain.JobProfile, err error) {
 
-	row := s.Connection.QueryRow("SELECT tablename, id,dataprov_id, podname, poddate, records_loaded, file_name FROM extractlog where id=$1", id)
-	err = row.Scan(&p.TableName, &p.ID, &p.DataProvenanceID, &p.JobName, &p.StartDate, &p.RecordsLoaded, &p.FileName)
+	row := s.Connection.QueryRow("SELECT tablename, id,dataprov_id, podname, poddate, records_loaded, conversion_errors, records_rejected, file_name, status, error_text FROM extractlog where id=$1", id)
+	err = row.Scan(&p.TableName, &p.ID, &p.DataProvenanceID, &p.JobName, &p.StartDate, &p.RecordsLoaded, &p.ConversionErrors, &p.RecordsRejected, &p.FileName, &p.Status, &p.ErrorText)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error getting job by id from extract log " + id)
 		return p, err
@@ -277,3 +380,38 @@ func (s CockroachChurroDatabase) GetExtractLogById(id string) (p domain.JobProfi
 	return p, nil
 
 }
+
+func (s CockroachChurroDatabase) CreateSchemaVersion(v domain.SchemaVersion) error {
+	var INSERT = "INSERT INTO schemaversion ( tablename, version, extractsource_id, column_list, lastupdated ) values ($1, $2, $3, $4, now())"
+
+	_, err := s.Connection.Exec(INSERT, v.TableName, v.Version, v.ExtractSourceID, v.Columns)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetSchemaVersions returns the schema versions of a table, oldest first
+func (s CockroachChurroDatabase) GetSchemaVersions(tableName string) (versions []domain.SchemaVersion, err error) {
+	versions = make([]domain.SchemaVersion, 0)
+	rows, err := s.Connection.Query("SELECT tablename, version, extractsource_id, column_list, lastupdated FROM schemaversion where tablename=$1 order by version", tableName)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error getting schema versions of " + tableName)
+		return versions, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		v := domain.SchemaVersion{}
+		err = rows.Scan(&v.TableName, &v.Version, &v.ExtractSourceID, &v.Columns, &v.LastUpdated)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("error getting schema versions of " + tableName)
+			return versions, err
+		}
+		versions = append(versions, v)
+	}
+
+	return versions, rows.Err()
+}
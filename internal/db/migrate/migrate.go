@@ -0,0 +1,112 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package migrate adds the columns of later churro releases to the
+// tables of pipelines created before them, each change is recorded as
+// a schema version of its table so it is only made once
+package migrate
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/churrodata/churro/internal/domain"
+)
+
+// Column is a column added to a pipeline table after the table was
+// first created.  Columns added together share a version, the
+// definition is written into ALTER TABLE as is.
+type Column struct {
+	Table      string
+	Version    int
+	Name       string
+	Definition string
+}
+
+// Database is the part of a churro database the migrations use
+type Database interface {
+	GetSchemaVersions(tableName string) ([]domain.SchemaVersion, error)
+	CreateSchemaVersion(v domain.SchemaVersion) error
+	GetTableColumns(database, tableName string) (map[string]string, error)
+}
+
+// the column_list of a schema version, as recorded for extract rules
+type schemaColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Run adds the columns newer than the latest schema version of their
+// table, in order, using addColumn.  A column the table already has,
+// as it does when the table was just created, is only recorded.
+func Run(d Database, database string, columns []Column, addColumn func(table, column, definition string) error) error {
+	latest := make(map[string]int)
+	var added []schemaColumn
+
+	for i, c := range columns {
+		if _, ok := latest[c.Table]; !ok {
+			versions, err := d.GetSchemaVersions(c.Table)
+			if err != nil {
+				return err
+			}
+			latest[c.Table] = 0
+			if len(versions) > 0 {
+				latest[c.Table] = versions[len(versions)-1].Version
+			}
+		}
+		if c.Version <= latest[c.Table] {
+			continue
+		}
+
+		live, err := d.GetTableColumns(database, c.Table)
+		if err != nil {
+			return err
+		}
+		if !hasColumn(live, c.Name) {
+			log.Info().Msg("adding column " + c.Name + " to " + c.Table)
+			err = addColumn(c.Table, c.Name, c.Definition)
+			if err != nil {
+				return err
+			}
+		}
+		added = append(added, schemaColumn{Name: c.Name, Type: c.Definition})
+
+		// the version is recorded after its last column
+		if i+1 < len(columns) && columns[i+1].Table == c.Table && columns[i+1].Version == c.Version {
+			continue
+		}
+		b, err := json.Marshal(added)
+		if err != nil {
+			return err
+		}
+		err = d.CreateSchemaVersion(domain.SchemaVersion{
+			TableName: c.Table,
+			Version:   c.Version,
+			Columns:   string(b),
+		})
+		if err != nil {
+			return err
+		}
+		latest[c.Table] = c.Version
+		added = nil
+	}
+	return nil
+}
+
+func hasColumn(live map[string]string, name string) bool {
+	for n := range live {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
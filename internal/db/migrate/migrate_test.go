@@ -0,0 +1,67 @@
+package migrate
+
+import (
+	"testing"
+
+	"github.com/churrodata/churro/internal/domain"
+)
+
+type fakeDatabase struct {
+	versions map[string][]domain.SchemaVersion
+	columns  map[string]map[string]string
+}
+
+func (f *fakeDatabase) GetSchemaVersions(tableName string) ([]domain.SchemaVersion, error) {
+	return f.versions[tableName], nil
+}
+
+func (f *fakeDatabase) CreateSchemaVersion(v domain.SchemaVersion) error {
+	f.versions[v.TableName] = append(f.versions[v.TableName], v)
+	return nil
+}
+
+func (f *fakeDatabase) GetTableColumns(database, tableName string) (map[string]string, error) {
+	return f.columns[tableName], nil
+}
+
+func TestRun(t *testing.T) {
+	columns := []Column{
+		{Table: "extractlog", Version: 1, Name: "conversion_errors", Definition: "int not null default 0"},
+		{Table: "extractlog", Version: 2, Name: "status", Definition: "text not null default ''"},
+		{Table: "extractlog", Version: 2, Name: "error_text", Definition: "text not null default ''"},
+		{Table: "dataprov", Version: 1, Name: "fingerprint", Definition: "text"},
+	}
+	f := &fakeDatabase{
+		versions: map[string][]domain.SchemaVersion{},
+		columns: map[string]map[string]string{
+			"extractlog": {"id": "text", "ERROR_TEXT": "text"},
+			"dataprov":   {"id": "text", "fingerprint": "text"},
+		},
+	}
+
+	var altered []string
+	addColumn := func(table, column, definition string) error {
+		altered = append(altered, table+"."+column)
+		f.columns[table][column] = definition
+		return nil
+	}
+
+	if err := Run(f, "pipeline1", columns, addColumn); err != nil {
+		t.Fatalf("migrate.Run Error: %v", err)
+	}
+	if len(altered) != 2 || altered[0] != "extractlog.conversion_errors" || altered[1] != "extractlog.status" {
+		t.Fatalf("migrate.Run altered %v", altered)
+	}
+	if len(f.versions["extractlog"]) != 2 || len(f.versions["dataprov"]) != 1 {
+		t.Fatalf("migrate.Run recorded %v", f.versions)
+	}
+
+	// the versions are recorded so a second run changes nothing
+	altered = nil
+	if err := Run(f, "pipeline1", columns, addColumn); err != nil {
+		t.Fatalf("migrate.Run Error: %v", err)
+	}
+	if len(altered) != 0 || len(f.versions["extractlog"]) != 2 {
+		t.Fatalf("migrate.Run second run altered %v recorded %v", altered, f.versions)
+	}
+}
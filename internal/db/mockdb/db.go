@@ -48,6 +48,22 @@ func (d MockChurroDatabase) CreateDataprov(data domain.DataProvenance) error {
 	return nil
 }
 
+func (d MockChurroDatabase) GetDataprovsByFingerprint(fingerprint string) ([]domain.DataProvenance, error) {
+	return []domain.DataProvenance{}, nil
+}
+
+func (d MockChurroDatabase) ClearDataprovFingerprint(id string) error {
+	return nil
+}
+
+func (d MockChurroDatabase) CreatePrivacyAudit(a domain.PrivacyAudit) error {
+	return nil
+}
+
+func (d MockChurroDatabase) GetPrivacyAudits(dataprovID string) ([]domain.PrivacyAudit, error) {
+	return []domain.PrivacyAudit{}, nil
+}
+
 func (d *MockChurroDatabase) UpdatePipelineStats(data stats.PipelineStats) error {
 	return nil
 }
@@ -32,3 +32,39 @@ func (d MockChurroDatabase) GetBulkInsertStatement(scheme, database, tableName s
 	return nil
 
 }
+
+func (d MockChurroDatabase) GetUpsertStatement(scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string) error {
+
+	return nil
+
+}
+
+func (d MockChurroDatabase) GetReplacePartitionStatement(scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string, keyCols []string, partitions [][]interface{}) error {
+
+	return nil
+
+}
+
+func (d MockChurroDatabase) GetTableColumns(database, tableName string) (map[string]string, error) {
+
+	return map[string]string{}, nil
+
+}
+
+func (d MockChurroDatabase) GetColumnType(columnType string) (string, error) {
+
+	return columnType, nil
+
+}
+
+func (d MockChurroDatabase) AddColumn(database, tableName, columnName, columnType string) error {
+
+	return nil
+
+}
+
+func (d MockChurroDatabase) GetReferenceRows(database, tableName string, columns []string) ([][]interface{}, error) {
+
+	return [][]interface{}{}, nil
+
+}
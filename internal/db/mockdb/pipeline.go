@@ -48,6 +48,10 @@ func (s MockChurroDatabase) UpdateExtractLog(p domain.JobProfile) error {
 	return nil
 }
 
+func (s MockChurroDatabase) UpdateExtractLogStatus(id, status, errorText string) error {
+	return nil
+}
+
 func (s MockChurroDatabase) GetExtractLog(jobName string) (p domain.JobProfile, err error) {
 
 	return p, nil
@@ -59,3 +63,13 @@ func (s MockChurroDatabase) GetExtractLogById(id string) (p domain.JobProfile, e
 	return p, nil
 
 }
+
+func (s MockChurroDatabase) CreateSchemaVersion(v domain.SchemaVersion) error {
+	return nil
+}
+
+func (s MockChurroDatabase) GetSchemaVersions(tableName string) (versions []domain.SchemaVersion, err error) {
+
+	return versions, nil
+
+}
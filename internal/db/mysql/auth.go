@@ -13,6 +13,7 @@ import (
 	"database/sql"
 	"fmt"
 
+	"github.com/churrodata/churro/internal/db/sqlsafe"
 	"github.com/churrodata/churro/internal/domain"
 	"github.com/rs/xid"
 	"github.com/rs/zerolog/log"
@@ -38,9 +39,14 @@ func (d MysqlChurroDatabase) CreateAuthenticatedUser(u domain.AuthenticatedUser)
 }
 
 func (d MysqlChurroDatabase) CreateChurroDatabase(dbName string) error {
+	database, err := sqlsafe.QuoteMySQL(dbName)
+	if err != nil {
+		return err
+	}
+
 	// make sure churro admin database is created
-	sqlStr := fmt.Sprintf("CREATE DATABASE if not exists %s", dbName)
-	_, err := d.Connection.Exec(sqlStr)
+	sqlStr := fmt.Sprintf("CREATE DATABASE if not exists %s", database)
+	_, err = d.Connection.Exec(sqlStr)
 	//log.Info().Msg(sqlStr)
 	if err != nil {
 		return err
@@ -72,7 +78,7 @@ func (d MysqlChurroDatabase) CreateAuthObjects() error {
 }
 
 func (d MysqlChurroDatabase) DeleteAuthenticatedUser(id string) (err error) {
-	_, err = d.Connection.Exec(fmt.Sprintf("DELETE FROM authenticateduser where id='%s'", id))
+	_, err = d.Connection.Exec("DELETE FROM authenticateduser where id=?", id)
 	if err != nil {
 		log.Error().Stack().Err(err)
 		return err
@@ -203,7 +209,7 @@ func (d MysqlChurroDatabase) UpdateUserProfile(u domain.UserProfile) error {
 }
 
 func (d MysqlChurroDatabase) DeleteUserProfile(id string) (err error) {
-	_, err = d.Connection.Exec(fmt.Sprintf("DELETE FROM userprofile where id='%s'", id))
+	_, err = d.Connection.Exec("DELETE FROM userprofile where id=?", id)
 	if err != nil {
 		log.Error().Stack().Err(err)
 		return err
@@ -12,14 +12,21 @@ package mysql
 import (
 	"fmt"
 
+	"github.com/churrodata/churro/internal/db/migrate"
+	"github.com/churrodata/churro/internal/db/sqlsafe"
 	"github.com/churrodata/churro/internal/domain"
 	"github.com/rs/zerolog/log"
 )
 
 func (d MysqlChurroDatabase) CreatePipelineDatabase(dbName string) error {
+	database, err := sqlsafe.QuoteMySQL(dbName)
+	if err != nil {
+		return err
+	}
+
 	// make sure churro admin database is created
-	sqlStr := fmt.Sprintf("CREATE DATABASE if not exists %s", dbName)
-	_, err := d.Connection.Exec(sqlStr)
+	sqlStr := fmt.Sprintf("CREATE DATABASE if not exists %s", database)
+	_, err = d.Connection.Exec(sqlStr)
 	log.Info().Msg(sqlStr)
 	if err != nil {
 		return err
@@ -28,9 +35,28 @@ func (d MysqlChurroDatabase) CreatePipelineDatabase(dbName string) error {
 	return nil
 }
 
+// pipelineColumns are the columns added to the pipeline tables after
+// they were first created, CreatePipelineObjects adds them to the
+// tables of an existing pipeline
+var pipelineColumns = []migrate.Column{
+	{Table: "extractlog", Version: 1, Name: "conversion_errors", Definition: "bigint default 0"},
+	{Table: "extractlog", Version: 2, Name: "records_rejected", Definition: "bigint default 0"},
+	{Table: "dataprov", Version: 1, Name: "fingerprint", Definition: "varchar(64)"},
+	{Table: "extractlog", Version: 3, Name: "status", Definition: "varchar(16) not null default ''"},
+	{Table: "extractlog", Version: 3, Name: "error_text", Definition: "varchar(1024) not null default ''"},
+}
+
 func (d MysqlChurroDatabase) CreatePipelineObjects(dbName, username string) error {
+	database, err := sqlsafe.QuoteMySQL(dbName)
+	if err != nil {
+		return err
+	}
+	if err := sqlsafe.ValidIdentifier(username); err != nil {
+		return err
+	}
+	user := sqlsafe.QuoteMySQLString(username)
 
-	sqlStr := fmt.Sprintf("CREATE TABLE if not exists %s.dataprov ( id varchar(30) PRIMARY KEY, name varchar(30), path varchar(80), lastupdated TIMESTAMP default '1970-01-01 00:00:01');", dbName)
+	sqlStr := fmt.Sprintf("CREATE TABLE if not exists %s.dataprov ( id varchar(30) PRIMARY KEY, name varchar(30), path varchar(80), fingerprint varchar(64), lastupdated TIMESTAMP default '1970-01-01 00:00:01');", database)
 	stmt, err := d.Connection.Prepare(sqlStr)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg(sqlStr)
@@ -46,7 +72,7 @@ func (d MysqlChurroDatabase) CreatePipelineObjects(dbName, username string) erro
 
 	// grant privs to pipeline database user
 	// grant insert,update,select on pipeline1.dataprov to someuser
-	sqlStr = fmt.Sprintf("grant insert,select on %s.dataprov to '%s';", dbName, username)
+	sqlStr = fmt.Sprintf("grant insert,select on %s.dataprov to %s;", database, user)
 	stmt, err = d.Connection.Prepare(sqlStr)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error on " + sqlStr)
@@ -59,7 +85,7 @@ func (d MysqlChurroDatabase) CreatePipelineObjects(dbName, username string) erro
 	}
 	log.Info().Msg(sqlStr)
 
-	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.pipeline_stats ( id serial PRIMARY KEY, dataprov_id text, file_name text, records_in bigint, lastupdated TIMESTAMP default '1970-01-01 00:00:01');", dbName)
+	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.pipeline_stats ( id serial PRIMARY KEY, dataprov_id text, file_name text, records_in bigint, lastupdated TIMESTAMP default '1970-01-01 00:00:01');", database)
 	stmt, err = d.Connection.Prepare(sqlStr)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error on " + sqlStr)
@@ -74,7 +100,7 @@ func (d MysqlChurroDatabase) CreatePipelineObjects(dbName, username string) erro
 
 	// grant privs to pipeline database user
 	// grant insert,update,select on pipeline1.pipeline_stats to someuser
-	sqlStr = fmt.Sprintf("grant insert,select on %s.pipeline_stats to '%s';", dbName, username)
+	sqlStr = fmt.Sprintf("grant insert,select on %s.pipeline_stats to %s;", database, user)
 	stmt, err = d.Connection.Prepare(sqlStr)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error on " + sqlStr)
@@ -87,7 +113,7 @@ func (d MysqlChurroDatabase) CreatePipelineObjects(dbName, username string) erro
 	}
 	log.Info().Msg(sqlStr)
 
-	sqlStr2 := fmt.Sprintf("CREATE TABLE if not exists %s.extractlog ( tablename varchar(32), id varchar(32) PRIMARY KEY, dataprov_id varchar(32), podname varchar(32), poddate timestamp, records_loaded bigint, file_name varchar(32), lastupdated TIMESTAMP default '1970-01-01 00:00:01');", dbName)
+	sqlStr2 := fmt.Sprintf("CREATE TABLE if not exists %s.extractlog ( tablename varchar(32), id varchar(32) PRIMARY KEY, dataprov_id varchar(32), podname varchar(32), poddate timestamp, records_loaded bigint, conversion_errors bigint default 0, records_rejected bigint default 0, file_name varchar(32), status varchar(16) not null default '', error_text varchar(1024) not null default '', lastupdated TIMESTAMP default '1970-01-01 00:00:01');", database)
 	stmt, err = d.Connection.Prepare(sqlStr2)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error on " + sqlStr2)
@@ -102,7 +128,7 @@ func (d MysqlChurroDatabase) CreatePipelineObjects(dbName, username string) erro
 
 	// grant privs to pipeline database user
 	// grant insert,update,select on pipeline1.pipeline_stats to someuser
-	sqlStr = fmt.Sprintf("grant insert,select on %s.extractlog to '%s';", dbName, username)
+	sqlStr = fmt.Sprintf("grant insert,select on %s.extractlog to %s;", database, user)
 	stmt, err = d.Connection.Prepare(sqlStr)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error on " + sqlStr)
@@ -114,7 +140,70 @@ func (d MysqlChurroDatabase) CreatePipelineObjects(dbName, username string) erro
 		return err
 	}
 	log.Info().Msg(sqlStr)
-	return nil
+
+	// the columns of an extract source table after each extract rule
+	// change, one row per version
+	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.schemaversion ( tablename varchar(64) not null, version int not null, extractsource_id varchar(32), column_list text, lastupdated TIMESTAMP default '1970-01-01 00:00:01', PRIMARY KEY (tablename, version));", database)
+	stmt, err = d.Connection.Prepare(sqlStr)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error on " + sqlStr)
+		return err
+	}
+	_, err = stmt.Exec()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error on " + sqlStr)
+		return err
+	}
+	log.Info().Msg(sqlStr)
+
+	sqlStr = fmt.Sprintf("grant insert,select on %s.schemaversion to %s;", database, user)
+	stmt, err = d.Connection.Prepare(sqlStr)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error on " + sqlStr)
+		return err
+	}
+	_, err = stmt.Exec()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error on " + sqlStr)
+		return err
+	}
+	log.Info().Msg(sqlStr)
+
+	// the privacy policies applied to the columns of each dataprov
+	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.privacyaudit ( id varchar(30) PRIMARY KEY, dataprov_id varchar(30) not null, tablename varchar(64) not null, columnname varchar(64) not null, policy varchar(64) not null, lastupdated TIMESTAMP default '1970-01-01 00:00:01');", database)
+	stmt, err = d.Connection.Prepare(sqlStr)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error on " + sqlStr)
+		return err
+	}
+	_, err = stmt.Exec()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error on " + sqlStr)
+		return err
+	}
+	log.Info().Msg(sqlStr)
+
+	sqlStr = fmt.Sprintf("grant insert,select on %s.privacyaudit to %s;", database, user)
+	stmt, err = d.Connection.Prepare(sqlStr)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error on " + sqlStr)
+		return err
+	}
+	_, err = stmt.Exec()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error on " + sqlStr)
+		return err
+	}
+	log.Info().Msg(sqlStr)
+
+	// add the columns of later releases to the tables of an existing
+	// pipeline
+	return migrate.Run(d, dbName, pipelineColumns, func(table, column, definition string) error {
+		sqlStr := fmt.Sprintf("ALTER TABLE %s.%s ADD COLUMN %s %s;", database, table, column, definition)
+		log.Info().Msg(sqlStr)
+		_, err := d.Connection.Exec(sqlStr)
+		return err
+	})
 }
 
 func (d MysqlChurroDatabase) GetAllPipelineMetrics() (metrics []domain.PipelineMetric, err error) {
@@ -173,20 +262,28 @@ func (d MysqlChurroDatabase) CreatePipelineMetric(m domain.PipelineMetric) error
 }
 func (d MysqlChurroDatabase) CreateUser(username, password string) error {
 
-	sqlStr := "create user if not exists '" + username + "'@'%' identified by '" + password + "';"
+	database, err := sqlsafe.QuoteMySQL(username)
+	if err != nil {
+		return err
+	}
+	user := sqlsafe.QuoteMySQLString(username)
+
+	// create user does not accept placeholders, the password is
+	// escaped as a string literal and kept out of the log
+	sqlStr := "create user if not exists " + user + "@'%' identified by " + sqlsafe.QuoteMySQLString(password) + ";"
 	stmt, err := d.Connection.Prepare(sqlStr)
 	if err != nil {
-		log.Error().Stack().Err(err).Msg(sqlStr)
+		log.Error().Stack().Err(err).Msg("error creating user " + username)
 		return err
 	}
 	_, err = stmt.Exec()
 	if err != nil {
-		log.Error().Stack().Err(err).Msg(sqlStr)
+		log.Error().Stack().Err(err).Msg("error creating user " + username)
 		return err
 	}
-	log.Info().Msg(sqlStr)
+	log.Info().Msg("created user " + username)
 
-	sqlStr = "grant all privileges on " + username + ".* to '" + username + "'@'%' with grant option"
+	sqlStr = "grant all privileges on " + database + ".* to " + user + "@'%' with grant option"
 	stmt, err = d.Connection.Prepare(sqlStr)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg(sqlStr)
@@ -217,14 +314,29 @@ func (d MysqlChurroDatabase) CreateExtractLog(p domain.JobProfile) error {
 	return nil
 }
 func (d MysqlChurroDatabase) UpdateExtractLog(p domain.JobProfile) error {
-	var UPDATE = fmt.Sprintf("UPDATE extractlog set records_loaded = ?, lastupdated = now() where id = ?")
+	var UPDATE = "UPDATE extractlog set records_loaded = ?, conversion_errors = ?, records_rejected = ?, lastupdated = now() where id = ?"
 	stmt, err := d.Connection.Prepare(UPDATE)
 	if err != nil {
 		log.Error().Stack().Err(err)
 		return err
 	}
 
-	_, err = stmt.Exec(p.RecordsLoaded, p.ID)
+	_, err = stmt.Exec(p.RecordsLoaded, p.ConversionErrors, p.RecordsRejected, p.ID)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateExtractLogStatus sets the status of an extract job and the
+// error it failed with
+func (d MysqlChurroDatabase) UpdateExtractLogStatus(id, status, errorText string) error {
+	var UPDATE = "UPDATE extractlog set status = ?, error_text = ?, lastupdated = now() where id = ?"
+	log.Info().Msg(UPDATE)
+
+	_, err := d.Connection.Exec(UPDATE, status, errorText, id)
 	if err != nil {
 		log.Error().Stack().Err(err)
 		return err
@@ -234,8 +346,8 @@ func (d MysqlChurroDatabase) UpdateExtractLog(p domain.JobProfile) error {
 }
 
 func (d MysqlChurroDatabase) GetExtractLog(jobName string) (p domain.JobProfile, err error) {
-	row := d.Connection.QueryRow("SELECT tablename, id,dataprov_id, podname, poddate, records_loaded, file_name FROM extractlog where podname=?", jobName)
-	err = row.Scan(&p.TableName, &p.ID, &p.DataProvenanceID, &p.JobName, &p.StartDate, &p.RecordsLoaded, &p.FileName)
+	row := d.Connection.QueryRow("SELECT tablename, id,dataprov_id, podname, poddate, records_loaded, conversion_errors, records_rejected, file_name, status, error_text FROM extractlog where podname=?", jobName)
+	err = row.Scan(&p.TableName, &p.ID, &p.DataProvenanceID, &p.JobName, &p.StartDate, &p.RecordsLoaded, &p.ConversionErrors, &p.RecordsRejected, &p.FileName, &p.Status, &p.ErrorText)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error getting job from extractlog " + jobName)
 		return p, err
@@ -245,8 +357,8 @@ func (d MysqlChurroDatabase) GetExtractLog(jobName string) (p domain.JobProfile,
 }
 
 func (d MysqlChurroDatabase) GetExtractLogById(id string) (p domain.JobProfile, err error) {
-	row := d.Connection.QueryRow("SELECT tablename, id,dataprov_id, podname, poddate, records_loaded, file_name FROM extractlog where id=?", id)
-	err = row.Scan(&p.TableName, &p.ID, &p.DataProvenanceID, &p.JobName, &p.StartDate, &p.RecordsLoaded, &p.FileName)
+	row := d.Connection.QueryRow("SELECT tablename, id,dataprov_id, podname, poddate, records_loaded, conversion_errors, records_rejected, file_name, status, error_text FROM extractlog where id=?", id)
+	err = row.Scan(&p.TableName, &p.ID, &p.DataProvenanceID, &p.JobName, &p.StartDate, &p.RecordsLoaded, &p.ConversionErrors, &p.RecordsRejected, &p.FileName, &p.Status, &p.ErrorText)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error getting job from extractlog by id" + id)
 		return p, err
@@ -254,3 +366,36 @@ func (d MysqlChurroDatabase) GetExtractLogById(id string) (p domain.JobProfile,
 
 	return p, nil
 }
+
+func (d MysqlChurroDatabase) CreateSchemaVersion(v domain.SchemaVersion) error {
+	_, err := d.Connection.Exec("insert into schemaversion(tablename, version, extractsource_id, column_list, lastupdated) values(?,?,?,?,now())", v.TableName, v.Version, v.ExtractSourceID, v.Columns)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetSchemaVersions returns the schema versions of a table, oldest first
+func (d MysqlChurroDatabase) GetSchemaVersions(tableName string) (versions []domain.SchemaVersion, err error) {
+	versions = make([]domain.SchemaVersion, 0)
+	rows, err := d.Connection.Query("SELECT tablename, version, extractsource_id, column_list, lastupdated FROM schemaversion where tablename=? order by version", tableName)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error getting schema versions of " + tableName)
+		return versions, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		v := domain.SchemaVersion{}
+		err = rows.Scan(&v.TableName, &v.Version, &v.ExtractSourceID, &v.Columns, &v.LastUpdated)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("error getting schema versions of " + tableName)
+			return versions, err
+		}
+		versions = append(versions, v)
+	}
+
+	return versions, rows.Err()
+}
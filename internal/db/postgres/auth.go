@@ -0,0 +1,285 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/churrodata/churro/internal/db/sqlsafe"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/rs/xid"
+)
+
+func (d PostgresChurroDatabase) CreateAuthenticatedUser(u domain.AuthenticatedUser) error {
+	u.ID = xid.New().String()
+	INSERT := "INSERT INTO authenticateduser(id, token, locked, lastupdated) values($1,$2,$3,now()) returning id"
+
+	err := d.Connection.QueryRow(INSERT, u.ID, u.Token, u.Locked).Scan(&u.ID)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d PostgresChurroDatabase) DeleteAuthenticatedUser(id string) (err error) {
+	_, err = d.Connection.Exec("DELETE FROM authenticateduser where id=$1", id)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d PostgresChurroDatabase) GetUserPipelineAccess(pipeline, id string) (a domain.UserPipelineAccess, err error) {
+	a.PipelineID = pipeline
+	a.UserProfileID = id
+
+	row := d.Connection.QueryRow("SELECT access, lastupdated FROM userpipelineaccess where pipelineid=$1 and userprofileid=$2", pipeline, id)
+	switch err := row.Scan(&a.Access, &a.LastUpdated); err {
+	case sql.ErrNoRows:
+		log.Error().Stack().Err(err).Msg("userpipelineaccess id was not found")
+		return a, err
+	case nil:
+		log.Info().Msg("userpipelineaccess id was found")
+		return a, nil
+	default:
+		return a, err
+	}
+}
+
+func (d PostgresChurroDatabase) CreateUserPipelineAccess(a domain.UserPipelineAccess) error {
+	var INSERT = "INSERT INTO userpipelineaccess(userprofileid, pipelineid, access, lastupdated) values($1,$2,$3,now()) returning userprofileid"
+
+	err := d.Connection.QueryRow(INSERT, a.UserProfileID, a.PipelineID, a.Access).Scan(&a.UserProfileID)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d PostgresChurroDatabase) UpdateUserPipelineAccess(a domain.UserPipelineAccess) error {
+	datetime := time.Now()
+
+	_, err := d.Connection.Exec("UPDATE userpipelineaccess set access = $1, lastupdated = $2 where userprofileid = $3 and pipelineid = $4",
+		a.Access,
+		datetime,
+		a.UserProfileID,
+		a.PipelineID)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d PostgresChurroDatabase) DeleteAllUserPipelineAccess(pipeline string) error {
+	_, err := d.Connection.Exec("DELETE FROM UserPipelineAccess where pipelineid = $1", pipeline)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d PostgresChurroDatabase) DeleteUserPipelineAccess(pipeline, id string) error {
+	_, err := d.Connection.Exec("DELETE FROM UserPipelineAccess where pipelineid = $1 and userprofileid = $2", pipeline, id)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d PostgresChurroDatabase) CreateUserProfile(u domain.UserProfile) error {
+	id := xid.New().String()
+	var INSERT = "INSERT INTO userprofile(password, id, lastname, firstname, email, access, lastupdated) values($1,$2,$3,$4,$5,$6,now()) returning id"
+
+	err := d.Connection.QueryRow(INSERT, u.Password, id, u.LastName, u.FirstName, u.Email, u.Access).Scan(&id)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (d PostgresChurroDatabase) UpdateUserProfile(u domain.UserProfile) error {
+	datetime := time.Now()
+
+	sqlStr := "UPDATE userprofile set password = $1, lastname = $2, firstname = $3, email = $4, access = $5, lastupdated = $6 where id = $7"
+	_, err := d.Connection.Exec(sqlStr, u.Password, u.LastName, u.FirstName, u.Email, u.Access, datetime, u.ID)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d PostgresChurroDatabase) DeleteUserProfile(id string) (err error) {
+	_, err = d.Connection.Exec("DELETE FROM userprofile where id=$1", id)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d PostgresChurroDatabase) Authenticate(email, password string) (u domain.UserProfile, err error) {
+	return u, nil
+}
+func (d PostgresChurroDatabase) GetAllUserProfile() (users []domain.UserProfile, err error) {
+	users = make([]domain.UserProfile, 0)
+
+	rows, err := d.Connection.Query("SELECT id, firstname, lastname, password, access, email, lastupdated FROM userprofile")
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return users, err
+	}
+
+	for rows.Next() {
+		p := domain.UserProfile{}
+		err = rows.Scan(&p.ID, &p.FirstName, &p.LastName, &p.Password, &p.Access, &p.Email, &p.LastUpdated)
+		if err != nil {
+			log.Error().Stack().Err(err)
+			return users, err
+		}
+		users = append(users, p)
+	}
+
+	return users, nil
+}
+
+func (d PostgresChurroDatabase) GetAllUserProfileForPipeline(pipelineid string) (users []domain.UserProfile, err error) {
+	users = make([]domain.UserProfile, 0)
+
+	rows, err := d.Connection.Query("SELECT a.id, a.firstname, a.lastname, a.password, a.access, a.email, a.lastupdated FROM userprofile a, userpipelineaccess b where a.id = b.userprofileid and b.pipelineid = $1", pipelineid)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return users, err
+	}
+
+	for rows.Next() {
+		p := domain.UserProfile{}
+		err = rows.Scan(&p.ID, &p.FirstName, &p.LastName, &p.Password, &p.Access, &p.Email, &p.LastUpdated)
+		if err != nil {
+			log.Error().Stack().Err(err)
+			return users, err
+		}
+		users = append(users, p)
+	}
+	return users, nil
+}
+
+func (d PostgresChurroDatabase) GetUserProfileByEmail(email string) (u domain.UserProfile, err error) {
+	row := d.Connection.QueryRow("SELECT id, firstname, lastname, password, access, email, lastupdated FROM userprofile where email=$1", email)
+	switch err := row.Scan(&u.ID, &u.FirstName, &u.LastName, &u.Password, &u.Access, &u.Email, &u.LastUpdated); err {
+	case sql.ErrNoRows:
+		log.Error().Stack().Err(err).Msg("userprofile email was not found" + email)
+		return u, err
+	case nil:
+		return u, nil
+	default:
+		return u, err
+	}
+}
+func (d PostgresChurroDatabase) GetUserProfile(id string) (u domain.UserProfile, err error) {
+	row := d.Connection.QueryRow("SELECT id, firstname, lastname, password, access, email, lastupdated FROM userprofile where id=$1", id)
+	switch err := row.Scan(&u.ID, &u.FirstName, &u.LastName, &u.Password, &u.Access, &u.Email, &u.LastUpdated); err {
+	case sql.ErrNoRows:
+		log.Error().Stack().Err(err).Msg("userprofile id was not found")
+		return u, err
+	case nil:
+		log.Info().Msg("userprofile id was found")
+		return u, nil
+	default:
+		return u, err
+	}
+}
+
+func (d PostgresChurroDatabase) Bootstrap() (err error) {
+	var id string
+	bootstrapID := "0000"
+	row := d.Connection.QueryRow("SELECT id FROM userprofile where id=$1", bootstrapID)
+	switch err := row.Scan(&id); err {
+	case sql.ErrNoRows:
+	case nil:
+		return nil
+	default:
+		return err
+	}
+	sqlStatement := "INSERT INTO userprofile(id, firstname, lastname, password, access, email, lastupdated) values($1,$2,$3,$4,$5,$6,now()) returning id"
+
+	err = d.Connection.QueryRow(sqlStatement, bootstrapID, "admin", "admin", "admin", "Admin", "admin@admin.org").Scan(&id)
+	if err != nil {
+		return err
+	}
+	return nil
+
+}
+
+func (d PostgresChurroDatabase) CreateChurroDatabase(dbName string) (err error) {
+	schema, err := sqlsafe.QuotePostgres(dbName)
+	if err != nil {
+		return err
+	}
+
+	// make sure churro admin schema is created
+	sqlStr := fmt.Sprintf("CREATE SCHEMA if not exists %s", schema)
+	_, err = d.Connection.Exec(sqlStr)
+	log.Info().Msg(sqlStr)
+	if err != nil {
+		return err
+	}
+	log.Info().Msg("Successfully created schema " + dbName)
+	return nil
+
+}
+
+func (d PostgresChurroDatabase) CreateAuthObjects() (err error) {
+
+	// create AuthenticatedUser
+	_, err = d.Connection.Exec("CREATE TABLE if not exists authenticateduser (id VARCHAR(255) PRIMARY KEY, token VARCHAR(64) NOT NULL, locked boolean NOT NULL, lastupdated TIMESTAMP NULL)")
+	if err != nil {
+		return err
+	}
+
+	// create UserProfile
+	_, err = d.Connection.Exec("CREATE TABLE if not exists userprofile (id VARCHAR(255) PRIMARY KEY, firstname VARCHAR(64) NOT NULL, lastname VARCHAR(64) NOT NULL, password VARCHAR(64) NOT NULL, access VARCHAR(25) NOT NULL, email VARCHAR(64) NOT NULL, lastupdated TIMESTAMP NULL)")
+	if err != nil {
+		return err
+	}
+	// create UserPipelineAccess
+	_, err = d.Connection.Exec("CREATE TABLE if not exists userpipelineaccess (userprofileid VARCHAR(255) NOT NULL, pipelineid VARCHAR(64) NOT NULL, access VARCHAR(25) NOT NULL, lastupdated TIMESTAMP NULL)")
+	if err != nil {
+		return err
+	}
+	// create Pipeline
+	/**
+	_, err = d.Connection.Exec("CREATE TABLE if not exists pipeline (id VARCHAR(255) PRIMARY KEY, name VARCHAR(64) NOT NULL, lastupdated TIMESTAMP NULL)")
+	if err != nil {
+		return err
+	}
+	*/
+	return nil
+}
@@ -0,0 +1,251 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package postgres implements the churro database on an externally
+// provided postgres server.  Postgres can not qualify a table with
+// another database, so each churro database (churro, pipeline1, ...)
+// is a schema within the single database the server provides.
+package postgres
+
+import (
+	"database/sql"
+	b64 "encoding/base64"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	_ "github.com/lib/pq"
+
+	"github.com/churrodata/churro/api/v1alpha1"
+	"github.com/churrodata/churro/internal/db/sqlsafe"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/churrodata/churro/internal/stats"
+	"github.com/churrodata/churro/pkg/config"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	DB_POSTGRES = "postgres"
+)
+
+type PostgresChurroDatabase struct {
+	Connection *sql.DB
+	namespace  string
+}
+
+func (d PostgresChurroDatabase) GetVersion() (string, error) {
+	var version string
+	err := d.Connection.QueryRow("SELECT VERSION()").Scan(&version)
+	if err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+func (d PostgresChurroDatabase) CreateObjects(dbName string) error {
+
+	schema, err := sqlsafe.QuotePostgres(dbName)
+	if err != nil {
+		return err
+	}
+
+	// make sure churro admin schema is created
+	sqlStr := fmt.Sprintf("CREATE SCHEMA if not exists %s", schema)
+	_, err = d.Connection.Exec(sqlStr)
+	log.Info().Msg(sqlStr)
+	if err != nil {
+		return err
+	}
+	log.Info().Msg("Successfully created schema " + dbName)
+
+	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.pipelinemetric ( name text PRIMARY KEY, value text NOT NULL, lastupdated TIMESTAMP);", schema)
+	log.Info().Msg(sqlStr)
+	_, err = d.Connection.Exec(sqlStr)
+	if err != nil {
+		return err
+	}
+	log.Info().Msg("pipelinemetric Table created successfully..")
+
+	// seed the metric table unless it already is
+	sqlStr = fmt.Sprintf("INSERT into %s.pipelinemetric ( name, value, lastupdated) values ($1, '0', now()) on conflict (name) do nothing;", schema)
+	log.Info().Msg(sqlStr)
+	_, err = d.Connection.Exec(sqlStr, domain.MetricFilesProcessed)
+	if err != nil {
+		return err
+	}
+
+	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.extractsourcemetric ( extractsourceid text, name text NOT NULL, value text NOT NULL, lastupdated TIMESTAMP);", schema)
+	log.Info().Msg(sqlStr)
+	_, err = d.Connection.Exec(sqlStr)
+	if err != nil {
+		return err
+	}
+	log.Info().Msg("extractsourcemetric Table created successfully..")
+
+	return nil
+}
+
+func (d PostgresChurroDatabase) GetDatabaseType() string {
+	return DB_POSTGRES
+}
+
+// GetConnection connects to the source's Externaldatabase, the source
+// Database is the schema searched for unqualified table names.  The
+// sslmode defaults to require and can be changed with PGSSLMODE.
+func (d *PostgresChurroDatabase) GetConnection(dbCreds config.DBCredentials, source v1alpha1.Source) (err error) {
+	d.namespace = os.Getenv("CHURRO_NAMESPACE")
+	if d.namespace == "" {
+		log.Error().Stack().Msg("error CHURRO_NAMESPACE is empty")
+		return fmt.Errorf("CHURRO_NAMESPACE env var required")
+	}
+
+	connectString, err := getConnectString(source)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in GetConnection")
+		return err
+	}
+	d.Connection, err = sql.Open("postgres", connectString)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in GetConnection")
+		return err
+	}
+
+	return nil
+}
+
+func getConnectString(source v1alpha1.Source) (string, error) {
+	if source.Host == "" {
+		return "", fmt.Errorf("postgres host is required")
+	}
+	if source.Externaldatabase == "" {
+		return "", fmt.Errorf("postgres externaldatabase is required")
+	}
+	password, err := getPassword(source.Password)
+	if err != nil {
+		return "", err
+	}
+
+	u := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(source.Username, password),
+		Host:   source.Host,
+		Path:   "/" + source.Externaldatabase,
+	}
+	if source.Port != 0 {
+		u.Host = net.JoinHostPort(source.Host, strconv.Itoa(source.Port))
+	}
+	if source.Database != "" {
+		if err := sqlsafe.ValidIdentifier(source.Database); err != nil {
+			return "", err
+		}
+		q := url.Values{}
+		q.Set("search_path", strings.ToLower(source.Database))
+		u.RawQuery = q.Encode()
+	}
+	return u.String(), nil
+}
+
+// getPassword decodes a password, the pipeline CR holds passwords
+// base64 encoded
+func getPassword(encoded string) (string, error) {
+	b, err := b64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("postgres password is not base64 encoded %v", err)
+	}
+	return string(b), nil
+}
+
+func (d PostgresChurroDatabase) CreateDataprov(data domain.DataProvenance) error {
+	insertStmt, err := d.Connection.Prepare("INSERT into dataprov (id, name, path, fingerprint, lastupdated) values ($1, $2, $3, $4, $5)")
+	if err != nil {
+		return err
+	}
+	if _, err := insertStmt.Exec(data.ID, data.Name, data.Path, data.Fingerprint, data.LastUpdated); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetDataprovsByFingerprint returns the data provenance of the files
+// registered with a fingerprint, oldest first
+func (d PostgresChurroDatabase) GetDataprovsByFingerprint(fingerprint string) ([]domain.DataProvenance, error) {
+	rows, err := d.Connection.Query("SELECT id, name, path, fingerprint, lastupdated FROM dataprov WHERE fingerprint = $1 ORDER BY lastupdated", fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dps := make([]domain.DataProvenance, 0)
+	for rows.Next() {
+		dp := domain.DataProvenance{}
+		if err := rows.Scan(&dp.ID, &dp.Name, &dp.Path, &dp.Fingerprint, &dp.LastUpdated); err != nil {
+			return nil, err
+		}
+		dps = append(dps, dp)
+	}
+	return dps, rows.Err()
+}
+
+// ClearDataprovFingerprint blanks the fingerprint of a dataprov whose
+// extraction failed, so the file is not skipped when it is requeued
+func (d PostgresChurroDatabase) ClearDataprovFingerprint(id string) error {
+	_, err := d.Connection.Exec("UPDATE dataprov SET fingerprint = '' WHERE id = $1", id)
+	return err
+}
+
+// CreatePrivacyAudit records a privacy policy applied to a column
+func (d PostgresChurroDatabase) CreatePrivacyAudit(a domain.PrivacyAudit) error {
+	insertStmt, err := d.Connection.Prepare("INSERT into privacyaudit (id, dataprov_id, tablename, columnname, policy, lastupdated) values ($1, $2, $3, $4, $5, $6)")
+	if err != nil {
+		return err
+	}
+	if _, err := insertStmt.Exec(a.ID, a.DataprovID, a.TableName, a.ColumnName, a.Policy, a.LastUpdated); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetPrivacyAudits returns the privacy policies applied to the columns
+// of a dataprov
+func (d PostgresChurroDatabase) GetPrivacyAudits(dataprovID string) ([]domain.PrivacyAudit, error) {
+	rows, err := d.Connection.Query("SELECT id, dataprov_id, tablename, columnname, policy, lastupdated FROM privacyaudit WHERE dataprov_id = $1 ORDER BY tablename, columnname", dataprovID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	audits := make([]domain.PrivacyAudit, 0)
+	for rows.Next() {
+		a := domain.PrivacyAudit{}
+		if err := rows.Scan(&a.ID, &a.DataprovID, &a.TableName, &a.ColumnName, &a.Policy, &a.LastUpdated); err != nil {
+			return nil, err
+		}
+		audits = append(audits, a)
+	}
+	return audits, rows.Err()
+}
+
+func (d *PostgresChurroDatabase) UpdatePipelineStats(data stats.PipelineStats) error {
+	schema, err := sqlsafe.QuotePostgres(data.Pipeline)
+	if err != nil {
+		return err
+	}
+
+	sqlstr := fmt.Sprintf("INSERT into %s.pipeline_stats (dataprov_id, file_name, records_in, lastupdated ) values ($1, $2, $3, now()) on conflict (file_name) do update set dataprov_id = excluded.dataprov_id, records_in = pipeline_stats.records_in + excluded.records_in, lastupdated = excluded.lastupdated", schema)
+	log.Info().Msg("stats upsert " + sqlstr)
+	if _, err := d.Connection.Exec(sqlstr, data.DataprovID, data.FileName, data.RecordsIn); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/churrodata/churro/api/v1alpha1"
+)
+
+func TestGetConnectString(t *testing.T) {
+	src := v1alpha1.Source{
+		Host:             "pg.example.com",
+		Port:             5432,
+		Username:         "pipeline1",
+		Password:         "cEBzcy93b3JkOg==",
+		Database:         "Pipeline1",
+		Externaldatabase: "analytics",
+	}
+
+	s, err := getConnectString(src)
+	if err != nil {
+		t.Fatalf("getConnectString Error: %v", err)
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		t.Fatalf("url.Parse %s Error: %v", s, err)
+	}
+	pw, _ := u.User.Password()
+	if u.Host != "pg.example.com:5432" || u.Path != "/analytics" || u.User.Username() != "pipeline1" || pw != "p@ss/word:" {
+		t.Fatalf("getConnectString got %s", s)
+	}
+	if u.Query().Get("search_path") != "pipeline1" {
+		t.Fatalf("getConnectString search_path got %s", s)
+	}
+
+	bad := src
+	bad.Externaldatabase = ""
+	if _, err := getConnectString(bad); err == nil {
+		t.Fatal("getConnectString expected an error without an externaldatabase")
+	}
+	bad = src
+	bad.Database = "pipeline1&sslmode=disable"
+	if _, err := getConnectString(bad); err == nil {
+		t.Fatal("getConnectString expected an error for an invalid schema")
+	}
+	bad = src
+	bad.Password = "not base64!"
+	if _, err := getConnectString(bad); err == nil {
+		t.Fatal("getConnectString expected an error for a password that is not encoded")
+	}
+}
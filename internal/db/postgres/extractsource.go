@@ -0,0 +1,85 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/churrodata/churro/internal/db/sqlsafe"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+func (d PostgresChurroDatabase) UpdateExtractSourceMetric(a domain.ExtractSourceMetric) (err error) {
+	var UPDATE = "UPDATE extractsourcemetric set value = $1, lastupdated = now() where extractsourceid = $2 and name = $3"
+	log.Info().Msg(UPDATE)
+
+	_, err = d.Connection.Exec(UPDATE, a.Value, a.ExtractSourceID, a.Name)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d PostgresChurroDatabase) CreateExtractSourceMetric(a domain.ExtractSourceMetric) (err error) {
+	var INSERT = "INSERT INTO extractsourcemetric(extractsourceid, name, value, lastupdated) values($1,$2,$3,now())"
+	stmt, err := d.Connection.Prepare(INSERT)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	_, err = stmt.Exec(a.ExtractSourceID, a.Name, a.Value)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (d PostgresChurroDatabase) GetExtractSourceMetrics(id string) (wdirs []domain.ExtractSourceMetric, err error) {
+	rows, err := d.Connection.Query("SELECT extractsourceid, name, value from extractsourcemetric where extractsourceid = $1", id)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return wdirs, err
+	}
+
+	for rows.Next() {
+		p := domain.ExtractSourceMetric{}
+		err = rows.Scan(&p.ExtractSourceID, &p.Name, &p.Value)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("some error")
+			return wdirs, err
+		}
+		wdirs = append(wdirs, p)
+	}
+
+	return wdirs, nil
+}
+
+func (d PostgresChurroDatabase) IsInitialized(tablename string) bool {
+	table, err := sqlsafe.QuotePostgres(d.namespace, tablename)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in isInitialized ")
+		return false
+	}
+	sqlString := fmt.Sprintf("select count(*) from %s", table)
+	row := d.Connection.QueryRow(sqlString)
+	var t int
+	err = row.Scan(&t)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in isInitialized ")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,284 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/churrodata/churro/internal/db/migrate"
+	"github.com/churrodata/churro/internal/db/sqlsafe"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+)
+
+func (s PostgresChurroDatabase) CreatePipelineDatabase(dbName string) error {
+	schema, err := sqlsafe.QuotePostgres(dbName)
+	if err != nil {
+		return err
+	}
+
+	// make sure the pipeline schema is created
+	sqlStr := fmt.Sprintf("CREATE SCHEMA if not exists %s", schema)
+	_, err = s.Connection.Exec(sqlStr)
+	log.Info().Msg(sqlStr)
+	if err != nil {
+		return err
+	}
+	log.Info().Msg("Successfully created schema " + dbName)
+
+	// the pipeline user is named after the pipeline and creates the
+	// pipeline objects within its schema
+	sqlStr = fmt.Sprintf("grant usage, create on schema %s to %s", schema, schema)
+	_, err = s.Connection.Exec(sqlStr)
+	log.Info().Msg(sqlStr)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// pipelineColumns are the columns added to the pipeline tables after
+// they were first created, CreatePipelineObjects adds them to the
+// tables of an existing pipeline
+var pipelineColumns = []migrate.Column{
+	{Table: "extractlog", Version: 1, Name: "conversion_errors", Definition: "int not null default 0"},
+	{Table: "extractlog", Version: 2, Name: "records_rejected", Definition: "int not null default 0"},
+	{Table: "dataprov", Version: 1, Name: "fingerprint", Definition: "text"},
+	{Table: "extractlog", Version: 3, Name: "status", Definition: "text not null default ''"},
+	{Table: "extractlog", Version: 3, Name: "error_text", Definition: "text not null default ''"},
+}
+
+func (s PostgresChurroDatabase) CreatePipelineObjects(dbName, username string) error {
+	schema, err := sqlsafe.QuotePostgres(dbName)
+	if err != nil {
+		return err
+	}
+	user, err := sqlsafe.QuotePostgres(username)
+	if err != nil {
+		return err
+	}
+
+	statements := []string{
+		fmt.Sprintf("CREATE TABLE if not exists %s.dataprov ( id text PRIMARY KEY, name text, path text, fingerprint text, lastupdated TIMESTAMP);", schema),
+		fmt.Sprintf("grant insert,select on %s.dataprov to %s;", schema, user),
+		fmt.Sprintf("CREATE TABLE if not exists %s.pipeline_stats ( id serial PRIMARY KEY, dataprov_id text, file_name text UNIQUE, records_in bigint, lastupdated TIMESTAMP);", schema),
+		fmt.Sprintf("grant insert,update,select on %s.pipeline_stats to %s;", schema, user),
+		fmt.Sprintf("CREATE TABLE if not exists %s.extractlog ( tablename text not null, id text PRIMARY KEY, dataprov_id text not null, podname text not null, poddate timestamp, records_loaded int not null, conversion_errors int not null default 0, records_rejected int not null default 0, file_name text, status text not null default '', error_text text not null default '', lastupdated TIMESTAMP);", schema),
+		fmt.Sprintf("grant insert,update,select on %s.extractlog to %s;", schema, user),
+		fmt.Sprintf("CREATE TABLE if not exists %s.schemaversion ( tablename text not null, version int not null, extractsource_id text, column_list text, lastupdated TIMESTAMP, PRIMARY KEY (tablename, version));", schema),
+		fmt.Sprintf("grant insert,select on %s.schemaversion to %s;", schema, user),
+		fmt.Sprintf("CREATE TABLE if not exists %s.privacyaudit ( id text PRIMARY KEY, dataprov_id text not null, tablename text not null, columnname text not null, policy text not null, lastupdated TIMESTAMP);", schema),
+		fmt.Sprintf("grant insert,select on %s.privacyaudit to %s;", schema, user),
+	}
+
+	for _, sqlStr := range statements {
+		_, err = s.Connection.Exec(sqlStr)
+		if err != nil {
+			return err
+		}
+		log.Info().Msg(sqlStr)
+	}
+
+	// add the columns of later releases to the tables of an existing
+	// pipeline
+	return migrate.Run(s, dbName, pipelineColumns, func(table, column, definition string) error {
+		sqlStr := fmt.Sprintf("ALTER TABLE %s.%s ADD COLUMN IF NOT EXISTS %s %s;", schema, table, column, definition)
+		log.Info().Msg(sqlStr)
+		_, err := s.Connection.Exec(sqlStr)
+		return err
+	})
+}
+
+func (s PostgresChurroDatabase) GetAllPipelineMetrics() (metrics []domain.PipelineMetric, err error) {
+	metrics = make([]domain.PipelineMetric, 0)
+
+	rows, err := s.Connection.Query("SELECT name, value, lastupdated FROM pipelinemetric order by name")
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return metrics, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p := domain.PipelineMetric{}
+		err = rows.Scan(&p.Name, &p.Value, &p.LastUpdated)
+		if err != nil {
+			log.Error().Stack().Err(err)
+			return metrics, err
+		}
+		metrics = append(metrics, p)
+	}
+
+	return metrics, nil
+}
+
+func (s PostgresChurroDatabase) UpdatePipelineMetric(m domain.PipelineMetric) error {
+	datetime := time.Now()
+	var UPDATE = "UPDATE pipelinemetric set value = $1, lastupdated = $2 where name = $3"
+	log.Info().Msg(UPDATE)
+
+	_, err := s.Connection.Exec(UPDATE, m.Value, datetime, m.Name)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s PostgresChurroDatabase) CreatePipelineMetric(m domain.PipelineMetric) error {
+	INSERT := "INSERT INTO pipelinemetric(name, value, lastupdated) values($1,$2,now()) returning name"
+
+	var name string
+	err := s.Connection.QueryRow(INSERT, m.Name, m.Value).Scan(&name)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+// CreateUser creates a login role unless it exists, postgres has no
+// create user if not exists and does not accept a placeholder for the
+// password
+func (s PostgresChurroDatabase) CreateUser(username, password string) (err error) {
+	user, err := sqlsafe.QuotePostgres(username)
+	if err != nil {
+		return err
+	}
+	pw, err := getPassword(password)
+	if err != nil {
+		return err
+	}
+
+	var found int
+	err = s.Connection.QueryRow("SELECT 1 FROM pg_roles where rolname = $1", username).Scan(&found)
+	switch err {
+	case nil:
+		log.Info().Msg("user already exists " + username)
+		return nil
+	case sql.ErrNoRows:
+	default:
+		return err
+	}
+
+	_, err = s.Connection.Exec(fmt.Sprintf("create user %s with login password %s;", user, pq.QuoteLiteral(pw)))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s PostgresChurroDatabase) CreateExtractLog(p domain.JobProfile) error {
+	var INSERT = "INSERT INTO extractlog ( tablename, id, dataprov_id, podname, poddate, records_loaded, file_name, lastupdated ) values ($1, $2, $3, $4, $5, $6, $7, now()) returning id"
+
+	err := s.Connection.QueryRow(INSERT, p.TableName, p.ID, p.DataProvenanceID, p.JobName, p.StartDate, p.RecordsLoaded, p.FileName).Scan(&p.ID)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s PostgresChurroDatabase) UpdateExtractLog(p domain.JobProfile) error {
+	var UPDATE = "UPDATE extractlog set records_loaded = $1, conversion_errors = $2, records_rejected = $3, lastupdated = now() where id = $4"
+	log.Info().Msg(UPDATE)
+
+	_, err := s.Connection.Exec(UPDATE, p.RecordsLoaded, p.ConversionErrors, p.RecordsRejected, p.ID)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateExtractLogStatus sets the status of an extract job and the
+// error it failed with
+func (s PostgresChurroDatabase) UpdateExtractLogStatus(id, status, errorText string) error {
+	var UPDATE = "UPDATE extractlog set status = $1, error_text = $2, lastupdated = now() where id = $3"
+	log.Info().Msg(UPDATE)
+
+	_, err := s.Connection.Exec(UPDATE, status, errorText, id)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s PostgresChurroDatabase) GetExtractLog(jobName string) (p domain.JobProfile, err error) {
+
+	row := s.Connection.QueryRow("SELECT tablename, id, dataprov_id, podname, poddate, records_loaded, conversion_errors, records_rejected, file_name, status, error_text FROM extractlog where podname=$1", jobName)
+	err = row.Scan(&p.TableName, &p.ID, &p.DataProvenanceID, &p.JobName, &p.StartDate, &p.RecordsLoaded, &p.ConversionErrors, &p.RecordsRejected, &p.FileName, &p.Status, &p.ErrorText)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error getting job from extract log " + jobName)
+		return p, err
+	}
+
+	log.Info().Msg(fmt.Sprintf("returning extract log values of %+v", p))
+	return p, nil
+}
+
+func (s PostgresChurroDatabase) GetExtractLogById(id string) (p domain.JobProfile, err error) {
+
+	row := s.Connection.QueryRow("SELECT tablename, id, dataprov_id, podname, poddate, records_loaded, conversion_errors, records_rejected, file_name, status, error_text FROM extractlog where id=$1", id)
+	err = row.Scan(&p.TableName, &p.ID, &p.DataProvenanceID, &p.JobName, &p.StartDate, &p.RecordsLoaded, &p.ConversionErrors, &p.RecordsRejected, &p.FileName, &p.Status, &p.ErrorText)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error getting job by id from extract log " + id)
+		return p, err
+	}
+	log.Info().Msg(fmt.Sprintf("returning extract log values of %+v", p))
+
+	return p, nil
+}
+
+func (s PostgresChurroDatabase) CreateSchemaVersion(v domain.SchemaVersion) error {
+	var INSERT = "INSERT INTO schemaversion ( tablename, version, extractsource_id, column_list, lastupdated ) values ($1, $2, $3, $4, now())"
+
+	_, err := s.Connection.Exec(INSERT, v.TableName, v.Version, v.ExtractSourceID, v.Columns)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetSchemaVersions returns the schema versions of a table, oldest first
+func (s PostgresChurroDatabase) GetSchemaVersions(tableName string) (versions []domain.SchemaVersion, err error) {
+	versions = make([]domain.SchemaVersion, 0)
+	rows, err := s.Connection.Query("SELECT tablename, version, extractsource_id, column_list, lastupdated FROM schemaversion where tablename=$1 order by version", tableName)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error getting schema versions of " + tableName)
+		return versions, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		v := domain.SchemaVersion{}
+		err = rows.Scan(&v.TableName, &v.Version, &v.ExtractSourceID, &v.Columns, &v.LastUpdated)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("error getting schema versions of " + tableName)
+			return versions, err
+		}
+		versions = append(versions, v)
+	}
+
+	return versions, rows.Err()
+}
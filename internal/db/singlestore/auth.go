@@ -13,6 +13,7 @@ import (
 	"database/sql"
 	"fmt"
 
+	"github.com/churrodata/churro/internal/db/sqlsafe"
 	"github.com/churrodata/churro/internal/domain"
 	"github.com/rs/xid"
 	"github.com/rs/zerolog/log"
@@ -37,9 +38,14 @@ func (d SinglestoreChurroDatabase) CreateAuthenticatedUser(u domain.Authenticate
 }
 
 func (d SinglestoreChurroDatabase) CreateChurroDatabase(dbName string) error {
+	database, err := sqlsafe.QuoteMySQL(dbName)
+	if err != nil {
+		return err
+	}
+
 	// make sure churro admin database is created
-	sqlStr := fmt.Sprintf("CREATE DATABASE if not exists %s", dbName)
-	_, err := d.Connection.Exec(sqlStr)
+	sqlStr := fmt.Sprintf("CREATE DATABASE if not exists %s", database)
+	_, err = d.Connection.Exec(sqlStr)
 	log.Info().Msg(sqlStr)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg(sqlStr)
@@ -78,7 +84,7 @@ func (d SinglestoreChurroDatabase) CreateAuthObjects() error {
 }
 
 func (d SinglestoreChurroDatabase) DeleteAuthenticatedUser(id string) (err error) {
-	_, err = d.Connection.Exec(fmt.Sprintf("DELETE FROM authenticateduser where id='%s'", id))
+	_, err = d.Connection.Exec("DELETE FROM authenticateduser where id=?", id)
 	if err != nil {
 		log.Error().Stack().Err(err)
 		return err
@@ -210,7 +216,7 @@ func (d SinglestoreChurroDatabase) UpdateUserProfile(u domain.UserProfile) error
 }
 
 func (d SinglestoreChurroDatabase) DeleteUserProfile(id string) (err error) {
-	_, err = d.Connection.Exec(fmt.Sprintf("DELETE FROM userprofile where id='%s'", id))
+	_, err = d.Connection.Exec("DELETE FROM userprofile where id=?", id)
 	if err != nil {
 		log.Error().Stack().Err(err)
 		return err
@@ -17,6 +17,7 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 
 	"github.com/churrodata/churro/api/v1alpha1"
+	"github.com/churrodata/churro/internal/db/sqlsafe"
 	"github.com/churrodata/churro/internal/domain"
 	"github.com/churrodata/churro/internal/stats"
 	"github.com/churrodata/churro/pkg/config"
@@ -48,9 +49,14 @@ func (d SinglestoreChurroDatabase) GetVersion() (string, error) {
 }
 
 func (d SinglestoreChurroDatabase) CreatePipelineDatabase(dbName string) error {
+	database, err := sqlsafe.QuoteMySQL(dbName)
+	if err != nil {
+		return err
+	}
+
 	// make sure pipeline database is created
-	sqlStr := fmt.Sprintf("CREATE DATABASE if not exists %s", dbName)
-	_, err := d.Connection.Exec(sqlStr)
+	sqlStr := fmt.Sprintf("CREATE DATABASE if not exists %s", database)
+	_, err = d.Connection.Exec(sqlStr)
 	log.Info().Msg(sqlStr)
 	if err != nil {
 		return err
@@ -60,9 +66,14 @@ func (d SinglestoreChurroDatabase) CreatePipelineDatabase(dbName string) error {
 }
 
 func (d SinglestoreChurroDatabase) CreateObjects(dbName string) error {
+	database, err := sqlsafe.QuoteMySQL(dbName)
+	if err != nil {
+		return err
+	}
+
 	// make sure churro admin database is created
-	sqlStr := fmt.Sprintf("CREATE DATABASE if not exists %s", dbName)
-	_, err := d.Connection.Exec(sqlStr)
+	sqlStr := fmt.Sprintf("CREATE DATABASE if not exists %s", database)
+	_, err = d.Connection.Exec(sqlStr)
 	log.Info().Msg(sqlStr)
 	if err != nil {
 		return err
@@ -124,7 +135,7 @@ func (d SinglestoreChurroDatabase) CreateObjects(dbName string) error {
 	}
 	log.Info().Msg("transformfunction Table created successfully..")
 	*/
-	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.pipelinemetric ( name varchar(30) PRIMARY KEY, value varchar(30) NOT NULL, lastupdated TIMESTAMP);", dbName)
+	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.pipelinemetric ( name varchar(30) PRIMARY KEY, value varchar(30) NOT NULL, lastupdated TIMESTAMP);", database)
 	log.Info().Msg(sqlStr)
 	stmt, err := d.Connection.Prepare(sqlStr)
 	if err != nil {
@@ -136,22 +147,22 @@ func (d SinglestoreChurroDatabase) CreateObjects(dbName string) error {
 	}
 	log.Info().Msg("pipelinemetric Table created successfully..")
 	// select to see if we have already seeded the metric table
-	sqlStr = fmt.Sprintf("SELECT name from %s.pipelinemetric where name = '%s'", dbName, domain.MetricFilesProcessed)
+	sqlStr = fmt.Sprintf("SELECT name from %s.pipelinemetric where name = ?", database)
 	log.Info().Msg(sqlStr)
 	stmt, err = d.Connection.Prepare(sqlStr)
 	if err != nil {
 		return err
 	}
 	var xname string
-	err = stmt.QueryRow().Scan(&xname)
+	err = stmt.QueryRow(domain.MetricFilesProcessed).Scan(&xname)
 	if err == sql.ErrNoRows {
-		sqlStr = fmt.Sprintf("INSERT into %s.pipelinemetric ( name, value, lastupdated) values ('%s', '0', now());", dbName, domain.MetricFilesProcessed)
+		sqlStr = fmt.Sprintf("INSERT into %s.pipelinemetric ( name, value, lastupdated) values (?, '0', now());", database)
 		log.Info().Msg(sqlStr)
 		stmt, err = d.Connection.Prepare(sqlStr)
 		if err != nil {
 			return err
 		}
-		_, err = stmt.Exec()
+		_, err = stmt.Exec(domain.MetricFilesProcessed)
 		if err != nil {
 			return err
 		}
@@ -159,7 +170,7 @@ func (d SinglestoreChurroDatabase) CreateObjects(dbName string) error {
 	} else {
 		log.Info().Msg("pipelinemetric Table already seeded..")
 	}
-	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.extractsourcemetric ( extractsourceid varchar(30) NOT NULL, name varchar(30) NOT NULL, value varchar(30) NOT NULL, lastupdated TIMESTAMP );", dbName)
+	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.extractsourcemetric ( extractsourceid varchar(30) NOT NULL, name varchar(30) NOT NULL, value varchar(30) NOT NULL, lastupdated TIMESTAMP );", database)
 	log.Info().Msg(sqlStr)
 	stmt, err = d.Connection.Prepare(sqlStr)
 	if err != nil {
@@ -200,17 +211,77 @@ func (d *SinglestoreChurroDatabase) GetConnection(creds config.DBCredentials, so
 }
 
 func (d SinglestoreChurroDatabase) CreateDataprov(dp domain.DataProvenance) error {
-	insertStmt, err := d.Connection.Prepare("insert into dataprov (id, name, path, lastupdated) values (?, ?, ?, now())")
+	insertStmt, err := d.Connection.Prepare("insert into dataprov (id, name, path, fingerprint, lastupdated) values (?, ?, ?, ?, now())")
 	if err != nil {
 		return err
 	}
-	if _, err := insertStmt.Exec(dp.ID, dp.Name, dp.Path); err != nil {
+	if _, err := insertStmt.Exec(dp.ID, dp.Name, dp.Path, dp.Fingerprint); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// GetDataprovsByFingerprint returns the data provenance of the files
+// registered with a fingerprint, oldest first
+func (d SinglestoreChurroDatabase) GetDataprovsByFingerprint(fingerprint string) ([]domain.DataProvenance, error) {
+	rows, err := d.Connection.Query("SELECT id, name, path, fingerprint, lastupdated FROM dataprov WHERE fingerprint = ? ORDER BY lastupdated", fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dps := make([]domain.DataProvenance, 0)
+	for rows.Next() {
+		dp := domain.DataProvenance{}
+		if err := rows.Scan(&dp.ID, &dp.Name, &dp.Path, &dp.Fingerprint, &dp.LastUpdated); err != nil {
+			return nil, err
+		}
+		dps = append(dps, dp)
+	}
+	return dps, rows.Err()
+}
+
+// ClearDataprovFingerprint blanks the fingerprint of a dataprov whose
+// extraction failed, so the file is not skipped when it is requeued
+func (d SinglestoreChurroDatabase) ClearDataprovFingerprint(id string) error {
+	_, err := d.Connection.Exec("UPDATE dataprov SET fingerprint = '' WHERE id = ?", id)
+	return err
+}
+
+// CreatePrivacyAudit records a privacy policy applied to a column
+func (d SinglestoreChurroDatabase) CreatePrivacyAudit(a domain.PrivacyAudit) error {
+	insertStmt, err := d.Connection.Prepare("insert into privacyaudit (id, dataprov_id, tablename, columnname, policy, lastupdated) values (?, ?, ?, ?, ?, now())")
+	if err != nil {
+		return err
+	}
+	if _, err := insertStmt.Exec(a.ID, a.DataprovID, a.TableName, a.ColumnName, a.Policy); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetPrivacyAudits returns the privacy policies applied to the columns
+// of a dataprov
+func (d SinglestoreChurroDatabase) GetPrivacyAudits(dataprovID string) ([]domain.PrivacyAudit, error) {
+	rows, err := d.Connection.Query("SELECT id, dataprov_id, tablename, columnname, policy, lastupdated FROM privacyaudit WHERE dataprov_id = ? ORDER BY tablename, columnname", dataprovID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	audits := make([]domain.PrivacyAudit, 0)
+	for rows.Next() {
+		a := domain.PrivacyAudit{}
+		if err := rows.Scan(&a.ID, &a.DataprovID, &a.TableName, &a.ColumnName, &a.Policy, &a.LastUpdated); err != nil {
+			return nil, err
+		}
+		audits = append(audits, a)
+	}
+	return audits, rows.Err()
+}
+
 func (d *SinglestoreChurroDatabase) UpdatePipelineStats(t stats.PipelineStats) error {
 
 	return nil
@@ -10,15 +10,29 @@
 package singlestore
 
 import (
+	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db/sqlsafe"
 	"github.com/rs/zerolog/log"
 )
 
+// the most placeholders a single prepared statement can bind
+const maxPlaceholders = 65535
+
 func (d SinglestoreChurroDatabase) CreateTable(userid, dbname, tableName string, columnNames, columnTypes []string) error {
-	sqlStr := fmt.Sprintf("CREATE TABLE if not exists %s.%s ( primarykey bigint primary key, dataformat varchar(30), %s lastupdated timestamp);", dbname, tableName, getTableColumns(columnNames, columnTypes))
+	table, err := sqlsafe.QuoteMySQL(dbname, tableName)
+	if err != nil {
+		return err
+	}
+	tableColumns, err := getTableColumns(columnNames, columnTypes)
+	if err != nil {
+		return err
+	}
+	sqlStr := fmt.Sprintf("CREATE TABLE if not exists %s ( primarykey bigint primary key, dataformat varchar(30), %s lastupdated timestamp);", table, tableColumns)
 	log.Info().Msg(sqlStr)
 
 	stmt, err := d.Connection.Prepare(sqlStr)
@@ -37,20 +51,23 @@ func (d SinglestoreChurroDatabase) CreateTable(userid, dbname, tableName string,
 
 func (d SinglestoreChurroDatabase) GetInsertStatement(scheme, database, tablename string, cols []string, vals []interface{}, key int64) error {
 
-	var b string
-	for _, v := range cols {
-		b = b + fmt.Sprintf("%s,", v)
+	table, err := sqlsafe.QuoteMySQL(database, tablename)
+	if err != nil {
+		return err
 	}
-	var c string
-	for _, v := range vals {
-		c = c + fmt.Sprintf("'%s',", v)
+	colNames, err := getInsertColumns(cols)
+	if err != nil {
+		return err
 	}
 
-	csvsql := fmt.Sprintf("insert into %s.%s (primarykey, dataformat, %s lastupdated) values (%d, '%s', %s now())", database, tablename, b, key, scheme, c)
+	var sqlString strings.Builder
+	fmt.Fprintf(&sqlString, "insert into %s (%s) values ", table, colNames)
+	writePlaceholders(&sqlString, len(vals)+2)
 
-	_, err := d.Connection.Query(csvsql)
+	args := append([]interface{}{key, scheme}, vals...)
+	_, err = d.Connection.Exec(sqlString.String(), args...)
 	if err != nil {
-		log.Error().Stack().Err(err).Msg(csvsql)
+		log.Error().Stack().Err(err).Msg(sqlString.String())
 		return err
 	}
 
@@ -58,66 +75,371 @@ func (d SinglestoreChurroDatabase) GetInsertStatement(scheme, database, tablenam
 }
 
 func (d SinglestoreChurroDatabase) GetBulkInsertStatement(scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string) error {
-	var sqlString strings.Builder
 
-	var colNames string
-	for i, v := range cols {
-		if i < len(cols)-1 {
-			colNames = colNames + fmt.Sprintf("%s,", v)
-		} else {
-			colNames = colNames + fmt.Sprintf("%s", v)
-		}
-	}
-	tmp := fmt.Sprintf("insert into %s.%s (primarykey, dataformat, %s, lastupdated) values ", database, tableName, colNames)
-	sqlString.WriteString(tmp)
-	numRecords := len(records)
-	var currentRecord int
-	for _, r := range records {
-		log.Info().Msg(fmt.Sprintf("colTypes %v\n", colTypes))
-		log.Info().Msg(fmt.Sprintf("cols %v\n", r.Cols))
-		currentRecord++
-		var tmp string
-		var colValues string
-		for i := 0; i < len(r.Cols); i++ {
-			if i == len(r.Cols)-1 {
-				if colTypes[i] == extractapi.COLTYPE_TEXT ||
-					colTypes[i] == extractapi.COLTYPE_VARCHAR {
-					colValues = colValues + "'" + r.Cols[i].(string) + "'"
-				} else {
-					colValues = colValues + fmt.Sprintf("%v", r.Cols[i])
-				}
-			} else {
-				if colTypes[i] == extractapi.COLTYPE_TEXT ||
-					colTypes[i] == extractapi.COLTYPE_VARCHAR {
-					colValues = colValues + "'" + r.Cols[i].(string) + "'" + ","
-				} else {
-					colValues = colValues + fmt.Sprintf("%v", r.Cols[i]) + ","
-				}
-			}
+	table, err := sqlsafe.QuoteMySQL(database, tableName)
+	if err != nil {
+		return err
+	}
+	colNames, err := getInsertColumns(cols)
+	if err != nil {
+		return err
+	}
+
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return err
+	}
+
+	err = insertRows(tx, fmt.Sprintf("insert into %s (%s) values ", table, colNames), "", scheme, cols, records, colTypes)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetUpsertStatement loads the records with INSERT ... ON DUPLICATE KEY
+// UPDATE, a record replaces the row that has the same primary key
+func (d SinglestoreChurroDatabase) GetUpsertStatement(scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string) error {
+
+	table, err := sqlsafe.QuoteMySQL(database, tableName)
+	if err != nil {
+		return err
+	}
+	colNames, err := getInsertColumns(cols)
+	if err != nil {
+		return err
+	}
+	update, err := getDuplicateUpdate(cols)
+	if err != nil {
+		return err
+	}
+
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return err
+	}
+
+	err = insertRows(tx, fmt.Sprintf("insert into %s (%s) values ", table, colNames), " on duplicate key update "+update, scheme, cols, records, colTypes)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetReplacePartitionStatement deletes the rows of each partition and
+// then loads the records within one transaction, a partition holds
+// the values of the key columns
+func (d SinglestoreChurroDatabase) GetReplacePartitionStatement(scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string, keyCols []string, partitions [][]interface{}) error {
+
+	// the partitions are checked before the database is touched
+	filters := make([]string, 0, len(partitions))
+	args := make([][]interface{}, 0, len(partitions))
+	for _, partition := range partitions {
+		filter, values, err := getPartitionFilter(cols, colTypes, keyCols, partition)
+		if err != nil {
+			return err
 		}
-		if currentRecord < numRecords {
-			tmp = fmt.Sprintf("(%d, '%s', %s, now()),", r.Key, scheme, colValues)
-		} else {
-			tmp = fmt.Sprintf("(%d, '%s', %s, now())", r.Key, scheme, colValues)
+		filters = append(filters, filter)
+		args = append(args, values)
+	}
+
+	table, err := sqlsafe.QuoteMySQL(database, tableName)
+	if err != nil {
+		return err
+	}
+	colNames, err := getInsertColumns(cols)
+	if err != nil {
+		return err
+	}
+
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return err
+	}
+
+	for i := range filters {
+		_, err = tx.Exec(fmt.Sprintf("delete from %s where %s", table, filters[i]), args[i]...)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("error in partition delete")
+			tx.Rollback()
+			return err
 		}
-		sqlString.WriteString(tmp)
 	}
-	//log.Info().Msg("bulk sql is "+ sqlString.String())
-	_, err := d.Connection.Exec(sqlString.String())
+
+	err = insertRows(tx, fmt.Sprintf("insert into %s (%s) values ", table, colNames), "", scheme, cols, records, colTypes)
 	if err != nil {
-		log.Error().Stack().Err(err).Msg(sqlString.String())
+		tx.Rollback()
 		return err
 	}
 
+	return tx.Commit()
+}
+
+// insertRows writes the records with multi-row statements, wide tables
+// are split across statements to stay under the placeholder limit
+func insertRows(tx *sql.Tx, prefix, suffix, scheme string, cols []string, records []extractapi.GenericRow, colTypes []string) error {
+	perRow := len(cols) + 2
+	rowsPerStatement := maxPlaceholders / perRow
+
+	for start := 0; start < len(records); start += rowsPerStatement {
+		end := start + rowsPerStatement
+		if end > len(records) {
+			end = len(records)
+		}
+
+		var sqlString strings.Builder
+		sqlString.WriteString(prefix)
+		args := make([]interface{}, 0, (end-start)*perRow)
+		for i, r := range records[start:end] {
+			if i > 0 {
+				sqlString.WriteString(", ")
+			}
+			writePlaceholders(&sqlString, perRow)
+			args = append(args, getRowValues(scheme, r, colTypes)...)
+		}
+		sqlString.WriteString(suffix)
+
+		_, err := tx.Exec(sqlString.String(), args...)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("error in bulk insert")
+			return err
+		}
+	}
 	return nil
 }
 
-func getTableColumns(columnNames, columnTypes []string) string {
+// getPartitionFilter returns the condition matching the rows whose key
+// columns hold the values of the partition
+func getPartitionFilter(cols, colTypes, keyCols []string, partition []interface{}) (string, []interface{}, error) {
+	if len(keyCols) == 0 || len(keyCols) != len(partition) {
+		return "", nil, fmt.Errorf("partition has %d values for %d key columns", len(partition), len(keyCols))
+	}
+
+	var sqlString strings.Builder
+	args := make([]interface{}, 0, len(keyCols))
+	for i, k := range keyCols {
+		c := columnIndex(cols, k)
+		if c < 0 {
+			return "", nil, fmt.Errorf("key column %s is not a loaded column", k)
+		}
+		name, err := sqlsafe.QuoteMySQL(k)
+		if err != nil {
+			return "", nil, err
+		}
+		if i > 0 {
+			sqlString.WriteString(" and ")
+		}
+		v := getValue(colTypes[c], partition[i])
+		if v == nil {
+			fmt.Fprintf(&sqlString, "%s is null", name)
+			continue
+		}
+		args = append(args, v)
+		fmt.Fprintf(&sqlString, "%s = ?", name)
+	}
+	return sqlString.String(), args, nil
+}
+
+func columnIndex(cols []string, name string) int {
+	for i := range cols {
+		if cols[i] == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func getTableColumns(columnNames, columnTypes []string) (string, error) {
 	var result string
 	for i, v := range columnNames {
-		col := fmt.Sprintf("%s %s,", v, columnTypes[i])
-		result = result + col
+		name, err := sqlsafe.QuoteMySQL(v)
+		if err != nil {
+			return "", err
+		}
+		if err := sqlsafe.ValidColumnType(columnTypes[i]); err != nil {
+			return "", err
+		}
+		result = result + fmt.Sprintf("%s %s,", name, getColumnType(columnTypes[i]))
 	}
 	log.Info().Msg("getTableColumns " + result)
-	return result
+	return result, nil
+}
+
+// getColumnType returns the type a column is created with, singlestore stores JSONB columns as JSON
+func getColumnType(columnType string) string {
+	if strings.EqualFold(columnType, extractapi.COLTYPE_JSONB) {
+		return "JSON"
+	}
+	return columnType
+}
+
+// getInsertColumns returns the quoted column list of an insert,
+// surrounded by the columns churro adds to every table
+func getInsertColumns(cols []string) (string, error) {
+	quoted, err := sqlsafe.QuoteList(sqlsafe.QuoteMySQL, cols)
+	if err != nil {
+		return "", err
+	}
+	if quoted == "" {
+		return "primarykey, dataformat, lastupdated", nil
+	}
+	return "primarykey, dataformat, " + quoted + ", lastupdated", nil
+}
+
+// getDuplicateUpdate returns the assignments an upsert makes to the
+// row with the same primary key
+func getDuplicateUpdate(cols []string) (string, error) {
+	sets := []string{"dataformat = values(dataformat)"}
+	for _, v := range cols {
+		name, err := sqlsafe.QuoteMySQL(v)
+		if err != nil {
+			return "", err
+		}
+		sets = append(sets, fmt.Sprintf("%s = values(%s)", name, name))
+	}
+	sets = append(sets, "lastupdated = values(lastupdated)")
+	return strings.Join(sets, ", "), nil
+}
+
+// writePlaceholders writes the placeholders of one row
+func writePlaceholders(sb *strings.Builder, count int) {
+	sb.WriteString("(")
+	sb.WriteString(strings.Repeat("?, ", count))
+	sb.WriteString("now())")
+}
+
+// getRowValues returns the key, data format and column values of a
+// record
+func getRowValues(scheme string, r extractapi.GenericRow, colTypes []string) []interface{} {
+	values := make([]interface{}, 0, len(r.Cols)+2)
+	values = append(values, r.Key, scheme)
+	for i := 0; i < len(r.Cols); i++ {
+		values = append(values, getValue(colTypes[i], r.Cols[i]))
+	}
+	return values
+}
+
+// getValue returns the value sent for a column, a nil value and a null
+// value in a non-text column are sent as a database NULL
+func getValue(colType string, v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	switch colType {
+	case extractapi.COLTYPE_TEXT, extractapi.COLTYPE_VARCHAR:
+		return fmt.Sprintf("%v", v)
+	}
+	if v == "null" {
+		return nil
+	}
+	switch v.(type) {
+	case int64, float64, bool, time.Time:
+		// converted to the column type by the extract
+		return v
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// singlestoreTypes are the information_schema data types of the extract
+// rule column types, a BOOLEAN is a tinyint in singlestore
+var singlestoreTypes = map[string]string{
+	extractapi.COLTYPE_TEXT:      "text",
+	extractapi.COLTYPE_VARCHAR:   "varchar",
+	extractapi.COLTYPE_INT:       "int",
+	extractapi.COLTYPE_BIGINT:    "bigint",
+	extractapi.COLTYPE_DECIMAL:   "decimal",
+	extractapi.COLTYPE_DOUBLE:    "double",
+	extractapi.COLTYPE_BOOLEAN:   "tinyint",
+	extractapi.COLTYPE_DATE:      "date",
+	extractapi.COLTYPE_TIMESTAMP: "timestamp",
+	extractapi.COLTYPE_JSONB:     "json",
+}
+
+// GetTableColumns returns the data type of each column of a table,
+// keyed by column name
+func (d SinglestoreChurroDatabase) GetTableColumns(database, tableName string) (map[string]string, error) {
+	if _, err := sqlsafe.QuoteMySQL(database, tableName); err != nil {
+		return nil, err
+	}
+
+	rows, err := d.Connection.Query("SELECT column_name, data_type FROM information_schema.columns WHERE table_schema = ? AND table_name = ?", database, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := make(map[string]string)
+	for rows.Next() {
+		var name, dataType string
+		if err := rows.Scan(&name, &dataType); err != nil {
+			return nil, err
+		}
+		columns[name] = strings.ToLower(dataType)
+	}
+	return columns, rows.Err()
+}
+
+// GetColumnType returns the data type GetTableColumns reports for an
+// extract rule column type, blank when the type is not one churro knows
+func (d SinglestoreChurroDatabase) GetColumnType(columnType string) (string, error) {
+	return singlestoreTypes[strings.ToUpper(columnType)], nil
+}
+
+// AddColumn adds a column to an existing table
+func (d SinglestoreChurroDatabase) AddColumn(database, tableName, columnName, columnType string) error {
+	table, err := sqlsafe.QuoteMySQL(database, tableName)
+	if err != nil {
+		return err
+	}
+	column, err := getTableColumns([]string{columnName}, []string{columnType})
+	if err != nil {
+		return err
+	}
+
+	sqlStr := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", table, strings.TrimSuffix(column, ","))
+	log.Info().Msg(sqlStr)
+	_, err = d.Connection.Exec(sqlStr)
+	return err
+}
+
+// GetReferenceRows returns the values of the columns of every row of a
+// table, for the lookups of an extract source
+func (d SinglestoreChurroDatabase) GetReferenceRows(database, tableName string, columns []string) ([][]interface{}, error) {
+	table, err := sqlsafe.QuoteMySQL(database, tableName)
+	if err != nil {
+		return nil, err
+	}
+	quoted, err := sqlsafe.QuoteList(sqlsafe.QuoteMySQL, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.Connection.Query(fmt.Sprintf("SELECT %s FROM %s", quoted, table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make([][]interface{}, 0)
+	for rows.Next() {
+		row := make([]interface{}, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range row {
+			dest[i] = &row[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		for i, v := range row {
+			if b, ok := v.([]byte); ok {
+				row[i] = string(b)
+			}
+		}
+		values = append(values, row)
+	}
+	return values, rows.Err()
 }
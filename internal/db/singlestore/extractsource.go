@@ -12,6 +12,7 @@ package singlestore
 import (
 	"fmt"
 
+	"github.com/churrodata/churro/internal/db/sqlsafe"
 	"github.com/churrodata/churro/internal/domain"
 	"github.com/rs/zerolog/log"
 )
@@ -35,7 +36,7 @@ func (d SinglestoreChurroDatabase) UpdateExtractSourceMetric(a domain.ExtractSou
 }
 
 func (d SinglestoreChurroDatabase) CreateExtractSourceMetric(a domain.ExtractSourceMetric) (err error) {
-	var INSERT = fmt.Sprintf("INSERT INTO extractsourcemetric(extractsourceid, name, value, lastupdated) values(?,?,?,now())")
+	var INSERT = "INSERT INTO extractsourcemetric(extractsourceid, name, value, lastupdated) values(?,?,?,now())"
 	stmt, err := d.Connection.Prepare(INSERT)
 	if err != nil {
 		log.Error().Stack().Err(err)
@@ -52,7 +53,7 @@ func (d SinglestoreChurroDatabase) CreateExtractSourceMetric(a domain.ExtractSou
 }
 
 func (d SinglestoreChurroDatabase) GetExtractSourceMetrics(id string) (wdirs []domain.ExtractSourceMetric, err error) {
-	rows, err := d.Connection.Query(fmt.Sprintf("SELECT extractsourceid, name, value from extractsourcemetric where extractsourceid = '%s'", id))
+	rows, err := d.Connection.Query("SELECT extractsourceid, name, value from extractsourcemetric where extractsourceid = ?", id)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("some error")
 		return wdirs, err
@@ -87,10 +88,15 @@ func (d SinglestoreChurroDatabase) GetExtractSourceByName(name string) (a domain
 }
 
 func (d SinglestoreChurroDatabase) IsInitialized(tablename string) bool {
-	sqlString := fmt.Sprintf("select count(*) from %s.%s", d.namespace, tablename)
+	table, err := sqlsafe.QuoteMySQL(d.namespace, tablename)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in isInitialized ")
+		return false
+	}
+	sqlString := fmt.Sprintf("select count(*) from %s", table)
 	row := d.Connection.QueryRow(sqlString)
 	var t int
-	err := row.Scan(&t)
+	err = row.Scan(&t)
 	if err != nil {
 		log.Error().Stack().Err(err)
 		return false
@@ -12,13 +12,30 @@ package singlestore
 import (
 	"fmt"
 
+	"github.com/churrodata/churro/internal/db/migrate"
+	"github.com/churrodata/churro/internal/db/sqlsafe"
 	"github.com/churrodata/churro/internal/domain"
 	"github.com/rs/zerolog/log"
 )
 
+// pipelineColumns are the columns added to the pipeline tables after
+// they were first created, CreatePipelineObjects adds them to the
+// tables of an existing pipeline
+var pipelineColumns = []migrate.Column{
+	{Table: "extractlog", Version: 1, Name: "conversion_errors", Definition: "int not null default 0"},
+	{Table: "extractlog", Version: 2, Name: "records_rejected", Definition: "int not null default 0"},
+	{Table: "dataprov", Version: 1, Name: "fingerprint", Definition: "varchar(64)"},
+	{Table: "extractlog", Version: 3, Name: "status", Definition: "varchar(16) not null default ''"},
+	{Table: "extractlog", Version: 3, Name: "error_text", Definition: "varchar(1024) not null default ''"},
+}
+
 func (d SinglestoreChurroDatabase) CreatePipelineObjects(dbName, username string) error {
+	database, err := sqlsafe.QuoteMySQL(dbName)
+	if err != nil {
+		return err
+	}
 
-	sqlStr := fmt.Sprintf("CREATE TABLE if not exists %s.dataprov ( id varchar(30) PRIMARY KEY, name varchar(30), path varchar(80), lastupdated TIMESTAMP);", dbName)
+	sqlStr := fmt.Sprintf("CREATE TABLE if not exists %s.dataprov ( id varchar(30) PRIMARY KEY, name varchar(30), path varchar(80), fingerprint varchar(64), lastupdated TIMESTAMP);", database)
 	stmt, err := d.Connection.Prepare(sqlStr)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg(sqlStr)
@@ -32,7 +49,7 @@ func (d SinglestoreChurroDatabase) CreatePipelineObjects(dbName, username string
 
 	log.Info().Msg(sqlStr)
 
-	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.pipeline_stats ( id serial PRIMARY KEY, dataprov_id text, file_name text, records_in bigint, lastupdated TIMESTAMP);", dbName)
+	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.pipeline_stats ( id serial PRIMARY KEY, dataprov_id text, file_name text, records_in bigint, lastupdated TIMESTAMP);", database)
 	stmt, err = d.Connection.Prepare(sqlStr)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg(sqlStr)
@@ -45,7 +62,7 @@ func (d SinglestoreChurroDatabase) CreatePipelineObjects(dbName, username string
 	}
 	log.Info().Msg(sqlStr)
 
-	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.extractlog ( tablename varchar(32) not null, id varchar(32) PRIMARY KEY, dataprov_id varchar(32) not null, podname varchar(64) not null, poddate timestamp, records_loaded int not null, file_name varchar(64), lastupdated TIMESTAMP);", dbName)
+	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.extractlog ( tablename varchar(32) not null, id varchar(32) PRIMARY KEY, dataprov_id varchar(32) not null, podname varchar(64) not null, poddate timestamp, records_loaded int not null, conversion_errors int not null default 0, records_rejected int not null default 0, file_name varchar(64), status varchar(16) not null default '', error_text varchar(1024) not null default '', lastupdated TIMESTAMP);", database)
 	stmt, err = d.Connection.Prepare(sqlStr)
 	if err != nil {
 		return err
@@ -57,7 +74,41 @@ func (d SinglestoreChurroDatabase) CreatePipelineObjects(dbName, username string
 
 	log.Info().Msg(sqlStr)
 
-	return nil
+	// the columns of an extract source table after each extract rule
+	// change, one row per version
+	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.schemaversion ( tablename varchar(64) not null, version int not null, extractsource_id varchar(32), column_list text, lastupdated TIMESTAMP, PRIMARY KEY (tablename, version));", database)
+	stmt, err = d.Connection.Prepare(sqlStr)
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec()
+	if err != nil {
+		return err
+	}
+
+	log.Info().Msg(sqlStr)
+
+	// the privacy policies applied to the columns of each dataprov
+	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s.privacyaudit ( id varchar(30) PRIMARY KEY, dataprov_id varchar(30) not null, tablename varchar(64) not null, columnname varchar(64) not null, policy varchar(64) not null, lastupdated TIMESTAMP);", database)
+	stmt, err = d.Connection.Prepare(sqlStr)
+	if err != nil {
+		return err
+	}
+	_, err = stmt.Exec()
+	if err != nil {
+		return err
+	}
+
+	log.Info().Msg(sqlStr)
+
+	// add the columns of later releases to the tables of an existing
+	// pipeline
+	return migrate.Run(d, dbName, pipelineColumns, func(table, column, definition string) error {
+		sqlStr := fmt.Sprintf("ALTER TABLE %s.%s ADD COLUMN %s %s;", database, table, column, definition)
+		log.Info().Msg(sqlStr)
+		_, err := d.Connection.Exec(sqlStr)
+		return err
+	})
 }
 
 /**
@@ -209,20 +260,29 @@ func (d SinglestoreChurroDatabase) CreateUser(username, password string) error {
 
 	// TODO need to implement 'if not exists' here!
 
-	sqlStr := "create user " + username + " identified by '" + password + "';"
+	database, err := sqlsafe.QuoteMySQL(username)
+	if err != nil {
+		return err
+	}
+	user := sqlsafe.QuoteMySQLString(username)
+
+	// create user does not accept placeholders, the password is
+	// escaped as a string literal and kept out of the log
+	sqlStr := "create user " + user + " identified by " + sqlsafe.QuoteMySQLString(password) + ";"
 	stmt, err := d.Connection.Prepare(sqlStr)
 	if err != nil {
-		log.Error().Stack().Err(err).Msg(sqlStr)
+		log.Error().Stack().Err(err).Msg("error creating user " + username)
 		return err
 	}
 	_, err = stmt.Exec()
 	if err != nil {
-		log.Error().Stack().Err(err).Msg(sqlStr)
+		log.Error().Stack().Err(err).Msg("error creating user " + username)
 		//return err
 	}
-	log.Info().Msg(sqlStr)
+	log.Info().Msg("created user " + username)
 
-	sqlStr = "grant create,select,insert,delete,update on " + username + ".* to '" + username + "'@'%'"
+	// alter lets CreatePipelineObjects add the columns of later releases
+	sqlStr = "grant create,alter,select,insert,delete,update on " + database + ".* to " + user + "@'%'"
 	stmt, err = d.Connection.Prepare(sqlStr)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg(sqlStr)
@@ -253,14 +313,29 @@ func (d SinglestoreChurroDatabase) CreateExtractLog(p domain.JobProfile) error {
 	return nil
 }
 func (d SinglestoreChurroDatabase) UpdateExtractLog(p domain.JobProfile) error {
-	var UPDATE = fmt.Sprintf("UPDATE extractlog set records_loaded = ?, lastupdated = now() where id = ?")
+	var UPDATE = "UPDATE extractlog set records_loaded = ?, conversion_errors = ?, records_rejected = ?, lastupdated = now() where id = ?"
 	stmt, err := d.Connection.Prepare(UPDATE)
 	if err != nil {
 		log.Error().Stack().Err(err)
 		return err
 	}
 
-	_, err = stmt.Exec(p.RecordsLoaded, p.ID)
+	_, err = stmt.Exec(p.RecordsLoaded, p.ConversionErrors, p.RecordsRejected, p.ID)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateExtractLogStatus sets the status of an extract job and the
+// error it failed with
+func (d SinglestoreChurroDatabase) UpdateExtractLogStatus(id, status, errorText string) error {
+	var UPDATE = "UPDATE extractlog set status = ?, error_text = ?, lastupdated = now() where id = ?"
+	log.Info().Msg(UPDATE)
+
+	_, err := d.Connection.Exec(UPDATE, status, errorText, id)
 	if err != nil {
 		log.Error().Stack().Err(err)
 		return err
@@ -270,8 +345,8 @@ func (d SinglestoreChurroDatabase) UpdateExtractLog(p domain.JobProfile) error {
 }
 
 func (d SinglestoreChurroDatabase) GetExtractLog(jobName string) (p domain.JobProfile, err error) {
-	row := d.Connection.QueryRow("SELECT tablename, id,dataprov_id, podname, poddate, records_loaded, file_name FROM extractlog where podname=?", jobName)
-	err = row.Scan(&p.TableName, &p.ID, &p.DataProvenanceID, &p.JobName, &p.StartDate, &p.RecordsLoaded, &p.FileName)
+	row := d.Connection.QueryRow("SELECT tablename, id,dataprov_id, podname, poddate, records_loaded, conversion_errors, records_rejected, file_name, status, error_text FROM extractlog where podname=?", jobName)
+	err = row.Scan(&p.TableName, &p.ID, &p.DataProvenanceID, &p.JobName, &p.StartDate, &p.RecordsLoaded, &p.ConversionErrors, &p.RecordsRejected, &p.FileName, &p.Status, &p.ErrorText)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg(jobName)
 		return p, err
@@ -281,8 +356,8 @@ func (d SinglestoreChurroDatabase) GetExtractLog(jobName string) (p domain.JobPr
 }
 
 func (d SinglestoreChurroDatabase) GetExtractLogById(id string) (p domain.JobProfile, err error) {
-	row := d.Connection.QueryRow("SELECT tablename, id,dataprov_id, podname, poddate, records_loaded, file_name FROM extractlog where id=?", id)
-	err = row.Scan(&p.TableName, &p.ID, &p.DataProvenanceID, &p.JobName, &p.StartDate, &p.RecordsLoaded, &p.FileName)
+	row := d.Connection.QueryRow("SELECT tablename, id,dataprov_id, podname, poddate, records_loaded, conversion_errors, records_rejected, file_name, status, error_text FROM extractlog where id=?", id)
+	err = row.Scan(&p.TableName, &p.ID, &p.DataProvenanceID, &p.JobName, &p.StartDate, &p.RecordsLoaded, &p.ConversionErrors, &p.RecordsRejected, &p.FileName, &p.Status, &p.ErrorText)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg(id)
 		return p, err
@@ -290,3 +365,36 @@ func (d SinglestoreChurroDatabase) GetExtractLogById(id string) (p domain.JobPro
 
 	return p, nil
 }
+
+func (d SinglestoreChurroDatabase) CreateSchemaVersion(v domain.SchemaVersion) error {
+	_, err := d.Connection.Exec("insert into schemaversion(tablename, version, extractsource_id, column_list, lastupdated) values(?,?,?,?,now())", v.TableName, v.Version, v.ExtractSourceID, v.Columns)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetSchemaVersions returns the schema versions of a table, oldest first
+func (d SinglestoreChurroDatabase) GetSchemaVersions(tableName string) (versions []domain.SchemaVersion, err error) {
+	versions = make([]domain.SchemaVersion, 0)
+	rows, err := d.Connection.Query("SELECT tablename, version, extractsource_id, column_list, lastupdated FROM schemaversion where tablename=? order by version", tableName)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error getting schema versions of " + tableName)
+		return versions, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		v := domain.SchemaVersion{}
+		err = rows.Scan(&v.TableName, &v.Version, &v.ExtractSourceID, &v.Columns, &v.LastUpdated)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("error getting schema versions of " + tableName)
+			return versions, err
+		}
+		versions = append(versions, v)
+	}
+
+	return versions, rows.Err()
+}
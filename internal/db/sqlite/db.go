@@ -0,0 +1,263 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package sqlite implements the churro database as sqlite files for
+// single node and test deployments.  Each churro database (churro,
+// pipeline1, ...) is a file in the source Path directory, the file
+// named by the source Database is opened and the others are attached
+// to the connection as they are used.
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/churrodata/churro/api/v1alpha1"
+	"github.com/churrodata/churro/internal/db/sqlsafe"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/churrodata/churro/internal/stats"
+	"github.com/churrodata/churro/pkg/config"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	DB_SQLITE = "sqlite"
+)
+
+// how long a statement waits on another process holding the file lock
+const busyTimeoutMillis = 10000
+
+type SqliteChurroDatabase struct {
+	Connection *sql.DB
+	namespace  string
+	dir        string
+	database   string
+}
+
+func (d SqliteChurroDatabase) GetVersion() (string, error) {
+	var version string
+	err := d.Connection.QueryRow("SELECT sqlite_version()").Scan(&version)
+	if err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+func (d SqliteChurroDatabase) CreateObjects(dbName string) error {
+	metricTable, err := d.table(dbName, "pipelinemetric")
+	if err != nil {
+		return err
+	}
+	sourceMetricTable, err := d.table(dbName, "extractsourcemetric")
+	if err != nil {
+		return err
+	}
+
+	sqlStr := fmt.Sprintf("CREATE TABLE if not exists %s ( name text PRIMARY KEY, value text NOT NULL, lastupdated TIMESTAMP);", metricTable)
+	log.Info().Msg(sqlStr)
+	_, err = d.Connection.Exec(sqlStr)
+	if err != nil {
+		return err
+	}
+	log.Info().Msg("pipelinemetric Table created successfully..")
+
+	// seed the metric table unless it already is
+	sqlStr = fmt.Sprintf("INSERT into %s ( name, value, lastupdated) values (?, '0', CURRENT_TIMESTAMP) on conflict (name) do nothing;", metricTable)
+	log.Info().Msg(sqlStr)
+	_, err = d.Connection.Exec(sqlStr, domain.MetricFilesProcessed)
+	if err != nil {
+		return err
+	}
+
+	sqlStr = fmt.Sprintf("CREATE TABLE if not exists %s ( extractsourceid text, name text NOT NULL, value text NOT NULL, lastupdated TIMESTAMP);", sourceMetricTable)
+	log.Info().Msg(sqlStr)
+	_, err = d.Connection.Exec(sqlStr)
+	if err != nil {
+		return err
+	}
+	log.Info().Msg("extractsourcemetric Table created successfully..")
+
+	return nil
+}
+
+func (d SqliteChurroDatabase) GetDatabaseType() string {
+	return DB_SQLITE
+}
+
+// GetConnection opens the source Database file within the source Path
+// directory, the directory is created if necessary
+func (d *SqliteChurroDatabase) GetConnection(dbCreds config.DBCredentials, source v1alpha1.Source) (err error) {
+	d.namespace = os.Getenv("CHURRO_NAMESPACE")
+	if d.namespace == "" {
+		log.Error().Stack().Msg("error CHURRO_NAMESPACE is empty")
+		return fmt.Errorf("CHURRO_NAMESPACE env var required")
+	}
+	if source.Path == "" {
+		return fmt.Errorf("sqlite path is required")
+	}
+	if err := sqlsafe.ValidIdentifier(source.Database); err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(source.Path, 0755)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in GetConnection")
+		return err
+	}
+	d.dir = source.Path
+	d.database = source.Database
+
+	connectString := fmt.Sprintf("file:%s?_busy_timeout=%d", d.getPath(source.Database), busyTimeoutMillis)
+	d.Connection, err = sql.Open("sqlite3", connectString)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in GetConnection")
+		return err
+	}
+	// attached databases belong to a connection, a single connection
+	// keeps them attached for every statement
+	d.Connection.SetMaxOpenConns(1)
+
+	return nil
+}
+
+// getPath returns the file holding a churro database
+func (d SqliteChurroDatabase) getPath(dbName string) string {
+	return filepath.Join(d.dir, strings.ToLower(dbName)+".db")
+}
+
+// table returns the quoted name of a table within a churro database,
+// attaching the database file unless it is the one opened
+func (d SqliteChurroDatabase) table(dbName, tableName string) (string, error) {
+	if _, err := sqlsafe.QuoteSQLite(dbName, tableName); err != nil {
+		return "", err
+	}
+	if strings.EqualFold(dbName, d.database) {
+		return sqlsafe.QuoteSQLite("main", tableName)
+	}
+	err := d.attach(dbName)
+	if err != nil {
+		return "", err
+	}
+	return sqlsafe.QuoteSQLite(strings.ToLower(dbName), tableName)
+}
+
+// attach attaches the file of a churro database to the connection,
+// the file is created if it does not exist
+func (d SqliteChurroDatabase) attach(dbName string) error {
+	schema, err := sqlsafe.QuoteSQLite(strings.ToLower(dbName))
+	if err != nil {
+		return err
+	}
+
+	var found int
+	err = d.Connection.QueryRow("SELECT 1 FROM pragma_database_list where name = ?", strings.ToLower(dbName)).Scan(&found)
+	switch err {
+	case nil:
+		return nil
+	case sql.ErrNoRows:
+	default:
+		return err
+	}
+
+	sqlStr := fmt.Sprintf("ATTACH DATABASE ? AS %s", schema)
+	log.Info().Msg(sqlStr)
+	_, err = d.Connection.Exec(sqlStr, d.getPath(dbName))
+	return err
+}
+
+func (d SqliteChurroDatabase) CreateDataprov(data domain.DataProvenance) error {
+	insertStmt, err := d.Connection.Prepare("INSERT into dataprov (id, name, path, fingerprint, lastupdated) values (?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	if _, err := insertStmt.Exec(data.ID, data.Name, data.Path, data.Fingerprint, data.LastUpdated); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetDataprovsByFingerprint returns the data provenance of the files
+// registered with a fingerprint, oldest first
+func (d SqliteChurroDatabase) GetDataprovsByFingerprint(fingerprint string) ([]domain.DataProvenance, error) {
+	rows, err := d.Connection.Query("SELECT id, name, path, fingerprint, lastupdated FROM dataprov WHERE fingerprint = ? ORDER BY lastupdated", fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	dps := make([]domain.DataProvenance, 0)
+	for rows.Next() {
+		dp := domain.DataProvenance{}
+		if err := rows.Scan(&dp.ID, &dp.Name, &dp.Path, &dp.Fingerprint, &dp.LastUpdated); err != nil {
+			return nil, err
+		}
+		dps = append(dps, dp)
+	}
+	return dps, rows.Err()
+}
+
+// ClearDataprovFingerprint blanks the fingerprint of a dataprov whose
+// extraction failed, so the file is not skipped when it is requeued
+func (d SqliteChurroDatabase) ClearDataprovFingerprint(id string) error {
+	_, err := d.Connection.Exec("UPDATE dataprov SET fingerprint = '' WHERE id = ?", id)
+	return err
+}
+
+// CreatePrivacyAudit records a privacy policy applied to a column
+func (d SqliteChurroDatabase) CreatePrivacyAudit(a domain.PrivacyAudit) error {
+	insertStmt, err := d.Connection.Prepare("INSERT into privacyaudit (id, dataprov_id, tablename, columnname, policy, lastupdated) values (?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	if _, err := insertStmt.Exec(a.ID, a.DataprovID, a.TableName, a.ColumnName, a.Policy, a.LastUpdated); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetPrivacyAudits returns the privacy policies applied to the columns
+// of a dataprov
+func (d SqliteChurroDatabase) GetPrivacyAudits(dataprovID string) ([]domain.PrivacyAudit, error) {
+	rows, err := d.Connection.Query("SELECT id, dataprov_id, tablename, columnname, policy, lastupdated FROM privacyaudit WHERE dataprov_id = ? ORDER BY tablename, columnname", dataprovID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	audits := make([]domain.PrivacyAudit, 0)
+	for rows.Next() {
+		a := domain.PrivacyAudit{}
+		if err := rows.Scan(&a.ID, &a.DataprovID, &a.TableName, &a.ColumnName, &a.Policy, &a.LastUpdated); err != nil {
+			return nil, err
+		}
+		audits = append(audits, a)
+	}
+	return audits, rows.Err()
+}
+
+func (d *SqliteChurroDatabase) UpdatePipelineStats(data stats.PipelineStats) error {
+	table, err := d.table(data.Pipeline, "pipeline_stats")
+	if err != nil {
+		return err
+	}
+
+	sqlstr := fmt.Sprintf("INSERT into %s (dataprov_id, file_name, records_in, lastupdated ) values (?, ?, ?, CURRENT_TIMESTAMP) on conflict (file_name) do update set dataprov_id = excluded.dataprov_id, records_in = records_in + excluded.records_in, lastupdated = excluded.lastupdated", table)
+	log.Info().Msg("stats upsert " + sqlstr)
+	if _, err := d.Connection.Exec(sqlstr, data.DataprovID, data.FileName, data.RecordsIn); err != nil {
+		return err
+	}
+
+	return nil
+}
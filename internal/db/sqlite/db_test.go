@@ -0,0 +1,364 @@
+package sqlite
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/api/v1alpha1"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/churrodata/churro/internal/stats"
+	"github.com/churrodata/churro/pkg/config"
+)
+
+const testPipeline = "pipeline1"
+
+func getTestDatabase(t *testing.T, dir, database string) *SqliteChurroDatabase {
+	t.Helper()
+	os.Setenv("CHURRO_NAMESPACE", testPipeline)
+	d := &SqliteChurroDatabase{}
+	err := d.GetConnection(config.DBCredentials{}, v1alpha1.Source{Path: dir, Database: database})
+	if err != nil {
+		t.Fatalf("GetConnection Error: %v", err)
+	}
+	t.Cleanup(func() { d.Connection.Close() })
+	return d
+}
+
+func TestPipeline(t *testing.T) {
+	dir := t.TempDir()
+
+	// as the admin, the churro and pipeline databases are attached
+	admin := getTestDatabase(t, dir, "churro")
+	if _, err := admin.GetVersion(); err != nil {
+		t.Fatalf("GetVersion Error: %v", err)
+	}
+	if err := admin.CreateObjects("churro"); err != nil {
+		t.Fatalf("CreateObjects Error: %v", err)
+	}
+	if err := admin.CreateObjects("churro"); err != nil {
+		t.Fatalf("CreateObjects twice Error: %v", err)
+	}
+	if err := admin.CreateUser(testPipeline, ""); err != nil {
+		t.Fatalf("CreateUser Error: %v", err)
+	}
+	if err := admin.CreatePipelineDatabase(testPipeline); err != nil {
+		t.Fatalf("CreatePipelineDatabase Error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, testPipeline+".db")); err != nil {
+		t.Fatalf("pipeline database file Error: %v", err)
+	}
+	metrics, err := admin.GetAllPipelineMetrics()
+	if err != nil || len(metrics) != 1 || metrics[0].Name != domain.MetricFilesProcessed {
+		t.Fatalf("GetAllPipelineMetrics got %+v %v", metrics, err)
+	}
+
+	// as the pipeline, the pipeline database is the one opened
+	d := getTestDatabase(t, dir, testPipeline)
+	if err := d.CreatePipelineObjects(testPipeline, testPipeline); err != nil {
+		t.Fatalf("CreatePipelineObjects Error: %v", err)
+	}
+
+	for i := 1; i <= 2; i++ {
+		v := domain.SchemaVersion{TableName: "mytable", Version: i, ExtractSourceID: "es1", Columns: `[{"name":"city","type":"TEXT"}]`}
+		if err := d.CreateSchemaVersion(v); err != nil {
+			t.Fatalf("CreateSchemaVersion Error: %v", err)
+		}
+	}
+	versions, err := d.GetSchemaVersions("mytable")
+	if err != nil || len(versions) != 2 || versions[1].Version != 2 || versions[1].LastUpdated.IsZero() {
+		t.Fatalf("GetSchemaVersions got %+v %v", versions, err)
+	}
+
+	job := domain.JobProfile{
+		TableName:        "mytable",
+		ID:               "job1",
+		DataProvenanceID: "dp1",
+		JobName:          "extract-job1",
+		StartDate:        time.Now().Format("2006-01-02 15:04:05"),
+		FileName:         "a.csv",
+	}
+	if err := d.CreateExtractLog(job); err != nil {
+		t.Fatalf("CreateExtractLog Error: %v", err)
+	}
+	job.RecordsLoaded = 7
+	job.ConversionErrors = 2
+	job.RecordsRejected = 3
+	if err := d.UpdateExtractLog(job); err != nil {
+		t.Fatalf("UpdateExtractLog Error: %v", err)
+	}
+	p, err := d.GetExtractLog(job.JobName)
+	if err != nil || p.RecordsLoaded != 7 || p.ConversionErrors != 2 || p.RecordsRejected != 3 || p.Status != "" {
+		t.Fatalf("GetExtractLog got %+v %v", p, err)
+	}
+	if err := d.UpdateExtractLogStatus(job.ID, domain.JobStatusFailed, "bad row"); err != nil {
+		t.Fatalf("UpdateExtractLogStatus Error: %v", err)
+	}
+	p, err = d.GetExtractLogById(job.ID)
+	if err != nil || p.Status != domain.JobStatusFailed || p.ErrorText != "bad row" || p.RecordsLoaded != 7 {
+		t.Fatalf("GetExtractLogById after UpdateExtractLogStatus got %+v %v", p, err)
+	}
+
+	for _, dp := range []domain.DataProvenance{
+		{ID: "dp1", Name: "a.csv", Path: "/churro/a.csv", Fingerprint: "f1", LastUpdated: time.Now()},
+		{ID: "dp2", Name: "b.csv", Path: "/churro/b.csv", Fingerprint: "f2", LastUpdated: time.Now()},
+		{ID: "dp3", Name: "c.csv", Path: "/churro/c.csv", Fingerprint: "f1", LastUpdated: time.Now().Add(time.Second)},
+	} {
+		if err := d.CreateDataprov(dp); err != nil {
+			t.Fatalf("CreateDataprov Error: %v", err)
+		}
+	}
+	dps, err := d.GetDataprovsByFingerprint("f1")
+	if err != nil || len(dps) != 2 || dps[0].ID != "dp1" || dps[1].ID != "dp3" || dps[0].Fingerprint != "f1" {
+		t.Fatalf("GetDataprovsByFingerprint got %+v %v", dps, err)
+	}
+	if dps, err := d.GetDataprovsByFingerprint("none"); err != nil || len(dps) != 0 {
+		t.Fatalf("GetDataprovsByFingerprint of an unknown fingerprint got %+v %v", dps, err)
+	}
+	if err := d.ClearDataprovFingerprint("dp1"); err != nil {
+		t.Fatalf("ClearDataprovFingerprint Error: %v", err)
+	}
+	if dps, err := d.GetDataprovsByFingerprint("f1"); err != nil || len(dps) != 1 || dps[0].ID != "dp3" {
+		t.Fatalf("GetDataprovsByFingerprint after ClearDataprovFingerprint got %+v %v", dps, err)
+	}
+
+	for _, a := range []domain.PrivacyAudit{
+		{ID: "pa1", DataprovID: "dp1", TableName: "people", ColumnName: "ssn", Policy: "tokenize", LastUpdated: time.Now()},
+		{ID: "pa2", DataprovID: "dp1", TableName: "people", ColumnName: "card", Policy: "mask(4)", LastUpdated: time.Now()},
+		{ID: "pa3", DataprovID: "dp2", TableName: "people", ColumnName: "ssn", Policy: "tokenize", LastUpdated: time.Now()},
+	} {
+		if err := d.CreatePrivacyAudit(a); err != nil {
+			t.Fatalf("CreatePrivacyAudit Error: %v", err)
+		}
+	}
+	audits, err := d.GetPrivacyAudits("dp1")
+	if err != nil || len(audits) != 2 || audits[0].ColumnName != "card" || audits[0].Policy != "mask(4)" || audits[1].ColumnName != "ssn" {
+		t.Fatalf("GetPrivacyAudits got %+v %v", audits, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		err := d.UpdatePipelineStats(stats.PipelineStats{Pipeline: testPipeline, DataprovID: "dp1", FileName: "a.csv", RecordsIn: 3})
+		if err != nil {
+			t.Fatalf("UpdatePipelineStats Error: %v", err)
+		}
+	}
+	var recordsIn int64
+	if err := d.Connection.QueryRow("select records_in from pipeline_stats where file_name = 'a.csv'").Scan(&recordsIn); err != nil || recordsIn != 6 {
+		t.Fatalf("pipeline_stats records_in got %d %v", recordsIn, err)
+	}
+
+	cols := []string{"city", "population"}
+	colTypes := []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_INT}
+	if err := d.CreateTable(testPipeline, testPipeline, "mytable", cols, colTypes); err != nil {
+		t.Fatalf("CreateTable Error: %v", err)
+	}
+	if !d.IsInitialized("mytable") {
+		t.Fatal("IsInitialized expected mytable")
+	}
+	if err := d.GetInsertStatement(extractapi.CSVScheme, testPipeline, "mytable", cols, []interface{}{"O'Brien", 5}, 1); err != nil {
+		t.Fatalf("GetInsertStatement Error: %v", err)
+	}
+	records := make([]extractapi.GenericRow, 0)
+	for i := int64(2); i < 2000; i++ {
+		records = append(records, extractapi.GenericRow{Key: i, Cols: []interface{}{"boerne", "null"}})
+	}
+	if err := d.GetBulkInsertStatement(extractapi.CSVScheme, testPipeline, "mytable", cols, records, colTypes); err != nil {
+		t.Fatalf("GetBulkInsertStatement Error: %v", err)
+	}
+	var count, nulls int
+	if err := d.Connection.QueryRow("select count(*), sum(population is null) from mytable").Scan(&count, &nulls); err != nil || count != 1999 || nulls != 1998 {
+		t.Fatalf("mytable got %d rows %d nulls %v", count, nulls, err)
+	}
+}
+
+func TestAuth(t *testing.T) {
+	d := getTestDatabase(t, t.TempDir(), "churro")
+
+	if err := d.CreateAuthObjects(); err != nil {
+		t.Fatalf("CreateAuthObjects Error: %v", err)
+	}
+	if err := d.Bootstrap(); err != nil {
+		t.Fatalf("Bootstrap Error: %v", err)
+	}
+	if err := d.Bootstrap(); err != nil {
+		t.Fatalf("Bootstrap twice Error: %v", err)
+	}
+	u, err := d.GetUserProfileByEmail("admin@admin.org")
+	if err != nil || u.ID != "0000" {
+		t.Fatalf("GetUserProfileByEmail got %+v %v", u, err)
+	}
+
+	a := domain.UserPipelineAccess{UserProfileID: u.ID, PipelineID: testPipeline, Access: "Read"}
+	if err := d.CreateUserPipelineAccess(a); err != nil {
+		t.Fatalf("CreateUserPipelineAccess Error: %v", err)
+	}
+	a.Access = "Write"
+	if err := d.UpdateUserPipelineAccess(a); err != nil {
+		t.Fatalf("UpdateUserPipelineAccess Error: %v", err)
+	}
+	got, err := d.GetUserPipelineAccess(testPipeline, u.ID)
+	if err != nil || got.Access != "Write" {
+		t.Fatalf("GetUserPipelineAccess got %+v %v", got, err)
+	}
+	users, err := d.GetAllUserProfileForPipeline(testPipeline)
+	if err != nil || len(users) != 1 {
+		t.Fatalf("GetAllUserProfileForPipeline got %+v %v", users, err)
+	}
+}
+
+func TestLoadModes(t *testing.T) {
+	d := getTestDatabase(t, t.TempDir(), testPipeline)
+
+	cols := []string{"vendor", "sku", "price"}
+	colTypes := []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_TEXT, extractapi.COLTYPE_INT}
+	if err := d.CreateTable(testPipeline, testPipeline, "prices", cols, colTypes); err != nil {
+		t.Fatalf("CreateTable Error: %v", err)
+	}
+	count := func(query string, args ...interface{}) int {
+		var n int
+		if err := d.Connection.QueryRow(query, args...).Scan(&n); err != nil {
+			t.Fatalf("%s Error: %v", query, err)
+		}
+		return n
+	}
+
+	// loading the same keys again updates the rows in place
+	records := []extractapi.GenericRow{
+		{Key: 1, Cols: []interface{}{"acme", "a1", "10"}},
+		{Key: 2, Cols: []interface{}{"acme", "a2", "20"}},
+	}
+	if err := d.GetUpsertStatement(extractapi.CSVScheme, testPipeline, "prices", cols, records, colTypes); err != nil {
+		t.Fatalf("GetUpsertStatement Error: %v", err)
+	}
+	records[1].Cols[2] = "25"
+	if err := d.GetUpsertStatement(extractapi.CSVScheme, testPipeline, "prices", cols, records, colTypes); err != nil {
+		t.Fatalf("GetUpsertStatement again Error: %v", err)
+	}
+	if n := count("select count(*) from prices"); n != 2 {
+		t.Fatalf("upsert got %d rows", n)
+	}
+	if n := count("select price from prices where primarykey = 2"); n != 25 {
+		t.Fatalf("upsert got price %d", n)
+	}
+
+	// a partition is replaced, the rows of other partitions are kept
+	other := []extractapi.GenericRow{{Key: 3, Cols: []interface{}{"zenith", "z1", "5"}}}
+	if err := d.GetBulkInsertStatement(extractapi.CSVScheme, testPipeline, "prices", cols, other, colTypes); err != nil {
+		t.Fatalf("GetBulkInsertStatement Error: %v", err)
+	}
+	corrected := []extractapi.GenericRow{{Key: 4, Cols: []interface{}{"acme", "a3", "30"}}}
+	err := d.GetReplacePartitionStatement(extractapi.CSVScheme, testPipeline, "prices", cols, corrected, colTypes, []string{"vendor"}, [][]interface{}{{"acme"}})
+	if err != nil {
+		t.Fatalf("GetReplacePartitionStatement Error: %v", err)
+	}
+	if n := count("select count(*) from prices where vendor = ?", "acme"); n != 1 {
+		t.Fatalf("replace-partition got %d acme rows", n)
+	}
+	if n := count("select count(*) from prices where vendor = ?", "zenith"); n != 1 {
+		t.Fatalf("replace-partition got %d zenith rows", n)
+	}
+
+	err = d.GetReplacePartitionStatement(extractapi.CSVScheme, testPipeline, "prices", cols, corrected, colTypes, []string{"region"}, [][]interface{}{{"west"}})
+	if err == nil {
+		t.Fatal("GetReplacePartitionStatement expected an error for a key column that is not loaded")
+	}
+}
+
+func TestAddColumn(t *testing.T) {
+	d := getTestDatabase(t, t.TempDir(), testPipeline)
+
+	if err := d.CreateTable(testPipeline, testPipeline, "cities", []string{"city"}, []string{extractapi.COLTYPE_TEXT}); err != nil {
+		t.Fatalf("CreateTable Error: %v", err)
+	}
+	if err := d.AddColumn(testPipeline, "cities", "population", extractapi.COLTYPE_INT); err != nil {
+		t.Fatalf("AddColumn Error: %v", err)
+	}
+
+	// the live types are compared with the extract rule types
+	columns, err := d.GetTableColumns(testPipeline, "cities")
+	if err != nil {
+		t.Fatalf("GetTableColumns Error: %v", err)
+	}
+	for name, colType := range map[string]string{"city": extractapi.COLTYPE_TEXT, "population": extractapi.COLTYPE_INT} {
+		want, err := d.GetColumnType(colType)
+		if err != nil || columns[name] != want {
+			t.Fatalf("GetTableColumns got %s %q want %q %v", name, columns[name], want, err)
+		}
+	}
+
+	cols := []string{"city", "population"}
+	records := []extractapi.GenericRow{{Key: 1, Cols: []interface{}{"boerne", "18000"}}}
+	if err := d.GetBulkInsertStatement(extractapi.CSVScheme, testPipeline, "cities", cols, records, []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_INT}); err != nil {
+		t.Fatalf("GetBulkInsertStatement Error: %v", err)
+	}
+
+	// the table is reference data for a lookup
+	rows, err := d.GetReferenceRows(testPipeline, "cities", cols)
+	if err != nil {
+		t.Fatalf("GetReferenceRows Error: %v", err)
+	}
+	if len(rows) != 1 || rows[0][0] != "boerne" || fmt.Sprintf("%v", rows[0][1]) != "18000" {
+		t.Fatalf("GetReferenceRows got %v", rows)
+	}
+}
+
+func TestUpgradePipelineObjects(t *testing.T) {
+	dir := t.TempDir()
+	d := getTestDatabase(t, dir, testPipeline)
+
+	// the tables of a pipeline created by an earlier release
+	for _, sqlStr := range []string{
+		"CREATE TABLE dataprov ( id text PRIMARY KEY, name text, path text, lastupdated TIMESTAMP);",
+		"CREATE TABLE extractlog ( tablename text not null, id text PRIMARY KEY, dataprov_id text not null, podname text not null, poddate timestamp, records_loaded int not null, file_name text, lastupdated TIMESTAMP);",
+		"INSERT INTO extractlog VALUES ('mytable', 'job1', 'dp1', 'extract-job1', '2021-01-01', 5, 'f1', '2021-01-01');",
+	} {
+		if _, err := d.Connection.Exec(sqlStr); err != nil {
+			t.Fatalf("%s Error: %v", sqlStr, err)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := d.CreatePipelineObjects(testPipeline, testPipeline); err != nil {
+			t.Fatalf("CreatePipelineObjects Error: %v", err)
+		}
+	}
+
+	columns, err := d.GetTableColumns(testPipeline, "extractlog")
+	if err != nil {
+		t.Fatalf("GetTableColumns Error: %v", err)
+	}
+	for _, name := range []string{"conversion_errors", "records_rejected", "status", "error_text"} {
+		if _, ok := columns[name]; !ok {
+			t.Fatalf("extractlog is missing %s got %v", name, columns)
+		}
+	}
+
+	columns, err = d.GetTableColumns(testPipeline, "dataprov")
+	if _, ok := columns["fingerprint"]; err != nil || !ok {
+		t.Fatalf("dataprov is missing fingerprint got %v %v", columns, err)
+	}
+	if _, err := d.GetDataprovsByFingerprint("f1"); err != nil {
+		t.Fatalf("GetDataprovsByFingerprint Error: %v", err)
+	}
+
+	// the job of the earlier release reads with the added columns
+	jp, err := d.GetExtractLogById("job1")
+	if err != nil || jp.RecordsLoaded != 5 || jp.Status != "" {
+		t.Fatalf("GetExtractLogById got %+v %v", jp, err)
+	}
+	if err := d.UpdateExtractLogStatus("job1", domain.JobStatusFailed, "bad row"); err != nil {
+		t.Fatalf("UpdateExtractLogStatus Error: %v", err)
+	}
+
+	versions, err := d.GetSchemaVersions("extractlog")
+	if err != nil || len(versions) != 3 {
+		t.Fatalf("GetSchemaVersions got %+v %v", versions, err)
+	}
+	versions, err = d.GetSchemaVersions("dataprov")
+	if err != nil || len(versions) != 1 {
+		t.Fatalf("GetSchemaVersions got %+v %v", versions, err)
+	}
+}
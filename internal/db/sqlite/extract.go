@@ -0,0 +1,438 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db/sqlsafe"
+	"github.com/rs/zerolog/log"
+)
+
+// the most placeholders a single statement can bind
+const maxPlaceholders = 32766
+
+func (d SqliteChurroDatabase) CreateTable(userid, dbname, tableName string, columnNames, columnTypes []string) error {
+	tableColumns, err := getTableColumns(columnNames, columnTypes)
+	if err != nil {
+		return err
+	}
+	// sqlite has no users to grant privs to
+	if err := sqlsafe.ValidIdentifier(userid); err != nil {
+		return err
+	}
+	table, err := d.table(dbname, tableName)
+	if err != nil {
+		return err
+	}
+
+	sqlStr := fmt.Sprintf("CREATE TABLE if not exists %s ( primarykey bigint PRIMARY KEY, dataformat text, %s lastupdated TIMESTAMP);", table, tableColumns)
+	log.Info().Msg(sqlStr)
+
+	_, err = d.Connection.Exec(sqlStr)
+	if err != nil {
+		return err
+	}
+	log.Info().Msg(tableName + " table created")
+
+	return nil
+}
+
+func (d SqliteChurroDatabase) GetInsertStatement(scheme, database, tablename string, cols []string, vals []interface{}, key int64) error {
+
+	colNames, err := getInsertColumns(cols)
+	if err != nil {
+		return err
+	}
+	table, err := d.table(database, tablename)
+	if err != nil {
+		return err
+	}
+
+	var sqlString strings.Builder
+	fmt.Fprintf(&sqlString, "insert into %s (%s) values ", table, colNames)
+	writePlaceholders(&sqlString, len(vals)+2)
+
+	args := append([]interface{}{key, scheme}, vals...)
+	_, err = d.Connection.Exec(sqlString.String(), args...)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg(sqlString.String())
+		return err
+	}
+
+	return nil
+}
+
+func (d SqliteChurroDatabase) GetBulkInsertStatement(scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string) error {
+
+	colNames, err := getInsertColumns(cols)
+	if err != nil {
+		return err
+	}
+	// the database is attached before the transaction begins, sqlite
+	// does not attach within a transaction
+	table, err := d.table(database, tableName)
+	if err != nil {
+		return err
+	}
+
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return err
+	}
+
+	err = insertRows(tx, fmt.Sprintf("insert into %s (%s) values ", table, colNames), "", scheme, cols, records, colTypes)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetUpsertStatement loads the records with INSERT ... ON CONFLICT, a
+// record replaces the row that has the same primary key
+func (d SqliteChurroDatabase) GetUpsertStatement(scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string) error {
+
+	colNames, err := getInsertColumns(cols)
+	if err != nil {
+		return err
+	}
+	table, err := d.table(database, tableName)
+	if err != nil {
+		return err
+	}
+	update, err := getConflictUpdate(cols)
+	if err != nil {
+		return err
+	}
+
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return err
+	}
+
+	err = insertRows(tx, fmt.Sprintf("insert into %s (%s) values ", table, colNames), " on conflict (primarykey) do update set "+update, scheme, cols, records, colTypes)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetReplacePartitionStatement deletes the rows of each partition and
+// then loads the records within one transaction, a partition holds
+// the values of the key columns
+func (d SqliteChurroDatabase) GetReplacePartitionStatement(scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string, keyCols []string, partitions [][]interface{}) error {
+
+	// the partitions are checked before the database is touched
+	filters := make([]string, 0, len(partitions))
+	args := make([][]interface{}, 0, len(partitions))
+	for _, partition := range partitions {
+		filter, values, err := getPartitionFilter(cols, colTypes, keyCols, partition)
+		if err != nil {
+			return err
+		}
+		filters = append(filters, filter)
+		args = append(args, values)
+	}
+
+	colNames, err := getInsertColumns(cols)
+	if err != nil {
+		return err
+	}
+	table, err := d.table(database, tableName)
+	if err != nil {
+		return err
+	}
+
+	tx, err := d.Connection.Begin()
+	if err != nil {
+		return err
+	}
+
+	for i := range filters {
+		_, err = tx.Exec(fmt.Sprintf("delete from %s where %s", table, filters[i]), args[i]...)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("error in partition delete")
+			tx.Rollback()
+			return err
+		}
+	}
+
+	err = insertRows(tx, fmt.Sprintf("insert into %s (%s) values ", table, colNames), "", scheme, cols, records, colTypes)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insertRows writes the records with multi-row statements, wide tables
+// are split across statements to stay under the placeholder limit
+func insertRows(tx *sql.Tx, prefix, suffix, scheme string, cols []string, records []extractapi.GenericRow, colTypes []string) error {
+	perRow := len(cols) + 2
+	rowsPerStatement := maxPlaceholders / perRow
+
+	for start := 0; start < len(records); start += rowsPerStatement {
+		end := start + rowsPerStatement
+		if end > len(records) {
+			end = len(records)
+		}
+
+		var sqlString strings.Builder
+		sqlString.WriteString(prefix)
+		args := make([]interface{}, 0, (end-start)*perRow)
+		for i, r := range records[start:end] {
+			if i > 0 {
+				sqlString.WriteString(", ")
+			}
+			writePlaceholders(&sqlString, perRow)
+			args = append(args, getRowValues(scheme, r, colTypes)...)
+		}
+		sqlString.WriteString(suffix)
+
+		_, err := tx.Exec(sqlString.String(), args...)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("error in bulk insert")
+			return err
+		}
+	}
+	return nil
+}
+
+// getPartitionFilter returns the condition matching the rows whose key
+// columns hold the values of the partition
+func getPartitionFilter(cols, colTypes, keyCols []string, partition []interface{}) (string, []interface{}, error) {
+	if len(keyCols) == 0 || len(keyCols) != len(partition) {
+		return "", nil, fmt.Errorf("partition has %d values for %d key columns", len(partition), len(keyCols))
+	}
+
+	var sqlString strings.Builder
+	args := make([]interface{}, 0, len(keyCols))
+	for i, k := range keyCols {
+		c := columnIndex(cols, k)
+		if c < 0 {
+			return "", nil, fmt.Errorf("key column %s is not a loaded column", k)
+		}
+		name, err := sqlsafe.QuoteSQLite(k)
+		if err != nil {
+			return "", nil, err
+		}
+		if i > 0 {
+			sqlString.WriteString(" and ")
+		}
+		v := getValue(colTypes[c], partition[i])
+		if v == nil {
+			fmt.Fprintf(&sqlString, "%s is null", name)
+			continue
+		}
+		args = append(args, v)
+		fmt.Fprintf(&sqlString, "%s = ?", name)
+	}
+	return sqlString.String(), args, nil
+}
+
+func columnIndex(cols []string, name string) int {
+	for i := range cols {
+		if cols[i] == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func getTableColumns(columnNames, columnTypes []string) (string, error) {
+	var result string
+	for i, v := range columnNames {
+		name, err := sqlsafe.QuoteSQLite(v)
+		if err != nil {
+			return "", err
+		}
+		if err := sqlsafe.ValidColumnType(columnTypes[i]); err != nil {
+			return "", err
+		}
+		result = result + fmt.Sprintf("%s %s,", name, getColumnType(columnTypes[i]))
+	}
+	log.Debug().Msg("getTableColumns " + result)
+	return result, nil
+}
+
+// getColumnType returns the type a column is created with, sqlite has no JSONB type, the JSON text
+// is kept in a TEXT column
+func getColumnType(columnType string) string {
+	if strings.EqualFold(columnType, extractapi.COLTYPE_JSONB) {
+		return "TEXT"
+	}
+	return columnType
+}
+
+// getInsertColumns returns the quoted column list of an insert,
+// surrounded by the columns churro adds to every table
+func getInsertColumns(cols []string) (string, error) {
+	quoted, err := sqlsafe.QuoteList(sqlsafe.QuoteSQLite, cols)
+	if err != nil {
+		return "", err
+	}
+	if quoted == "" {
+		return "primarykey, dataformat, lastupdated", nil
+	}
+	return "primarykey, dataformat, " + quoted + ", lastupdated", nil
+}
+
+// getConflictUpdate returns the assignments an upsert makes to the
+// row with the same primary key
+func getConflictUpdate(cols []string) (string, error) {
+	sets := []string{"dataformat = excluded.dataformat"}
+	for _, v := range cols {
+		name, err := sqlsafe.QuoteSQLite(v)
+		if err != nil {
+			return "", err
+		}
+		sets = append(sets, fmt.Sprintf("%s = excluded.%s", name, name))
+	}
+	sets = append(sets, "lastupdated = excluded.lastupdated")
+	return strings.Join(sets, ", "), nil
+}
+
+// writePlaceholders writes the placeholders of one row
+func writePlaceholders(sb *strings.Builder, count int) {
+	sb.WriteString("(")
+	sb.WriteString(strings.Repeat("?, ", count))
+	sb.WriteString("CURRENT_TIMESTAMP)")
+}
+
+// getRowValues returns the key, data format and column values of a
+// record
+func getRowValues(scheme string, r extractapi.GenericRow, colTypes []string) []interface{} {
+	values := make([]interface{}, 0, len(r.Cols)+2)
+	values = append(values, r.Key, scheme)
+	for i := 0; i < len(r.Cols); i++ {
+		values = append(values, getValue(colTypes[i], r.Cols[i]))
+	}
+	return values
+}
+
+// getValue returns the value sent for a column, a nil value and a null
+// value in a non-text column are sent as a database NULL
+func getValue(colType string, v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	switch colType {
+	case extractapi.COLTYPE_TEXT, extractapi.COLTYPE_VARCHAR:
+		return fmt.Sprintf("%v", v)
+	}
+	if v == "null" {
+		return nil
+	}
+	switch v.(type) {
+	case int64, float64, bool, time.Time:
+		// converted to the column type by the extract
+		return v
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// GetTableColumns returns the declared type of each column of a table,
+// keyed by column name
+func (d SqliteChurroDatabase) GetTableColumns(database, tableName string) (map[string]string, error) {
+	if _, err := d.table(database, tableName); err != nil {
+		return nil, err
+	}
+	schema := "main"
+	if !strings.EqualFold(database, d.database) {
+		schema = strings.ToLower(database)
+	}
+
+	rows, err := d.Connection.Query("SELECT name, type FROM pragma_table_info(?, ?)", tableName, schema)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := make(map[string]string)
+	for rows.Next() {
+		var name, declared string
+		if err := rows.Scan(&name, &declared); err != nil {
+			return nil, err
+		}
+		columns[name] = strings.ToLower(declared)
+	}
+	return columns, rows.Err()
+}
+
+// GetColumnType returns the type GetTableColumns reports for an extract
+// rule column type, sqlite keeps the type a column was declared with
+func (d SqliteChurroDatabase) GetColumnType(columnType string) (string, error) {
+	return strings.ToLower(getColumnType(columnType)), nil
+}
+
+// AddColumn adds a column to an existing table
+func (d SqliteChurroDatabase) AddColumn(database, tableName, columnName, columnType string) error {
+	column, err := getTableColumns([]string{columnName}, []string{columnType})
+	if err != nil {
+		return err
+	}
+	table, err := d.table(database, tableName)
+	if err != nil {
+		return err
+	}
+
+	sqlStr := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", table, strings.TrimSuffix(column, ","))
+	log.Info().Msg(sqlStr)
+	_, err = d.Connection.Exec(sqlStr)
+	return err
+}
+
+// GetReferenceRows returns the values of the columns of every row of a
+// table, for the lookups of an extract source
+func (d SqliteChurroDatabase) GetReferenceRows(database, tableName string, columns []string) ([][]interface{}, error) {
+	table, err := d.table(database, tableName)
+	if err != nil {
+		return nil, err
+	}
+	quoted, err := sqlsafe.QuoteList(sqlsafe.QuoteSQLite, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.Connection.Query(fmt.Sprintf("SELECT %s FROM %s", quoted, table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make([][]interface{}, 0)
+	for rows.Next() {
+		row := make([]interface{}, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range row {
+			dest[i] = &row[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		for i, v := range row {
+			if b, ok := v.([]byte); ok {
+				row[i] = string(b)
+			}
+		}
+		values = append(values, row)
+	}
+	return values, rows.Err()
+}
@@ -0,0 +1,253 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sqlite
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/churrodata/churro/internal/db/migrate"
+	"github.com/churrodata/churro/internal/db/sqlsafe"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+// CreatePipelineDatabase creates the pipeline database file
+func (s SqliteChurroDatabase) CreatePipelineDatabase(dbName string) error {
+	_, err := s.table(dbName, "dataprov")
+	if err != nil {
+		return err
+	}
+	log.Info().Msg("Successfully created database " + dbName)
+	return nil
+}
+
+// pipelineColumns are the columns added to the pipeline tables after
+// they were first created, CreatePipelineObjects adds them to the
+// tables of an existing pipeline
+var pipelineColumns = []migrate.Column{
+	{Table: "extractlog", Version: 1, Name: "conversion_errors", Definition: "int not null default 0"},
+	{Table: "extractlog", Version: 2, Name: "records_rejected", Definition: "int not null default 0"},
+	{Table: "dataprov", Version: 1, Name: "fingerprint", Definition: "text"},
+	{Table: "extractlog", Version: 3, Name: "status", Definition: "text not null default ''"},
+	{Table: "extractlog", Version: 3, Name: "error_text", Definition: "text not null default ''"},
+}
+
+func (s SqliteChurroDatabase) CreatePipelineObjects(dbName, username string) error {
+	if err := sqlsafe.ValidIdentifier(username); err != nil {
+		return err
+	}
+	dataprov, err := s.table(dbName, "dataprov")
+	if err != nil {
+		return err
+	}
+	pipelineStats, err := s.table(dbName, "pipeline_stats")
+	if err != nil {
+		return err
+	}
+	extractlog, err := s.table(dbName, "extractlog")
+	if err != nil {
+		return err
+	}
+	schemaversion, err := s.table(dbName, "schemaversion")
+	if err != nil {
+		return err
+	}
+	privacyaudit, err := s.table(dbName, "privacyaudit")
+	if err != nil {
+		return err
+	}
+
+	// sqlite has no users, the statements only create the tables
+	statements := []string{
+		fmt.Sprintf("CREATE TABLE if not exists %s ( id text PRIMARY KEY, name text, path text, fingerprint text, lastupdated TIMESTAMP);", dataprov),
+		fmt.Sprintf("CREATE TABLE if not exists %s ( id integer PRIMARY KEY AUTOINCREMENT, dataprov_id text, file_name text UNIQUE, records_in bigint, lastupdated TIMESTAMP);", pipelineStats),
+		fmt.Sprintf("CREATE TABLE if not exists %s ( tablename text not null, id text PRIMARY KEY, dataprov_id text not null, podname text not null, poddate timestamp, records_loaded int not null, conversion_errors int not null default 0, records_rejected int not null default 0, file_name text, status text not null default '', error_text text not null default '', lastupdated TIMESTAMP);", extractlog),
+		fmt.Sprintf("CREATE TABLE if not exists %s ( tablename text not null, version int not null, extractsource_id text, column_list text, lastupdated TIMESTAMP, PRIMARY KEY (tablename, version));", schemaversion),
+		fmt.Sprintf("CREATE TABLE if not exists %s ( id text PRIMARY KEY, dataprov_id text not null, tablename text not null, columnname text not null, policy text not null, lastupdated TIMESTAMP);", privacyaudit),
+	}
+
+	for _, sqlStr := range statements {
+		_, err = s.Connection.Exec(sqlStr)
+		if err != nil {
+			return err
+		}
+		log.Info().Msg(sqlStr)
+	}
+
+	// add the columns of later releases to the tables of an existing
+	// pipeline
+	return migrate.Run(s, dbName, pipelineColumns, func(table, column, definition string) error {
+		t, err := s.table(dbName, table)
+		if err != nil {
+			return err
+		}
+		sqlStr := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s;", t, column, definition)
+		log.Info().Msg(sqlStr)
+		_, err = s.Connection.Exec(sqlStr)
+		return err
+	})
+}
+
+func (s SqliteChurroDatabase) GetAllPipelineMetrics() (metrics []domain.PipelineMetric, err error) {
+	metrics = make([]domain.PipelineMetric, 0)
+
+	rows, err := s.Connection.Query("SELECT name, value, lastupdated FROM pipelinemetric order by name")
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return metrics, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p := domain.PipelineMetric{}
+		err = rows.Scan(&p.Name, &p.Value, &p.LastUpdated)
+		if err != nil {
+			log.Error().Stack().Err(err)
+			return metrics, err
+		}
+		metrics = append(metrics, p)
+	}
+
+	return metrics, nil
+}
+
+func (s SqliteChurroDatabase) UpdatePipelineMetric(m domain.PipelineMetric) error {
+	datetime := time.Now()
+	var UPDATE = "UPDATE pipelinemetric set value = ?, lastupdated = ? where name = ?"
+	log.Info().Msg(UPDATE)
+
+	_, err := s.Connection.Exec(UPDATE, m.Value, datetime, m.Name)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s SqliteChurroDatabase) CreatePipelineMetric(m domain.PipelineMetric) error {
+	INSERT := "INSERT INTO pipelinemetric(name, value, lastupdated) values(?,?,CURRENT_TIMESTAMP)"
+
+	_, err := s.Connection.Exec(INSERT, m.Name, m.Value)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+// CreateUser only validates the user name, sqlite has no users
+func (s SqliteChurroDatabase) CreateUser(username, password string) error {
+	return sqlsafe.ValidIdentifier(username)
+}
+
+func (s SqliteChurroDatabase) CreateExtractLog(p domain.JobProfile) error {
+	var INSERT = "INSERT INTO extractlog ( tablename, id, dataprov_id, podname, poddate, records_loaded, file_name, lastupdated ) values (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)"
+
+	_, err := s.Connection.Exec(INSERT, p.TableName, p.ID, p.DataProvenanceID, p.JobName, p.StartDate, p.RecordsLoaded, p.FileName)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s SqliteChurroDatabase) UpdateExtractLog(p domain.JobProfile) error {
+	var UPDATE = "UPDATE extractlog set records_loaded = ?, conversion_errors = ?, records_rejected = ?, lastupdated = CURRENT_TIMESTAMP where id = ?"
+	log.Info().Msg(UPDATE)
+
+	_, err := s.Connection.Exec(UPDATE, p.RecordsLoaded, p.ConversionErrors, p.RecordsRejected, p.ID)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+// UpdateExtractLogStatus sets the status of an extract job and the
+// error it failed with
+func (s SqliteChurroDatabase) UpdateExtractLogStatus(id, status, errorText string) error {
+	var UPDATE = "UPDATE extractlog set status = ?, error_text = ?, lastupdated = CURRENT_TIMESTAMP where id = ?"
+	log.Info().Msg(UPDATE)
+
+	_, err := s.Connection.Exec(UPDATE, status, errorText, id)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+func (s SqliteChurroDatabase) GetExtractLog(jobName string) (p domain.JobProfile, err error) {
+
+	row := s.Connection.QueryRow("SELECT tablename, id, dataprov_id, podname, poddate, records_loaded, conversion_errors, records_rejected, file_name, status, error_text FROM extractlog where podname=?", jobName)
+	err = row.Scan(&p.TableName, &p.ID, &p.DataProvenanceID, &p.JobName, &p.StartDate, &p.RecordsLoaded, &p.ConversionErrors, &p.RecordsRejected, &p.FileName, &p.Status, &p.ErrorText)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error getting job from extract log " + jobName)
+		return p, err
+	}
+
+	log.Info().Msg(fmt.Sprintf("returning extract log values of %+v", p))
+	return p, nil
+}
+
+func (s SqliteChurroDatabase) GetExtractLogById(id string) (p domain.JobProfile, err error) {
+
+	row := s.Connection.QueryRow("SELECT tablename, id, dataprov_id, podname, poddate, records_loaded, conversion_errors, records_rejected, file_name, status, error_text FROM extractlog where id=?", id)
+	err = row.Scan(&p.TableName, &p.ID, &p.DataProvenanceID, &p.JobName, &p.StartDate, &p.RecordsLoaded, &p.ConversionErrors, &p.RecordsRejected, &p.FileName, &p.Status, &p.ErrorText)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error getting job by id from extract log " + id)
+		return p, err
+	}
+	log.Info().Msg(fmt.Sprintf("returning extract log values of %+v", p))
+
+	return p, nil
+}
+
+func (s SqliteChurroDatabase) CreateSchemaVersion(v domain.SchemaVersion) error {
+	var INSERT = "INSERT INTO schemaversion ( tablename, version, extractsource_id, column_list, lastupdated ) values (?, ?, ?, ?, CURRENT_TIMESTAMP)"
+
+	_, err := s.Connection.Exec(INSERT, v.TableName, v.Version, v.ExtractSourceID, v.Columns)
+	if err != nil {
+		log.Error().Stack().Err(err)
+		return err
+	}
+
+	return nil
+}
+
+// GetSchemaVersions returns the schema versions of a table, oldest first
+func (s SqliteChurroDatabase) GetSchemaVersions(tableName string) (versions []domain.SchemaVersion, err error) {
+	versions = make([]domain.SchemaVersion, 0)
+	rows, err := s.Connection.Query("SELECT tablename, version, extractsource_id, column_list, lastupdated FROM schemaversion where tablename=? order by version", tableName)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error getting schema versions of " + tableName)
+		return versions, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		v := domain.SchemaVersion{}
+		err = rows.Scan(&v.TableName, &v.Version, &v.ExtractSourceID, &v.Columns, &v.LastUpdated)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("error getting schema versions of " + tableName)
+			return versions, err
+		}
+		versions = append(versions, v)
+	}
+
+	return versions, rows.Err()
+}
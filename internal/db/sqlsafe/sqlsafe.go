@@ -0,0 +1,114 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package sqlsafe validates and quotes the database, table and column
+// names that cannot be passed to a statement as placeholders
+package sqlsafe
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MaxIdentifierLength is the postgres limit, mysql allows 64
+const MaxIdentifierLength = 63
+
+var identifierRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// a type name of one or two words with an optional precision such as
+// TEXT, DOUBLE PRECISION, VARCHAR(32) or DECIMAL(10,2)
+var columnTypeRegex = regexp.MustCompile(`^[A-Za-z]+( [A-Za-z]+)?( ?\([0-9]+(, ?[0-9]+)?\))?$`)
+
+// ValidIdentifier returns an error unless name is a plain identifier
+// made up of letters, digits and underscores
+func ValidIdentifier(name string) error {
+	if len(name) > MaxIdentifierLength {
+		return fmt.Errorf("identifier %q is longer than %d characters", name, MaxIdentifierLength)
+	}
+	if !identifierRegex.MatchString(name) {
+		return fmt.Errorf("identifier %q is not valid, only letters, digits and underscores are allowed", name)
+	}
+	return nil
+}
+
+// ValidColumnType returns an error unless columnType looks like a
+// column type name, it is written into CREATE TABLE as is
+func ValidColumnType(columnType string) error {
+	if !columnTypeRegex.MatchString(columnType) {
+		return fmt.Errorf("column type %q is not valid", columnType)
+	}
+	return nil
+}
+
+// QuotePostgres validates and double quotes each name, joining them
+// with a dot so that a database and table give database.table.  Names
+// are lower cased to match how postgres and cockroach fold the
+// unquoted names churro has always created tables with.
+func QuotePostgres(names ...string) (string, error) {
+	return quote(names, `"`, strings.ToLower)
+}
+
+// QuoteMySQL validates and backtick quotes each name, joining them
+// with a dot so that a database and table give database.table
+func QuoteMySQL(names ...string) (string, error) {
+	return quote(names, "`", func(s string) string { return s })
+}
+
+// QuoteSQLite validates and double quotes each name, joining them with
+// a dot so that an attached database and table give database.table
+func QuoteSQLite(names ...string) (string, error) {
+	return quote(names, `"`, func(s string) string { return s })
+}
+
+// QuoteClickHouse validates and backtick quotes each name, joining
+// them with a dot so that a database and table give database.table
+func QuoteClickHouse(names ...string) (string, error) {
+	return quote(names, "`", func(s string) string { return s })
+}
+
+// QuoteMySQLString returns s as a mysql string literal, for the few
+// statements such as CREATE USER that do not accept placeholders
+func QuoteMySQLString(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		`'`, `\'`,
+		"\x00", `\0`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"\x1a", `\Z`,
+	)
+	return "'" + r.Replace(s) + "'"
+}
+
+func quote(names []string, q string, fold func(string) string) (string, error) {
+	quoted := make([]string, 0, len(names))
+	for _, n := range names {
+		if err := ValidIdentifier(n); err != nil {
+			return "", err
+		}
+		quoted = append(quoted, q+fold(n)+q)
+	}
+	return strings.Join(quoted, "."), nil
+}
+
+// QuoteList quotes each name with quoteFunc and joins them with a
+// comma for use in a column list
+func QuoteList(quoteFunc func(...string) (string, error), names []string) (string, error) {
+	quoted := make([]string, 0, len(names))
+	for _, n := range names {
+		q, err := quoteFunc(n)
+		if err != nil {
+			return "", err
+		}
+		quoted = append(quoted, q)
+	}
+	return strings.Join(quoted, ", "), nil
+}
@@ -0,0 +1,58 @@
+package sqlsafe
+
+import (
+	"testing"
+)
+
+func TestQuote(t *testing.T) {
+	q, err := QuotePostgres("Pipeline1", "myTable")
+	if err != nil || q != `"pipeline1"."mytable"` {
+		t.Fatalf("QuotePostgres got %s %v", q, err)
+	}
+
+	q, err = QuoteMySQL("pipeline1", "myTable")
+	if err != nil || q != "`pipeline1`.`myTable`" {
+		t.Fatalf("QuoteMySQL got %s %v", q, err)
+	}
+
+	q, err = QuoteSQLite("pipeline1", "myTable")
+	if err != nil || q != `"pipeline1"."myTable"` {
+		t.Fatalf("QuoteSQLite got %s %v", q, err)
+	}
+
+	q, err = QuoteClickHouse("pipeline1", "myTable")
+	if err != nil || q != "`pipeline1`.`myTable`" {
+		t.Fatalf("QuoteClickHouse got %s %v", q, err)
+	}
+
+	q, err = QuoteList(QuoteMySQL, []string{"city", "zip"})
+	if err != nil || q != "`city`, `zip`" {
+		t.Fatalf("QuoteList got %s %v", q, err)
+	}
+
+	_, err = QuotePostgres("pipeline1", `my"table`)
+	if err == nil {
+		t.Fatal("QuotePostgres expected an error for an embedded quote")
+	}
+
+	s := QuoteMySQLString(`it's a \ test`)
+	if s != `'it\'s a \\ test'` {
+		t.Fatalf("QuoteMySQLString got %s", s)
+	}
+}
+
+func TestValidColumnType(t *testing.T) {
+	valid := []string{"TEXT", "VARCHAR(32)", "DOUBLE PRECISION", "DECIMAL(10,2)", "jsonb"}
+	for _, v := range valid {
+		if err := ValidColumnType(v); err != nil {
+			t.Fatalf("ValidColumnType %s Error: %v", v, err)
+		}
+	}
+
+	invalid := []string{"", "TEXT)", "TEXT; DROP TABLE x", "INT, other INT", "VARCHAR(32) NOT NULL DEFAULT 'x'"}
+	for _, v := range invalid {
+		if err := ValidColumnType(v); err == nil {
+			t.Fatalf("ValidColumnType expected an error for %q", v)
+		}
+	}
+}
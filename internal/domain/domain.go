@@ -21,15 +21,68 @@ const DatabaseMysql = "mysql"
 // DatabaseSinglestore ...
 const DatabaseSinglestore = "singlestore"
 
+// DatabasePostgres ...
+const DatabasePostgres = "postgres"
+
+// DatabaseSqlite ...
+const DatabaseSqlite = "sqlite"
+
+// DatabaseClickhouse ...
+const DatabaseClickhouse = "clickhouse"
+
 // DatabaseMock ...
 const DatabaseMock = "mockdb"
 
+// the load modes of an extract source, upsert and replace-partition
+// are keyed on the extract rules marked as key columns
+const (
+	LoadModeAppend           = "append"
+	LoadModeUpsert           = "upsert"
+	LoadModeReplacePartition = "replace-partition"
+)
+
 // MetricFilesProcessed ...
 const MetricFilesProcessed = "Files Processed"
 
 // MetricLastFileProcessed ...
 const MetricLastFileProcessed = "Last File Processed"
 
+// MetricSchemaError holds the column type changes that block loading
+// an extract source, blank when the table matches the extract rules
+const MetricSchemaError = "Schema Error"
+
+// JobStatusFailed is the status of an extract job that failed, the
+// status of other jobs is the phase of their pod
+const JobStatusFailed = "failed"
+
+// the failed directory of an extract source holds the files whose
+// extraction failed, each with an error report named after the file
+// with the FailedReportSuffix
+const (
+	FailedDirectory    = "failed"
+	FailedReportSuffix = ".churro-error.json"
+)
+
+// FailedFile is the error report of a file whose extraction failed
+type FailedFile struct {
+	// ID is the dataprov ID of the failed extraction
+	ID                string `json:"id"`
+	ExtractSourceID   string `json:"extractsourceid"`
+	ExtractSourceName string `json:"extractsourcename"`
+	// FileName is the name of the file in the failed directory, it
+	// is blank for an archive member, the archive is left in place
+	FileName string `json:"filename"`
+	// OriginalPath is where the file is moved back to when requeued
+	OriginalPath  string    `json:"originalpath"`
+	ArchiveMember string    `json:"archivemember,omitempty"`
+	TableName     string    `json:"tablename"`
+	JobName       string    `json:"jobname"`
+	ExtractLogID  string    `json:"extractlogid"`
+	RecordsLoaded int       `json:"recordsloaded"`
+	Error         string    `json:"error"`
+	FailedAt      time.Time `json:"failedat"`
+}
+
 // Extension ...
 type Extension struct {
 	ID              string    `json:"id"`
@@ -39,6 +92,37 @@ type Extension struct {
 	LastUpdated     time.Time `json:"lastupdated"`
 }
 
+// Lookup enriches the rows of an extract source with columns of a
+// reference table in the pipeline database, or of a CSV file with a
+// header row in the pipeline PVC, matching rows on MatchColumns.
+// MatchColumns and Columns are comma separated column=reference pairs,
+// a name alone pairs columns of the same name.
+type Lookup struct {
+	ID              string    `json:"id"`
+	ExtractSourceID string    `json:"extractsourceid"`
+	LookupName      string    `json:"lookupname"`
+	ReferenceTable  string    `json:"referencetable"`
+	ReferenceFile   string    `json:"referencefile"`
+	MatchColumns    string    `json:"matchcolumns"`
+	Columns         string    `json:"columns"`
+	LastUpdated     time.Time `json:"lastupdated"`
+}
+
+// Route sends the rows of an extract source that match Predicate to
+// TargetTable, or drops them.  Routes are tried in Position order and
+// the first one matching a row wins, rows no route matches load into
+// the table of the extract source.
+type Route struct {
+	ID              string    `json:"id"`
+	ExtractSourceID string    `json:"extractsourceid"`
+	RouteName       string    `json:"routename"`
+	Position        int       `json:"position"`
+	Predicate       string    `json:"predicate"`
+	TargetTable     string    `json:"targettable"`
+	Drop            bool      `json:"drop"`
+	LastUpdated     time.Time `json:"lastupdated"`
+}
+
 // ExtractRule ...
 type ExtractRule struct {
 	ID                string    `json:"id"`
@@ -48,6 +132,14 @@ type ExtractRule struct {
 	ColumnType        string    `json:"columntype"`
 	MatchValues       string    `json:"matchvalues"`
 	TransformFunction string    `json:"transformfunction"`
+	KeyColumn         bool      `json:"keycolumn"`
+	ColumnFormat      string    `json:"columnformat"`
+	MatchRegex        string    `json:"matchregex"`
+	NotNull           bool      `json:"notnull"`
+	MinValue          string    `json:"minvalue"`
+	MaxValue          string    `json:"maxvalue"`
+	Unique            bool      `json:"unique"`
+	PrivacyPolicy     string    `json:"privacypolicy"`
 	LastUpdated       time.Time `json:"lastupdated"`
 }
 
@@ -67,11 +159,37 @@ type ExtractSource struct {
 	Encoding       string `json:"encoding"`
 	Transport      string `json:"transport"`
 	Servicetype    string `json:"servicetype"`
+	// CSV dialect, blank values use the encoding/csv defaults
+	Delimiter  string `json:"delimiter"`
+	Quote      string `json:"quote"`
+	Comment    string `json:"comment"`
+	LazyQuotes bool   `json:"lazyquotes"`
+	Charset    string `json:"charset"`
+	// rows and bytes per bulk load, zero uses the loader defaults
+	BatchRows  int `json:"batchrows"`
+	BatchBytes int `json:"batchbytes"`
+	// CopyThreshold is the least number of records of a batch loaded
+	// with the bulk copy protocol of the databases supporting it, zero
+	// uses the loader default
+	CopyThreshold int `json:"copythreshold"`
+	// LoadMode is append when blank
+	LoadMode string `json:"loadmode"`
+	// ReloadDuplicates loads a file whose fingerprint matches a file
+	// loaded before, such files are skipped otherwise
+	ReloadDuplicates bool `json:"reloadduplicates"`
+	// RowHash adds a row_hash column holding the SHA-256 of the
+	// extracted values of each row
+	RowHash bool `json:"rowhash"`
+	// DedupWindow is the number of preceding rows of a file a row is
+	// dropped as a duplicate of, zero keeps duplicate rows
+	DedupWindow int `json:"dedupwindow"`
 	// Initialized is calculated, not persisted
 	Initialized  bool                   `json:"initialized"`
 	Running      bool                   `json:"running"`
 	ExtractRules map[string]ExtractRule `json:"extractrules"`
 	Extensions   map[string]Extension   `json:"extensions"`
+	Lookups      map[string]Lookup      `json:"lookups"`
+	Routes       map[string]Route       `json:"routes"`
 	LastUpdated  time.Time              `json:"lastupdated"`
 }
 
@@ -83,11 +201,35 @@ type ExtractSourceMetric struct {
 	LastUpdated     time.Time `json:"lastupdated"`
 }
 
+// SchemaVersion is the set of columns an extract source table had
+// after an extract rule change, Columns holds the JSON of the columns
+type SchemaVersion struct {
+	TableName       string    `json:"tablename"`
+	Version         int       `json:"version"`
+	ExtractSourceID string    `json:"extractsourceid"`
+	Columns         string    `json:"columns"`
+	LastUpdated     time.Time `json:"lastupdated"`
+}
+
+// PrivacyAudit records the privacy policy an extract job applied to a
+// column of the rows of a dataprov
+type PrivacyAudit struct {
+	ID          string    `json:"id"`
+	DataprovID  string    `json:"dataprovid"`
+	TableName   string    `json:"tablename"`
+	ColumnName  string    `json:"columnname"`
+	Policy      string    `json:"policy"`
+	LastUpdated time.Time `json:"lastupdated"`
+}
+
 // DataProvenance ...
 type DataProvenance struct {
-	ID          string
-	Name        string
-	Path        string
+	ID   string
+	Name string
+	Path string
+	// Fingerprint is the SHA-256 of the file contents, it is blank
+	// when the data is not read from a file
+	Fingerprint string
 	LastUpdated time.Time
 }
 
@@ -129,6 +271,8 @@ type JobProfile struct {
 	ID               string `json:"id"`
 	JobName          string `json:"jobname"`
 	RecordsLoaded    int    `json:"recordsloaded"`
+	ConversionErrors int    `json:"conversionerrors"`
+	RecordsRejected  int    `json:"recordsrejected"`
 	DataProvenanceID string `json:"dpid"`
 	DataSource       string `json:"datasource"`
 	StartDate        string `json:"startdate"`
@@ -136,6 +280,7 @@ type JobProfile struct {
 	FileName         string `json:"filename"`
 	TableName        string `json:"tablename"`
 	Status           string `json:"status"`
+	ErrorText        string `json:"errortext"`
 }
 
 // UserPipelineAccess users can have access granted to a pipeline
@@ -148,8 +293,11 @@ type UserPipelineAccess struct {
 
 // TransformFunction ...
 type TransformFunction struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"transformname"`
-	Source      string    `json:"transformsource"`
+	ID     string `json:"id"`
+	Name   string `json:"transformname"`
+	Source string `json:"transformsource"`
+	// Shape is transform.ShapeCell or transform.ShapeRow, it is found
+	// from the function signature when the function is created
+	Shape       string    `json:"shape"`
 	LastUpdated time.Time `json:"lastupdated"`
 }
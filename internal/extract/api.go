@@ -26,7 +26,6 @@ import (
 	extractapi "github.com/churrodata/churro/api/extract"
 	"github.com/churrodata/churro/internal/db"
 	"github.com/churrodata/churro/internal/domain"
-	"github.com/churrodata/churro/internal/transform"
 )
 
 // ExtractAPI Extract from an API that produces json messages...forever!
@@ -63,6 +62,8 @@ func (s *Server) ExtractAPI(ctx context.Context) (err error) {
 		return
 	}
 	log.Info().Msg("inserted Extractlog")
+	// a message that fails to load stops the polling and fails the job
+	loadErr := make(chan error, 1)
 	c := cron.New()
 	cronExpression := "@every 30s"
 	if s.ExtractSource.Cronexpression != "" {
@@ -128,7 +129,7 @@ func (s *Server) ExtractAPI(ctx context.Context) (err error) {
 
 			for row := 0; row < rows; row++ {
 				r := extractapi.GenericRow{
-					Key: time.Now().UnixNano(),
+					Key: nextRowKey(),
 				}
 				r.Cols = make([]interface{}, 0)
 				for cell := 0; cell < len(allCols); cell++ {
@@ -137,7 +138,7 @@ func (s *Server) ExtractAPI(ctx context.Context) (err error) {
 
 				// here is where we would transform...the r
 				log.Info().Msg(fmt.Sprintf("cols going into transform %v\n", r.Cols))
-				err := transform.RunRules(jsonStruct.ColumnNames, r.Cols, s.ExtractSource.ExtractRules, s.TransformFunctions)
+				err := s.runRules(jsonStruct.ColumnNames, r.Cols)
 				if err != nil {
 					log.Error().Stack().Err(err)
 				}
@@ -161,13 +162,18 @@ func (s *Server) ExtractAPI(ctx context.Context) (err error) {
 
 		// write the message
 		msg := extractapi.LoaderMessage{
-			Key:        time.Now().UnixNano(),
+			Key:        nextRowKey(),
 			Metadata:   someBytes,
 			DataFormat: extractapi.APIScheme,
 		}
 
-		s.process(jobProfile, churroDB, s.Pi.Spec.DataSource.Database, msg)
-
+		_, err = s.process(jobProfile, churroDB, s.Pi.Spec.DataSource.Database, msg)
+		if err != nil {
+			select {
+			case loadErr <- err:
+			default:
+			}
+		}
 	})
 
 	c.Start()
@@ -178,9 +184,19 @@ func (s *Server) ExtractAPI(ctx context.Context) (err error) {
 			log.Info().Msg(fmt.Sprintf("stopping the API, using APIStopTime of %d", s.APIStopTime))
 			time.Sleep(time.Second * time.Duration(s.APIStopTime))
 			c.Stop()
-			return nil
+			select {
+			case err := <-loadErr:
+				return err
+			default:
+				return nil
+			}
+		}
+		select {
+		case err := <-loadErr:
+			c.Stop()
+			return err
+		case <-time.After(30 * time.Second):
 		}
-		time.Sleep(30 * time.Second)
 	}
 
 	return nil
@@ -0,0 +1,128 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package extract
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db"
+	"github.com/churrodata/churro/internal/domain"
+)
+
+// batch limits used when an extract source does not set its own
+const (
+	defaultBatchRows  = 1000
+	defaultBatchBytes = 4 * 1024 * 1024
+)
+
+// lastRowKey is the primary key of the last row extracted
+var lastRowKey int64
+
+// nextRowKey returns the primary key of an extracted row.  Keys follow
+// the clock but always increase, rows read within the same nanosecond
+// or while the clock steps back get the next key instead.
+func nextRowKey() int64 {
+	for {
+		last := atomic.LoadInt64(&lastRowKey)
+		key := time.Now().UnixNano()
+		if key <= last {
+			key = last + 1
+		}
+		if atomic.CompareAndSwapInt64(&lastRowKey, last, key) {
+			return key
+		}
+	}
+}
+
+// recordBatch accumulates extracted rows until either the row or the
+// byte limit of the extract source is reached
+type recordBatch struct {
+	maxRows  int
+	maxBytes int
+	bytes    int
+	records  []extractapi.GenericRow
+}
+
+func newRecordBatch(es domain.ExtractSource) *recordBatch {
+	b := &recordBatch{
+		maxRows:  es.BatchRows,
+		maxBytes: es.BatchBytes,
+	}
+	if b.maxRows <= 0 {
+		b.maxRows = defaultBatchRows
+	}
+	if b.maxBytes <= 0 {
+		b.maxBytes = defaultBatchBytes
+	}
+	b.reset()
+	return b
+}
+
+// add appends a row, size is the number of bytes the row used in
+// the source file
+func (b *recordBatch) add(r extractapi.GenericRow, size int) {
+	b.records = append(b.records, r)
+	b.bytes += size
+}
+
+func (b *recordBatch) full() bool {
+	return len(b.records) >= b.maxRows || b.bytes >= b.maxBytes
+}
+
+func (b *recordBatch) empty() bool {
+	return len(b.records) == 0
+}
+
+func (b *recordBatch) reset() {
+	b.records = make([]extractapi.GenericRow, 0)
+	b.bytes = 0
+}
+
+// loadBatch loads the records of format in a single bulk insert and
+// then updates the job profile with the rows that were committed
+func (s *Server) loadBatch(jobProfile *domain.JobProfile, churroDB db.ChurroDatabase, format extractapi.GenericFormat, scheme string) error {
+	log.Info().Msg(fmt.Sprintf("loading a batch of %d %s records", len(format.Records), scheme))
+
+	b, err := json.Marshal(format)
+	if err != nil {
+		return err
+	}
+	msg := extractapi.LoaderMessage{
+		Metadata:   b,
+		DataFormat: scheme,
+	}
+
+	loaded, err := s.process(*jobProfile, churroDB, s.Pi.Spec.DataSource.Database, msg)
+	if err != nil {
+		return err
+	}
+
+	// rows dropped by quality, dedup, routes or row functions are not
+	// in the count load returns
+	jobProfile.RecordsLoaded += int(loaded)
+	if s.conversions != nil {
+		jobProfile.ConversionErrors = s.conversions.total()
+	}
+	if s.quality != nil {
+		jobProfile.RecordsRejected = s.quality.total()
+	}
+	err = churroDB.UpdateExtractLog(*jobProfile)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in updating the extract log")
+	}
+	return nil
+}
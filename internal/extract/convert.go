@@ -0,0 +1,103 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package extract
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/rs/zerolog/log"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/domain"
+)
+
+// conversionCount counts the values of a job that could not be
+// converted to their column type
+type conversionCount struct {
+	errors int64
+}
+
+// add counts n more values that did not convert
+func (c *conversionCount) add(n int) {
+	atomic.AddInt64(&c.errors, int64(n))
+}
+
+// total returns the values of the job that did not convert
+func (c *conversionCount) total() int {
+	return int(atomic.LoadInt64(&c.errors))
+}
+
+// convertRecords converts the values of the records to their column
+// types using the column format of the extract rules.  A record with a
+// value that does not convert is returned as a reject row giving the
+// conversion errors instead of being loaded, the values that did not
+// convert are added to the job's conversion errors.  A column of a
+// legacy type the extract can not convert to is loaded as extracted.
+func (s *Server) convertRecords(cols []string, records []extractapi.GenericRow, colTypes []string) (passed []extractapi.GenericRow, rejects []extractapi.GenericRow) {
+	formats := make(map[string]string)
+	for _, r := range s.ExtractSource.ExtractRules {
+		formats[r.ColumnName] = r.ColumnFormat
+	}
+
+	// an INT column is 64 bit in cockroach, postgres creates them as
+	// bigint to match
+	dbType := s.Pi.Spec.DatabaseType
+	wideInt := dbType == domain.DatabaseCockroach || dbType == domain.DatabasePostgres
+
+	types := make([]string, len(colTypes))
+	for j := range colTypes {
+		types[j] = extractapi.NormalizeColumnType(colTypes[j])
+		if extractapi.ValidateColumnType(types[j], "") != nil {
+			types[j] = ""
+			continue
+		}
+		if wideInt && extractapi.BaseColumnType(types[j]) == extractapi.COLTYPE_INT {
+			types[j] = extractapi.COLTYPE_BIGINT
+		}
+	}
+
+	failed := 0
+	passed = make([]extractapi.GenericRow, 0, len(records))
+	for i := range records {
+		values := make([]interface{}, len(records[i].Cols))
+		copy(values, records[i].Cols)
+		reasons := make([]string, 0)
+		for j := range values {
+			if j >= len(cols) || j >= len(types) {
+				break
+			}
+			if types[j] == "" {
+				continue
+			}
+			v, err := extractapi.ConvertValue(types[j], formats[cols[j]], values[j])
+			if err != nil {
+				log.Error().Msg(fmt.Sprintf("column %s value %v is not a %s %s", cols[j], values[j], colTypes[j], err.Error()))
+				reasons = append(reasons, fmt.Sprintf("%s value %v is not a %s %s", cols[j], values[j], colTypes[j], err.Error()))
+				failed++
+			}
+			values[j] = v
+		}
+		if len(reasons) > 0 {
+			rejects = append(rejects, s.rejectRow(cols, records[i], strings.Join(reasons, "; ")))
+			continue
+		}
+		records[i].Cols = values
+		passed = append(passed, records[i])
+	}
+
+	if s.conversions == nil {
+		s.conversions = &conversionCount{}
+	}
+	s.conversions.add(failed)
+	return passed, rejects
+}
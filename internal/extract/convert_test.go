@@ -0,0 +1,151 @@
+package extract
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/domain"
+)
+
+func TestConvertRecords(t *testing.T) {
+	s := Server{
+		ExtractSource: domain.ExtractSource{
+			ExtractRules: map[string]domain.ExtractRule{
+				"1": {ColumnName: "shipped", ColumnType: extractapi.COLTYPE_DATE, ColumnFormat: "01/02/2006"},
+				"2": {ColumnName: "updated", ColumnType: extractapi.COLTYPE_TIMESTAMP, ColumnFormat: extractapi.FormatUnix},
+			},
+		},
+	}
+	cols := []string{"sku", "qty", "price", "active", "shipped", "updated", "attrs"}
+	colTypes := []string{
+		extractapi.COLTYPE_VARCHAR,
+		extractapi.COLTYPE_INT,
+		extractapi.COLTYPE_DECIMAL,
+		extractapi.COLTYPE_BOOLEAN,
+		extractapi.COLTYPE_DATE,
+		extractapi.COLTYPE_TIMESTAMP,
+		extractapi.COLTYPE_JSONB,
+	}
+	records := []extractapi.GenericRow{
+		{Key: 1, Cols: []interface{}{"a1", "12", "9.99", "yes", "03/15/2021", "1615766400", `{"color":"red"}`}},
+		{Key: 2, Cols: []interface{}{"a2", "twelve", "9,99", "maybe", "2021-03-15", "noon", `{"color":`}},
+		{Key: 3, Cols: []interface{}{"a3", "", "null", nil, "", "", ""}},
+	}
+
+	passed, rejects := s.convertRecords(cols, records, colTypes)
+	if s.conversions.total() != 6 {
+		t.Fatalf("conversions got %d expected 6", s.conversions.total())
+	}
+	if len(passed) != 2 || len(rejects) != 1 {
+		t.Fatalf("convertRecords passed %v rejected %v", passed, rejects)
+	}
+
+	got := passed[0].Cols
+	shipped := time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC)
+	if got[1] != int64(12) || got[2] != "9.99" || got[3] != true || got[4] != shipped || got[6] != `{"color":"red"}` {
+		t.Fatalf("convertRecords got %#v", got)
+	}
+	if updated, ok := got[5].(time.Time); !ok || updated.Unix() != 1615766400 {
+		t.Fatalf("convertRecords got updated %#v", got[5])
+	}
+	// blank and null values load as null
+	for i := 1; i < len(passed[1].Cols); i++ {
+		if passed[1].Cols[i] != nil {
+			t.Fatalf("convertRecords got %s %#v expected null", cols[i], passed[1].Cols[i])
+		}
+	}
+
+	// the rejected record is quarantined as extracted with the errors
+	reject := rejects[0]
+	reason, _ := reject.Cols[1].(string)
+	if reject.Key != 2 || !strings.Contains(reason, "qty value twelve is not a INT") || !strings.Contains(reason, "attrs") {
+		t.Fatalf("convertRecords reject %v", reject.Cols)
+	}
+	if record, _ := reject.Cols[2].(string); !strings.Contains(record, `"twelve"`) {
+		t.Fatalf("convertRecords reject record %v", reject.Cols[2])
+	}
+
+	// a VARCHAR value longer than the column does not convert
+	long := []extractapi.GenericRow{{Cols: []interface{}{"abcdefghijklmnopqrstuvwxyz0123456789"}}}
+	if passed, rejects := s.convertRecords(cols[:1], long, colTypes[:1]); len(passed) != 0 || len(rejects) != 1 {
+		t.Fatalf("convertRecords passed a long VARCHAR %v", passed)
+	}
+
+	// a legacy type is normalized, one that can not be converted to
+	// loads as extracted
+	legacy := []extractapi.GenericRow{{Cols: []interface{}{"7", "$1.00"}}}
+	passed, rejects = s.convertRecords([]string{"qty", "price"}, legacy, []string{"integer", "MONEY"})
+	if len(rejects) != 0 || passed[0].Cols[0] != int64(7) || passed[0].Cols[1] != "$1.00" {
+		t.Fatalf("convertRecords of legacy types got %v %v", passed, rejects)
+	}
+}
+
+func TestConvertParameterizedTypes(t *testing.T) {
+	v, err := extractapi.ConvertValue("DECIMAL(10,2)", "", "12.34")
+	if err != nil || v != "12.34" {
+		t.Fatalf("ConvertValue DECIMAL(10,2) got %#v %v", v, err)
+	}
+	if _, err := extractapi.ConvertValue("decimal(10, 2)", "", "12,34"); err == nil {
+		t.Fatal("ConvertValue DECIMAL(10,2) expected an error for 12,34")
+	}
+	if err := extractapi.ValidateColumnType("DECIMAL(10,2)", ""); err != nil {
+		t.Fatalf("ValidateColumnType DECIMAL(10,2) Error: %v", err)
+	}
+
+	// INT is 32 bit unless the database creates it as 64 bit
+	cols := []string{"qty"}
+	colTypes := []string{extractapi.COLTYPE_INT}
+	for dbType, want := range map[string]interface{}{
+		// a 32 bit INT is rejected
+		domain.DatabaseMysql:     nil,
+		domain.DatabaseCockroach: int64(3000000000),
+		domain.DatabasePostgres:  int64(3000000000),
+	} {
+		s := Server{}
+		s.Pi.Spec.DatabaseType = dbType
+		records := []extractapi.GenericRow{{Cols: []interface{}{"3000000000"}}}
+		passed, _ := s.convertRecords(cols, records, colTypes)
+		if want == nil && len(passed) != 0 || want != nil && (len(passed) != 1 || passed[0].Cols[0] != want) {
+			t.Fatalf("convertRecords %s got %v expected %#v", dbType, passed, want)
+		}
+	}
+}
+
+func TestValidateColumnType(t *testing.T) {
+	valid := [][2]string{
+		{extractapi.COLTYPE_TEXT, ""},
+		{extractapi.COLTYPE_JSONB, ""},
+		{extractapi.COLTYPE_DATE, "02/01/2006"},
+		{extractapi.COLTYPE_TIMESTAMP, extractapi.FormatUnixMilli},
+		{extractapi.COLTYPE_TIMESTAMP, ""},
+	}
+	for _, v := range valid {
+		if err := extractapi.ValidateColumnType(v[0], v[1]); err != nil {
+			t.Fatalf("ValidateColumnType %q %q Error: %v", v[0], v[1], err)
+		}
+	}
+	for legacy, want := range map[string]string{
+		"integer":       extractapi.COLTYPE_INT,
+		"NUMERIC(10,2)": "DECIMAL(10,2)",
+		"float":         extractapi.COLTYPE_DOUBLE,
+		"MONEY":         "MONEY",
+	} {
+		if got := extractapi.NormalizeColumnType(legacy); got != want {
+			t.Fatalf("NormalizeColumnType %q got %q want %q", legacy, got, want)
+		}
+	}
+
+	invalid := [][2]string{
+		{"MONEY", ""},
+		{extractapi.COLTYPE_INT, "2006-01-02"},
+		{extractapi.COLTYPE_DATE, "15:04:05"},
+		{extractapi.COLTYPE_DATE, "yyyy-mm-dd"},
+	}
+	for _, v := range invalid {
+		if err := extractapi.ValidateColumnType(v[0], v[1]); err == nil {
+			t.Fatalf("ValidateColumnType %q %q expected an error", v[0], v[1])
+		}
+	}
+}
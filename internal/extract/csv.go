@@ -13,20 +13,21 @@ package extract
 import (
 	"context"
 	"encoding/csv"
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
+	"golang.org/x/text/runes"
+	texttransform "golang.org/x/text/transform"
 
 	extractapi "github.com/churrodata/churro/api/extract"
 	"github.com/churrodata/churro/internal/db"
 	"github.com/churrodata/churro/internal/domain"
-	"github.com/churrodata/churro/internal/transform"
 )
 
 // ExtractCSV Extract a CSV file contents and exit
@@ -34,13 +35,19 @@ func (s *Server) ExtractCSV(ctx context.Context) (err error) {
 
 	log.Info().Msg("ExtractCSV starting...")
 
-	csvfile, err := os.Open(s.FileName)
+	csvfile, err := s.openFile()
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("could not open csv file " + s.FileName)
 		return err
 	}
+	defer csvfile.Close()
 
-	r := csv.NewReader(csvfile)
+	dialect, err := extractapi.GetCSVDialect(s.ExtractSource.Delimiter, s.ExtractSource.Quote, s.ExtractSource.Comment, s.ExtractSource.LazyQuotes, s.ExtractSource.Charset)
+	if err != nil {
+		return err
+	}
+
+	r := newCSVReader(csvfile, dialect)
 
 	csvStruct := extractapi.GenericFormat{
 		Path:         s.FileName,
@@ -56,9 +63,6 @@ func (s *Server) ExtractCSV(ctx context.Context) (err error) {
 	log.Info().Msg(fmt.Sprintf("columnTypes %+v", csvStruct.ColumnTypes))
 	log.Info().Msg(fmt.Sprintf("skipheaders %d", s.ExtractSource.Skipheaders))
 
-	//firstRow := true
-	csvStruct.Records = make([]extractapi.GenericRow, 0)
-
 	var churroDB db.ChurroDatabase
 	churroDB, err = db.NewChurroDB(s.Pi.Spec.DatabaseType)
 	if err != nil {
@@ -79,6 +83,24 @@ func (s *Server) ExtractCSV(ctx context.Context) (err error) {
 	}
 	csvStruct.Tablename = s.TableName
 
+	// the job profile is written once and then updated as each
+	// batch commits
+	jobProfile := domain.JobProfile{
+		ID:               os.Getenv("CHURRO_EXTRACTLOG"),
+		JobName:          os.Getenv("POD_NAME"),
+		StartDate:        time.Now().Format("2006-01-02 15:04:05"),
+		DataProvenanceID: s.DP.ID,
+		FileName:         s.FileName,
+		TableName:        s.TableName,
+	}
+	log.Info().Msg(fmt.Sprintf("creating extract log of %v", jobProfile))
+
+	err = churroDB.CreateExtractLog(jobProfile)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in createextractlog")
+	}
+
+	batch := newRecordBatch(s.ExtractSource)
 	var recordsRead int
 
 	for {
@@ -89,70 +111,101 @@ func (s *Server) ExtractCSV(ctx context.Context) (err error) {
 		if err != nil {
 			return err
 		}
+		if dialect.Quote != '"' {
+			unswapCSVQuotes(record, dialect.Quote)
+		}
 
 		recordsRead++
 
 		// process the csv header which we expect to be there
 		if recordsRead <= s.ExtractSource.Skipheaders {
 			log.Info().Msg(fmt.Sprintf("skipping header %d", recordsRead))
-		} else {
-			tmp := make([]interface{}, len(record))
-			for i, v := range record {
-				tmp[i] = v
-			}
+			continue
+		}
 
-			r := getCSVRow(record, csvStruct.Columns)
-			log.Info().Msg(fmt.Sprintf("row from GetCSVRow %v\n", r))
-			err := transform.RunRules(csvStruct.ColumnNames, r.Cols, s.ExtractSource.ExtractRules, s.TransformFunctions)
+		r := getCSVRow(record, csvStruct.Columns)
+		log.Debug().Msg(fmt.Sprintf("row from GetCSVRow %v", r))
+		err = s.runRules(csvStruct.ColumnNames, r.Cols)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("error in runRules")
+		}
+
+		batch.add(r, getCSVRecordSize(record))
+		if batch.full() {
+			csvStruct.Records = batch.records
+			err = s.loadBatch(&jobProfile, churroDB, csvStruct, extractapi.CSVScheme)
 			if err != nil {
-				log.Error().Stack().Err(err).Msg("error in runRules")
+				return err
 			}
+			batch.reset()
+		}
+	}
 
-			csvStruct.Records = append(csvStruct.Records, r)
-			log.Debug().Msg(fmt.Sprintf("csv record read %v", record))
+	if !batch.empty() {
+		csvStruct.Records = batch.records
+		err = s.loadBatch(&jobProfile, churroDB, csvStruct, extractapi.CSVScheme)
+		if err != nil {
+			return err
+		}
+	}
 
-			log.Info().Msg("pushing to Queue")
-			//convert csvStruct into []byte
-			csvBytes, _ := json.Marshal(csvStruct)
-			msg := extractapi.LoaderMessage{
-				Metadata:   csvBytes,
-				DataFormat: extractapi.CSVScheme,
-			}
+	log.Info().Msg(fmt.Sprintf("end of CSV file reached, %d records loaded", jobProfile.RecordsLoaded))
 
-			jobProfile := domain.JobProfile{
-				ID:               os.Getenv("CHURRO_EXTRACTLOG"),
-				JobName:          os.Getenv("POD_NAME"),
-				StartDate:        time.Now().Format("2006-01-02 15:04:05"),
-				DataProvenanceID: s.DP.ID,
-				FileName:         s.FileName,
-				TableName:        s.TableName,
-				RecordsLoaded:    recordsRead,
-			}
-			log.Info().Msg(fmt.Sprintf("creating extract log of %v", jobProfile))
+	return nil
+}
 
-			err = churroDB.CreateExtractLog(jobProfile)
-			if err != nil {
-				log.Error().Stack().Err(err)
-			}
+// newCSVReader applies the extract source dialect to a csv.Reader,
+// the file is decoded to UTF-8 first when a charset is given
+func newCSVReader(f io.Reader, dialect extractapi.CSVDialect) *csv.Reader {
+	var in io.Reader = f
+	if dialect.Charset != nil {
+		in = texttransform.NewReader(in, dialect.Charset.NewDecoder())
+	}
+	// encoding/csv only knows the double quote, so a custom quote
+	// character is swapped with it before parsing and swapped back
+	// in each record by unswapCSVQuotes
+	if dialect.Quote != '"' {
+		in = texttransform.NewReader(in, runes.Map(swapQuote(dialect.Quote)))
+	}
 
-			s.process(jobProfile, churroDB, s.Pi.Spec.DataSource.Database, msg)
-			csvStruct.Records = make([]extractapi.GenericRow, 0)
-		}
+	r := csv.NewReader(in)
+	r.Comma = dialect.Delimiter
+	r.Comment = dialect.Comment
+	r.LazyQuotes = dialect.LazyQuotes
+	return r
+}
 
+func swapQuote(quote rune) func(rune) rune {
+	return func(c rune) rune {
+		switch c {
+		case quote:
+			return '"'
+		case '"':
+			return quote
+		}
+		return c
 	}
+}
 
-	log.Info().Msg("end of CSV file reached, cancelling pushes...")
-
-	// TODO , here is where we insert a row into the extractlog table
-	// dataprov_id, podname, poddate, recordsloaded
-	// dp.Id, env var POD_NAME, (current time)poddate, recordsloaded
+func unswapCSVQuotes(record []string, quote rune) {
+	for i := 0; i < len(record); i++ {
+		record[i] = strings.Map(swapQuote(quote), record[i])
+	}
+}
 
-	return err
+// getCSVRecordSize approximates the bytes a record used in the file,
+// one delimiter or line ending is counted per field
+func getCSVRecordSize(record []string) int {
+	size := len(record)
+	for i := 0; i < len(record); i++ {
+		size += len(record[i])
+	}
+	return size
 }
 
 func getCSVRow(record []string, cols []extractapi.Column) extractapi.GenericRow {
 	csvRow := extractapi.GenericRow{
-		Key:  time.Now().UnixNano(),
+		Key:  nextRowKey(),
 		Cols: make([]interface{}, len(cols)),
 	}
 	for i := 0; i < len(cols); i++ {
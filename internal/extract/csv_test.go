@@ -1,16 +1,24 @@
 package extract
 
 import (
+	"bytes"
 	"context"
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	extractapi "github.com/churrodata/churro/api/extract"
 	"github.com/churrodata/churro/api/v1alpha1"
+	"github.com/churrodata/churro/internal/db"
+	"github.com/churrodata/churro/internal/db/sqlite"
 	"github.com/churrodata/churro/internal/domain"
 	"github.com/churrodata/churro/pkg/config"
+	"github.com/rs/zerolog"
+	zlog "github.com/rs/zerolog/log"
 )
 
 func TestExtractCSV(t *testing.T) {
@@ -76,3 +84,188 @@ num,city,zip
 	}
 
 }
+
+func TestCSVDialect(t *testing.T) {
+
+	// a latin-1 encoded, semicolon separated file using single quotes
+	// and a # comment line
+	content := []byte("# export\n'num';'city';'note'\n2;'K\xf6ln';'say \"hi\"'\n3;M\xfcnchen;'it''s'\n")
+
+	dialect, err := extractapi.GetCSVDialect(";", "'", "#", false, extractapi.CharsetLatin1)
+	if err != nil {
+		t.Fatalf("extract.GetCSVDialect Error: %v", err)
+	}
+
+	r := newCSVReader(bytes.NewReader(content), dialect)
+	records, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("csv ReadAll Error: %v", err)
+	}
+	for i := 0; i < len(records); i++ {
+		unswapCSVQuotes(records[i], dialect.Quote)
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records got %d", len(records))
+	}
+	if records[1][1] != "Köln" || records[1][2] != `say "hi"` {
+		t.Fatalf("unexpected record %v", records[1])
+	}
+	if records[2][1] != "München" || records[2][2] != "it's" {
+		t.Fatalf("unexpected record %v", records[2])
+	}
+
+	badDialects := [][]string{
+		{";", ";", "", ""},
+		{"ab", "", "", ""},
+		{"\n", "", "", ""},
+		{"", "", "\"", ""},
+		{"", "", "", "ebcdic"},
+	}
+	for _, d := range badDialects {
+		_, err = extractapi.GetCSVDialect(d[0], d[1], d[2], false, d[3])
+		if err == nil {
+			t.Fatalf("extract.GetCSVDialect expected an error for %q", d)
+		}
+	}
+}
+
+func TestCSVBatches(t *testing.T) {
+
+	b := newRecordBatch(domain.ExtractSource{})
+	if b.maxRows != defaultBatchRows || b.maxBytes != defaultBatchBytes {
+		t.Fatalf("expected default batch limits got %d rows %d bytes", b.maxRows, b.maxBytes)
+	}
+
+	b = newRecordBatch(domain.ExtractSource{BatchRows: 2, BatchBytes: 100})
+	b.add(extractapi.GenericRow{}, 10)
+	if b.full() {
+		t.Fatal("batch should not be full after one row")
+	}
+	b.add(extractapi.GenericRow{}, 10)
+	if !b.full() {
+		t.Fatal("batch should be full at the row limit")
+	}
+	b.reset()
+	if !b.empty() || b.bytes != 0 {
+		t.Fatal("batch should be empty after reset")
+	}
+	b.add(extractapi.GenericRow{}, 150)
+	if !b.full() {
+		t.Fatal("batch should be full at the byte limit")
+	}
+
+	// 5 data rows in batches of 2 loads 2 full batches and a final
+	// partial batch, the second 3,c is dropped as a duplicate so 4
+	// rows are loaded
+	dir := t.TempDir()
+	os.Setenv("CHURRO_NAMESPACE", "pipeline1")
+	os.Setenv("CHURRO_EXTRACTLOG", "csvbatchjob")
+	defer os.Unsetenv("CHURRO_EXTRACTLOG")
+	fileName := filepath.Join(dir, "batches.csv")
+	err := ioutil.WriteFile(fileName, []byte("num,city\n1,a\n2,b\n3,c\n3,c\n4,d\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pipeline := v1alpha1.Pipeline{
+		Spec: v1alpha1.PipelineSpec{
+			DatabaseType: domain.DatabaseSqlite,
+			DataSource:   v1alpha1.Source{Path: dir, Database: "pipeline1", Username: "pipeline1"},
+		},
+	}
+	pipeline.Name = "pipeline1"
+
+	churroDB, err := db.NewChurroDB(domain.DatabaseSqlite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := churroDB.GetConnection(config.DBCredentials{}, pipeline.Spec.DataSource); err != nil {
+		t.Fatal(err)
+	}
+	if err := churroDB.CreatePipelineObjects("pipeline1", "pipeline1"); err != nil {
+		t.Fatal(err)
+	}
+
+	extractRules := map[string]domain.ExtractRule{
+		"rule1": {ID: "rule1", ExtractSourceID: "one", ColumnName: "num", ColumnPath: "0", ColumnType: "TEXT"},
+		"rule2": {ID: "rule2", ExtractSourceID: "one", ColumnName: "city", ColumnPath: "1", ColumnType: "TEXT"},
+	}
+	s := Server{
+		FileName: fileName,
+		Pi:       pipeline,
+		ExtractSource: domain.ExtractSource{
+			ID:           "one",
+			Name:         "my-csv-files",
+			Path:         dir,
+			Scheme:       extractapi.CSVScheme,
+			ExtractRules: extractRules,
+			Tablename:    "mycsvtable",
+			Skipheaders:  1,
+			BatchRows:    2,
+			RowHash:      true,
+			DedupWindow:  1,
+		},
+		TableName:   "mycsvtable",
+		SchemeValue: extractapi.CSVScheme,
+	}
+
+	// each batch logs its size as it loads
+	var logged bytes.Buffer
+	saved := zlog.Logger
+	zlog.Logger = zerolog.New(&logged)
+	err = s.ExtractCSV(context.TODO())
+	zlog.Logger = saved
+	if err != nil {
+		t.Fatalf("extract.ExtractCSV Error: %v", err)
+	}
+	if n := strings.Count(logged.String(), "loading a batch of"); n != 3 {
+		t.Fatalf("loaded %d batches, want 3", n)
+	}
+
+	jp, err := churroDB.GetExtractLogById("csvbatchjob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jp.RecordsLoaded != 4 {
+		t.Fatalf("extract log has %d records loaded, want 4", jp.RecordsLoaded)
+	}
+	var rows int
+	conn := churroDB.(*sqlite.SqliteChurroDatabase).Connection
+	if err := conn.QueryRow("select count(*) from mycsvtable").Scan(&rows); err != nil {
+		t.Fatal(err)
+	}
+	if rows != 4 {
+		t.Fatalf("table has %d rows, want 4", rows)
+	}
+}
+
+func TestNextRowKey(t *testing.T) {
+	// rows read at once in several goroutines never share a key
+	const goroutines, rows = 4, 10000
+	keys := make([][]int64, goroutines)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < rows; i++ {
+				keys[g] = append(keys[g], nextRowKey())
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	seen := make(map[int64]bool, goroutines*rows)
+	for g := range keys {
+		for i, k := range keys[g] {
+			if seen[k] {
+				t.Fatalf("key %d given to two rows", k)
+			}
+			seen[k] = true
+			if i > 0 && k <= keys[g][i-1] {
+				t.Fatalf("key %d follows key %d", k, keys[g][i-1])
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package extract
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db"
+	"github.com/churrodata/churro/internal/domain"
+)
+
+// maxErrorText bounds the error kept in the extract log of a failed job
+const maxErrorText = 1000
+
+// failedDir returns the failed directory of the extract source, in the
+// directory it watches, which is not watched itself
+func (s *Server) failedDir() string {
+	dir := s.ExtractSource.Path
+	if dir == "" {
+		dir = filepath.Dir(s.FileName)
+	}
+	return filepath.Join(dir, domain.FailedDirectory)
+}
+
+// failFile handles a job whose extraction failed.  The job is marked
+// failed in the extract log and the fingerprint of its dataprov is
+// cleared so the file can be requeued, the file is then moved to the
+// failed directory of the extract source with an error report.
+func (s *Server) failFile(path string, extractErr error) {
+	report := domain.FailedFile{
+		ID:                s.DP.ID,
+		ExtractSourceID:   s.ExtractSource.ID,
+		ExtractSourceName: s.ExtractSource.Name,
+		OriginalPath:      path,
+		ArchiveMember:     s.ArchiveMember,
+		TableName:         s.TableName,
+		JobName:           os.Getenv("POD_NAME"),
+		ExtractLogID:      os.Getenv("CHURRO_EXTRACTLOG"),
+		Error:             extractErr.Error(),
+		FailedAt:          time.Now(),
+	}
+
+	churroDB, err := db.NewChurroDB(s.Pi.Spec.DatabaseType)
+	if err == nil {
+		err = churroDB.GetConnection(s.DBCreds, s.Pi.Spec.DataSource)
+	}
+	if err == nil {
+		err = s.markFailed(churroDB, &report)
+	}
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error marking the job failed")
+	}
+
+	// API extract sources have no file to move
+	if s.SchemeValue == extractapi.APIScheme || s.SchemeValue == extractapi.HTTPPostScheme {
+		return
+	}
+	err = moveToFailed(s.failedDir(), &report)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error moving the file to the failed directory")
+		return
+	}
+	log.Info().Msg(fmt.Sprintf("moved failed file %s to %s", path, s.failedDir()))
+}
+
+// markFailed sets the status of the extract log of the job to failed,
+// creating the log when the job failed before it was written, and
+// clears the fingerprint of the dataprov of the job
+func (s *Server) markFailed(churroDB db.ChurroDatabase, report *domain.FailedFile) error {
+	jp, err := churroDB.GetExtractLogById(report.ExtractLogID)
+	if err != nil {
+		jp = domain.JobProfile{
+			ID:               report.ExtractLogID,
+			JobName:          report.JobName,
+			StartDate:        report.FailedAt.Format("2006-01-02 15:04:05"),
+			DataProvenanceID: report.ID,
+			FileName:         s.FileName,
+			TableName:        report.TableName,
+		}
+		err = churroDB.CreateExtractLog(jp)
+		if err != nil {
+			return err
+		}
+	}
+	report.RecordsLoaded = jp.RecordsLoaded
+
+	errorText := report.Error
+	if len(errorText) > maxErrorText {
+		errorText = errorText[:maxErrorText]
+	}
+	err = churroDB.UpdateExtractLogStatus(report.ExtractLogID, domain.JobStatusFailed, errorText)
+	if err != nil {
+		return err
+	}
+	return churroDB.ClearDataprovFingerprint(report.ID)
+}
+
+// moveToFailed moves the file of a failed job to the failed directory
+// and writes the error report next to it.  The names are prefixed with
+// the dataprov ID so failures of files with the same name are kept
+// apart.  An archive member is not moved, the archive holds the other
+// members, only its report is written.
+func moveToFailed(dir string, report *domain.FailedFile) error {
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	name := report.ID + "-" + filepath.Base(report.OriginalPath)
+	if report.ArchiveMember != "" {
+		name = report.ID + "-" + filepath.Base(report.ArchiveMember)
+	} else {
+		err = os.Rename(report.OriginalPath, filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		report.FileName = name
+	}
+
+	b, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, name+domain.FailedReportSuffix), b, 0644)
+}
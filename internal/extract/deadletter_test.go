@@ -0,0 +1,60 @@
+package extract
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/churrodata/churro/internal/domain"
+)
+
+func TestMoveToFailed(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "data.csv")
+	if err := os.WriteFile(path, []byte("a,b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	failed := filepath.Join(dir, domain.FailedDirectory)
+
+	report := domain.FailedFile{ID: "dp1", OriginalPath: path, Error: "bad row"}
+	if err := moveToFailed(failed, &report); err != nil {
+		t.Fatalf("moveToFailed Error: %v", err)
+	}
+	if report.FileName != "dp1-data.csv" {
+		t.Fatalf("moveToFailed file name %q", report.FileName)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatal("moveToFailed should move the file")
+	}
+	if _, err := os.Stat(filepath.Join(failed, report.FileName)); err != nil {
+		t.Fatalf("moveToFailed file %v", err)
+	}
+	b, err := os.ReadFile(filepath.Join(failed, "dp1-data.csv"+domain.FailedReportSuffix))
+	if err != nil {
+		t.Fatalf("moveToFailed report %v", err)
+	}
+	var got domain.FailedFile
+	if err := json.Unmarshal(b, &got); err != nil || got.Error != "bad row" || got.OriginalPath != path {
+		t.Fatalf("moveToFailed report %s %v", b, err)
+	}
+
+	// an archive member leaves the archive in place
+	archive := filepath.Join(dir, "data.zip.churro-processed")
+	if err := os.WriteFile(archive, []byte("zip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	report = domain.FailedFile{ID: "dp2", OriginalPath: archive, ArchiveMember: "in/b.csv", Error: "bad member"}
+	if err := moveToFailed(failed, &report); err != nil {
+		t.Fatalf("moveToFailed member Error: %v", err)
+	}
+	if report.FileName != "" {
+		t.Fatalf("moveToFailed member file name %q", report.FileName)
+	}
+	if _, err := os.Stat(archive); err != nil {
+		t.Fatalf("moveToFailed should not move the archive %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(failed, "dp2-b.csv"+domain.FailedReportSuffix)); err != nil {
+		t.Fatalf("moveToFailed member report %v", err)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package extract
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db"
+	"github.com/churrodata/churro/internal/privacy"
+)
+
+// rowHashColumn holds the SHA-256 of the extracted values of a row when
+// the extract source has RowHash set, keyed with the privacy salt when
+// the extract source has privacy policies
+const rowHashColumn = "row_hash"
+
+// rowHash returns the SHA-256 of the values of a row, the HMAC-SHA-256
+// when a salt is given
+func rowHash(values []interface{}, salt []byte) string {
+	b, _ := json.Marshal(values)
+	if len(salt) > 0 {
+		mac := hmac.New(sha256.New, salt)
+		mac.Write(b)
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// rowHashSalt returns the privacy salt when the row hash is stored and
+// the extract source has privacy policies.  The row hash is of the
+// values before the policies run, unkeyed it could be reversed by
+// hashing guessed values.
+func (s *Server) rowHashSalt() ([]byte, error) {
+	if !s.ExtractSource.RowHash {
+		return nil, nil
+	}
+	columns, err := getPrivacyColumns(s.ExtractSource)
+	if err != nil || len(columns) == 0 {
+		return nil, err
+	}
+	keys := s.privacyKeys()
+	if len(keys.Salt) == 0 {
+		return nil, fmt.Errorf("%w: the row hash of an extract source with privacy policies needs %s in secret %s", privacy.ErrNoKey, privacy.SaltKey, privacy.SecretName)
+	}
+	return keys.Salt, nil
+}
+
+// dedupState holds the hashes of the last rows of a job, a ring of at
+// most DedupWindow hashes with the number of times each is in it
+type dedupState struct {
+	mu     sync.Mutex
+	ring   []string
+	next   int
+	counts map[string]int
+	added  bool
+}
+
+// seen reports whether the hash is one of the last window hashes, the
+// hash is then added as the latest
+func (d *dedupState) seen(hash string, window int) bool {
+	if d.counts == nil {
+		d.counts = make(map[string]int)
+	}
+	found := d.counts[hash] > 0
+
+	if len(d.ring) < window {
+		d.ring = append(d.ring, hash)
+	} else {
+		d.counts[d.ring[d.next]]--
+		if d.counts[d.ring[d.next]] == 0 {
+			delete(d.counts, d.ring[d.next])
+		}
+		d.ring[d.next] = hash
+		d.next = (d.next + 1) % window
+	}
+	d.counts[hash]++
+	return found
+}
+
+// hashRows drops the records with the values of one of the preceding
+// DedupWindow records of the job and, when the extract source has
+// RowHash set, appends the row_hash column to the table and records.
+// The hash is of the values as extracted, before any enrichment, and is
+// keyed with the privacy salt when the extract source has privacy
+// policies.
+func (s *Server) hashRows(churroDB db.ChurroDatabase, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string) ([]string, []extractapi.GenericRow, []string, error) {
+	window := s.ExtractSource.DedupWindow
+	if !s.ExtractSource.RowHash && window <= 0 {
+		return cols, records, colTypes, nil
+	}
+	salt, err := s.rowHashSalt()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if s.dedup == nil {
+		s.dedup = &dedupState{}
+	}
+	s.dedup.mu.Lock()
+	defer s.dedup.mu.Unlock()
+
+	kept := make([]extractapi.GenericRow, 0, len(records))
+	hashes := make([]string, 0, len(records))
+	for _, r := range records {
+		hash := rowHash(r.Cols, salt)
+		if window > 0 && s.dedup.seen(hash, window) {
+			continue
+		}
+		kept = append(kept, r)
+		hashes = append(hashes, hash)
+	}
+	if dropped := len(records) - len(kept); dropped > 0 {
+		log.Info().Msg(fmt.Sprintf("%d duplicate rows dropped", dropped))
+	}
+
+	if !s.ExtractSource.RowHash {
+		return cols, kept, colTypes, nil
+	}
+	for _, c := range cols {
+		if c == rowHashColumn {
+			return nil, nil, nil, fmt.Errorf("column %s is extracted and can not hold the row hash", rowHashColumn)
+		}
+	}
+	if !s.dedup.added {
+		err := addTableColumns(churroDB, database, tableName, []string{rowHashColumn}, []string{extractapi.COLTYPE_TEXT})
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		s.dedup.added = true
+	}
+
+	outCols := append(append([]string{}, cols...), rowHashColumn)
+	outTypes := append(append([]string{}, colTypes...), extractapi.COLTYPE_TEXT)
+	for i := range kept {
+		kept[i].Cols = append(append(make([]interface{}, 0, len(kept[i].Cols)+1), kept[i].Cols...), hashes[i])
+	}
+	return outCols, kept, outTypes, nil
+}
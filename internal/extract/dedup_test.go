@@ -0,0 +1,97 @@
+package extract
+
+import (
+	"errors"
+	"testing"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db/mockdb"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/churrodata/churro/internal/privacy"
+)
+
+func TestHashRows(t *testing.T) {
+	s := Server{
+		ExtractSource: domain.ExtractSource{RowHash: true, DedupWindow: 2},
+	}
+	churroDB := &mockdb.MockChurroDatabase{}
+	cols := []string{"id", "name"}
+	types := []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_TEXT}
+	batch1 := []extractapi.GenericRow{
+		{Key: 1, Cols: []interface{}{"1", "a"}},
+		{Key: 2, Cols: []interface{}{"1", "a"}},
+		{Key: 3, Cols: []interface{}{"2", "b"}},
+	}
+
+	outCols, records, outTypes, err := s.hashRows(churroDB, "db", "t", cols, batch1, types)
+	if err != nil {
+		t.Fatalf("hashRows Error: %v", err)
+	}
+	if len(outCols) != 3 || outCols[2] != rowHashColumn || outTypes[2] != extractapi.COLTYPE_TEXT || len(cols) != 2 {
+		t.Fatalf("hashRows cols %v types %v", outCols, outTypes)
+	}
+	if len(records) != 2 || records[0].Key != 1 || records[1].Key != 3 {
+		t.Fatalf("hashRows records %v", records)
+	}
+	if records[0].Cols[2] != rowHash([]interface{}{"1", "a"}, nil) || len(batch1[0].Cols) != 2 {
+		t.Fatalf("hashRows row hash %v", records[0].Cols)
+	}
+
+	// the window spans batches, 1,a is three rows back so it is kept
+	batch2 := []extractapi.GenericRow{
+		{Key: 4, Cols: []interface{}{"2", "b"}},
+		{Key: 5, Cols: []interface{}{"3", "c"}},
+		{Key: 6, Cols: []interface{}{"1", "a"}},
+	}
+	_, records, _, err = s.hashRows(churroDB, "db", "t", cols, batch2, types)
+	if err != nil {
+		t.Fatalf("hashRows Error: %v", err)
+	}
+	if len(records) != 2 || records[0].Key != 5 || records[1].Key != 6 {
+		t.Fatalf("hashRows second batch records %v", records)
+	}
+
+	s = Server{ExtractSource: domain.ExtractSource{RowHash: true}}
+	_, records, _, err = s.hashRows(churroDB, "db", "t", cols, batch1, types)
+	if err != nil || len(records) != 3 {
+		t.Fatalf("hashRows without a window got %v %v", records, err)
+	}
+	if _, _, _, err = s.hashRows(churroDB, "db", "t", []string{"id", rowHashColumn}, batch1, types); err == nil {
+		t.Fatal("hashRows should fail when row_hash is an extracted column")
+	}
+}
+
+func TestHashRowsPrivacy(t *testing.T) {
+	es := domain.ExtractSource{
+		RowHash: true,
+		ExtractRules: map[string]domain.ExtractRule{
+			"1": {ColumnName: "ssn", PrivacyPolicy: "mask(4)"},
+		},
+	}
+	churroDB := &mockdb.MockChurroDatabase{}
+	cols := []string{"id", "ssn"}
+	types := []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_TEXT}
+	row := []interface{}{"1", "123-45-6789"}
+
+	// the stored hash of a protected value is keyed with the salt
+	s := Server{
+		ExtractSource: es,
+		privacy:       &privacyState{keys: &privacy.Keys{Salt: []byte("pepper")}},
+	}
+	_, records, _, err := s.hashRows(churroDB, "db", "t", cols, []extractapi.GenericRow{{Key: 1, Cols: row}}, types)
+	if err != nil {
+		t.Fatalf("hashRows Error: %v", err)
+	}
+	if records[0].Cols[2] != rowHash(row, []byte("pepper")) || records[0].Cols[2] == rowHash(row, nil) {
+		t.Fatalf("hashRows row hash %v is not keyed with the salt", records[0].Cols[2])
+	}
+
+	s = Server{
+		ExtractSource: es,
+		privacy:       &privacyState{keys: &privacy.Keys{}},
+	}
+	_, _, _, err = s.hashRows(churroDB, "db", "t", cols, []extractapi.GenericRow{{Key: 1, Cols: row}}, types)
+	if !errors.Is(err, privacy.ErrNoKey) {
+		t.Fatalf("hashRows without a salt got %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package extract
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db"
+	"github.com/churrodata/churro/internal/domain"
+)
+
+type fixedWidthColumn struct {
+	column extractapi.Column
+	start  int
+	end    int
+}
+
+// ExtractFixedWidth Extract a fixed-width text file and exit, each
+// rule path is a character range within the line such as 10-24
+func (s *Server) ExtractFixedWidth(ctx context.Context) (err error) {
+
+	log.Info().Msg("ExtractFixedWidth starting...")
+
+	fwFile, err := s.openFile()
+	if err != nil {
+		return fmt.Errorf("could not open fixedwidth file: %s %v", s.FileName, err)
+	}
+	defer fwFile.Close()
+
+	cols, err := getFixedWidthColumns(getColumns(s.ExtractSource))
+	if err != nil {
+		return err
+	}
+
+	fwStruct := extractapi.GenericFormat{
+		Path:         s.FileName,
+		Dataprov:     s.DP.ID,
+		PipelineName: s.Pi.Name,
+		Columns:      make([]extractapi.Column, 0),
+	}
+	for i := 0; i < len(cols); i++ {
+		fwStruct.Columns = append(fwStruct.Columns, cols[i].column)
+	}
+	fwStruct.ColumnNames = getColumnNames(fwStruct.Columns)
+	fwStruct.ColumnTypes = getColumnTypes(fwStruct.Columns)
+
+	log.Info().Msg(fmt.Sprintf("columnNames %+v", fwStruct.ColumnNames))
+	log.Info().Msg(fmt.Sprintf("columnTypes %+v", fwStruct.ColumnTypes))
+	log.Info().Msg(fmt.Sprintf("skipheaders %d", s.ExtractSource.Skipheaders))
+
+	var churroDB db.ChurroDatabase
+	churroDB, err = db.NewChurroDB(s.Pi.Spec.DatabaseType)
+	if err != nil {
+		return err
+	}
+
+	err = churroDB.GetConnection(s.DBCreds, s.Pi.Spec.DataSource)
+	if err != nil {
+		return err
+	}
+
+	err = s.tableCheck(fwStruct.ColumnNames, fwStruct.ColumnTypes)
+	if err != nil {
+		return err
+	}
+	fwStruct.Tablename = s.TableName
+
+	jobProfile := domain.JobProfile{
+		ID:               os.Getenv("CHURRO_EXTRACTLOG"),
+		JobName:          os.Getenv("POD_NAME"),
+		StartDate:        time.Now().Format("2006-01-02 15:04:05"),
+		DataProvenanceID: s.DP.ID,
+		FileName:         s.FileName,
+		TableName:        s.TableName,
+	}
+
+	err = churroDB.CreateExtractLog(jobProfile)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in createextractlog")
+	}
+
+	batch := newRecordBatch(s.ExtractSource)
+
+	var linesRead int
+	reader := bufio.NewReader(fwFile)
+	for {
+		line, readErr := reader.ReadString('\n')
+		if readErr != nil && readErr != io.EOF {
+			return readErr
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line != "" {
+			linesRead++
+			if linesRead <= s.ExtractSource.Skipheaders {
+				log.Info().Msg(fmt.Sprintf("skipping header %d", linesRead))
+			} else {
+				r := getFixedWidthRow(line, cols)
+				err = s.runRules(fwStruct.ColumnNames, r.Cols)
+				if err != nil {
+					log.Error().Stack().Err(err).Msg("error in RunRules")
+				}
+				batch.add(r, len(line))
+			}
+		}
+
+		if batch.full() || (readErr == io.EOF && !batch.empty()) {
+			fwStruct.Records = batch.records
+			err = s.loadBatch(&jobProfile, churroDB, fwStruct, extractapi.FixedWidthScheme)
+			if err != nil {
+				return err
+			}
+			batch.reset()
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+	}
+
+	log.Info().Msg(fmt.Sprintf("end of fixedwidth file reached, %d records loaded", jobProfile.RecordsLoaded))
+
+	return nil
+}
+
+func getFixedWidthColumns(cols []extractapi.Column) (fwCols []fixedWidthColumn, err error) {
+	for i := 0; i < len(cols); i++ {
+		start, end, err := extractapi.ParseFixedWidthRange(cols[i].Path)
+		if err != nil {
+			return fwCols, err
+		}
+		fwCols = append(fwCols, fixedWidthColumn{column: cols[i], start: start, end: end})
+	}
+	return fwCols, nil
+}
+
+// getFixedWidthRow slices each column out of the line by character
+// position, the padding around a field is trimmed and a range past
+// the end of a short line yields an empty value
+func getFixedWidthRow(line string, cols []fixedWidthColumn) extractapi.GenericRow {
+	r := extractapi.GenericRow{
+		Key:  nextRowKey(),
+		Cols: make([]interface{}, len(cols)),
+	}
+	chars := []rune(line)
+	for i := 0; i < len(cols); i++ {
+		start := cols[i].start - 1
+		end := cols[i].end
+		if start >= len(chars) {
+			r.Cols[i] = ""
+			continue
+		}
+		if end > len(chars) {
+			end = len(chars)
+		}
+		r.Cols[i] = strings.TrimSpace(string(chars[start:end]))
+	}
+	return r
+}
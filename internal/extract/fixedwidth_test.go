@@ -0,0 +1,105 @@
+package extract
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/api/v1alpha1"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/churrodata/churro/pkg/config"
+)
+
+const fixedWidthTestData = "ACCOUNT   NAME          BALANCE\r\n" +
+	"0000012345joe smith     00120.50\r\n" +
+	"0000067890mary jones    00007.25\r\n" +
+	"0000011111short\r\n"
+
+func TestExtractFixedWidth(t *testing.T) {
+
+	// create a temp file based on the example data
+	f, err := ioutil.TempFile("/tmp", "myfixedwidthtest")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(fixedWidthTestData); err != nil {
+		log.Fatal(err)
+	}
+	f.Close()
+
+	pipeline := v1alpha1.Pipeline{
+		Spec: v1alpha1.PipelineSpec{
+			DatabaseType: domain.DatabaseMock,
+		},
+	}
+
+	rule := domain.ExtractRule{
+		ID:              "rule1",
+		ExtractSourceID: "one",
+		ColumnName:      "account",
+		ColumnPath:      "1-10",
+		ColumnType:      "TEXT",
+	}
+	rule2 := domain.ExtractRule{
+		ID:              "rule2",
+		ExtractSourceID: "one",
+		ColumnName:      "name",
+		ColumnPath:      "11-24",
+		ColumnType:      "TEXT",
+	}
+
+	extractRules := make(map[string]domain.ExtractRule)
+	extractRules[rule.ID] = rule
+	extractRules[rule2.ID] = rule2
+
+	extractSource := domain.ExtractSource{
+		ID:           "one",
+		Name:         "my-fixedwidth-files",
+		Path:         "/tmp",
+		Scheme:       extractapi.FixedWidthScheme,
+		Skipheaders:  1,
+		ExtractRules: extractRules,
+		Tablename:    "myfixedwidthtable",
+	}
+
+	s := Server{
+		DBCreds:       config.DBCredentials{},
+		FileName:      f.Name(),
+		Pi:            pipeline,
+		ExtractSource: extractSource,
+		SchemeValue:   extractapi.FixedWidthScheme,
+	}
+
+	err = s.ExtractFixedWidth(context.TODO())
+	if err != nil {
+		t.Fatalf("extract.ExtractFixedWidth Error: %v", err)
+	}
+
+	cols, err := getFixedWidthColumns([]extractapi.Column{
+		{Name: "account", Path: "1-10"},
+		{Name: "name", Path: "11-24"},
+		{Name: "balance", Path: "25-32"},
+	})
+	if err != nil {
+		t.Fatalf("extract.getFixedWidthColumns Error: %v", err)
+	}
+	r := getFixedWidthRow("0000011111short", cols)
+	expected := []string{"0000011111", "short", ""}
+	for i := 0; i < len(expected); i++ {
+		if r.Cols[i] != expected[i] {
+			t.Fatalf("extract.getFixedWidthRow column %d got %q expected %q", i, r.Cols[i], expected[i])
+		}
+	}
+
+	badPaths := []string{"10", "0-5", "24-10", "a-b"}
+	for _, p := range badPaths {
+		_, err = getFixedWidthColumns([]extractapi.Column{{Name: "bad", Path: p}})
+		if err == nil {
+			t.Fatalf("extract.getFixedWidthColumns expected an error for path %s", p)
+		}
+	}
+}
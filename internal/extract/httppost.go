@@ -31,7 +31,6 @@ import (
 	extractapi "github.com/churrodata/churro/api/extract"
 	"github.com/churrodata/churro/internal/db"
 	"github.com/churrodata/churro/internal/domain"
-	"github.com/churrodata/churro/internal/transform"
 	"github.com/churrodata/churro/pkg"
 )
 
@@ -327,7 +326,7 @@ func (u *httppostwrapper) getRowFromJSON(jsonMessage string) (someBytes []byte,
 	}
 
 	thisrow := extractapi.GenericRow{
-		Key: time.Now().UnixNano(),
+		Key: nextRowKey(),
 	}
 	thisrow.Cols = make([]interface{}, 0)
 	for cell := 0; cell < len(allCols); cell++ {
@@ -337,7 +336,7 @@ func (u *httppostwrapper) getRowFromJSON(jsonMessage string) (someBytes []byte,
 	row = append(row, thisrow)
 	u.CSVStruct.Records = row
 
-	err = transform.RunRules(u.CSVStruct.ColumnNames, u.CSVStruct.Records[0].Cols, u.Server.ExtractSource.ExtractRules, u.Server.TransformFunctions)
+	err = u.Server.runRules(u.CSVStruct.ColumnNames, u.CSVStruct.Records[0].Cols)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in RunRules ")
 	}
@@ -353,7 +352,7 @@ func (u *httppostwrapper) getRowFromForm(form url.Values) (someBytes []byte, err
 
 	row := make([]extractapi.GenericRow, 0)
 	thisrow := extractapi.GenericRow{}
-	thisrow.Key = time.Now().UnixNano()
+	thisrow.Key = nextRowKey()
 
 	for i := 0; i < len(u.CSVStruct.Columns); i++ {
 		c := u.CSVStruct.Columns[i]
@@ -368,7 +367,7 @@ func (u *httppostwrapper) getRowFromForm(form url.Values) (someBytes []byte, err
 
 	u.CSVStruct.Records = row
 
-	err = transform.RunRules(u.CSVStruct.ColumnNames, u.CSVStruct.Records[0].Cols, u.Server.ExtractSource.ExtractRules, u.Server.TransformFunctions)
+	err = u.Server.runRules(u.CSVStruct.ColumnNames, u.CSVStruct.Records[0].Cols)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in RunRules ")
 	}
@@ -31,7 +31,7 @@ func (s *Server) ExtractJSON(ctx context.Context) (err error) {
 
 	log.Info().Msg("ExtractJSON starting...\n")
 
-	jsonfile, err := os.Open(s.FileName)
+	jsonfile, err := s.openFile()
 	if err != nil {
 		return fmt.Errorf("could not open JSON file: %s %v", s.FileName, err)
 	}
@@ -81,7 +81,7 @@ func (s *Server) ExtractJSON(ctx context.Context) (err error) {
 	}
 
 	msg := extractapi.LoaderMessage{
-		Key:        time.Now().UnixNano(),
+		Key:        nextRowKey(),
 		Metadata:   someBytes,
 		DataFormat: extractapi.JSONScheme,
 	}
@@ -100,7 +100,6 @@ func (s *Server) ExtractJSON(ctx context.Context) (err error) {
 		log.Error().Stack().Err(err)
 	}
 
-	s.process(jobProfile, churroDB, s.Pi.Spec.DataSource.Database, msg)
-
+	_, err = s.process(jobProfile, churroDB, s.Pi.Spec.DataSource.Database, msg)
 	return err
 }
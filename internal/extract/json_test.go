@@ -5,10 +5,14 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	extractapi "github.com/churrodata/churro/api/extract"
 	"github.com/churrodata/churro/api/v1alpha1"
+	"github.com/churrodata/churro/internal/db"
+	"github.com/churrodata/churro/internal/db/sqlite"
 	"github.com/churrodata/churro/internal/domain"
 	"github.com/churrodata/churro/pkg/config"
 )
@@ -94,3 +98,56 @@ func TestExtractJSON(t *testing.T) {
 	}
 
 }
+
+func TestExtractJSONLoadError(t *testing.T) {
+	dir := t.TempDir()
+	os.Setenv("CHURRO_NAMESPACE", "pipeline1")
+	fileName := filepath.Join(dir, "books.json")
+	if err := ioutil.WriteFile(fileName, []byte(`{"title": "Moby Dick"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pipeline := v1alpha1.Pipeline{
+		Spec: v1alpha1.PipelineSpec{
+			DatabaseType: domain.DatabaseSqlite,
+			DataSource:   v1alpha1.Source{Path: dir, Database: "pipeline1", Username: "pipeline1"},
+		},
+	}
+
+	// the table exists but refuses every row, as a full disk would
+	churroDB, err := db.NewChurroDB(domain.DatabaseSqlite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := churroDB.GetConnection(config.DBCredentials{}, pipeline.Spec.DataSource); err != nil {
+		t.Fatal(err)
+	}
+	if err := churroDB.CreateTable("pipeline1", "pipeline1", "myjsontable", []string{"metadata"}, []string{"jsonb"}); err != nil {
+		t.Fatal(err)
+	}
+	sqliteDB := churroDB.(*sqlite.SqliteChurroDatabase)
+	_, err = sqliteDB.Connection.Exec(`CREATE TRIGGER refuse BEFORE INSERT ON myjsontable BEGIN SELECT RAISE(ABORT, 'load failed'); END;`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := Server{
+		FileName: fileName,
+		Pi:       pipeline,
+		ExtractSource: domain.ExtractSource{
+			ID:           "one",
+			Path:         dir,
+			Scheme:       extractapi.JSONScheme,
+			ExtractRules: map[string]domain.ExtractRule{},
+			Tablename:    "myjsontable",
+		},
+		TableName:   "myjsontable",
+		SchemeValue: extractapi.JSONScheme,
+	}
+
+	// the load error is returned so the file is moved to failed
+	err = s.ExtractJSON(context.TODO())
+	if err == nil || !strings.Contains(err.Error(), "load failed") {
+		t.Fatalf("extract.ExtractJSON expected the load error got %v", err)
+	}
+}
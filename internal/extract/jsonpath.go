@@ -23,7 +23,6 @@ import (
 	extractapi "github.com/churrodata/churro/api/extract"
 	"github.com/churrodata/churro/internal/db"
 	"github.com/churrodata/churro/internal/domain"
-	"github.com/churrodata/churro/internal/transform"
 
 	"github.com/ohler55/ojg/jp"
 	"github.com/ohler55/ojg/oj"
@@ -35,10 +34,16 @@ func (s *Server) ExtractJSONPath(ctx context.Context) (err error) {
 
 	log.Debug().Msg("ExtractJSONPath starting...")
 
-	byteValue, err := ioutil.ReadFile(s.FileName)
+	jsonfile, err := s.openFile()
 	if err != nil {
 		return fmt.Errorf("could not open JSONPath file: %s %v", s.FileName, err)
 	}
+	defer jsonfile.Close()
+
+	byteValue, err := ioutil.ReadAll(jsonfile)
+	if err != nil {
+		return fmt.Errorf("could not read JSONPath file: %s %v", s.FileName, err)
+	}
 
 	obj, parseError := oj.ParseString(string(byteValue))
 	if parseError != nil {
@@ -108,14 +113,14 @@ func (s *Server) ExtractJSONPath(ctx context.Context) (err error) {
 
 	for row := 0; row < rows; row++ {
 		r := extractapi.GenericRow{
-			Key: time.Now().UnixNano(),
+			Key: nextRowKey(),
 		}
 		r.Cols = make([]interface{}, 0)
 		for cell := 0; cell < len(allCols); cell++ {
 			r.Cols = append(r.Cols, allCols[cell][row])
 		}
 
-		err := transform.RunRules(jsonStruct.ColumnNames, r.Cols, s.ExtractSource.ExtractRules, s.TransformFunctions)
+		err := s.runRules(jsonStruct.ColumnNames, r.Cols)
 		if err != nil {
 			log.Error().Stack().Err(err).Msg("error in run rules")
 		}
@@ -139,7 +144,10 @@ func (s *Server) ExtractJSONPath(ctx context.Context) (err error) {
 		log.Error().Stack().Err(err)
 	}
 
-	s.process(jobProfile, churroDB, s.Pi.Spec.DataSource.Database, msg)
+	_, err = s.process(jobProfile, churroDB, s.Pi.Spec.DataSource.Database, msg)
+	if err != nil {
+		return err
+	}
 
 	log.Info().Msg("end of jsonpath file reached, cancelling pushes...")
 
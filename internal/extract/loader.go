@@ -23,70 +23,81 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
-func (s *Server) process(jp domain.JobProfile, xyz db.ChurroDatabase, database string, elem extractapi.LoaderMessage) {
+// process loads a message into the pipeline database and returns the
+// rows loaded, the error of the load is returned so callers can tell
+// when a batch committed
+func (s *Server) process(jp domain.JobProfile, xyz db.ChurroDatabase, database string, elem extractapi.LoaderMessage) (loaded int64, err error) {
 	switch s.SchemeValue {
 	case extractapi.FinnHubScheme:
-		s.processFinnhubStocks(jp, xyz, database, elem)
+		loaded, err = s.processFinnhubStocks(jp, xyz, database, elem)
 	case extractapi.APIScheme:
-		s.processAPI(jp, xyz, database, elem)
+		loaded, err = s.processAPI(jp, xyz, database, elem)
 	case extractapi.XMLScheme:
-		s.processXML(jp, xyz, database, elem)
+		loaded, err = s.processXML(jp, xyz, database, elem)
 	case extractapi.XLSXScheme:
-		s.processXLSX(jp, xyz, database, elem)
+		loaded, err = s.processXLSX(jp, xyz, database, elem)
 	case extractapi.CSVScheme:
-		s.processCSV(jp, xyz, database, elem)
+		loaded, err = s.processCSV(jp, xyz, database, elem)
+	case extractapi.ParquetScheme:
+		loaded, err = s.processParquet(jp, xyz, database, elem)
+	case extractapi.NDJSONScheme:
+		loaded, err = s.processNDJSON(jp, xyz, database, elem)
+	case extractapi.FixedWidthScheme:
+		loaded, err = s.processFixedWidth(jp, xyz, database, elem)
 	case extractapi.JSONPathScheme:
-		s.processJSONPath(jp, xyz, database, elem)
+		loaded, err = s.processJSONPath(jp, xyz, database, elem)
 	case extractapi.JSONScheme:
-		s.processJSON(jp, xyz, database, elem)
+		loaded, err = s.processJSON(jp, xyz, database, elem)
 	case extractapi.HTTPPostScheme:
-		s.processHTTPPost(jp, xyz, database, elem)
+		loaded, err = s.processHTTPPost(jp, xyz, database, elem)
 	default:
 		log.Error().Stack().Msg("invalid scheme found in process " + s.SchemeValue)
+		err = fmt.Errorf("invalid scheme found in process %s", s.SchemeValue)
 	}
 
 	s.processExtensions(elem)
+	return loaded, err
 }
 
-func (s *Server) processCSV(jp domain.JobProfile, churroDB db.ChurroDatabase, database string, elem extractapi.LoaderMessage) {
+func (s *Server) processCSV(jp domain.JobProfile, churroDB db.ChurroDatabase, database string, elem extractapi.LoaderMessage) (int64, error) {
 
 	//unmarshal elem metadata into CSV message
 	var csvMsg extractapi.GenericFormat
 	err := json.Unmarshal(elem.Metadata, &csvMsg)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in unmarshal")
-		return
+		return 0, err
 	}
 
-	err = churroDB.GetBulkInsertStatement(extractapi.CSVScheme, database, csvMsg.Tablename, csvMsg.ColumnNames, csvMsg.Records, csvMsg.ColumnTypes)
+	loaded, err := s.load(churroDB, extractapi.CSVScheme, database, csvMsg.Tablename, csvMsg.ColumnNames, csvMsg.Records, csvMsg.ColumnTypes)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in bulk insert ")
-		return
+		return 0, err
 	}
 
 	t := stats.PipelineStats{
 		DataprovID: csvMsg.Dataprov,
 		Pipeline:   csvMsg.PipelineName,
 		FileName:   csvMsg.Path,
-		RecordsIn:  int64(len(csvMsg.Records)),
+		RecordsIn:  loaded,
 	}
 
 	err = churroDB.UpdatePipelineStats(t)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in stats update ")
-		return
 	}
+	return loaded, nil
 }
 
-func (s *Server) processJSON(jp domain.JobProfile, churroDB db.ChurroDatabase, pipelineName string, elem extractapi.LoaderMessage) {
+func (s *Server) processJSON(jp domain.JobProfile, churroDB db.ChurroDatabase, pipelineName string, elem extractapi.LoaderMessage) (int64, error) {
 	colNames := []string{"metadata"}
 	cols := []interface{}{string(elem.Metadata)}
 	log.Info().Msg("table name here in processJSON " + s.ExtractSource.Tablename)
 	err := churroDB.GetInsertStatement(extractapi.JSONScheme, pipelineName, s.ExtractSource.Tablename, colNames, cols, elem.Key)
 	if err != nil {
-		return
+		return 0, err
 	}
-
+	return 1, nil
 }
 
 func (s *Server) startMetrics() {
@@ -97,52 +108,53 @@ func (s *Server) startMetrics() {
 	}
 }
 
-func (s *Server) processXML(jp domain.JobProfile, churroDB db.ChurroDatabase, database string, elem extractapi.LoaderMessage) {
+func (s *Server) processXML(jp domain.JobProfile, churroDB db.ChurroDatabase, database string, elem extractapi.LoaderMessage) (int64, error) {
 
 	//unmarshal elem metadata into XML message
 	var xmlMsg extractapi.GenericFormat
 	err := json.Unmarshal(elem.Metadata, &xmlMsg)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in processXML")
-		return
+		return 0, err
 	}
 
 	log.Info().Msg(fmt.Sprintf("loader is processing XML records %d", len(xmlMsg.Records)))
 	log.Info().Msg(fmt.Sprintf("loader is processing XML columns %v", xmlMsg.ColumnNames))
 
-	err = churroDB.GetBulkInsertStatement(extractapi.XMLScheme, database, xmlMsg.Tablename, xmlMsg.ColumnNames, xmlMsg.Records, xmlMsg.ColumnTypes)
+	loaded, err := s.load(churroDB, extractapi.XMLScheme, database, xmlMsg.Tablename, xmlMsg.ColumnNames, xmlMsg.Records, xmlMsg.ColumnTypes)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error on bulk insert")
-		return
+		return 0, err
 	}
 
 	t := stats.PipelineStats{
 		DataprovID: xmlMsg.Dataprov,
 		Pipeline:   xmlMsg.PipelineName,
 		FileName:   xmlMsg.Path,
-		RecordsIn:  int64(len(xmlMsg.Records)),
+		RecordsIn:  loaded,
 	}
 
 	err = churroDB.UpdatePipelineStats(t)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error on stats update")
 	}
+	return loaded, nil
 }
 
-func (s *Server) processFinnhubStocks(jp domain.JobProfile, churroDB db.ChurroDatabase, database string, elem extractapi.LoaderMessage) {
+func (s *Server) processFinnhubStocks(jp domain.JobProfile, churroDB db.ChurroDatabase, database string, elem extractapi.LoaderMessage) (int64, error) {
 
 	//unmarshal elem metadata into CSV message
 	var csvMsg extractapi.GenericFormat
 	err := json.Unmarshal(elem.Metadata, &csvMsg)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error on csv unmarshal")
-		return
+		return 0, err
 	}
 
 	for _, r := range csvMsg.Records {
 		err := churroDB.GetInsertStatement(extractapi.FinnHubScheme, database, csvMsg.Tablename, csvMsg.ColumnNames, r.Cols, r.Key)
 		if err != nil {
-			return
+			return 0, err
 		}
 	}
 
@@ -157,77 +169,167 @@ func (s *Server) processFinnhubStocks(jp domain.JobProfile, churroDB db.ChurroDa
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in stats update")
 	}
+	return int64(len(csvMsg.Records)), nil
 }
 
-func (s *Server) processXLSX(jp domain.JobProfile, churroDB db.ChurroDatabase, database string, elem extractapi.LoaderMessage) {
+func (s *Server) processXLSX(jp domain.JobProfile, churroDB db.ChurroDatabase, database string, elem extractapi.LoaderMessage) (int64, error) {
 
 	//unmarshal elem metadata into XLS message
 	var xlsMsg extractapi.GenericFormat
 	err := json.Unmarshal(elem.Metadata, &xlsMsg)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in xls unmarshal")
-		return
+		return 0, err
 	}
 
-	err = churroDB.GetBulkInsertStatement(extractapi.XLSXScheme, database, xlsMsg.Tablename, xlsMsg.ColumnNames, xlsMsg.Records, xlsMsg.ColumnTypes)
+	loaded, err := s.load(churroDB, extractapi.XLSXScheme, database, xlsMsg.Tablename, xlsMsg.ColumnNames, xlsMsg.Records, xlsMsg.ColumnTypes)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in bulk insert")
-		return
+		return 0, err
 	}
 
 	t := stats.PipelineStats{
 		DataprovID: xlsMsg.Dataprov,
 		Pipeline:   xlsMsg.PipelineName,
 		FileName:   xlsMsg.Path,
-		RecordsIn:  int64(len(xlsMsg.Records)),
+		RecordsIn:  loaded,
 	}
 
 	err = churroDB.UpdatePipelineStats(t)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in stats update")
-		return
 	}
+	return loaded, nil
 }
 
-func (s *Server) processJSONPath(jp domain.JobProfile, churroDB db.ChurroDatabase, database string, elem extractapi.LoaderMessage) {
+func (s *Server) processParquet(jp domain.JobProfile, churroDB db.ChurroDatabase, database string, elem extractapi.LoaderMessage) (int64, error) {
+
+	//unmarshal elem metadata into Parquet message
+	var parquetMsg extractapi.GenericFormat
+	err := json.Unmarshal(elem.Metadata, &parquetMsg)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in parquet unmarshal")
+		return 0, err
+	}
+
+	loaded, err := s.load(churroDB, extractapi.ParquetScheme, database, parquetMsg.Tablename, parquetMsg.ColumnNames, parquetMsg.Records, parquetMsg.ColumnTypes)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in bulk insert")
+		return 0, err
+	}
+
+	t := stats.PipelineStats{
+		DataprovID: parquetMsg.Dataprov,
+		Pipeline:   parquetMsg.PipelineName,
+		FileName:   parquetMsg.Path,
+		RecordsIn:  loaded,
+	}
+
+	err = churroDB.UpdatePipelineStats(t)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in stats update")
+	}
+	return loaded, nil
+}
+
+func (s *Server) processNDJSON(jp domain.JobProfile, churroDB db.ChurroDatabase, database string, elem extractapi.LoaderMessage) (int64, error) {
+
+	//unmarshal elem metadata into NDJSON message
+	var ndjsonMsg extractapi.GenericFormat
+	err := json.Unmarshal(elem.Metadata, &ndjsonMsg)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in ndjson unmarshal")
+		return 0, err
+	}
+
+	loaded, err := s.load(churroDB, extractapi.NDJSONScheme, database, ndjsonMsg.Tablename, ndjsonMsg.ColumnNames, ndjsonMsg.Records, ndjsonMsg.ColumnTypes)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in bulk insert")
+		return 0, err
+	}
+
+	t := stats.PipelineStats{
+		DataprovID: ndjsonMsg.Dataprov,
+		Pipeline:   ndjsonMsg.PipelineName,
+		FileName:   ndjsonMsg.Path,
+		RecordsIn:  loaded,
+	}
+
+	err = churroDB.UpdatePipelineStats(t)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in stats update")
+	}
+	return loaded, nil
+}
+
+func (s *Server) processFixedWidth(jp domain.JobProfile, churroDB db.ChurroDatabase, database string, elem extractapi.LoaderMessage) (int64, error) {
+
+	//unmarshal elem metadata into FixedWidth message
+	var fwMsg extractapi.GenericFormat
+	err := json.Unmarshal(elem.Metadata, &fwMsg)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in fixedwidth unmarshal")
+		return 0, err
+	}
+
+	loaded, err := s.load(churroDB, extractapi.FixedWidthScheme, database, fwMsg.Tablename, fwMsg.ColumnNames, fwMsg.Records, fwMsg.ColumnTypes)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in bulk insert")
+		return 0, err
+	}
+
+	t := stats.PipelineStats{
+		DataprovID: fwMsg.Dataprov,
+		Pipeline:   fwMsg.PipelineName,
+		FileName:   fwMsg.Path,
+		RecordsIn:  loaded,
+	}
+
+	err = churroDB.UpdatePipelineStats(t)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in stats update")
+	}
+	return loaded, nil
+}
+
+func (s *Server) processJSONPath(jp domain.JobProfile, churroDB db.ChurroDatabase, database string, elem extractapi.LoaderMessage) (int64, error) {
 
 	//unmarshal into JsonPathMessage
 	var jsonPathMsg extractapi.GenericFormat
 	err := json.Unmarshal(elem.Metadata, &jsonPathMsg)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in jsonpath unmarshal")
-		return
+		return 0, err
 	}
 
 	log.Info().Msg(fmt.Sprintf("jsonPathMsg %+v\n", jsonPathMsg))
 
-	err = churroDB.GetBulkInsertStatement(extractapi.JSONPathScheme, database, jsonPathMsg.Tablename, jsonPathMsg.ColumnNames, jsonPathMsg.Records, jsonPathMsg.ColumnTypes)
+	loaded, err := s.load(churroDB, extractapi.JSONPathScheme, database, jsonPathMsg.Tablename, jsonPathMsg.ColumnNames, jsonPathMsg.Records, jsonPathMsg.ColumnTypes)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in bulk insert")
-		return
+		return 0, err
 	}
 
 	t := stats.PipelineStats{
 		DataprovID: jsonPathMsg.Dataprov,
 		Pipeline:   jsonPathMsg.PipelineName,
 		FileName:   jsonPathMsg.Path,
-		RecordsIn:  int64(len(jsonPathMsg.Records)),
+		RecordsIn:  loaded,
 	}
 
 	err = churroDB.UpdatePipelineStats(t)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in jsonpath stats update")
-		return
 	}
-
+	return loaded, nil
 }
 
-func (s *Server) processAPI(jp domain.JobProfile, churroDB db.ChurroDatabase, pipelineName string, elem extractapi.LoaderMessage) {
+func (s *Server) processAPI(jp domain.JobProfile, churroDB db.ChurroDatabase, pipelineName string, elem extractapi.LoaderMessage) (int64, error) {
 	var jsonStruct extractapi.RawFormat
 	err := json.Unmarshal(elem.Metadata, &jsonStruct)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in unmarshal ")
-		return
+		return 0, err
 	}
 
 	cols := make([]interface{}, 1)
@@ -240,71 +342,81 @@ func (s *Server) processAPI(jp domain.JobProfile, churroDB db.ChurroDatabase, pi
 		RecordsIn:  int64(1),
 	}
 
+	var failed, rejected int
+	if s.conversions != nil {
+		failed = s.conversions.total()
+	}
+	if s.quality != nil {
+		rejected = s.quality.total()
+	}
 	recCount := len(jsonStruct.Records)
 	if recCount > 0 {
-		t.RecordsIn = int64(recCount)
-		err := churroDB.GetBulkInsertStatement(extractapi.APIScheme, pipelineName, s.TableName, jsonStruct.ColumnNames, jsonStruct.Records, jsonStruct.ColumnTypes)
+		loaded, err := s.load(churroDB, extractapi.APIScheme, pipelineName, s.TableName, jsonStruct.ColumnNames, jsonStruct.Records, jsonStruct.ColumnTypes)
 		if err != nil {
 			log.Error().Stack().Err(err).Msg("error in bulk insert")
-			return
+			return 0, err
 		}
+		t.RecordsIn = loaded
 	} else {
 		err := churroDB.GetInsertStatement(extractapi.APIScheme, pipelineName, s.TableName, jsonStruct.ColumnNames, cols, elem.Key)
 		if err != nil {
-			return
+			return 0, err
 		}
 	}
 
 	err = churroDB.UpdatePipelineStats(t)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in stats update")
-		return
 	}
 
-	jp.RecordsLoaded = recCount
-
 	var jp2 domain.JobProfile
 	jp2, err = churroDB.GetExtractLogById(jp.ID)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in jobprofile update")
-		return
+		return 0, err
+	}
+	jp2.RecordsLoaded += int(t.RecordsIn)
+	if s.conversions != nil {
+		jp2.ConversionErrors += s.conversions.total() - failed
+	}
+	if s.quality != nil {
+		jp2.RecordsRejected += s.quality.total() - rejected
 	}
-	jp2.RecordsLoaded += recCount
 
 	err = churroDB.UpdateExtractLog(jp2)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in jobprofile update")
-		return
+		return 0, err
 	}
-
+	return t.RecordsIn, nil
 }
 
-func (s *Server) processHTTPPost(jp domain.JobProfile, churroDB db.ChurroDatabase, database string, elem extractapi.LoaderMessage) {
+func (s *Server) processHTTPPost(jp domain.JobProfile, churroDB db.ChurroDatabase, database string, elem extractapi.LoaderMessage) (int64, error) {
 
 	//unmarshal elem metadata into CSV message
 	var csvMsg extractapi.GenericFormat
 	err := json.Unmarshal(elem.Metadata, &csvMsg)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in unmarshal")
-		return
+		return 0, err
 	}
 
-	err = churroDB.GetBulkInsertStatement(extractapi.HTTPPostScheme, database, csvMsg.Tablename, csvMsg.ColumnNames, csvMsg.Records, csvMsg.ColumnTypes)
+	loaded, err := s.load(churroDB, extractapi.HTTPPostScheme, database, csvMsg.Tablename, csvMsg.ColumnNames, csvMsg.Records, csvMsg.ColumnTypes)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in bulk insert ")
-		return
+		return 0, err
 	}
 
 	t := stats.PipelineStats{
 		DataprovID: csvMsg.Dataprov,
 		Pipeline:   csvMsg.PipelineName,
 		FileName:   csvMsg.Path,
-		RecordsIn:  int64(len(csvMsg.Records)),
+		RecordsIn:  loaded,
 	}
 
 	err = churroDB.UpdatePipelineStats(t)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in stats update ")
-		return
 	}
+	return loaded, nil
 }
@@ -0,0 +1,291 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package extract
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db"
+	"github.com/churrodata/churro/internal/domain"
+)
+
+// batches with at least this many records are copied into the table
+// when the extract source does not set a copy threshold
+const defaultCopyThreshold = 500
+
+// partitionSet remembers the partitions a replace-partition job has
+// already replaced, rows a job loaded in an earlier batch are kept
+type partitionSet struct {
+	mu       sync.Mutex
+	replaced map[string]bool
+}
+
+// add returns the partitions that were not replaced before and marks
+// them as replaced
+func (p *partitionSet) add(partitions [][]interface{}) [][]interface{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.replaced == nil {
+		p.replaced = make(map[string]bool)
+	}
+	added := make([][]interface{}, 0, len(partitions))
+	for _, v := range partitions {
+		b, _ := json.Marshal(v)
+		if !p.replaced[string(b)] {
+			p.replaced[string(b)] = true
+			added = append(added, v)
+		}
+	}
+	return added
+}
+
+// forget unmarks partitions whose replacement did not commit
+func (p *partitionSet) forget(partitions [][]interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, v := range partitions {
+		b, _ := json.Marshal(v)
+		delete(p.replaced, string(b))
+	}
+}
+
+// load writes the records to the table with the load mode of the
+// extract source, append is used when the mode is blank.  Records
+// duplicating one within the dedup window are dropped and the row hash
+// is added, records are enriched by the lookups, then row transform
+// functions run, records they fail on or failing a data quality rule
+// are loaded into the quarantine table.  The routes of the extract
+// source then send records to other tables, created when first routed
+// to, or drop them.  The records are converted to their column types,
+// records with a value that does not convert are loaded into the
+// quarantine table, and the privacy policies of the extract rules are
+// applied last before the records are written.  The number of records
+// written to the tables is returned.
+func (s *Server) load(churroDB db.ChurroDatabase, scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string) (int64, error) {
+	cols, records, colTypes, err := s.hashRows(churroDB, database, tableName, cols, records, colTypes)
+	if err != nil {
+		return 0, err
+	}
+	cols, records, colTypes, err = s.runLookups(churroDB, database, tableName, cols, records, colTypes)
+	if err != nil {
+		return 0, err
+	}
+	cols, records, colTypes, rejects, err := s.runRowFunctions(churroDB, database, tableName, cols, records, colTypes)
+	if err != nil {
+		return 0, err
+	}
+	records, qualityRejects, err := s.checkQuality(cols, records)
+	if err != nil {
+		return 0, err
+	}
+	rejects = append(rejects, qualityRejects...)
+	batches, routeRejects, err := s.routeRecords(tableName, cols, records)
+	if err != nil {
+		return 0, err
+	}
+	rejects = append(rejects, routeRejects...)
+	err = s.loadRejects(churroDB, scheme, database, tableName, rejects)
+	if err != nil {
+		return 0, err
+	}
+
+	var loaded int64
+	for _, b := range batches {
+		if len(b.records) == 0 {
+			continue
+		}
+		records, rejects := s.convertRecords(cols, b.records, colTypes)
+		if len(rejects) > 0 {
+			if s.quality == nil {
+				s.quality = &qualityState{}
+			}
+			err = s.loadRejects(churroDB, scheme, database, tableName, rejects)
+			if err != nil {
+				return loaded, err
+			}
+			s.quality.mu.Lock()
+			s.quality.rejected += len(rejects)
+			s.quality.mu.Unlock()
+		}
+		if len(records) == 0 {
+			continue
+		}
+		b.records = records
+		err = s.protectRecords(churroDB, b.table, cols, b.records)
+		if err != nil {
+			return loaded, err
+		}
+
+		if s.partitions == nil {
+			s.partitions = &partitionSet{}
+		}
+		partitions := s.partitions
+		if b.table != tableName {
+			err = s.checkRouteTable(churroDB, database, b.table, cols, colTypes)
+			if err != nil {
+				return loaded, err
+			}
+			partitions = s.routes.partitionSet(b.table)
+		}
+
+		n, err := s.loadTable(churroDB, scheme, database, b.table, cols, b.records, colTypes, partitions)
+		if err != nil {
+			return loaded, err
+		}
+		loaded += n
+	}
+	return loaded, nil
+}
+
+// loadTable writes the records to one table with the load mode of the
+// extract source and returns the number of records written, partitions
+// holds the partitions the job replaced in the table
+func (s *Server) loadTable(churroDB db.ChurroDatabase, scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string, partitions *partitionSet) (int64, error) {
+	mode := s.ExtractSource.LoadMode
+	if mode == "" || mode == domain.LoadModeAppend {
+		// large batches are copied by the databases supporting it
+		copier, ok := churroDB.(db.BulkCopier)
+		if ok && len(records) >= s.copyThreshold() {
+			return copier.CopyRecords(scheme, database, tableName, cols, records, colTypes)
+		}
+		err := churroDB.GetBulkInsertStatement(scheme, database, tableName, cols, records, colTypes)
+		if err != nil {
+			return 0, err
+		}
+		return int64(len(records)), nil
+	}
+
+	keyCols := getKeyColumns(s.ExtractSource)
+	keys, err := getKeyIndexes(keyCols, cols)
+	if err != nil {
+		return 0, err
+	}
+
+	switch mode {
+	case domain.LoadModeUpsert:
+		records = keyRecords(records, keys)
+		err = churroDB.GetUpsertStatement(scheme, database, tableName, cols, records, colTypes)
+		if err != nil {
+			return 0, err
+		}
+		return int64(len(records)), nil
+	case domain.LoadModeReplacePartition:
+		added := partitions.add(getPartitions(records, keys))
+		err = churroDB.GetReplacePartitionStatement(scheme, database, tableName, cols, records, colTypes, keyCols, added)
+		if err != nil {
+			partitions.forget(added)
+			return 0, err
+		}
+		log.Info().Msg(fmt.Sprintf("replaced %d partitions of %s", len(added), tableName))
+		return int64(len(records)), nil
+	}
+	return 0, fmt.Errorf("invalid load mode %s", mode)
+}
+
+// copyThreshold returns the least number of records of a batch that
+// are copied rather than inserted
+func (s *Server) copyThreshold() int {
+	if s.ExtractSource.CopyThreshold > 0 {
+		return s.ExtractSource.CopyThreshold
+	}
+	return defaultCopyThreshold
+}
+
+// getKeyColumns returns the column names of the extract rules marked
+// as key columns, sorted like the columns of an extract
+func getKeyColumns(es domain.ExtractSource) (keyCols []string) {
+	for _, r := range es.ExtractRules {
+		if r.KeyColumn {
+			keyCols = append(keyCols, r.ColumnName)
+		}
+	}
+	sort.Strings(keyCols)
+	return keyCols
+}
+
+// getKeyIndexes returns the position of each key column within cols
+func getKeyIndexes(keyCols, cols []string) ([]int, error) {
+	if len(keyCols) == 0 {
+		return nil, fmt.Errorf("load mode requires an extract rule marked as a key column")
+	}
+	keys := make([]int, 0, len(keyCols))
+	for _, k := range keyCols {
+		found := -1
+		for i := range cols {
+			if cols[i] == k {
+				found = i
+			}
+		}
+		if found < 0 {
+			return nil, fmt.Errorf("key column %s is not an extracted column", k)
+		}
+		keys = append(keys, found)
+	}
+	return keys, nil
+}
+
+// keyRecords replaces the key of each record with a hash of its key
+// column values so a record loaded again has the same primary key.
+// Only the last record of a key within the batch is kept, a statement
+// cannot change the same row twice.
+func keyRecords(records []extractapi.GenericRow, keys []int) []extractapi.GenericRow {
+	last := make(map[int64]int, len(records))
+	for i := range records {
+		records[i].Key = hashKey(records[i], keys)
+		last[records[i].Key] = i
+	}
+	if len(last) == len(records) {
+		return records
+	}
+	keyed := make([]extractapi.GenericRow, 0, len(last))
+	for i, r := range records {
+		if last[r.Key] == i {
+			keyed = append(keyed, r)
+		}
+	}
+	return keyed
+}
+
+// hashKey returns the FNV-1a hash of the key column values of a record
+func hashKey(r extractapi.GenericRow, keys []int) int64 {
+	h := fnv.New64a()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%v", r.Cols[k])
+		h.Write([]byte{0})
+	}
+	return int64(h.Sum64())
+}
+
+// getPartitions returns the distinct key column values of the records
+// in the order they are first found
+func getPartitions(records []extractapi.GenericRow, keys []int) [][]interface{} {
+	seen := make(map[string]bool)
+	partitions := make([][]interface{}, 0)
+	for _, r := range records {
+		values := make([]interface{}, 0, len(keys))
+		for _, k := range keys {
+			values = append(values, r.Cols[k])
+		}
+		b, _ := json.Marshal(values)
+		if !seen[string(b)] {
+			seen[string(b)] = true
+			partitions = append(partitions, values)
+		}
+	}
+	return partitions
+}
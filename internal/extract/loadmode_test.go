@@ -0,0 +1,147 @@
+package extract
+
+import (
+	"testing"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db"
+	"github.com/churrodata/churro/internal/domain"
+)
+
+func TestKeyRecords(t *testing.T) {
+	es := domain.ExtractSource{
+		ExtractRules: map[string]domain.ExtractRule{
+			"1": {ColumnName: "sku", KeyColumn: true},
+			"2": {ColumnName: "price"},
+			"3": {ColumnName: "vendor", KeyColumn: true},
+		},
+	}
+	cols := []string{"price", "sku", "vendor"}
+
+	keyCols := getKeyColumns(es)
+	if len(keyCols) != 2 || keyCols[0] != "sku" || keyCols[1] != "vendor" {
+		t.Fatalf("getKeyColumns got %v", keyCols)
+	}
+	keys, err := getKeyIndexes(keyCols, cols)
+	if err != nil || len(keys) != 2 || keys[0] != 1 || keys[1] != 2 {
+		t.Fatalf("getKeyIndexes got %v %v", keys, err)
+	}
+	if _, err := getKeyIndexes(nil, cols); err == nil {
+		t.Fatal("getKeyIndexes expected an error without key columns")
+	}
+	if _, err := getKeyIndexes([]string{"region"}, cols); err == nil {
+		t.Fatal("getKeyIndexes expected an error for a column that is not extracted")
+	}
+
+	records := []extractapi.GenericRow{
+		{Key: 1, Cols: []interface{}{"10", "a1", "acme"}},
+		{Key: 2, Cols: []interface{}{"20", "a2", "acme"}},
+		{Key: 3, Cols: []interface{}{"15", "a1", "acme"}},
+	}
+	keyed := keyRecords(records, keys)
+	if len(keyed) != 2 {
+		t.Fatalf("keyRecords got %d records", len(keyed))
+	}
+	if keyed[0].Cols[1] != "a2" || keyed[1].Cols[0] != "15" {
+		t.Fatalf("keyRecords did not keep the last record of a key %v", keyed)
+	}
+
+	// a file loaded again gets the same keys
+	again := keyRecords([]extractapi.GenericRow{{Key: 9, Cols: []interface{}{"15", "a1", "acme"}}}, keys)
+	if again[0].Key != keyed[1].Key {
+		t.Fatalf("keyRecords got key %d expected %d", again[0].Key, keyed[1].Key)
+	}
+	// the values of the key columns are kept apart
+	split := keyRecords([]extractapi.GenericRow{{Cols: []interface{}{"", "a", "1acme"}}, {Cols: []interface{}{"", "a1", "acme"}}}, keys)
+	if len(split) != 2 || split[0].Key == split[1].Key {
+		t.Fatalf("keyRecords got the same key for different values %v", split)
+	}
+}
+
+func TestPartitionSet(t *testing.T) {
+	keys := []int{0}
+	batch1 := []extractapi.GenericRow{
+		{Cols: []interface{}{"acme", "a1"}},
+		{Cols: []interface{}{"zenith", "z1"}},
+		{Cols: []interface{}{"acme", "a2"}},
+	}
+	batch2 := []extractapi.GenericRow{
+		{Cols: []interface{}{"acme", "a3"}},
+		{Cols: []interface{}{"orbit", "o1"}},
+	}
+
+	partitions := getPartitions(batch1, keys)
+	if len(partitions) != 2 || partitions[0][0] != "acme" || partitions[1][0] != "zenith" {
+		t.Fatalf("getPartitions got %v", partitions)
+	}
+
+	var p partitionSet
+	if added := p.add(partitions); len(added) != 2 {
+		t.Fatalf("add got %v", added)
+	}
+	// the rows of acme loaded by the first batch are not deleted
+	added := p.add(getPartitions(batch2, keys))
+	if len(added) != 1 || added[0][0] != "orbit" {
+		t.Fatalf("add of the second batch got %v", added)
+	}
+
+	p.forget(added)
+	if again := p.add(added); len(again) != 1 {
+		t.Fatalf("add after forget got %v", again)
+	}
+}
+
+// copyDB stands in for a database supporting the bulk copy protocol,
+// it counts the records copied and inserted
+type copyDB struct {
+	db.ChurroDatabase
+	copied   int
+	inserted int
+}
+
+func (d *copyDB) GetBulkInsertStatement(scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string) error {
+	d.inserted += len(records)
+	return nil
+}
+
+func (d *copyDB) CopyRecords(scheme, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string) (int64, error) {
+	d.copied += len(records)
+	return int64(len(records)), nil
+}
+
+func TestLoadTableCopyThreshold(t *testing.T) {
+	records := func(n int) []extractapi.GenericRow {
+		r := make([]extractapi.GenericRow, n)
+		for i := range r {
+			r[i] = extractapi.GenericRow{Key: int64(i), Cols: []interface{}{"boerne"}}
+		}
+		return r
+	}
+
+	tests := []struct {
+		name      string
+		threshold int
+		records   int
+		copied    int
+		inserted  int
+	}{
+		{"default below threshold", 0, defaultCopyThreshold - 1, 0, defaultCopyThreshold - 1},
+		{"default at threshold", 0, defaultCopyThreshold, defaultCopyThreshold, 0},
+		{"setting below threshold", 10, 9, 0, 9},
+		{"setting above threshold", 10, 11, 11, 0},
+	}
+	for _, tt := range tests {
+		d := &copyDB{}
+		s := &Server{ExtractSource: domain.ExtractSource{CopyThreshold: tt.threshold}}
+		loaded, err := s.loadTable(d, extractapi.CSVScheme, "pipeline1", "mytable", []string{"city"}, records(tt.records), []string{extractapi.COLTYPE_TEXT}, &partitionSet{})
+		if err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+		if d.copied != tt.copied || d.inserted != tt.inserted {
+			t.Fatalf("%s: copied %d inserted %d, want %d and %d", tt.name, d.copied, d.inserted, tt.copied, tt.inserted)
+		}
+		if loaded != int64(tt.records) {
+			t.Fatalf("%s: loaded %d, want %d", tt.name, loaded, tt.records)
+		}
+	}
+}
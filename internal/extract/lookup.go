@@ -0,0 +1,258 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package extract
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db"
+	"github.com/churrodata/churro/internal/domain"
+)
+
+// referenceData holds the appended column values of the reference rows
+// of a lookup, keyed by the values of the match columns
+type referenceData struct {
+	match   []extractapi.ColumnPair
+	columns []extractapi.ColumnPair
+	rows    map[string][]interface{}
+}
+
+// lookupState caches the reference data of the lookups of a job, it is
+// read the first time a batch is enriched
+type lookupState struct {
+	mu    sync.Mutex
+	data  map[string]*referenceData
+	added map[string]bool
+}
+
+// lookupKey joins the values of the match columns of a row
+func lookupKey(values []interface{}) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		if v != nil {
+			parts[i] = fmt.Sprintf("%v", v)
+		}
+	}
+	return strings.Join(parts, "\x1f")
+}
+
+// getReferenceData returns the reference data of a lookup, reading it
+// from the reference table or file the first time
+func (l *lookupState) getReferenceData(churroDB db.ChurroDatabase, database string, lookup domain.Lookup) (*referenceData, error) {
+	if l.data == nil {
+		l.data = make(map[string]*referenceData)
+	}
+	if ref, ok := l.data[lookup.ID]; ok {
+		return ref, nil
+	}
+
+	ref := &referenceData{rows: make(map[string][]interface{})}
+	var err error
+	ref.match, err = extractapi.ParseColumnPairs(lookup.MatchColumns)
+	if err != nil {
+		return nil, fmt.Errorf("lookup %s match columns %v", lookup.LookupName, err)
+	}
+	ref.columns, err = extractapi.ParseColumnPairs(lookup.Columns)
+	if err != nil {
+		return nil, fmt.Errorf("lookup %s columns %v", lookup.LookupName, err)
+	}
+
+	// the match columns are read first, then the appended columns
+	refCols := make([]string, 0, len(ref.match)+len(ref.columns))
+	for _, p := range ref.match {
+		refCols = append(refCols, p.Reference)
+	}
+	for _, p := range ref.columns {
+		refCols = append(refCols, p.Reference)
+	}
+
+	var rows [][]interface{}
+	if lookup.ReferenceTable != "" {
+		rows, err = churroDB.GetReferenceRows(database, lookup.ReferenceTable, refCols)
+	} else {
+		rows, err = readReferenceFile(lookup.ReferenceFile, refCols)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("lookup %s reference data %v", lookup.LookupName, err)
+	}
+
+	for _, row := range rows {
+		key := lookupKey(row[:len(ref.match)])
+		// the first reference row of a key is used
+		if _, ok := ref.rows[key]; !ok {
+			ref.rows[key] = row[len(ref.match):]
+		}
+	}
+	log.Info().Msg(fmt.Sprintf("lookup %s cached %d reference rows", lookup.LookupName, len(ref.rows)))
+	l.data[lookup.ID] = ref
+	return ref, nil
+}
+
+// readReferenceFile returns the values of the columns of each row of a
+// CSV file, the first row of the file names the columns
+func readReferenceFile(path string, columns []string) ([][]interface{}, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]int, len(header))
+	for i, h := range header {
+		index[strings.TrimSpace(h)] = i
+	}
+	positions := make([]int, len(columns))
+	for i, c := range columns {
+		p, ok := index[c]
+		if !ok {
+			return nil, fmt.Errorf("column %s is not in the header of %s", c, path)
+		}
+		positions[i] = p
+	}
+
+	rows := make([][]interface{}, 0)
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		row := make([]interface{}, len(columns))
+		for i, p := range positions {
+			if p < len(record) {
+				row[i] = record[p]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// runLookups enriches the records with the columns of the lookups of
+// the extract source.  A record is matched with the reference row
+// having the same values in the match columns.  A record without a
+// match keeps the values it has, so appended columns are null and an
+// extracted column a lookup sets is left alone.  Appended columns that are not
+// extracted columns follow the extract columns and are added to the
+// table as TEXT.
+func (s *Server) runLookups(churroDB db.ChurroDatabase, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string) ([]string, []extractapi.GenericRow, []string, error) {
+	if len(s.ExtractSource.Lookups) == 0 {
+		return cols, records, colTypes, nil
+	}
+	lookups := make([]domain.Lookup, 0, len(s.ExtractSource.Lookups))
+	for _, l := range s.ExtractSource.Lookups {
+		lookups = append(lookups, l)
+	}
+	sort.Slice(lookups, func(i, j int) bool { return lookups[i].LookupName < lookups[j].LookupName })
+
+	if s.lookups == nil {
+		s.lookups = &lookupState{}
+	}
+	s.lookups.mu.Lock()
+	defer s.lookups.mu.Unlock()
+
+	outCols := append([]string{}, cols...)
+	outTypes := append([]string{}, colTypes...)
+	index := make(map[string]int, len(cols))
+	for i, c := range cols {
+		index[c] = i
+	}
+	newCols := make([]string, 0)
+
+	for _, lookup := range lookups {
+		ref, err := s.lookups.getReferenceData(churroDB, database, lookup)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		match := make([]int, len(ref.match))
+		for i, p := range ref.match {
+			m, ok := index[p.Column]
+			if !ok {
+				return nil, nil, nil, fmt.Errorf("lookup %s match column %s is not a column of the extract source", lookup.LookupName, p.Column)
+			}
+			match[i] = m
+		}
+		appended := make([]int, len(ref.columns))
+		for i, p := range ref.columns {
+			a, ok := index[p.Column]
+			if !ok {
+				a = len(outCols)
+				index[p.Column] = a
+				outCols = append(outCols, p.Column)
+				outTypes = append(outTypes, extractapi.COLTYPE_TEXT)
+				newCols = append(newCols, p.Column)
+			}
+			appended[i] = a
+		}
+
+		unmatched := 0
+		values := make([]interface{}, len(match))
+		for r := range records {
+			for len(records[r].Cols) < len(outCols) {
+				records[r].Cols = append(records[r].Cols, nil)
+			}
+			for i, m := range match {
+				values[i] = records[r].Cols[m]
+			}
+			row, ok := ref.rows[lookupKey(values)]
+			if !ok {
+				unmatched++
+				continue
+			}
+			for i, a := range appended {
+				records[r].Cols[a] = row[i]
+			}
+		}
+		if unmatched > 0 {
+			log.Info().Msg(fmt.Sprintf("lookup %s found no match for %d rows", lookup.LookupName, unmatched))
+		}
+	}
+
+	if s.lookups.added == nil {
+		s.lookups.added = make(map[string]bool)
+	}
+	names := make([]string, 0)
+	types := make([]string, 0)
+	for _, c := range newCols {
+		if !s.lookups.added[c] {
+			names = append(names, c)
+			types = append(types, extractapi.COLTYPE_TEXT)
+		}
+	}
+	if len(names) > 0 {
+		err := addTableColumns(churroDB, database, tableName, names, types)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		for _, c := range names {
+			s.lookups.added[c] = true
+		}
+	}
+	return outCols, records, outTypes, nil
+}
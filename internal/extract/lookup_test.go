@@ -0,0 +1,102 @@
+package extract
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db/mockdb"
+	"github.com/churrodata/churro/internal/domain"
+)
+
+func TestRunLookups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stores.csv")
+	reference := "code,country,region,manager\n" +
+		"s1,us,south,ann\n" +
+		"s2,us,north,bob\n" +
+		"s1,mx,central,cruz\n"
+	if err := os.WriteFile(path, []byte(reference), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := Server{
+		ExtractSource: domain.ExtractSource{
+			Lookups: map[string]domain.Lookup{
+				"1": {
+					ID:            "1",
+					LookupName:    "stores",
+					ReferenceFile: path,
+					MatchColumns:  "store=code, country",
+					Columns:       "region, store_manager=manager",
+				},
+			},
+		},
+	}
+	churroDB := &mockdb.MockChurroDatabase{}
+	cols := []string{"store", "country", "amount"}
+	types := []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_TEXT, extractapi.COLTYPE_DECIMAL}
+	records := []extractapi.GenericRow{
+		{Key: 1, Cols: []interface{}{"s1", "mx", "10.5"}},
+		{Key: 2, Cols: []interface{}{"s2", "us", "3"}},
+		{Key: 3, Cols: []interface{}{"s9", "us", "1"}},
+	}
+
+	cols, records, types, err := s.runLookups(churroDB, "db", "sales", cols, records, types)
+	if err != nil {
+		t.Fatalf("runLookups Error: %v", err)
+	}
+	if len(cols) != 5 || cols[3] != "region" || cols[4] != "store_manager" || types[4] != extractapi.COLTYPE_TEXT {
+		t.Fatalf("runLookups cols %v types %v", cols, types)
+	}
+	want := [][]interface{}{
+		{"s1", "mx", "10.5", "central", "cruz"},
+		{"s2", "us", "3", "north", "bob"},
+		{"s9", "us", "1", nil, nil},
+	}
+	for i, w := range want {
+		for j := range w {
+			if records[i].Cols[j] != w[j] {
+				t.Fatalf("runLookups record %d got %v want %v", i, records[i].Cols, w)
+			}
+		}
+	}
+	if !s.lookups.added["region"] || len(s.lookups.data) != 1 {
+		t.Fatalf("runLookups state %+v", s.lookups)
+	}
+
+	// the reference data is cached for the job
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	_, records, _, err = s.runLookups(churroDB, "db", "sales", []string{"store", "country"}, []extractapi.GenericRow{{Key: 4, Cols: []interface{}{"s1", "us"}}}, types[:2])
+	if err != nil || records[0].Cols[2] != "south" {
+		t.Fatalf("runLookups of a second batch got %v %v", records, err)
+	}
+
+	// an extracted column a lookup sets keeps its value when no
+	// reference row matches
+	s.ExtractSource.Lookups["1"] = domain.Lookup{ID: "1", LookupName: "stores", ReferenceFile: path, MatchColumns: "store=code, country", Columns: "region"}
+	s.lookups = nil
+	if err := os.WriteFile(path, []byte(reference), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, records, _, err = s.runLookups(churroDB, "db", "sales", []string{"store", "country", "region"}, []extractapi.GenericRow{
+		{Key: 5, Cols: []interface{}{"s2", "us", "west"}},
+		{Key: 6, Cols: []interface{}{"s9", "us", "west"}},
+	}, []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_TEXT, extractapi.COLTYPE_TEXT})
+	if err != nil {
+		t.Fatalf("runLookups Error: %v", err)
+	}
+	if len(records[0].Cols) != 3 || records[0].Cols[2] != "north" || records[1].Cols[2] != "west" {
+		t.Fatalf("runLookups of an extracted column got %v %v", records[0].Cols, records[1].Cols)
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	s.ExtractSource.Lookups["2"] = domain.Lookup{ID: "2", LookupName: "missing", ReferenceFile: path, MatchColumns: "store", Columns: "x"}
+	if _, _, _, err := s.runLookups(churroDB, "db", "sales", cols[:3], records, types[:3]); err == nil {
+		t.Fatal("runLookups expected an error for a missing reference file")
+	}
+}
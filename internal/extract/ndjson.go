@@ -0,0 +1,221 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package extract
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ohler55/ojg/jp"
+	"github.com/rs/zerolog/log"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db"
+	"github.com/churrodata/churro/internal/domain"
+)
+
+type compiledJSONPathRule struct {
+	column extractapi.Column
+	expr   jp.Expr
+}
+
+// ExtractNDJSON Extract a JSON Lines (NDJSON) file and exit, the file
+// is streamed one JSON document per line and the jsonpath extract
+// rules are applied to each line
+func (s *Server) ExtractNDJSON(ctx context.Context) (err error) {
+
+	log.Info().Msg("ExtractNDJSON starting...")
+
+	ndjsonFile, err := s.openFile()
+	if err != nil {
+		return fmt.Errorf("could not open NDJSON file: %s %v", s.FileName, err)
+	}
+	defer ndjsonFile.Close()
+
+	rules, err := getJSONPathRules(getColumns(s.ExtractSource))
+	if err != nil {
+		return err
+	}
+
+	ndjsonStruct := extractapi.GenericFormat{
+		Path:         s.FileName,
+		Dataprov:     s.DP.ID,
+		PipelineName: s.Pi.Name,
+		Columns:      make([]extractapi.Column, 0),
+	}
+	for i := 0; i < len(rules); i++ {
+		ndjsonStruct.Columns = append(ndjsonStruct.Columns, rules[i].column)
+	}
+	ndjsonStruct.ColumnNames = getColumnNames(ndjsonStruct.Columns)
+	ndjsonStruct.ColumnTypes = getColumnTypes(ndjsonStruct.Columns)
+
+	log.Info().Msg(fmt.Sprintf("columnNames %+v", ndjsonStruct.ColumnNames))
+	log.Info().Msg(fmt.Sprintf("columnTypes %+v", ndjsonStruct.ColumnTypes))
+
+	var churroDB db.ChurroDatabase
+	churroDB, err = db.NewChurroDB(s.Pi.Spec.DatabaseType)
+	if err != nil {
+		return err
+	}
+
+	err = churroDB.GetConnection(s.DBCreds, s.Pi.Spec.DataSource)
+	if err != nil {
+		return err
+	}
+
+	err = s.tableCheck(ndjsonStruct.ColumnNames, ndjsonStruct.ColumnTypes)
+	if err != nil {
+		return err
+	}
+	ndjsonStruct.Tablename = s.TableName
+
+	jobProfile := domain.JobProfile{
+		ID:               os.Getenv("CHURRO_EXTRACTLOG"),
+		JobName:          os.Getenv("POD_NAME"),
+		StartDate:        time.Now().Format("2006-01-02 15:04:05"),
+		DataProvenanceID: s.DP.ID,
+		FileName:         s.FileName,
+		TableName:        s.TableName,
+	}
+
+	err = churroDB.CreateExtractLog(jobProfile)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in createextractlog")
+	}
+
+	batch := newRecordBatch(s.ExtractSource)
+	// lines that are not json are loaded into the quarantine table
+	// with the batch they were read in
+	rejects := make([]extractapi.GenericRow, 0)
+
+	var lineNumber int
+	reader := bufio.NewReader(ndjsonFile)
+	for {
+		// ReadBytes is used instead of a Scanner so that a single
+		// line is not limited to the Scanner max token size
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return readErr
+		}
+		lineNumber++
+
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			obj, err := parseNDJSONLine(line)
+			if err != nil {
+				reason := fmt.Sprintf("ndjson line %d is not valid %v", lineNumber, err)
+				log.Error().Msg(reason)
+				rejects = append(rejects, s.rejectLine(line, reason))
+			} else {
+				r := getNDJSONRow(obj, rules)
+				err = s.runRules(ndjsonStruct.ColumnNames, r.Cols)
+				if err != nil {
+					log.Error().Stack().Err(err).Msg("error in RunRules")
+				}
+				batch.add(r, len(line))
+			}
+		}
+
+		if batch.full() || len(rejects) >= batch.maxRows || (readErr == io.EOF && (!batch.empty() || len(rejects) > 0)) {
+			err = s.loadLineRejects(&jobProfile, churroDB, extractapi.NDJSONScheme, rejects)
+			if err != nil {
+				return err
+			}
+			rejects = rejects[:0]
+
+			ndjsonStruct.Records = batch.records
+			err = s.loadBatch(&jobProfile, churroDB, ndjsonStruct, extractapi.NDJSONScheme)
+			if err != nil {
+				return err
+			}
+			batch.reset()
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+	}
+
+	log.Info().Msg(fmt.Sprintf("end of ndjson file reached, %d records loaded", jobProfile.RecordsLoaded))
+
+	return nil
+}
+
+// getJSONPathRules compiles the jsonpath of each column once so that
+// it can be reused for every line
+func getJSONPathRules(cols []extractapi.Column) (rules []compiledJSONPathRule, err error) {
+	for i := 0; i < len(cols); i++ {
+		x, err := jp.ParseString(cols[i].Path)
+		if err != nil {
+			return rules, fmt.Errorf("error parsing jsonpath %s %v", cols[i].Path, err)
+		}
+		rules = append(rules, compiledJSONPathRule{column: cols[i], expr: x})
+	}
+	return rules, nil
+}
+
+// getNDJSONRow applies each rule to a single parsed line, when a
+// jsonpath matches more than one value, the first value is used.  A
+// path the line does not have is a null value.
+func getNDJSONRow(obj interface{}, rules []compiledJSONPathRule) extractapi.GenericRow {
+	r := extractapi.GenericRow{
+		Key:  nextRowKey(),
+		Cols: make([]interface{}, len(rules)),
+	}
+	for i := 0; i < len(rules); i++ {
+		values := rules[i].expr.Get(obj)
+		if len(values) == 0 {
+			continue
+		}
+		r.Cols[i] = getNDJSONValue(values[0])
+	}
+	return r
+}
+
+// parseNDJSONLine parses a single line, numbers are kept as
+// json.Number so that large integers are not rounded
+func parseNDJSONLine(line []byte) (obj interface{}, err error) {
+	d := json.NewDecoder(bytes.NewReader(line))
+	d.UseNumber()
+	err = d.Decode(&obj)
+	if err != nil {
+		return obj, err
+	}
+	if d.More() {
+		return obj, errors.New("more than one json document on the line")
+	}
+	return obj, nil
+}
+
+// getNDJSONValue returns a json value as loaded, a json null is nil
+func getNDJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	}
+	// objects and arrays are kept as json
+	b, _ := json.Marshal(value)
+	return string(b)
+}
@@ -0,0 +1,182 @@
+package extract
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/api/v1alpha1"
+	"github.com/churrodata/churro/internal/db"
+	"github.com/churrodata/churro/internal/db/sqlite"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/churrodata/churro/pkg/config"
+)
+
+const ndjsonTestData = `{"name":"joe","age":42,"address":{"city":"boerne"}}
+
+{"name":"mary","age":37.5,"address":{"city":"keller"},"tags":["a","b"]}
+{"name":"sam","active":true}`
+
+func TestExtractNDJSON(t *testing.T) {
+
+	// create a temp file based on the example data
+	f, err := ioutil.TempFile("/tmp", "myndjsontest")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(ndjsonTestData); err != nil {
+		log.Fatal(err)
+	}
+	f.Close()
+
+	pipeline := v1alpha1.Pipeline{
+		Spec: v1alpha1.PipelineSpec{
+			DatabaseType: domain.DatabaseMock,
+		},
+	}
+
+	rule := domain.ExtractRule{
+		ID:              "rule1",
+		ExtractSourceID: "one",
+		ColumnName:      "name",
+		ColumnPath:      "$.name",
+		ColumnType:      "TEXT",
+	}
+	rule2 := domain.ExtractRule{
+		ID:              "rule2",
+		ExtractSourceID: "one",
+		ColumnName:      "city",
+		ColumnPath:      "$.address.city",
+		ColumnType:      "TEXT",
+	}
+
+	extractRules := make(map[string]domain.ExtractRule)
+	extractRules[rule.ID] = rule
+	extractRules[rule2.ID] = rule2
+
+	extractSource := domain.ExtractSource{
+		ID:           "one",
+		Name:         "my-ndjson-files",
+		Path:         "/tmp",
+		Scheme:       extractapi.NDJSONScheme,
+		ExtractRules: extractRules,
+		Tablename:    "myndjsontable",
+	}
+
+	s := Server{
+		DBCreds:       config.DBCredentials{},
+		FileName:      f.Name(),
+		Pi:            pipeline,
+		ExtractSource: extractSource,
+		SchemeValue:   extractapi.NDJSONScheme,
+	}
+
+	err = s.ExtractNDJSON(context.TODO())
+	if err != nil {
+		t.Fatalf("extract.ExtractNDJSON Error: %v", err)
+	}
+
+	rules, err := getJSONPathRules([]extractapi.Column{
+		{Name: "age", Path: "$.age"},
+		{Name: "city", Path: "$.address.city"},
+		{Name: "tags", Path: "$.tags"},
+		{Name: "active", Path: "$.active"},
+		{Name: "zip", Path: "$.address.zip"},
+	})
+	if err != nil {
+		t.Fatalf("extract.getJSONPathRules Error: %v", err)
+	}
+	// a missing path and a json null are both null
+	obj, err := parseNDJSONLine([]byte(`{"age":37.5,"address":{"city":"keller","zip":null},"tags":["a","b"]}`))
+	if err != nil {
+		t.Fatalf("extract.parseNDJSONLine Error: %v", err)
+	}
+	r := getNDJSONRow(obj, rules)
+	expected := []interface{}{"37.5", "keller", `["a","b"]`, nil, nil}
+	for i := 0; i < len(expected); i++ {
+		if r.Cols[i] != expected[i] {
+			t.Fatalf("extract.getNDJSONRow column %d got %#v expected %#v", i, r.Cols[i], expected[i])
+		}
+	}
+
+}
+
+func TestExtractNDJSONMalformedLine(t *testing.T) {
+	dir := t.TempDir()
+	os.Setenv("CHURRO_NAMESPACE", "pipeline1")
+	os.Setenv("CHURRO_EXTRACTLOG", "ndjsonjob")
+	defer os.Unsetenv("CHURRO_EXTRACTLOG")
+	fileName := filepath.Join(dir, "people.ndjson")
+	data := "{\"name\":\"joe\"}\n{\"name\":\"mary\"\n{\"name\":\"sam\"}\n"
+	if err := ioutil.WriteFile(fileName, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pipeline := v1alpha1.Pipeline{
+		Spec: v1alpha1.PipelineSpec{
+			DatabaseType: domain.DatabaseSqlite,
+			DataSource:   v1alpha1.Source{Path: dir, Database: "pipeline1", Username: "pipeline1"},
+		},
+	}
+	pipeline.Name = "pipeline1"
+
+	churroDB, err := db.NewChurroDB(domain.DatabaseSqlite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := churroDB.GetConnection(config.DBCredentials{}, pipeline.Spec.DataSource); err != nil {
+		t.Fatal(err)
+	}
+	if err := churroDB.CreatePipelineObjects("pipeline1", "pipeline1"); err != nil {
+		t.Fatal(err)
+	}
+
+	s := Server{
+		FileName: fileName,
+		Pi:       pipeline,
+		ExtractSource: domain.ExtractSource{
+			ID:     "one",
+			Path:   dir,
+			Scheme: extractapi.NDJSONScheme,
+			ExtractRules: map[string]domain.ExtractRule{
+				"rule1": {ID: "rule1", ExtractSourceID: "one", ColumnName: "name", ColumnPath: "$.name", ColumnType: "TEXT"},
+			},
+			Tablename: "mypeople",
+		},
+		TableName:   "mypeople",
+		SchemeValue: extractapi.NDJSONScheme,
+	}
+
+	// the bad line is quarantined and the lines after it still load
+	err = s.ExtractNDJSON(context.TODO())
+	if err != nil {
+		t.Fatalf("extract.ExtractNDJSON Error: %v", err)
+	}
+
+	conn := churroDB.(*sqlite.SqliteChurroDatabase).Connection
+	var count int
+	if err := conn.QueryRow("select count(*) from mypeople").Scan(&count); err != nil || count != 2 {
+		t.Fatalf("loaded %d rows %v, want 2", count, err)
+	}
+	var reason, record string
+	if err := conn.QueryRow("select reason, record from "+rejectTableName("mypeople")).Scan(&reason, &record); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(reason, "line 2") || record != `{"name":"mary"` {
+		t.Fatalf("rejected line reason %q record %q", reason, record)
+	}
+
+	jp, err := churroDB.GetExtractLogById("ndjsonjob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jp.RecordsLoaded != 2 || jp.RecordsRejected != 1 {
+		t.Fatalf("extract log loaded %d rejected %d, want 2 and 1", jp.RecordsLoaded, jp.RecordsRejected)
+	}
+}
@@ -0,0 +1,375 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package extract
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/common"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/schema"
+	"github.com/xitongsys/parquet-go/types"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/archive"
+	"github.com/churrodata/churro/internal/db"
+	"github.com/churrodata/churro/internal/domain"
+)
+
+// number of parquet rows read per column and pushed per load
+const parquetBatchSize = 500
+
+// parquetColumn ties an extract rule column to its parquet schema element
+type parquetColumn struct {
+	column  extractapi.Column
+	inPath  string
+	element *parquet.SchemaElement
+	// repeated is set when the field or one of its parents is repeated,
+	// a definition level below listLevel is a row with no values and
+	// one below nullLevel a row whose list is null
+	repeated  bool
+	listLevel int32
+	nullLevel int32
+}
+
+// ExtractParquet Extract a Parquet file contents and exit, extract rule
+// column paths are parquet column names, nested fields are addressed
+// using a dotted path (e.g. address.city)
+func (s *Server) ExtractParquet(ctx context.Context) (err error) {
+
+	log.Info().Msg("ExtractParquet starting...")
+
+	// parquet needs random access so compressed files are spooled
+	// to a local temporary file first
+	localFile, cleanup, err := archive.Spool(s.FileName, s.ArchiveMember)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("could not decompress parquet file " + s.FileName)
+		return err
+	}
+	defer cleanup()
+
+	fr, err := local.NewLocalFileReader(localFile)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("could not open parquet file " + s.FileName)
+		return err
+	}
+	defer fr.Close()
+
+	pr, err := reader.NewParquetColumnReader(fr, 1)
+	if err != nil {
+		return fmt.Errorf("could not read parquet file: %s %v", s.FileName, err)
+	}
+	defer pr.ReadStop()
+
+	cols, err := getParquetColumns(pr.SchemaHandler, getColumns(s.ExtractSource))
+	if err != nil {
+		return err
+	}
+
+	parquetStruct := extractapi.GenericFormat{
+		Path:         s.FileName,
+		Dataprov:     s.DP.ID,
+		PipelineName: s.Pi.Name,
+		Columns:      make([]extractapi.Column, 0),
+	}
+	for i := 0; i < len(cols); i++ {
+		parquetStruct.Columns = append(parquetStruct.Columns, cols[i].column)
+	}
+	parquetStruct.ColumnNames = getColumnNames(parquetStruct.Columns)
+	parquetStruct.ColumnTypes = getColumnTypes(parquetStruct.Columns)
+
+	log.Info().Msg(fmt.Sprintf("columns %+v", parquetStruct.Columns))
+	log.Info().Msg(fmt.Sprintf("columnNames %+v", parquetStruct.ColumnNames))
+	log.Info().Msg(fmt.Sprintf("columnTypes %+v", parquetStruct.ColumnTypes))
+
+	var churroDB db.ChurroDatabase
+	churroDB, err = db.NewChurroDB(s.Pi.Spec.DatabaseType)
+	if err != nil {
+		return err
+	}
+
+	err = churroDB.GetConnection(s.DBCreds, s.Pi.Spec.DataSource)
+	if err != nil {
+		return err
+	}
+
+	// initialize the table using the types found in the parquet schema
+	err = s.tableCheck(parquetStruct.ColumnNames, parquetStruct.ColumnTypes)
+	if err != nil {
+		return err
+	}
+	parquetStruct.Tablename = s.TableName
+
+	numRows := pr.GetNumRows()
+	log.Info().Msg(fmt.Sprintf("parquet rows to process %d", numRows))
+
+	// the job profile is written once and then updated as each
+	// batch commits
+	jobProfile := domain.JobProfile{
+		ID:               os.Getenv("CHURRO_EXTRACTLOG"),
+		JobName:          os.Getenv("POD_NAME"),
+		StartDate:        time.Now().Format("2006-01-02 15:04:05"),
+		DataProvenanceID: s.DP.ID,
+		FileName:         s.FileName,
+		TableName:        s.TableName,
+	}
+	log.Info().Msg(fmt.Sprintf("creating extract log of %v", jobProfile))
+
+	err = churroDB.CreateExtractLog(jobProfile)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in createextractlog")
+	}
+
+	for rowsRead := int64(0); rowsRead < numRows; {
+		batch := int64(parquetBatchSize)
+		if numRows-rowsRead < batch {
+			batch = numRows - rowsRead
+		}
+
+		allCols := make([][]interface{}, 0)
+		for i := 0; i < len(cols); i++ {
+			values, rls, dls, err := pr.ReadColumnByPath(cols[i].inPath, batch)
+			if err != nil {
+				return fmt.Errorf("error reading parquet column %s %v", cols[i].column.Path, err)
+			}
+			allCols = append(allCols, getParquetColumnValues(values, rls, dls, cols[i]))
+		}
+
+		parquetStruct.Records = make([]extractapi.GenericRow, 0)
+		for row := int64(0); row < batch; row++ {
+			r := extractapi.GenericRow{
+				Key:  nextRowKey(),
+				Cols: make([]interface{}, len(cols)),
+			}
+			for c := 0; c < len(allCols); c++ {
+				if row < int64(len(allCols[c])) {
+					r.Cols[c] = allCols[c][row]
+				}
+			}
+			err := s.runRules(parquetStruct.ColumnNames, r.Cols)
+			if err != nil {
+				log.Error().Stack().Err(err).Msg("error in RunRules")
+			}
+			parquetStruct.Records = append(parquetStruct.Records, r)
+		}
+		rowsRead += batch
+
+		err = s.loadBatch(&jobProfile, churroDB, parquetStruct, extractapi.ParquetScheme)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Info().Msg(fmt.Sprintf("end of parquet file reached, %d records loaded", jobProfile.RecordsLoaded))
+
+	return nil
+}
+
+// getParquetColumns resolves each column path against the parquet schema
+// and sets the column type from the schema element
+func getParquetColumns(sh *schema.SchemaHandler, inCols []extractapi.Column) (cols []parquetColumn, err error) {
+	for i := 0; i < len(inCols); i++ {
+		exPath := sh.GetRootExName() + common.PAR_GO_PATH_DELIMITER + strings.Replace(inCols[i].Path, ".", common.PAR_GO_PATH_DELIMITER, -1)
+		inPath, err := sh.ConvertToInPathStr(exPath)
+		if err != nil {
+			return cols, fmt.Errorf("parquet column %s not found in file schema", inCols[i].Path)
+		}
+		index, ok := sh.MapIndex[inPath]
+		if !ok {
+			return cols, fmt.Errorf("parquet column %s not found in file schema", inCols[i].Path)
+		}
+		element := sh.SchemaElements[index]
+		if element.GetNumChildren() > 0 {
+			return cols, fmt.Errorf("parquet column %s is a group, a leaf field is required", inCols[i].Path)
+		}
+
+		c := parquetColumn{column: inCols[i], inPath: inPath, element: element}
+		c.column.Type = getParquetColumnType(element)
+		err = setParquetRepetition(sh, &c)
+		if err != nil {
+			return cols, fmt.Errorf("parquet column %s %v", inCols[i].Path, err)
+		}
+		if c.repeated {
+			// repeated values are loaded as a json array
+			c.column.Type = extractapi.COLTYPE_TEXT
+		}
+		cols = append(cols, c)
+	}
+	return cols, nil
+}
+
+// setParquetRepetition finds the innermost repeated field of the column
+// path, the definition levels of it and of its parent tell an empty or
+// null list from a list of values
+func setParquetRepetition(sh *schema.SchemaHandler, c *parquetColumn) error {
+	path := common.StrToPath(c.inPath)
+	for i := len(path); i >= 2; i-- {
+		rt, err := sh.GetRepetitionType(path[:i])
+		if err != nil {
+			return err
+		}
+		if rt != parquet.FieldRepetitionType_REPEATED {
+			continue
+		}
+		c.repeated = true
+		c.listLevel, err = sh.MaxDefinitionLevel(path[:i])
+		if err != nil {
+			return err
+		}
+		c.nullLevel, err = sh.MaxDefinitionLevel(path[:i-1])
+		return err
+	}
+	return nil
+}
+
+// getParquetColumnType maps a parquet schema element to a column type
+func getParquetColumnType(element *parquet.SchemaElement) string {
+	if element.IsSetConvertedType() {
+		switch element.GetConvertedType() {
+		case parquet.ConvertedType_DECIMAL:
+			return fmt.Sprintf("%s(%d,%d)", extractapi.COLTYPE_DECIMAL, element.GetPrecision(), element.GetScale())
+		case parquet.ConvertedType_DATE:
+			return extractapi.COLTYPE_DATE
+		case parquet.ConvertedType_TIMESTAMP_MILLIS, parquet.ConvertedType_TIMESTAMP_MICROS:
+			return extractapi.COLTYPE_TIMESTAMP
+		case parquet.ConvertedType_UTF8, parquet.ConvertedType_ENUM, parquet.ConvertedType_JSON:
+			return extractapi.COLTYPE_TEXT
+		}
+	}
+
+	if element.IsSetLogicalType() && element.GetLogicalType().IsSetTIMESTAMP() {
+		return extractapi.COLTYPE_TIMESTAMP
+	}
+
+	switch element.GetType() {
+	case parquet.Type_BOOLEAN:
+		return extractapi.COLTYPE_BOOLEAN
+	case parquet.Type_INT32:
+		return extractapi.COLTYPE_INT
+	case parquet.Type_INT64:
+		return extractapi.COLTYPE_BIGINT
+	case parquet.Type_FLOAT, parquet.Type_DOUBLE:
+		return extractapi.COLTYPE_DOUBLE
+	case parquet.Type_INT96:
+		return extractapi.COLTYPE_TIMESTAMP
+	}
+	return extractapi.COLTYPE_TEXT
+}
+
+// getParquetColumnValues groups the values read from a column into rows
+// using the repetition levels, a repetition level of 0 starts a new row.
+// The values of a repeated column are a json array, a null value or
+// list is nil.
+func getParquetColumnValues(values []interface{}, rls, dls []int32, c parquetColumn) (rows []interface{}) {
+	if !c.repeated {
+		for i := 0; i < len(values); i++ {
+			rows = append(rows, getParquetValue(values[i], c.element))
+		}
+		return rows
+	}
+
+	lists := make([][]interface{}, 0)
+	nulls := make([]bool, 0)
+	for i := 0; i < len(values); i++ {
+		if i >= len(rls) || rls[i] == 0 {
+			lists = append(lists, make([]interface{}, 0))
+			nulls = append(nulls, false)
+		}
+		last := len(lists) - 1
+		if i < len(dls) && dls[i] < c.listLevel {
+			// the row has no values, its list is empty or null
+			nulls[last] = dls[i] < c.nullLevel
+			continue
+		}
+		lists[last] = append(lists[last], getParquetValue(values[i], c.element))
+	}
+
+	for i := 0; i < len(lists); i++ {
+		if nulls[i] {
+			rows = append(rows, nil)
+			continue
+		}
+		b, _ := json.Marshal(lists[i])
+		rows = append(rows, string(b))
+	}
+	return rows
+}
+
+// getParquetValue converts a single parquet value to its string form,
+// a null value is nil
+func getParquetValue(value interface{}, element *parquet.SchemaElement) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	convertedType := parquet.ConvertedType(-1)
+	if element.IsSetConvertedType() {
+		convertedType = element.GetConvertedType()
+	}
+	precision := int(element.GetPrecision())
+	scale := int(element.GetScale())
+
+	switch v := value.(type) {
+	case bool:
+		return strconv.FormatBool(v)
+	case int32:
+		switch convertedType {
+		case parquet.ConvertedType_DATE:
+			return time.Unix(int64(v)*24*60*60, 0).UTC().Format("2006-01-02")
+		case parquet.ConvertedType_DECIMAL:
+			return types.DECIMAL_INT_ToString(int64(v), precision, scale)
+		}
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		switch convertedType {
+		case parquet.ConvertedType_TIMESTAMP_MILLIS:
+			return types.TIMESTAMP_MILLISToTime(v, true).Format("2006-01-02 15:04:05.999999")
+		case parquet.ConvertedType_TIMESTAMP_MICROS:
+			return types.TIMESTAMP_MICROSToTime(v, true).Format("2006-01-02 15:04:05.999999")
+		case parquet.ConvertedType_DECIMAL:
+			return types.DECIMAL_INT_ToString(v, precision, scale)
+		}
+		if element.IsSetLogicalType() && element.GetLogicalType().IsSetTIMESTAMP() {
+			unit := element.GetLogicalType().GetTIMESTAMP().GetUnit()
+			switch {
+			case unit.IsSetMILLIS():
+				return types.TIMESTAMP_MILLISToTime(v, true).Format("2006-01-02 15:04:05.999999")
+			case unit.IsSetMICROS():
+				return types.TIMESTAMP_MICROSToTime(v, true).Format("2006-01-02 15:04:05.999999")
+			case unit.IsSetNANOS():
+				return types.TIMESTAMP_NANOSToTime(v, true).Format("2006-01-02 15:04:05.999999")
+			}
+		}
+		return strconv.FormatInt(v, 10)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		if element.GetType() == parquet.Type_INT96 {
+			return types.INT96ToTime(v).Format("2006-01-02 15:04:05.999999")
+		}
+		if convertedType == parquet.ConvertedType_DECIMAL {
+			return types.DECIMAL_BYTE_ARRAY_ToString([]byte(v), precision, scale)
+		}
+		return v
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,280 @@
+package extract
+
+import (
+	"context"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/writer"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/api/v1alpha1"
+	"github.com/churrodata/churro/internal/db"
+	"github.com/churrodata/churro/internal/db/sqlite"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/churrodata/churro/pkg/config"
+)
+
+type parquetTestAddress struct {
+	City string `parquet:"name=city, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Zip  int32  `parquet:"name=zip, type=INT32"`
+}
+
+type parquetTestRow struct {
+	Num     int64              `parquet:"name=num, type=INT64"`
+	Price   float64            `parquet:"name=price, type=DOUBLE"`
+	Address parquetTestAddress `parquet:"name=address"`
+}
+
+type parquetTestPhone struct {
+	Number string `parquet:"name=number, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+// parquetTestNullRow has optional, repeated and nested repeated columns
+type parquetTestNullRow struct {
+	Num     int64               `parquet:"name=num, type=INT64"`
+	Nick    *string             `parquet:"name=nick, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL"`
+	Address *parquetTestAddress `parquet:"name=address, repetitiontype=OPTIONAL"`
+	Tags    []string            `parquet:"name=tags, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=REPEATED"`
+	Phones  []parquetTestPhone  `parquet:"name=phones, repetitiontype=REPEATED"`
+}
+
+func TestExtractParquet(t *testing.T) {
+
+	// create a temp file based on the example data
+	f, err := ioutil.TempFile("/tmp", "myparquettest")
+	if err != nil {
+		log.Fatal(err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	fw, err := local.NewLocalFileWriter(f.Name())
+	if err != nil {
+		log.Fatal(err)
+	}
+	pw, err := writer.NewParquetWriter(fw, new(parquetTestRow), 1)
+	if err != nil {
+		log.Fatal(err)
+	}
+	rows := []parquetTestRow{
+		{Num: 2, Price: 1.5, Address: parquetTestAddress{City: "boerne", Zip: 78006}},
+		{Num: 3, Price: 2.25, Address: parquetTestAddress{City: "keller", Zip: 76248}},
+	}
+	for _, r := range rows {
+		if err := pw.Write(r); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err := pw.WriteStop(); err != nil {
+		log.Fatal(err)
+	}
+	fw.Close()
+
+	pipeline := v1alpha1.Pipeline{
+		Spec: v1alpha1.PipelineSpec{
+			DatabaseType: domain.DatabaseMock,
+		},
+	}
+
+	rule := domain.ExtractRule{
+		ID:              "rule1",
+		ExtractSourceID: "one",
+		ColumnName:      "city",
+		ColumnPath:      "address.city",
+		ColumnType:      "TEXT",
+	}
+	rule2 := domain.ExtractRule{
+		ID:              "rule2",
+		ExtractSourceID: "one",
+		ColumnName:      "num",
+		ColumnPath:      "num",
+		ColumnType:      "TEXT",
+	}
+
+	extractRules := make(map[string]domain.ExtractRule)
+	extractRules[rule.ID] = rule
+	extractRules[rule2.ID] = rule2
+
+	extractSource := domain.ExtractSource{
+		ID:           "one",
+		Name:         "my-parquet-files",
+		Path:         "/tmp",
+		Scheme:       extractapi.ParquetScheme,
+		ExtractRules: extractRules,
+		Tablename:    "myparquettable",
+	}
+
+	s := Server{
+		DBCreds:       config.DBCredentials{},
+		FileName:      f.Name(),
+		Pi:            pipeline,
+		ExtractSource: extractSource,
+		SchemeValue:   extractapi.ParquetScheme,
+	}
+
+	err = s.ExtractParquet(context.TODO())
+	if err != nil {
+		t.Fatalf("extract.ExtractParquet Error: %v", err)
+	}
+
+	// the column types come from the parquet schema, not the rules
+	fr, err := local.NewLocalFileReader(f.Name())
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer fr.Close()
+	pr, err := reader.NewParquetColumnReader(fr, 1)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer pr.ReadStop()
+
+	cols, err := getParquetColumns(pr.SchemaHandler, []extractapi.Column{
+		{Name: "city", Path: "address.city"},
+		{Name: "num", Path: "num"},
+		{Name: "price", Path: "price"},
+	})
+	if err != nil {
+		t.Fatalf("extract.getParquetColumns Error: %v", err)
+	}
+	expected := []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_BIGINT, extractapi.COLTYPE_DOUBLE}
+	for i := 0; i < len(expected); i++ {
+		if cols[i].column.Type != expected[i] {
+			t.Fatalf("extract.getParquetColumns column %s type %s expected %s", cols[i].column.Name, cols[i].column.Type, expected[i])
+		}
+	}
+
+	values, rls, dls, err := pr.ReadColumnByPath(cols[0].inPath, 2)
+	if err != nil {
+		t.Fatalf("extract.ReadColumnByPath Error: %v", err)
+	}
+	cities := getParquetColumnValues(values, rls, dls, cols[0])
+	if len(cities) != 2 || cities[1] != "keller" {
+		t.Fatalf("extract.getParquetColumnValues unexpected values %v", cities)
+	}
+
+	_, err = getParquetColumns(pr.SchemaHandler, []extractapi.Column{{Name: "bad", Path: "address.state"}})
+	if err == nil {
+		t.Fatalf("extract.getParquetColumns expected an error for an unknown column")
+	}
+}
+
+func TestExtractParquetNulls(t *testing.T) {
+	dir := t.TempDir()
+	os.Setenv("CHURRO_NAMESPACE", "pipeline1")
+	os.Setenv("CHURRO_EXTRACTLOG", "parquetjob")
+	defer os.Unsetenv("CHURRO_EXTRACTLOG")
+	fileName := filepath.Join(dir, "people.parquet")
+
+	fw, err := local.NewLocalFileWriter(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pw, err := writer.NewParquetWriter(fw, new(parquetTestNullRow), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nick := "bo"
+	rows := []parquetTestNullRow{
+		{Num: 1, Nick: &nick, Address: &parquetTestAddress{City: "boerne"}, Tags: []string{"a", "b"}, Phones: []parquetTestPhone{{"111"}, {"222"}}},
+		{Num: 2},
+	}
+	for _, r := range rows {
+		if err := pw.Write(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := pw.WriteStop(); err != nil {
+		t.Fatal(err)
+	}
+	fw.Close()
+
+	pipeline := v1alpha1.Pipeline{
+		Spec: v1alpha1.PipelineSpec{
+			DatabaseType: domain.DatabaseSqlite,
+			DataSource:   v1alpha1.Source{Path: dir, Database: "pipeline1", Username: "pipeline1"},
+		},
+	}
+	pipeline.Name = "pipeline1"
+
+	churroDB, err := db.NewChurroDB(domain.DatabaseSqlite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := churroDB.GetConnection(config.DBCredentials{}, pipeline.Spec.DataSource); err != nil {
+		t.Fatal(err)
+	}
+	if err := churroDB.CreatePipelineObjects("pipeline1", "pipeline1"); err != nil {
+		t.Fatal(err)
+	}
+
+	extractRules := make(map[string]domain.ExtractRule)
+	for i, c := range [][]string{{"num", "num"}, {"nick", "nick"}, {"city", "address.city"}, {"tags", "tags"}, {"phones", "phones.number"}} {
+		id := string(rune('a' + i))
+		extractRules[id] = domain.ExtractRule{ID: id, ExtractSourceID: "one", ColumnName: c[0], ColumnPath: c[1], ColumnType: "TEXT"}
+	}
+
+	s := Server{
+		FileName: fileName,
+		Pi:       pipeline,
+		ExtractSource: domain.ExtractSource{
+			ID:           "one",
+			Path:         dir,
+			Scheme:       extractapi.ParquetScheme,
+			ExtractRules: extractRules,
+			Tablename:    "mypeople",
+		},
+		TableName:   "mypeople",
+		SchemeValue: extractapi.ParquetScheme,
+	}
+	err = s.ExtractParquet(context.TODO())
+	if err != nil {
+		t.Fatalf("extract.ExtractParquet Error: %v", err)
+	}
+
+	conn := churroDB.(*sqlite.SqliteChurroDatabase).Connection
+	result, err := conn.Query("select nick, city, tags, phones from mypeople order by num")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer result.Close()
+	var got [][]interface{}
+	for result.Next() {
+		var nick, city, tags, phones interface{}
+		if err := result.Scan(&nick, &city, &tags, &phones); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, []interface{}{nick, city, tags, phones})
+	}
+	if len(got) != 2 {
+		t.Fatalf("loaded %d rows, want 2", len(got))
+	}
+	want := []string{"bo", "boerne", `["a","b"]`, `["111","222"]`}
+	for i, w := range want {
+		if v, ok := got[0][i].(string); !ok || v != w {
+			t.Fatalf("first row column %d got %v, want %s", i, got[0][i], w)
+		}
+	}
+	// a missing value or group is null, a missing list is empty
+	if got[1][0] != nil || got[1][1] != nil {
+		t.Fatalf("second row nulls loaded as %#v", got[1])
+	}
+	if got[1][2] != "[]" || got[1][3] != "[]" {
+		t.Fatalf("second row lists loaded as %v", got[1])
+	}
+
+	// the extract log counts the rows as they load
+	jp, err := churroDB.GetExtractLogById("parquetjob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jp.RecordsLoaded != 2 {
+		t.Fatalf("extract log records loaded %d, want 2", jp.RecordsLoaded)
+	}
+}
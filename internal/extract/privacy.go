@@ -0,0 +1,184 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package extract
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/churrodata/churro/internal/privacy"
+)
+
+// redactedReason replaces a redacted value in the reason a row was
+// rejected
+const redactedReason = "[redacted]"
+
+// privacyColumn is a column with a privacy policy
+type privacyColumn struct {
+	column string
+	policy privacy.Policy
+}
+
+// privacyState holds the privacy keys of the job and the table columns
+// whose policy was recorded in the privacy audit
+type privacyState struct {
+	mu      sync.Mutex
+	keys    *privacy.Keys
+	audited map[string]bool
+}
+
+// getPrivacyColumns returns the extract rules of the extract source
+// that have a privacy policy, sorted by column name
+func getPrivacyColumns(es domain.ExtractSource) ([]privacyColumn, error) {
+	columns := make([]privacyColumn, 0)
+	for _, r := range es.ExtractRules {
+		if strings.TrimSpace(r.PrivacyPolicy) == "" {
+			continue
+		}
+		p, err := privacy.Parse(r.PrivacyPolicy)
+		if err != nil {
+			return nil, fmt.Errorf("column %s %v", r.ColumnName, err)
+		}
+		columns = append(columns, privacyColumn{column: r.ColumnName, policy: p})
+	}
+	sort.Slice(columns, func(i, j int) bool { return columns[i].column < columns[j].column })
+	return columns, nil
+}
+
+// privacyKeys returns the keys of the job, read from the environment
+// the first time they are needed
+func (s *Server) privacyKeys() privacy.Keys {
+	if s.privacy == nil {
+		s.privacy = &privacyState{}
+	}
+	s.privacy.mu.Lock()
+	defer s.privacy.mu.Unlock()
+	if s.privacy.keys == nil {
+		keys := privacy.KeysFromEnv()
+		s.privacy.keys = &keys
+	}
+	return *s.privacy.keys
+}
+
+// protectRecords applies the privacy policies of the extract rules to
+// the records loaded into a table, replacing the values in place.  The
+// first time a job applies a policy to a column of a table the policy
+// is recorded in the privacy audit of the dataprov.
+func (s *Server) protectRecords(churroDB db.ChurroDatabase, tableName string, cols []string, records []extractapi.GenericRow) error {
+	columns, err := getPrivacyColumns(s.ExtractSource)
+	if err != nil || len(columns) == 0 {
+		return err
+	}
+	keys := s.privacyKeys()
+	for _, c := range columns {
+		if err := c.policy.Check(keys); err != nil {
+			return fmt.Errorf("column %s %w", c.column, err)
+		}
+	}
+
+	index := make(map[string]int, len(cols))
+	for i, c := range cols {
+		index[c] = i
+	}
+	for _, r := range records {
+		for _, c := range columns {
+			i, ok := index[c.column]
+			if !ok || i >= len(r.Cols) {
+				continue
+			}
+			r.Cols[i], err = c.policy.Apply(r.Cols[i], keys)
+			if err != nil {
+				return fmt.Errorf("column %s %v", c.column, err)
+			}
+		}
+	}
+
+	return s.auditPrivacy(churroDB, tableName, columns, index)
+}
+
+// auditPrivacy records the policies applied to the columns of a table
+// that the job has not recorded yet
+func (s *Server) auditPrivacy(churroDB db.ChurroDatabase, tableName string, columns []privacyColumn, index map[string]int) error {
+	s.privacy.mu.Lock()
+	defer s.privacy.mu.Unlock()
+	if s.privacy.audited == nil {
+		s.privacy.audited = make(map[string]bool)
+	}
+	for _, c := range columns {
+		key := tableName + "." + c.column
+		if _, ok := index[c.column]; !ok || s.privacy.audited[key] {
+			continue
+		}
+		a := domain.PrivacyAudit{
+			ID:          xid.New().String(),
+			DataprovID:  s.DP.ID,
+			TableName:   tableName,
+			ColumnName:  c.column,
+			Policy:      c.policy.String(),
+			LastUpdated: time.Now(),
+		}
+		if err := churroDB.CreatePrivacyAudit(a); err != nil {
+			return err
+		}
+		log.Info().Msg(fmt.Sprintf("privacy policy %s applied to %s of dataprov %s", a.Policy, key, s.DP.ID))
+		s.privacy.audited[key] = true
+	}
+	return nil
+}
+
+// protectReject applies the privacy policies to a copy of the values of
+// a rejected record, so the quarantine table holds no raw values of a
+// protected column, and removes the raw values from the reason.  A value
+// a policy fails on is redacted.
+func (s *Server) protectReject(cols []string, values []interface{}, reason string) ([]interface{}, string) {
+	columns, err := getPrivacyColumns(s.ExtractSource)
+	if err != nil || len(columns) == 0 {
+		return values, reason
+	}
+	keys := s.privacyKeys()
+
+	protected := make([]interface{}, len(values))
+	copy(protected, values)
+	index := make(map[string]int, len(cols))
+	for i, c := range cols {
+		index[c] = i
+	}
+	for _, c := range columns {
+		i, ok := index[c.column]
+		if !ok || i >= len(values) || values[i] == nil {
+			continue
+		}
+		v, err := c.policy.Apply(values[i], keys)
+		if err != nil {
+			v = nil
+		}
+		protected[i] = v
+		raw := fmt.Sprintf("%v", values[i])
+		if raw == "" {
+			continue
+		}
+		replacement := redactedReason
+		if v != nil {
+			replacement = fmt.Sprintf("%v", v)
+		}
+		reason = strings.ReplaceAll(reason, raw, replacement)
+	}
+	return protected, reason
+}
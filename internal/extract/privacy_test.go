@@ -0,0 +1,75 @@
+package extract
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db/mockdb"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/churrodata/churro/internal/privacy"
+)
+
+func TestProtectRecords(t *testing.T) {
+	key := []byte("secret")
+	s := Server{
+		DP: domain.DataProvenance{ID: "dp1"},
+		ExtractSource: domain.ExtractSource{
+			ExtractRules: map[string]domain.ExtractRule{
+				"1": {ColumnName: "name"},
+				"2": {ColumnName: "ssn", PrivacyPolicy: "tokenize"},
+				"3": {ColumnName: "card", PrivacyPolicy: "mask(4)"},
+				"4": {ColumnName: "email", PrivacyPolicy: "hash"},
+				"5": {ColumnName: "notes", PrivacyPolicy: "redact"},
+			},
+		},
+		privacy: &privacyState{keys: &privacy.Keys{Salt: []byte("pepper"), TokenKey: key}},
+	}
+	churroDB := &mockdb.MockChurroDatabase{}
+	cols := []string{"name", "ssn", "card", "email", "notes"}
+	records := []extractapi.GenericRow{
+		{Key: 1, Cols: []interface{}{"jane", "123-45-6789", "4111111111111111", "jane@example.com", "vip"}},
+		{Key: 2, Cols: []interface{}{"john", nil, "", "john@example.com", nil}},
+	}
+
+	if err := s.protectRecords(churroDB, "people", cols, records); err != nil {
+		t.Fatalf("protectRecords Error: %v", err)
+	}
+	r := records[0].Cols
+	if r[0] != "jane" || r[2] != "************1111" || r[4] != nil {
+		t.Fatalf("protectRecords got %v", r)
+	}
+	if ssn, err := privacy.Detokenize(r[1].(string), key); err != nil || ssn != "123-45-6789" {
+		t.Fatalf("protectRecords token %v got %q %v", r[1], ssn, err)
+	}
+	if email := r[3].(string); len(email) != 64 || strings.Contains(email, "jane") {
+		t.Fatalf("protectRecords hash %v", r[3])
+	}
+	if records[1].Cols[1] != nil || records[1].Cols[2] != "" {
+		t.Fatalf("protectRecords null and blank values got %v", records[1].Cols)
+	}
+	for _, c := range []string{"people.ssn", "people.card", "people.email", "people.notes"} {
+		if !s.privacy.audited[c] {
+			t.Fatalf("protectRecords audited %v", s.privacy.audited)
+		}
+	}
+	if s.privacy.audited["people.name"] {
+		t.Fatal("protectRecords should not audit a column without a policy")
+	}
+
+	reject := s.rejectRow(cols, extractapi.GenericRow{Key: 3, Cols: []interface{}{"jim", "987-65-4321", "5500000000000004", "x", "y"}}, `ssn value "987-65-4321" is not unique`)
+	record := reject.Cols[2].(string)
+	if strings.Contains(record, "987-65-4321") || strings.Contains(record, "5500000000000004") || !strings.Contains(record, "jim") {
+		t.Fatalf("rejectRow record %s", record)
+	}
+	if reason := reject.Cols[1].(string); strings.Contains(reason, "987-65-4321") {
+		t.Fatalf("rejectRow reason %s", reason)
+	}
+
+	s.privacy = &privacyState{keys: &privacy.Keys{}}
+	err := s.protectRecords(churroDB, "people", cols, []extractapi.GenericRow{{Key: 4, Cols: []interface{}{"a", "b", "c", "d", "e"}}})
+	if !errors.Is(err, privacy.ErrNoKey) {
+		t.Fatalf("protectRecords without keys should fail with ErrNoKey, got %v", err)
+	}
+}
@@ -0,0 +1,288 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package extract
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db"
+	"github.com/churrodata/churro/internal/domain"
+)
+
+// the columns of the quarantine table rows failing a data quality rule
+// are loaded into, the record is the JSON of the rejected row
+var (
+	rejectColumns     = []string{"dataprov_id", "reason", "record"}
+	rejectColumnTypes = []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_TEXT, extractapi.COLTYPE_TEXT}
+)
+
+// rejectTableName returns the quarantine table of a table
+func rejectTableName(tableName string) string {
+	return tableName + "_rejects"
+}
+
+// qualityRule holds the data quality checks of an extract rule
+type qualityRule struct {
+	column  string
+	allowed map[string]bool
+	regex   *regexp.Regexp
+	notNull bool
+	min     *float64
+	max     *float64
+	unique  bool
+}
+
+// getQualityRules returns the extract rules of the extract source that
+// have data quality checks, sorted by column name
+func getQualityRules(es domain.ExtractSource) ([]qualityRule, error) {
+	rules := make([]qualityRule, 0)
+	for _, r := range es.ExtractRules {
+		q := qualityRule{column: r.ColumnName, notNull: r.NotNull, unique: r.Unique}
+		if strings.TrimSpace(r.MatchValues) != "" {
+			q.allowed = make(map[string]bool)
+			for _, v := range strings.Split(r.MatchValues, ",") {
+				q.allowed[strings.TrimSpace(v)] = true
+			}
+		}
+		if r.MatchRegex != "" {
+			regex, err := regexp.Compile(r.MatchRegex)
+			if err != nil {
+				return nil, fmt.Errorf("column %s match regex is not valid %v", r.ColumnName, err)
+			}
+			q.regex = regex
+		}
+		if r.MinValue != "" {
+			min, err := strconv.ParseFloat(r.MinValue, 64)
+			if err != nil {
+				return nil, fmt.Errorf("column %s minimum value %q is not a number", r.ColumnName, r.MinValue)
+			}
+			q.min = &min
+		}
+		if r.MaxValue != "" {
+			max, err := strconv.ParseFloat(r.MaxValue, 64)
+			if err != nil {
+				return nil, fmt.Errorf("column %s maximum value %q is not a number", r.ColumnName, r.MaxValue)
+			}
+			q.max = &max
+		}
+		if q.allowed != nil || q.regex != nil || q.notNull || q.min != nil || q.max != nil || q.unique {
+			rules = append(rules, q)
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].column < rules[j].column })
+	return rules, nil
+}
+
+// check returns why the value fails the rule, it is blank when the
+// value passes
+func (q qualityRule) check(v interface{}) string {
+	s := ""
+	if v != nil {
+		s = strings.TrimSpace(fmt.Sprintf("%v", v))
+	}
+	if s == "" || s == "null" {
+		if q.notNull {
+			return fmt.Sprintf("%s is null", q.column)
+		}
+		return ""
+	}
+	if q.allowed != nil && !q.allowed[s] {
+		return fmt.Sprintf("%s value %q is not an allowed value", q.column, s)
+	}
+	if q.regex != nil && !q.regex.MatchString(s) {
+		return fmt.Sprintf("%s value %q does not match %s", q.column, s, q.regex.String())
+	}
+	if q.min != nil || q.max != nil {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Sprintf("%s value %q is not a number", q.column, s)
+		}
+		if q.min != nil && f < *q.min {
+			return fmt.Sprintf("%s value %s is less than %v", q.column, s, *q.min)
+		}
+		if q.max != nil && f > *q.max {
+			return fmt.Sprintf("%s value %s is greater than %v", q.column, s, *q.max)
+		}
+	}
+	return ""
+}
+
+// qualityState holds what the data quality rules of a job remember
+// across batches, the values of unique columns already loaded and the
+// rows rejected
+type qualityState struct {
+	mu           sync.Mutex
+	seen         map[string]map[string]bool
+	rejected     int
+	tableCreated bool
+}
+
+// total returns the rows of the job that were rejected
+func (q *qualityState) total() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.rejected
+}
+
+// checkQuality returns the records passing the data quality rules of
+// the extract rules and a reject row for each record failing them.  A
+// value of a unique column is taken by the first record loaded with it.
+func (s *Server) checkQuality(cols []string, records []extractapi.GenericRow) (passed []extractapi.GenericRow, rejects []extractapi.GenericRow, err error) {
+	rules, err := getQualityRules(s.ExtractSource)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rules) == 0 {
+		return records, nil, nil
+	}
+
+	index := make(map[string]int, len(cols))
+	for i, c := range cols {
+		index[c] = i
+	}
+
+	if s.quality == nil {
+		s.quality = &qualityState{}
+	}
+	s.quality.mu.Lock()
+	defer s.quality.mu.Unlock()
+	if s.quality.seen == nil {
+		s.quality.seen = make(map[string]map[string]bool)
+	}
+
+	passed = make([]extractapi.GenericRow, 0, len(records))
+	for _, r := range records {
+		reasons := make([]string, 0)
+		for _, q := range rules {
+			i, ok := index[q.column]
+			if !ok || i >= len(r.Cols) {
+				continue
+			}
+			if reason := q.check(r.Cols[i]); reason != "" {
+				reasons = append(reasons, reason)
+			}
+		}
+		if len(reasons) == 0 {
+			reasons = s.quality.checkUnique(rules, index, r)
+		}
+		if len(reasons) > 0 {
+			rejects = append(rejects, s.rejectRow(cols, r, strings.Join(reasons, "; ")))
+			continue
+		}
+		passed = append(passed, r)
+	}
+	s.quality.rejected += len(rejects)
+	return passed, rejects, nil
+}
+
+// rejectRow returns the row of the quarantine table for a rejected
+// record, the privacy policies are applied to the record and reason
+func (s *Server) rejectRow(cols []string, r extractapi.GenericRow, reason string) extractapi.GenericRow {
+	values, reason := s.protectReject(cols, r.Cols, reason)
+	b, _ := json.Marshal(values)
+	return extractapi.GenericRow{
+		Key:  r.Key,
+		Cols: []interface{}{s.DP.ID, reason, string(b)},
+	}
+}
+
+// rejectLine returns the row of the quarantine table for a line of a
+// file that could not be parsed, the record is the line as read.  The
+// values of protected columns can not be told apart in such a line so
+// it is left out when the extract source has privacy policies.
+func (s *Server) rejectLine(line []byte, reason string) extractapi.GenericRow {
+	var record interface{} = string(line)
+	if columns, err := getPrivacyColumns(s.ExtractSource); err != nil || len(columns) > 0 {
+		record = nil
+	}
+	return extractapi.GenericRow{
+		Key:  nextRowKey(),
+		Cols: []interface{}{s.DP.ID, reason, record},
+	}
+}
+
+// loadLineRejects loads the rows of lines that could not be parsed into
+// the quarantine table and counts them in the job profile
+func (s *Server) loadLineRejects(jobProfile *domain.JobProfile, churroDB db.ChurroDatabase, scheme string, rejects []extractapi.GenericRow) error {
+	if len(rejects) == 0 {
+		return nil
+	}
+	if s.quality == nil {
+		s.quality = &qualityState{}
+	}
+	err := s.loadRejects(churroDB, scheme, s.Pi.Spec.DataSource.Database, s.TableName, rejects)
+	if err != nil {
+		return err
+	}
+	s.quality.mu.Lock()
+	s.quality.rejected += len(rejects)
+	s.quality.mu.Unlock()
+	jobProfile.RecordsRejected = s.quality.total()
+	return nil
+}
+
+// checkUnique returns why a record repeats a value of a unique column,
+// the values of a record that is not rejected are remembered
+func (q *qualityState) checkUnique(rules []qualityRule, index map[string]int, r extractapi.GenericRow) []string {
+	reasons := make([]string, 0)
+	values := make(map[string]string)
+	for _, rule := range rules {
+		i, ok := index[rule.column]
+		if !rule.unique || !ok || i >= len(r.Cols) || r.Cols[i] == nil {
+			continue
+		}
+		v := fmt.Sprintf("%v", r.Cols[i])
+		if q.seen[rule.column][v] {
+			reasons = append(reasons, fmt.Sprintf("%s value %q is not unique", rule.column, v))
+		}
+		values[rule.column] = v
+	}
+	if len(reasons) > 0 {
+		return reasons
+	}
+	for c, v := range values {
+		if q.seen[c] == nil {
+			q.seen[c] = make(map[string]bool)
+		}
+		q.seen[c][v] = true
+	}
+	return nil
+}
+
+// loadRejects loads the rejected rows into the quarantine table of the
+// table, creating it the first time rows of the job are rejected
+func (s *Server) loadRejects(churroDB db.ChurroDatabase, scheme, database, tableName string, rejects []extractapi.GenericRow) error {
+	if len(rejects) == 0 {
+		return nil
+	}
+	rejectTable := rejectTableName(tableName)
+	log.Info().Msg(fmt.Sprintf("%d rows rejected by data quality rules, loading them into %s", len(rejects), rejectTable))
+
+	s.quality.mu.Lock()
+	defer s.quality.mu.Unlock()
+	if !s.quality.tableCreated {
+		err := churroDB.CreateTable(s.Pi.Spec.DataSource.Username, database, rejectTable, rejectColumns, rejectColumnTypes)
+		if err != nil {
+			return err
+		}
+		s.quality.tableCreated = true
+	}
+	return churroDB.GetBulkInsertStatement(scheme, database, rejectTable, rejectColumns, rejects, rejectColumnTypes)
+}
@@ -0,0 +1,71 @@
+package extract
+
+import (
+	"strings"
+	"testing"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/domain"
+)
+
+func TestCheckQuality(t *testing.T) {
+	s := Server{
+		DP: domain.DataProvenance{ID: "dp1"},
+		ExtractSource: domain.ExtractSource{
+			ExtractRules: map[string]domain.ExtractRule{
+				"1": {ColumnName: "sku", MatchRegex: "^[a-z][0-9]+$", NotNull: true, Unique: true},
+				"2": {ColumnName: "qty", MinValue: "0", MaxValue: "100"},
+				"3": {ColumnName: "vendor", MatchValues: "acme, zenith"},
+				"4": {ColumnName: "note"},
+			},
+		},
+	}
+	cols := []string{"sku", "qty", "vendor", "note"}
+
+	batch1 := []extractapi.GenericRow{
+		{Key: 1, Cols: []interface{}{"a1", "10", "acme", "ok"}},
+		{Key: 2, Cols: []interface{}{"", "5", "acme", "no sku"}},
+		{Key: 3, Cols: []interface{}{"a3", "101", "orbit", "too many"}},
+		{Key: 4, Cols: []interface{}{"a1", "1", "zenith", "repeated"}},
+		{Key: 5, Cols: []interface{}{"a5", "null", "", "blanks pass"}},
+	}
+	passed, rejects, err := s.checkQuality(cols, batch1)
+	if err != nil {
+		t.Fatalf("checkQuality Error: %v", err)
+	}
+	if len(passed) != 2 || passed[0].Key != 1 || passed[1].Key != 5 {
+		t.Fatalf("checkQuality passed %+v", passed)
+	}
+	if len(rejects) != 3 {
+		t.Fatalf("checkQuality rejected %+v", rejects)
+	}
+	for i, want := range []string{"sku is null", "qty value 101 is greater than 100; vendor value \"orbit\" is not an allowed value", "sku value \"a1\" is not unique"} {
+		if rejects[i].Cols[0] != "dp1" || rejects[i].Cols[1] != want {
+			t.Fatalf("reject %d got %v expected %q", i, rejects[i].Cols, want)
+		}
+	}
+	if !strings.Contains(rejects[1].Cols[2].(string), "too many") {
+		t.Fatalf("reject record got %v", rejects[1].Cols[2])
+	}
+
+	// the unique values of an earlier batch of the file are remembered
+	batch2 := []extractapi.GenericRow{
+		{Key: 6, Cols: []interface{}{"a5", "1", "acme", ""}},
+		{Key: 7, Cols: []interface{}{"b7", "x", "acme", ""}},
+		{Key: 8, Cols: []interface{}{"b8", "2", "zenith", ""}},
+	}
+	passed, rejects, err = s.checkQuality(cols, batch2)
+	if err != nil || len(passed) != 1 || passed[0].Key != 8 || len(rejects) != 2 {
+		t.Fatalf("checkQuality of the second batch got %+v %+v %v", passed, rejects, err)
+	}
+	if s.quality.total() != 5 {
+		t.Fatalf("rejected got %d expected 5", s.quality.total())
+	}
+
+	// without quality rules every record passes
+	s.ExtractSource.ExtractRules = map[string]domain.ExtractRule{"4": {ColumnName: "note"}}
+	passed, rejects, err = s.checkQuality(cols, batch1)
+	if err != nil || len(passed) != len(batch1) || len(rejects) != 0 {
+		t.Fatalf("checkQuality without rules got %+v %+v %v", passed, rejects, err)
+	}
+}
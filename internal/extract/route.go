@@ -0,0 +1,186 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package extract
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/churrodata/churro/internal/predicate"
+)
+
+// route is a route of the extract source with its predicate compiled,
+// the table is blank when the route drops the rows it matches
+type route struct {
+	name      string
+	table     string
+	predicate *predicate.Predicate
+}
+
+// getRoutes returns the routes of the extract source in position order,
+// routes of the same position are ordered by name
+func getRoutes(es domain.ExtractSource) ([]route, error) {
+	sorted := make([]domain.Route, 0, len(es.Routes))
+	for _, r := range es.Routes {
+		sorted = append(sorted, r)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Position != sorted[j].Position {
+			return sorted[i].Position < sorted[j].Position
+		}
+		return sorted[i].RouteName < sorted[j].RouteName
+	})
+
+	routes := make([]route, 0, len(sorted))
+	for _, r := range sorted {
+		p, err := predicate.Compile(r.Predicate)
+		if err != nil {
+			return nil, fmt.Errorf("route %s %v", r.RouteName, err)
+		}
+		rt := route{name: r.RouteName, predicate: p}
+		if !r.Drop {
+			rt.table = r.TargetTable
+		}
+		routes = append(routes, rt)
+	}
+	return routes, nil
+}
+
+// routeState holds the routed tables a job checked, with the number of
+// columns they were checked with, and the partitions replaced in each
+type routeState struct {
+	mu         sync.Mutex
+	checked    map[string]int
+	partitions map[string]*partitionSet
+}
+
+// partitionSet returns the partitions replaced in a routed table
+func (r *routeState) partitionSet(tableName string) *partitionSet {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.partitions == nil {
+		r.partitions = make(map[string]*partitionSet)
+	}
+	p, ok := r.partitions[tableName]
+	if !ok {
+		p = &partitionSet{}
+		r.partitions[tableName] = p
+	}
+	return p
+}
+
+// routedBatch holds the records of a batch going to one table
+type routedBatch struct {
+	table   string
+	records []extractapi.GenericRow
+}
+
+// routeRecords splits the records by the table of the first route
+// matching them, records no route matches stay in tableName.  Records a
+// route drops are left out and records a predicate fails on are
+// returned as rejects.
+func (s *Server) routeRecords(tableName string, cols []string, records []extractapi.GenericRow) ([]routedBatch, []extractapi.GenericRow, error) {
+	routes, err := getRoutes(s.ExtractSource)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(routes) == 0 {
+		return []routedBatch{{table: tableName, records: records}}, nil, nil
+	}
+
+	batches := []routedBatch{{table: tableName}}
+	index := map[string]int{tableName: 0}
+	rejects := make([]extractapi.GenericRow, 0)
+	dropped := 0
+	for _, r := range records {
+		row := make(map[string]interface{}, len(cols))
+		for i, c := range cols {
+			if i < len(r.Cols) {
+				row[c] = r.Cols[i]
+			}
+		}
+
+		target := tableName
+		var reason string
+		for _, rt := range routes {
+			matched, err := rt.predicate.Match(row)
+			if err != nil {
+				reason = fmt.Sprintf("route %s %v", rt.name, err)
+				break
+			}
+			if matched {
+				target = rt.table
+				break
+			}
+		}
+		switch {
+		case reason != "":
+			rejects = append(rejects, s.rejectRow(cols, r, reason))
+			continue
+		case target == "":
+			dropped++
+			continue
+		}
+
+		i, ok := index[target]
+		if !ok {
+			i = len(batches)
+			index[target] = i
+			batches = append(batches, routedBatch{table: target})
+		}
+		batches[i].records = append(batches[i].records, r)
+	}
+	if dropped > 0 {
+		log.Info().Msg(fmt.Sprintf("%d rows dropped by routes", dropped))
+	}
+	if len(rejects) > 0 {
+		if s.quality == nil {
+			s.quality = &qualityState{}
+		}
+		s.quality.mu.Lock()
+		s.quality.rejected += len(rejects)
+		s.quality.mu.Unlock()
+	}
+	return batches, rejects, nil
+}
+
+// checkRouteTable creates a routed table the first time rows are routed
+// to it and adds the columns derived or looked up since it was checked
+func (s *Server) checkRouteTable(churroDB db.ChurroDatabase, database, tableName string, cols, colTypes []string) error {
+	if s.routes == nil {
+		s.routes = &routeState{}
+	}
+	s.routes.mu.Lock()
+	defer s.routes.mu.Unlock()
+	if s.routes.checked == nil {
+		s.routes.checked = make(map[string]int)
+	}
+	if s.routes.checked[tableName] == len(cols) {
+		return nil
+	}
+
+	changed, err := s.checkTable(churroDB, database, tableName, cols, colTypes)
+	if err != nil {
+		return err
+	}
+	if len(changed) > 0 {
+		return fmt.Errorf("routed table %s does not match the extract rules, %s", tableName, strings.Join(changed, ", "))
+	}
+	s.routes.checked[tableName] = len(cols)
+	return nil
+}
@@ -0,0 +1,79 @@
+package extract
+
+import (
+	"testing"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db/mockdb"
+	"github.com/churrodata/churro/internal/domain"
+)
+
+func TestRouteRecords(t *testing.T) {
+	s := Server{
+		ExtractSource: domain.ExtractSource{
+			Routes: map[string]domain.Route{
+				"1": {ID: "1", RouteName: "refunds", Position: 1, Predicate: `type == "refund"`, TargetTable: "refunds"},
+				"2": {ID: "2", RouteName: "voids", Position: 2, Predicate: `type == "void"`, Drop: true},
+				"3": {ID: "3", RouteName: "big", Position: 0, Predicate: `amount > 1000`, TargetTable: "review"},
+				"4": {ID: "4", RouteName: "flagged", Position: 3, Predicate: `flag && type == "sale"`, TargetTable: "flagged"},
+			},
+		},
+	}
+	cols := []string{"type", "amount", "flag"}
+	records := []extractapi.GenericRow{
+		{Key: 1, Cols: []interface{}{"sale", "10", "false"}},
+		{Key: 2, Cols: []interface{}{"refund", "5", "false"}},
+		{Key: 3, Cols: []interface{}{"void", "7", "false"}},
+		{Key: 4, Cols: []interface{}{"refund", "5000", "false"}},
+		{Key: 5, Cols: []interface{}{"sale", "20", "maybe"}},
+		{Key: 6, Cols: []interface{}{"sale", "30", "false"}},
+	}
+
+	batches, rejects, err := s.routeRecords("transactions", cols, records)
+	if err != nil {
+		t.Fatalf("routeRecords Error: %v", err)
+	}
+	want := map[string][]int64{
+		"transactions": {1, 6},
+		"refunds":      {2},
+		"review":       {4},
+	}
+	if len(batches) != len(want) || batches[0].table != "transactions" {
+		t.Fatalf("routeRecords got %v", batches)
+	}
+	for _, b := range batches {
+		keys := want[b.table]
+		if len(b.records) != len(keys) {
+			t.Fatalf("routeRecords table %s got %v want keys %v", b.table, b.records, keys)
+		}
+		for i, r := range b.records {
+			if r.Key != keys[i] {
+				t.Fatalf("routeRecords table %s got %v want keys %v", b.table, b.records, keys)
+			}
+		}
+	}
+	if len(rejects) != 1 || rejects[0].Key != 5 {
+		t.Fatalf("routeRecords rejects %v", rejects)
+	}
+	if s.quality.total() != 1 {
+		t.Fatalf("routeRecords rejected %d", s.quality.total())
+	}
+
+	churroDB := &mockdb.MockChurroDatabase{}
+	types := []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_DECIMAL, extractapi.COLTYPE_TEXT}
+	if err := s.checkRouteTable(churroDB, "db", "refunds", cols, types); err != nil {
+		t.Fatalf("checkRouteTable Error: %v", err)
+	}
+	if s.routes.checked["refunds"] != 3 {
+		t.Fatalf("checkRouteTable checked %v", s.routes.checked)
+	}
+	if s.routes.partitionSet("refunds") != s.routes.partitionSet("refunds") || s.routes.partitionSet("refunds") == s.routes.partitionSet("review") {
+		t.Fatal("partitionSet should be kept per routed table")
+	}
+
+	s.ExtractSource.Routes = nil
+	batches, _, err = s.routeRecords("transactions", cols, records)
+	if err != nil || len(batches) != 1 || len(batches[0].records) != len(records) {
+		t.Fatalf("routeRecords without routes got %v %v", batches, err)
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package extract
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db"
+	"github.com/churrodata/churro/internal/transform"
+)
+
+// derivedColumns holds the columns the row transform functions of a
+// job derived, in the order they were first returned, with the column
+// type of their first value
+type derivedColumns struct {
+	mu    sync.Mutex
+	names []string
+	types map[string]string
+	added map[string]bool
+}
+
+// add remembers a derived column, a nil value does not give the
+// column a type so it is remembered once a value is returned
+func (d *derivedColumns) add(name string, v interface{}) {
+	if v == nil {
+		return
+	}
+	if d.types == nil {
+		d.types = make(map[string]string)
+	}
+	if _, ok := d.types[name]; ok {
+		return
+	}
+	d.names = append(d.names, name)
+	d.types[name] = derivedColumnType(v)
+}
+
+// derivedColumnType returns the column type of a value returned by a
+// row transform function
+func derivedColumnType(v interface{}) string {
+	switch v.(type) {
+	case bool:
+		return extractapi.COLTYPE_BOOLEAN
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return extractapi.COLTYPE_BIGINT
+	case float32, float64:
+		return extractapi.COLTYPE_DOUBLE
+	case time.Time:
+		return extractapi.COLTYPE_TIMESTAMP
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return extractapi.COLTYPE_JSONB
+	}
+	return extractapi.COLTYPE_TEXT
+}
+
+// rowValue returns a value returned by a row transform function as it
+// is loaded, maps and slices are loaded as JSON
+func rowValue(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(b)
+	}
+	return v
+}
+
+// runRowFunctions runs the row transform functions of the extract
+// rules on each record.  A record a function drops is not loaded and
+// a record a function fails on is rejected.  The columns the functions
+// derive follow the extract columns and are added to the table.
+func (s *Server) runRowFunctions(churroDB db.ChurroDatabase, database, tableName string, cols []string, records []extractapi.GenericRow, colTypes []string) ([]string, []extractapi.GenericRow, []string, []extractapi.GenericRow, error) {
+	if s.transforms == nil {
+		s.transforms = transform.Compile(s.TransformFunctions)
+	}
+	names := s.transforms.RowFunctions(s.ExtractSource.ExtractRules)
+	if len(names) == 0 {
+		return cols, records, colTypes, nil, nil
+	}
+
+	if s.derived == nil {
+		s.derived = &derivedColumns{}
+	}
+	s.derived.mu.Lock()
+	defer s.derived.mu.Unlock()
+
+	index := make(map[string]bool, len(cols))
+	for _, c := range cols {
+		index[c] = true
+	}
+
+	rows := make([]map[string]interface{}, 0, len(records))
+	keys := make([]int64, 0, len(records))
+	rejects := make([]extractapi.GenericRow, 0)
+	dropped := 0
+	for _, r := range records {
+		row := make(map[string]interface{}, len(cols))
+		for i, c := range cols {
+			if i < len(r.Cols) {
+				row[c] = r.Cols[i]
+			}
+		}
+		out, err := s.transforms.RunRow(names, row)
+		if err != nil {
+			rejects = append(rejects, s.rejectRow(cols, r, err.Error()))
+			continue
+		}
+		if out == nil {
+			dropped++
+			continue
+		}
+		for k, v := range out {
+			if !index[k] {
+				s.derived.add(k, v)
+			}
+		}
+		rows = append(rows, out)
+		keys = append(keys, r.Key)
+	}
+	if dropped > 0 {
+		log.Info().Msg(fmt.Sprintf("%d rows dropped by row transform functions", dropped))
+	}
+	if len(rejects) > 0 {
+		if s.quality == nil {
+			s.quality = &qualityState{}
+		}
+		s.quality.mu.Lock()
+		s.quality.rejected += len(rejects)
+		s.quality.mu.Unlock()
+	}
+
+	outCols := append([]string{}, cols...)
+	outTypes := append([]string{}, colTypes...)
+	for _, name := range s.derived.names {
+		outCols = append(outCols, name)
+		outTypes = append(outTypes, s.derived.types[name])
+	}
+
+	err := s.addDerivedColumns(churroDB, database, tableName)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	outRecords := make([]extractapi.GenericRow, len(rows))
+	for i, row := range rows {
+		values := make([]interface{}, len(outCols))
+		for j, c := range outCols {
+			values[j] = rowValue(row[c])
+		}
+		outRecords[i] = extractapi.GenericRow{Key: keys[i], Cols: values}
+	}
+	return outCols, outRecords, outTypes, rejects, nil
+}
+
+// addDerivedColumns adds the derived columns not yet added to the
+// table, a column the table already has must have the derived type
+func (s *Server) addDerivedColumns(churroDB db.ChurroDatabase, database, tableName string) error {
+	if s.derived.added == nil {
+		s.derived.added = make(map[string]bool)
+	}
+	names := make([]string, 0)
+	types := make([]string, 0)
+	for _, name := range s.derived.names {
+		if !s.derived.added[name] {
+			names = append(names, name)
+			types = append(types, s.derived.types[name])
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	err := addTableColumns(churroDB, database, tableName, names, types)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		s.derived.added[name] = true
+	}
+	return nil
+}
+
+// addTableColumns adds the columns a table does not have, a column the
+// table already has must have the column type
+func addTableColumns(churroDB db.ChurroDatabase, database, tableName string, names, types []string) error {
+	live, err := churroDB.GetTableColumns(database, tableName)
+	if err != nil {
+		return err
+	}
+	added, changed, err := compareColumns(live, names, types, churroDB.GetColumnType)
+	if err != nil {
+		return err
+	}
+	if len(changed) > 0 {
+		return fmt.Errorf("table %s does not match the added columns, %s", tableName, strings.Join(changed, ", "))
+	}
+	for _, i := range added {
+		log.Info().Msg(fmt.Sprintf("adding column %s %s to %s", names[i], types[i], tableName))
+		err = churroDB.AddColumn(database, tableName, names[i], types[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package extract
+
+import (
+	"testing"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db/mockdb"
+	"github.com/churrodata/churro/internal/domain"
+)
+
+const splitSource = `package rows
+
+      import (
+        "errors"
+        "strings"
+      )
+
+      func Split(row map[string]interface{}) (map[string]interface{}, error) {
+        name, _ := row["name"].(string)
+        if name == "" {
+          return nil, nil
+        }
+        parts := strings.Fields(name)
+        if len(parts) != 2 {
+          return nil, errors.New("name is not a first and last name")
+        }
+        row["first"] = parts[0]
+        row["last"] = parts[1]
+        row["words"] = len(parts)
+        return row, nil
+      }`
+
+func TestRunRowFunctions(t *testing.T) {
+	s := Server{
+		DP: domain.DataProvenance{ID: "dp1"},
+		TransformFunctions: []domain.TransformFunction{
+			{Name: "rows.Split", Source: splitSource},
+		},
+		ExtractSource: domain.ExtractSource{
+			ExtractRules: map[string]domain.ExtractRule{
+				"1": {ColumnName: "name", TransformFunction: "rows.Split"},
+				"2": {ColumnName: "city"},
+			},
+		},
+	}
+	churroDB := &mockdb.MockChurroDatabase{}
+	cols := []string{"city", "name"}
+	types := []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_TEXT}
+	records := []extractapi.GenericRow{
+		{Key: 1, Cols: []interface{}{"boerne", "ann lee"}},
+		{Key: 2, Cols: []interface{}{"austin", ""}},
+		{Key: 3, Cols: []interface{}{"dallas", "cher"}},
+	}
+
+	cols, records, types, rejects, err := s.runRowFunctions(churroDB, "db", "t", cols, records, types)
+	if err != nil {
+		t.Fatalf("runRowFunctions Error: %v", err)
+	}
+	if len(cols) != 5 || cols[0] != "city" || cols[1] != "name" || len(types) != 5 {
+		t.Fatalf("runRowFunctions cols %v types %v", cols, types)
+	}
+	got := make(map[string]interface{})
+	for i, c := range cols {
+		got[c] = records[0].Cols[i]
+		if c == "words" && types[i] != extractapi.COLTYPE_BIGINT {
+			t.Fatalf("derived column words type got %s", types[i])
+		}
+	}
+	if len(records) != 1 || records[0].Key != 1 || got["first"] != "ann" || got["last"] != "lee" || got["city"] != "boerne" {
+		t.Fatalf("runRowFunctions records %+v", records)
+	}
+	if len(rejects) != 1 || rejects[0].Key != 3 || rejects[0].Cols[0] != "dp1" {
+		t.Fatalf("runRowFunctions rejects %+v", rejects)
+	}
+	if !s.derived.added["first"] || s.quality.total() != 1 {
+		t.Fatalf("runRowFunctions derived %+v rejected %d", s.derived, s.quality.total())
+	}
+}
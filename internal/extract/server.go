@@ -13,15 +13,19 @@ package extract
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
 
 	extractapi "github.com/churrodata/churro/api/extract"
 	"github.com/churrodata/churro/api/v1alpha1"
+	"github.com/churrodata/churro/internal/archive"
 	"github.com/churrodata/churro/internal/dataprov"
 	"github.com/churrodata/churro/internal/db"
 	"github.com/churrodata/churro/internal/domain"
+	"github.com/churrodata/churro/internal/transform"
 	"github.com/churrodata/churro/pkg"
 	"github.com/churrodata/churro/pkg/config"
 	pb "github.com/churrodata/churro/rpc/extract"
@@ -33,22 +37,52 @@ var backPressure int32
 
 // Server is the extract server configuration
 type Server struct {
-	Pi                 v1alpha1.Pipeline
-	ServiceCreds       config.ServiceCredentials
-	DBCreds            config.DBCredentials
-	TableName          string
-	SchemeValue        string
-	FileName           string
+	Pi           v1alpha1.Pipeline
+	ServiceCreds config.ServiceCredentials
+	DBCreds      config.DBCredentials
+	TableName    string
+	SchemeValue  string
+	FileName     string
+	// ArchiveMember is the member of the FileName archive to extract,
+	// it is blank when FileName is not an archive
+	ArchiveMember      string
 	DP                 domain.DataProvenance
 	TransformFunctions []domain.TransformFunction
 	ExtractSource      domain.ExtractSource
 	APIStopTime        int
+	// partitions replaced by the job in the replace-partition mode
+	partitions *partitionSet
+	// conversions counts the values that did not convert to their
+	// column type
+	conversions *conversionCount
+	// quality holds the unique values and rejected rows of the job
+	quality *qualityState
+	// transforms are the TransformFunctions compiled for the job
+	transforms *transform.Functions
+	// derived holds the columns row transform functions derived
+	derived *derivedColumns
+	// lookups caches the reference data of the lookups of the job
+	lookups *lookupState
+	// routes holds the routed tables checked and their partitions
+	routes *routeState
+	// dedup holds the hashes of the rows duplicates are dropped of
+	dedup *dedupState
+	// privacy holds the privacy keys and audited columns of the job
+	privacy *privacyState
 }
 
 // NewExtractServer creates an extract server based on the configPath
 // and returns a pointer to the extract server
 func NewExtractServer(fileName, schemeValue, tableName string, debug bool, svcCreds config.ServiceCredentials, dbCreds config.DBCredentials, pipeline v1alpha1.Pipeline) *Server {
 	s := &Server{
+		partitions:   &partitionSet{},
+		conversions:  &conversionCount{},
+		quality:      &qualityState{},
+		derived:      &derivedColumns{},
+		lookups:      &lookupState{},
+		routes:       &routeState{},
+		dedup:        &dedupState{},
+		privacy:      &privacyState{},
 		ServiceCreds: svcCreds,
 		DBCreds:      dbCreds,
 		Pi:           pipeline,
@@ -56,19 +90,16 @@ func NewExtractServer(fileName, schemeValue, tableName string, debug bool, svcCr
 		SchemeValue:  schemeValue,
 		TableName:    tableName,
 	}
+	s.ArchiveMember = os.Getenv("CHURRO_ARCHIVE_MEMBER")
 
 	s.DP = domain.DataProvenance{
 		Name: s.FileName,
 		Path: s.FileName,
 	}
-	var err error
-	err = dataprov.Register(&s.DP, s.Pi, s.DBCreds)
-	if err != nil {
-		log.Error().Stack().Err(err).Msg("can not register data prov")
-		os.Exit(1)
+	if s.ArchiveMember != "" {
+		s.DP.Name = s.FileName + "!" + s.ArchiveMember
 	}
-	log.Info().Msg(fmt.Sprintf("dp info %+v", s.DP))
-
+	var err error
 	var churroDB db.ChurroDatabase
 
 	churroDB, err = db.NewChurroDB(pipeline.Spec.DatabaseType)
@@ -114,6 +145,7 @@ func NewExtractServer(fileName, schemeValue, tableName string, debug bool, svcCr
 	}
 
 	log.Info().Msg(fmt.Sprintf("transform functions %d\n", len(s.TransformFunctions)))
+	s.transforms = transform.Compile(s.TransformFunctions)
 
 	wdirName := os.Getenv("CHURRO_WATCHDIR_NAME")
 
@@ -132,8 +164,20 @@ func NewExtractServer(fileName, schemeValue, tableName string, debug bool, svcCr
 				Sheetname:      c.Sheetname,
 				Encoding:       c.Encoding,
 				Transport:      c.Transport,
+				Delimiter:      c.Delimiter,
+				Quote:          c.Quote,
+				Comment:        c.Comment,
+				Charset:        c.Charset,
+				BatchRows:      c.Batchrows,
+				BatchBytes:     c.Batchbytes,
+				CopyThreshold:  c.Copythreshold,
+				LoadMode:       c.Loadmode,
+				RowHash:        c.Rowhash,
+				DedupWindow:    c.Dedupwindow,
 				ExtractRules:   make(map[string]domain.ExtractRule),
 			}
+			s.ExtractSource.LazyQuotes = c.Lazyquotes
+			s.ExtractSource.ReloadDuplicates = c.Reloadduplicates
 			g := pipelineToUpdate.Spec.Extractrules
 			for i := 0; i < len(g); i++ {
 				if g[i].Extractsourceid == c.ID {
@@ -146,6 +190,14 @@ func NewExtractServer(fileName, schemeValue, tableName string, debug bool, svcCr
 						MatchValues:     g[i].MatchValues,
 					}
 					d.TransformFunction = g[i].TransformFunctionName
+					d.KeyColumn = g[i].Keycolumn
+					d.ColumnFormat = g[i].ColumnFormat
+					d.MatchRegex = g[i].Matchregex
+					d.NotNull = g[i].Notnull
+					d.MinValue = g[i].Minvalue
+					d.MaxValue = g[i].Maxvalue
+					d.Unique = g[i].Unique
+					d.PrivacyPolicy = g[i].Privacypolicy
 					s.ExtractSource.ExtractRules[d.ID] = d
 				}
 			}
@@ -162,9 +214,57 @@ func NewExtractServer(fileName, schemeValue, tableName string, debug bool, svcCr
 					s.ExtractSource.Extensions[d.ID] = d
 				}
 			}
+			s.ExtractSource.Lookups = make(map[string]domain.Lookup)
+			l := pipelineToUpdate.Spec.Lookups
+			for i := 0; i < len(l); i++ {
+				if l[i].Extractsourceid == c.ID {
+					d := domain.Lookup{
+						ID:              l[i].ID,
+						ExtractSourceID: l[i].Extractsourceid,
+						LookupName:      l[i].Lookupname,
+						ReferenceTable:  l[i].Referencetable,
+						ReferenceFile:   l[i].Referencefile,
+						MatchColumns:    l[i].Matchcolumns,
+						Columns:         l[i].Columns,
+					}
+					s.ExtractSource.Lookups[d.ID] = d
+				}
+			}
+			s.ExtractSource.Routes = make(map[string]domain.Route)
+			rt := pipelineToUpdate.Spec.Routes
+			for i := 0; i < len(rt); i++ {
+				if rt[i].Extractsourceid == c.ID {
+					d := domain.Route{
+						ID:              rt[i].ID,
+						ExtractSourceID: rt[i].Extractsourceid,
+						RouteName:       rt[i].Routename,
+						Position:        rt[i].Position,
+						Predicate:       rt[i].Predicate,
+						TargetTable:     rt[i].Targettable,
+						Drop:            rt[i].Drop,
+					}
+					s.ExtractSource.Routes[d.ID] = d
+				}
+			}
 		}
 	}
 
+	// a file with the fingerprint of a file loaded before is skipped
+	// unless the extract source reloads duplicate files
+	err = dataprov.Register(&s.DP, s.Pi, s.DBCreds, s.ArchiveMember, s.ExtractSource.ReloadDuplicates)
+	if errors.Is(err, dataprov.ErrDuplicate) {
+		log.Info().Msg(fmt.Sprintf("skipping %s, %s", s.DP.Name, err.Error()))
+		if schemeValue != extractapi.APIScheme && schemeValue != extractapi.HTTPPostScheme && s.ArchiveMember == "" {
+			s.renameFile(fileName)
+		}
+		return s
+	}
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("can not register data prov")
+		os.Exit(1)
+	}
+	log.Info().Msg(fmt.Sprintf("dp info %+v", s.DP))
+
 	ctx := context.Background()
 
 	s.createMetric()
@@ -195,6 +295,24 @@ func NewExtractServer(fileName, schemeValue, tableName string, debug bool, svcCr
 		if err != nil {
 			log.Error().Stack().Err(err).Msg("error in csv processing")
 		}
+	case extractapi.ParquetScheme:
+		log.Info().Msg("Info: extract is processing a parquet file")
+		err = s.ExtractParquet(ctx)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("error in parquet processing")
+		}
+	case extractapi.NDJSONScheme:
+		log.Info().Msg("Info: extract is processing a ndjson file")
+		err = s.ExtractNDJSON(ctx)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("error in ndjson processing")
+		}
+	case extractapi.FixedWidthScheme:
+		log.Info().Msg("Info: extract is processing a fixedwidth file")
+		err = s.ExtractFixedWidth(ctx)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("error in fixedwidth processing")
+		}
 	case extractapi.XLSXScheme:
 		log.Info().Msg("Info: extract is processing a xlsx file")
 		err = s.ExtractXLS(ctx)
@@ -218,11 +336,20 @@ func NewExtractServer(fileName, schemeValue, tableName string, debug bool, svcCr
 		os.Exit(1)
 	}
 
+	// a file that failed is not renamed as processed, it is moved to
+	// the failed directory of the extract source to be requeued
+	if err != nil {
+		s.failFile(fileName, err)
+		return s
+	}
+
 	log.Info().Msg("schemeValue for rename is " + schemeValue)
 	switch schemeValue {
 	default:
 		s.bumpMetric(churroDB)
-		if schemeValue != extractapi.APIScheme && schemeValue != extractapi.HTTPPostScheme {
+		// archives are renamed by the extractsource service when
+		// their members are queued
+		if schemeValue != extractapi.APIScheme && schemeValue != extractapi.HTTPPostScheme && s.ArchiveMember == "" {
 			s.renameFile(fileName)
 		}
 	}
@@ -238,8 +365,23 @@ func (s *Server) Ping(ctx context.Context, size *pb.PingRequest) (hat *pb.PingRe
 	}, nil
 }
 
+// openFile opens the file being extracted, compressed files and
+// archive members are decompressed on the fly
+func (s *Server) openFile() (io.ReadCloser, error) {
+	return archive.Open(s.FileName, s.ArchiveMember)
+}
+
+// runRules runs the transform functions of the extract rules on a
+// record, the functions are compiled the first time they are needed
+func (s *Server) runRules(cols []string, record []interface{}) error {
+	if s.transforms == nil {
+		s.transforms = transform.Compile(s.TransformFunctions)
+	}
+	return s.transforms.RunRules(cols, record, s.ExtractSource.ExtractRules)
+}
+
 func (s *Server) renameFile(path string) {
-	newPath := path + ".churro-processed"
+	newPath := path + archive.ProcessedSuffix
 	err := os.Rename(path, newPath)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error in renaming file")
@@ -10,12 +10,20 @@
 
 package extract
 
-import "github.com/churrodata/churro/internal/db"
+import (
+	"fmt"
+	"strings"
 
-// create the table based on column names and column types
+	"github.com/churrodata/churro/internal/db"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/rs/zerolog/log"
+)
+
+// create the table based on column names and column types, columns of
+// extract rules added after the table was created are added to it and a
+// column whose type no longer matches its extract rule blocks the load
 func (s Server) tableCheck(columnNames, columnTypes []string) (err error) {
 
-	userid := s.Pi.Spec.DataSource.Username
 	dbname := s.Pi.Spec.DataSource.Database
 
 	tableName := s.TableName
@@ -31,10 +39,121 @@ func (s Server) tableCheck(columnNames, columnTypes []string) (err error) {
 		return err
 	}
 
-	err = churroDB.CreateTable(userid, dbname, tableName, columnNames, columnTypes)
+	changed, err := s.checkTable(churroDB, dbname, tableName, columnNames, columnTypes)
 	if err != nil {
 		return err
 	}
 
+	s.setSchemaError(strings.Join(changed, ", "))
+	if len(changed) > 0 {
+		return fmt.Errorf("table %s does not match the extract rules, %s", tableName, strings.Join(changed, ", "))
+	}
+
 	return nil
 }
+
+// checkTable creates a table when it does not exist and adds the
+// columns missing from it, it returns a description of each column
+// whose type changed
+func (s Server) checkTable(churroDB db.ChurroDatabase, dbname, tableName string, columnNames, columnTypes []string) (changed []string, err error) {
+	userid := s.Pi.Spec.DataSource.Username
+
+	err = churroDB.CreateTable(userid, dbname, tableName, columnNames, columnTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	live, err := churroDB.GetTableColumns(dbname, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	added, changed, err := compareColumns(live, columnNames, columnTypes, churroDB.GetColumnType)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range added {
+		log.Info().Msg(fmt.Sprintf("adding column %s %s to %s", columnNames[i], columnTypes[i], tableName))
+		err = churroDB.AddColumn(dbname, tableName, columnNames[i], columnTypes[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return changed, nil
+}
+
+// compareColumns returns the index of each column missing from the live
+// table and a description of each column whose live type is not the
+// type columnType gives for its extract rule type
+func compareColumns(live map[string]string, columnNames, columnTypes []string, columnType func(string) (string, error)) (added []int, changed []string, err error) {
+	liveTypes := make(map[string]string, len(live))
+	for name, t := range live {
+		liveTypes[strings.ToLower(name)] = t
+	}
+
+	for i, name := range columnNames {
+		t, ok := liveTypes[strings.ToLower(name)]
+		if !ok {
+			added = append(added, i)
+			continue
+		}
+		want, err := columnType(columnTypes[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		// a type the database can not report is not compared
+		if want != "" && !strings.EqualFold(t, want) {
+			changed = append(changed, fmt.Sprintf("column %s is %s but the extract rule type is %s", name, t, columnTypes[i]))
+		}
+	}
+	return added, changed, nil
+}
+
+// setSchemaError records the column type changes on the extract source
+// metrics so they are shown with the extract source, a blank value
+// clears an earlier error
+func (s Server) setSchemaError(value string) {
+	churroDB, err := db.NewChurroDB(s.Pi.Spec.DatabaseType)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in recording schema error")
+		return
+	}
+	err = churroDB.GetConnection(s.DBCreds, s.Pi.Spec.AdminDataSource)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in recording schema error")
+		return
+	}
+
+	metrics, err := churroDB.GetExtractSourceMetrics(s.ExtractSource.ID)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in getting extract source metrics")
+		return
+	}
+
+	m := domain.ExtractSourceMetric{
+		ExtractSourceID: s.ExtractSource.ID,
+		Name:            domain.MetricSchemaError,
+		Value:           value,
+	}
+	for i := 0; i < len(metrics); i++ {
+		if metrics[i].Name == domain.MetricSchemaError {
+			if metrics[i].Value != value {
+				err = churroDB.UpdateExtractSourceMetric(m)
+			}
+			if err != nil {
+				log.Error().Stack().Err(err).Msg("error in updating schema error")
+			}
+			return
+		}
+	}
+
+	if value == "" {
+		return
+	}
+	err = churroDB.CreateExtractSourceMetric(m)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in creating schema error")
+	}
+}
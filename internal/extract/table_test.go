@@ -0,0 +1,42 @@
+package extract
+
+import (
+	"strings"
+	"testing"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+)
+
+func TestCompareColumns(t *testing.T) {
+	live := map[string]string{
+		"primarykey": "bigint",
+		"City":       "text",
+		"population": "text",
+	}
+	names := []string{"city", "population", "state"}
+	types := []string{extractapi.COLTYPE_TEXT, extractapi.COLTYPE_INT, extractapi.COLTYPE_VARCHAR}
+	columnType := func(t string) (string, error) {
+		if t == extractapi.COLTYPE_VARCHAR {
+			return "", nil
+		}
+		return strings.ToLower(t), nil
+	}
+
+	added, changed, err := compareColumns(live, names, types, columnType)
+	if err != nil {
+		t.Fatalf("compareColumns Error: %v", err)
+	}
+	if len(added) != 1 || names[added[0]] != "state" {
+		t.Fatalf("compareColumns added %v", added)
+	}
+	if len(changed) != 1 || !strings.Contains(changed[0], "population") {
+		t.Fatalf("compareColumns changed %v", changed)
+	}
+
+	// a type the database can not report is not compared
+	live["state"] = "character varying"
+	added, changed, err = compareColumns(live, names[2:], types[2:], columnType)
+	if err != nil || len(added) != 0 || len(changed) != 0 {
+		t.Fatalf("compareColumns got %v %v %v", added, changed, err)
+	}
+}
@@ -22,9 +22,9 @@ import (
 	"github.com/xuri/excelize/v2"
 
 	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/archive"
 	"github.com/churrodata/churro/internal/db"
 	"github.com/churrodata/churro/internal/domain"
-	"github.com/churrodata/churro/internal/transform"
 )
 
 // ExtractXLS Excel file contents and exit
@@ -32,7 +32,14 @@ func (s *Server) ExtractXLS(ctx context.Context) (err error) {
 
 	log.Info().Msg("ExtractXLS starting...sheetname is " + s.ExtractSource.Sheetname)
 
-	xlsxFile, err := excelize.OpenFile(s.FileName)
+	localFile, cleanup, err := archive.Spool(s.FileName, s.ArchiveMember)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("could not decompress xlsx file " + s.FileName)
+		return err
+	}
+	defer cleanup()
+
+	xlsxFile, err := excelize.OpenFile(localFile)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("could not open xlsx file" + s.FileName)
 		return err
@@ -110,7 +117,7 @@ func (s *Server) ExtractXLS(ctx context.Context) (err error) {
 			r := getXLSRow(record, xlsStruct.Columns)
 
 			// TODO apply transforms to XLS data
-			err := transform.RunRules(xlsStruct.ColumnNames, r.Cols, s.ExtractSource.ExtractRules, s.TransformFunctions)
+			err := s.runRules(xlsStruct.ColumnNames, r.Cols)
 			if err != nil {
 				log.Error().Stack().Err(err).Msg("error in RunRules")
 			}
@@ -125,7 +132,10 @@ func (s *Server) ExtractXLS(ctx context.Context) (err error) {
 				DataFormat: extractapi.XLSXScheme,
 			}
 
-			s.process(jobProfile, churroDB, s.Pi.Spec.DataSource.Database, msg)
+			_, err = s.process(jobProfile, churroDB, s.Pi.Spec.DataSource.Database, msg)
+			if err != nil {
+				return err
+			}
 			xlsStruct.Records = make([]extractapi.GenericRow, 0)
 		}
 
@@ -145,7 +155,7 @@ func (s *Server) ExtractXLS(ctx context.Context) (err error) {
 
 func getXLSRow(record []string, cols []extractapi.Column) extractapi.GenericRow {
 	xlsRow := extractapi.GenericRow{
-		Key:  time.Now().UnixNano(),
+		Key:  nextRowKey(),
 		Cols: make([]interface{}, len(cols)),
 	}
 
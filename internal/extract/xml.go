@@ -22,7 +22,6 @@ import (
 	extractapi "github.com/churrodata/churro/api/extract"
 	"github.com/churrodata/churro/internal/db"
 	"github.com/churrodata/churro/internal/domain"
-	"github.com/churrodata/churro/internal/transform"
 	"github.com/rs/zerolog/log"
 	"gopkg.in/xmlpath.v2"
 )
@@ -38,10 +37,11 @@ func (s *Server) ExtractXML(ctx context.Context) (err error) {
 	log.Info().Msg("ExtractXML starting...")
 
 	// read the XML file to be processed and parse it
-	reader, err := os.Open(s.FileName)
+	reader, err := s.openFile()
 	if err != nil {
 		return err
 	}
+	defer reader.Close()
 	decoder := xml.NewDecoder(reader)
 	decoder.CharsetReader = func(label string, input io.Reader) (io.Reader, error) {
 		return input, nil
@@ -93,14 +93,14 @@ func (s *Server) ExtractXML(ctx context.Context) (err error) {
 	recordsProcessed := 0
 	for i := 0; i < recLen; i++ {
 		log.Info().Msg(fmt.Sprintf("before transform %v", xmlStruct.Records[i].Cols))
-		err := transform.RunRules(xmlStruct.ColumnNames, xmlStruct.Records[i].Cols, s.ExtractSource.ExtractRules, s.TransformFunctions)
+		err := s.runRules(xmlStruct.ColumnNames, xmlStruct.Records[i].Cols)
 		if err != nil {
 			log.Error().Stack().Err(err).Msg("error in RunRules ")
 		}
 		log.Info().Msg(fmt.Sprintf("after transform %+v", xmlStruct.Records[i].Cols))
 
 		recordsProcessed++
-		xmlStruct.Records[i].Key = time.Now().UnixNano()
+		xmlStruct.Records[i].Key = nextRowKey()
 
 		partStruct.Records = append(partStruct.Records, xmlStruct.Records[i])
 		log.Info().Msg("pushing to Queue")
@@ -123,7 +123,10 @@ func (s *Server) ExtractXML(ctx context.Context) (err error) {
 			log.Error().Stack().Err(err)
 		}
 
-		s.process(jobProfile, churroDB, s.Pi.Spec.DataSource.Database, msg)
+		_, err = s.process(jobProfile, churroDB, s.Pi.Spec.DataSource.Database, msg)
+		if err != nil {
+			return err
+		}
 		recordsProcessed = 0
 		partStruct.Records = make([]extractapi.GenericRow, 0)
 
@@ -179,7 +182,7 @@ func getXMLFormat(rules []compiledXMLRule, root *xmlpath.Node) (format extractap
 	columns := len(cols)
 	for rec := 0; rec < records; rec++ {
 		xmlrow := extractapi.GenericRow{
-			Key: time.Now().UnixNano(),
+			Key: nextRowKey(),
 		}
 		for c := 0; c < columns; c++ {
 			xmlrow.Cols = append(xmlrow.Cols, cols[c][rec])
@@ -75,7 +75,7 @@ func (s *Server) StartAPI(ctx context.Context, req *pb.StartAPIRequest) (respons
 		return resp, err
 	}
 
-	err = s.createExtractPod(otherClient, wd.Scheme, wd.Path, s.Pi, wd.Tablename, wd.Name)
+	err = s.createExtractPod(otherClient, wd.Scheme, wd.Path, "", s.Pi, wd.Tablename, wd.Name)
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error creating extract pod err")
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
@@ -0,0 +1,199 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package extractsource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/churrodata/churro/api/v1alpha1"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/churrodata/churro/pkg"
+	pb "github.com/churrodata/churro/rpc/extractsource"
+)
+
+// GetFailedFiles returns the error reports of the files of an extract
+// source that failed extraction, oldest first
+func (s *Server) GetFailedFiles(ctx context.Context, req *pb.GetFailedFilesRequest) (*pb.GetFailedFilesResponse, error) {
+	es, err := s.getExtractSourceDefinition(req.ExtractSourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := readFailedFiles(filepath.Join(es.Path, domain.FailedDirectory))
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error reading failed files")
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	b, _ := json.Marshal(reports)
+	return &pb.GetFailedFilesResponse{FailedFilesString: string(b)}, nil
+}
+
+// RequeueFailedFiles extracts the failed files of an extract source
+// again, all of them when no IDs are requested
+func (s *Server) RequeueFailedFiles(ctx context.Context, req *pb.RequeueFailedFilesRequest) (*pb.RequeueFailedFilesResponse, error) {
+	resp := &pb.RequeueFailedFilesResponse{}
+
+	es, err := s.getExtractSourceDefinition(req.ExtractSourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := filepath.Join(es.Path, domain.FailedDirectory)
+	reports, err := readFailedFiles(dir)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error reading failed files")
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	ids := make(map[string]bool)
+	for _, id := range req.IDs {
+		ids[id] = true
+	}
+
+	for _, report := range reports {
+		if len(ids) > 0 && !ids[report.ID] {
+			continue
+		}
+		member, err := requeueFailedFile(dir, es.Path, report)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("error requeuing " + report.ID)
+			return resp, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		// archive members are not watched, they are queued directly,
+		// the watcher queues files moved back to the source directory
+		if member {
+			go func(f domain.FailedFile) {
+				s.QueueOfFiles <- QueueEntry{
+					dirPath:  es.Path,
+					filePath: f.OriginalPath,
+					regex:    es.Regex,
+					member:   f.ArchiveMember,
+				}
+			}(report)
+		}
+		log.Info().Msg(fmt.Sprintf("requeued failed file %s", report.OriginalPath))
+		resp.IDs = append(resp.IDs, report.ID)
+	}
+
+	return resp, nil
+}
+
+// getExtractSourceDefinition looks up an extract source of the pipeline
+func (s *Server) getExtractSourceDefinition(id string) (v1alpha1.ExtractSourceDefinition, error) {
+	_, config, err := pkg.GetKubeClient()
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return v1alpha1.ExtractSourceDefinition{}, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipelineClient, err := pkg.NewClient(config, s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return v1alpha1.ExtractSourceDefinition{}, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pipeline, err := pipelineClient.Get(s.Pi.Name)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		return v1alpha1.ExtractSourceDefinition{}, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	for _, es := range pipeline.Spec.Extractsources {
+		if es.ID == id {
+			return es, nil
+		}
+	}
+	return v1alpha1.ExtractSourceDefinition{}, status.Errorf(codes.NotFound, "extract source %s not found", id)
+}
+
+// readFailedFiles reads the error reports in a failed directory, a
+// missing directory has no failed files
+func readFailedFiles(dir string) ([]domain.FailedFile, error) {
+	reports := make([]domain.FailedFile, 0)
+
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return reports, nil
+	}
+	if err != nil {
+		return reports, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), domain.FailedReportSuffix) {
+			continue
+		}
+		b, err := ioutil.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return reports, err
+		}
+		var report domain.FailedFile
+		err = json.Unmarshal(b, &report)
+		if err != nil {
+			return reports, fmt.Errorf("error report %s: %w", entry.Name(), err)
+		}
+		reports = append(reports, report)
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].FailedAt.Before(reports[j].FailedAt)
+	})
+	return reports, nil
+}
+
+// requeueFailedFile moves a failed file back to the source directory
+// and removes its error report.  An archive member has no file of its
+// own, true is returned so the caller queues the member.
+func requeueFailedFile(dir, sourceDir string, report domain.FailedFile) (bool, error) {
+	// the report is read from the shared volume, only move files
+	// between the failed directory and the source directory
+	if filepath.Dir(filepath.Clean(report.OriginalPath)) != filepath.Clean(sourceDir) {
+		return false, fmt.Errorf("%s is not in %s", report.OriginalPath, sourceDir)
+	}
+
+	reportName := report.ID + "-" + filepath.Base(report.OriginalPath)
+	member := report.ArchiveMember != ""
+	if member {
+		reportName = report.ID + "-" + filepath.Base(report.ArchiveMember)
+		if _, err := os.Stat(report.OriginalPath); err != nil {
+			return false, err
+		}
+	} else {
+		if report.FileName != filepath.Base(report.FileName) {
+			return false, fmt.Errorf("invalid failed file name %s", report.FileName)
+		}
+		if _, err := os.Stat(report.OriginalPath); err == nil {
+			return false, fmt.Errorf("%s already exists", report.OriginalPath)
+		}
+		err := os.Rename(filepath.Join(dir, report.FileName), report.OriginalPath)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	err := os.Remove(filepath.Join(dir, reportName+domain.FailedReportSuffix))
+	if err != nil {
+		return false, err
+	}
+	return member, nil
+}
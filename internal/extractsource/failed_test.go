@@ -0,0 +1,95 @@
+package extractsource
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/churrodata/churro/internal/domain"
+)
+
+func writeFailedReport(t *testing.T, dir string, report domain.FailedFile, name string) {
+	b, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, name+domain.FailedReportSuffix), b, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRequeueFailedFile(t *testing.T) {
+	sourceDir := t.TempDir()
+	dir := filepath.Join(sourceDir, domain.FailedDirectory)
+	if err := os.Mkdir(dir, os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+
+	reports, err := readFailedFiles(filepath.Join(sourceDir, "missing"))
+	if err != nil || len(reports) != 0 {
+		t.Fatalf("missing dir: got %v %v", reports, err)
+	}
+
+	now := time.Now()
+	file := domain.FailedFile{
+		ID:           "dp2",
+		FileName:     "dp2-b.csv",
+		OriginalPath: filepath.Join(sourceDir, "b.csv"),
+		FailedAt:     now,
+	}
+	writeFailedReport(t, dir, file, file.FileName)
+	if err := ioutil.WriteFile(filepath.Join(dir, file.FileName), []byte("a,b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	member := domain.FailedFile{
+		ID:            "dp1",
+		OriginalPath:  filepath.Join(sourceDir, "a.zip.churro-processed"),
+		ArchiveMember: "data/c.csv",
+		FailedAt:      now.Add(-time.Minute),
+	}
+	writeFailedReport(t, dir, member, "dp1-c.csv")
+
+	reports, err = readFailedFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 2 || reports[0].ID != "dp1" || reports[1].ID != "dp2" {
+		t.Fatalf("expected dp1 then dp2, got %+v", reports)
+	}
+
+	// the archive was removed, the member can not be requeued
+	if _, err := requeueFailedFile(dir, sourceDir, member); err == nil {
+		t.Fatal("expected an error for a missing archive")
+	}
+	if err := ioutil.WriteFile(member.OriginalPath, []byte("zip"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	queue, err := requeueFailedFile(dir, sourceDir, member)
+	if err != nil || !queue {
+		t.Fatalf("member: got %v %v", queue, err)
+	}
+
+	queue, err = requeueFailedFile(dir, sourceDir, file)
+	if err != nil || queue {
+		t.Fatalf("file: got %v %v", queue, err)
+	}
+	if _, err := os.Stat(file.OriginalPath); err != nil {
+		t.Fatalf("file not moved back: %v", err)
+	}
+
+	reports, err = readFailedFiles(dir)
+	if err != nil || len(reports) != 0 {
+		t.Fatalf("expected no reports left, got %v %v", reports, err)
+	}
+
+	outside := file
+	outside.OriginalPath = "/etc/passwd"
+	if _, err := requeueFailedFile(dir, sourceDir, outside); err == nil {
+		t.Fatal("expected an error for a file outside the source directory")
+	}
+}
@@ -15,9 +15,8 @@ import (
 	"context"
 	"crypto/x509"
 	"fmt"
-	"path/filepath"
 	"regexp"
-	"strings"
+	"sync"
 	"time"
 	"unsafe"
 
@@ -32,8 +31,10 @@ import (
 	//	"github.com/fsnotify/fsnotify"
 	extractapi "github.com/churrodata/churro/api/extract"
 	"github.com/churrodata/churro/api/v1alpha1"
+	"github.com/churrodata/churro/internal/archive"
 	"github.com/churrodata/churro/internal/db"
 	"github.com/churrodata/churro/internal/pipeline"
+	"github.com/churrodata/churro/internal/privacy"
 	"github.com/churrodata/churro/pkg"
 	"github.com/churrodata/churro/pkg/config"
 	pb "github.com/churrodata/churro/rpc/extractsource"
@@ -52,10 +53,16 @@ import (
 // DefaultPort is the extractsource service port
 const DefaultPort = ":8087"
 
+// DataVolumePath is where extract pods mount the churrodata volume of
+// the pipeline
+const DataVolumePath = "/churro"
+
 type QueueEntry struct {
 	filePath string
 	dirPath  string
 	regex    string
+	// member is the archive member to extract from filePath
+	member string
 }
 
 // Server implements the extractsource service
@@ -66,6 +73,41 @@ type Server struct {
 	UserDBCreds  config.DBCredentials
 	//	Watcher      *fsnotify.Watcher
 	QueueOfFiles chan QueueEntry
+	// watched holds the regexes of the extract sources watching each
+	// path
+	watched *watchedPaths
+}
+
+// watchedPaths holds the regexes of the extract sources by the path
+// they watch, several extract sources can watch one path
+type watchedPaths struct {
+	mu      sync.Mutex
+	regexes map[string][]string
+}
+
+// add records that an extract source watches path with regex
+func (w *watchedPaths) add(path, regex string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.regexes == nil {
+		w.regexes = make(map[string][]string)
+	}
+	for _, r := range w.regexes[path] {
+		if r == regex {
+			return
+		}
+	}
+	w.regexes[path] = append(w.regexes[path], regex)
+}
+
+// of returns the regexes of the extract sources watching path
+func (w *watchedPaths) of(path string) []string {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]string{}, w.regexes[path]...)
 }
 
 // Ping ...
@@ -84,6 +126,7 @@ func NewExtractSourceServer(debug bool, svcCreds config.ServiceCredentials, pipe
 		DBCreds:      dbCreds,
 		Pi:           pipeline,
 		QueueOfFiles: make(chan QueueEntry),
+		watched:      &watchedPaths{},
 	}
 
 	go s.startQueueConsumer()
@@ -95,18 +138,7 @@ func NewExtractSourceServer(debug bool, svcCreds config.ServiceCredentials, pipe
 	return s
 }
 
-func getTable(filePath, scheme string, dirs []v1alpha1.ExtractSourceDefinition) (string, string, error) {
-	baseDir := filepath.Dir(filePath)
-
-	for _, dir := range dirs {
-		if dir.Scheme == scheme && strings.Contains(baseDir, dir.Path) {
-			return dir.Name, dir.Tablename, nil
-		}
-	}
-	return "", "", fmt.Errorf("could not find right scheme %s and dir %s in churro config", scheme, baseDir)
-}
-
-func (s *Server) createExtractPod(client kubernetes.Interface, scheme string, filePath string, cfg v1alpha1.Pipeline, tableName, extractSourceName string) error {
+func (s *Server) createExtractPod(client kubernetes.Interface, scheme string, filePath, member string, cfg v1alpha1.Pipeline, tableName, extractSourceName string) error {
 	imageName := os.Getenv("CHURRO_EXTRACT_IMAGE")
 	ns := os.Getenv("CHURRO_NAMESPACE")
 	pipelineName := os.Getenv("CHURRO_PIPELINE")
@@ -121,6 +153,9 @@ func (s *Server) createExtractPod(client kubernetes.Interface, scheme string, fi
 	case extractapi.JSONScheme:
 	case extractapi.JSONPathScheme:
 	case extractapi.XLSXScheme:
+	case extractapi.ParquetScheme:
+	case extractapi.NDJSONScheme:
+	case extractapi.FixedWidthScheme:
 	case extractapi.HTTPPostScheme:
 		log.Debug().Msg("scheme used for extract job " + scheme)
 	default:
@@ -134,7 +169,7 @@ func (s *Server) createExtractPod(client kubernetes.Interface, scheme string, fi
 	}
 	*/
 
-	pod := getPodDefinition(filePath, tableName, scheme, rand.String(4), ns, imageName, pipelineName, extractSourceName)
+	pod := getPodDefinition(filePath, member, tableName, scheme, rand.String(4), ns, imageName, pipelineName, extractSourceName)
 	log.Debug().Msg("creating pod " + pod.Name)
 
 	_, err := client.CoreV1().Pods(ns).Create(ctx, pod, metav1.CreateOptions{})
@@ -146,7 +181,7 @@ func (s *Server) createExtractPod(client kubernetes.Interface, scheme string, fi
 }
 
 // getPodDefinition fills out a Pod definition
-func getPodDefinition(filePath, tableName, scheme, suffix, namespace, imageName, pipelineName, extractSourceName string) *v1.Pod {
+func getPodDefinition(filePath, member, tableName, scheme, suffix, namespace, imageName, pipelineName, extractSourceName string) *v1.Pod {
 	entrypoint := []string{
 		"/usr/local/bin/churro-extract",
 		"-servicecert",
@@ -161,6 +196,9 @@ func getPodDefinition(filePath, tableName, scheme, suffix, namespace, imageName,
 	//mode = 0620
 	mode = 256
 
+	// the privacy secret is only needed by hash and tokenize policies
+	optional := true
+
 	extractLogID := xid.New().String()
 
 	pp := &v1.Pod{
@@ -195,7 +233,7 @@ func getPodDefinition(filePath, tableName, scheme, suffix, namespace, imageName,
 							ReadOnly:  true,
 						},
 						{
-							MountPath: "/churro",
+							MountPath: DataVolumePath,
 							Name:      "churrodata",
 							ReadOnly:  false,
 						},
@@ -229,6 +267,10 @@ func getPodDefinition(filePath, tableName, scheme, suffix, namespace, imageName,
 							Name:  "CHURRO_FILENAME",
 							Value: filePath,
 						},
+						{
+							Name:  "CHURRO_ARCHIVE_MEMBER",
+							Value: member,
+						},
 						{
 							Name:  "CHURRO_SCHEME",
 							Value: scheme,
@@ -241,6 +283,26 @@ func getPodDefinition(filePath, tableName, scheme, suffix, namespace, imageName,
 							Name:  "CHURRO_TABLENAME",
 							Value: tableName,
 						},
+						{
+							Name: privacy.SaltEnv,
+							ValueFrom: &v1.EnvVarSource{
+								SecretKeyRef: &v1.SecretKeySelector{
+									LocalObjectReference: v1.LocalObjectReference{Name: privacy.SecretName},
+									Key:                  privacy.SaltKey,
+									Optional:             &optional,
+								},
+							},
+						},
+						{
+							Name: privacy.TokenKeyEnv,
+							ValueFrom: &v1.EnvVarSource{
+								SecretKeyRef: &v1.SecretKeySelector{
+									LocalObjectReference: v1.LocalObjectReference{Name: privacy.SecretName},
+									Key:                  privacy.TokenKeyKey,
+									Optional:             &optional,
+								},
+							},
+						},
 					},
 				},
 			},
@@ -359,6 +421,9 @@ func (s *Server) createExtractSources() {
 		_, err = os.Stat(dir.Path)
 		if err == nil {
 			//err = s.Watcher.Add(dir.Path)
+			if s.watched != nil {
+				s.watched.add(dir.Path, dir.Regex)
+			}
 			go s.watchEventsFor(dir.Path, dir.Regex)
 			//if err != nil {
 			//	log.Error().Stack().Err(err).Msg("error adding watch path " + dir.Path)
@@ -369,7 +434,7 @@ func (s *Server) createExtractSources() {
 	}
 }
 
-func (s *Server) createExtractPodForNewFile(dirPath, filePath, regex string) error {
+func (s *Server) createExtractPodForNewFile(dirPath, filePath, member, regex string) error {
 	_, config, err := pkg.GetKubeClient()
 	if err != nil {
 		log.Error().Stack().Err(err).Msg("error getting kubeclientset err ")
@@ -397,24 +462,21 @@ func (s *Server) createExtractPodForNewFile(dirPath, filePath, regex string) err
 			continue
 		}
 
-		// match the extractsource using the dirpath which is unique
-		if dirPath == c.Path {
+		// match the extractsource using the dirpath and the regex, as
+		// several extract sources can watch one dirpath
+		if dirPath == c.Path && (regex == "" || regex == c.Regex) {
 
 			scheme := c.Scheme
 			log.Info().Msg("dir " + dirPath + "scheme " + scheme + " regex " + regex)
 
-			extractSourceName, tableName, err := getTable(filePath, c.Scheme, pipelineToUpdate.Spec.Extractsources)
-			if err != nil {
-				log.Error().Stack().Err(err).Msg("error getting table")
-				return err
-			}
+			extractSourceName, tableName := c.Name, c.Tablename
 			otherClient, err := GetKubeClient("")
 			if err != nil {
 				log.Error().Stack().Err(err).Msg("error getting otherclient")
 				return err
 			}
 
-			err = s.createExtractPod(otherClient, c.Scheme, filePath, s.Pi, tableName, extractSourceName)
+			err = s.createExtractPod(otherClient, c.Scheme, filePath, member, s.Pi, tableName, extractSourceName)
 			if err != nil {
 				log.Error().Stack().Err(err).Msg("error in createExtractPod ")
 				return err
@@ -507,7 +569,7 @@ func (s *Server) startQueueConsumer() {
 			continue
 		}
 		log.Info().Msg(fmt.Sprintf("working on file %s regex %s current jobs %d\n", f.filePath, f.regex, podCount))
-		err = s.createExtractPodForNewFile(f.dirPath, f.filePath, f.regex)
+		err = s.createExtractPodForNewFile(f.dirPath, f.filePath, f.member, f.regex)
 		if err != nil {
 			log.Error().Stack().Err(err)
 		}
@@ -623,8 +685,9 @@ func (s *Server) watchEventsFor(dir, regex string) {
 				name += " (dir)"
 			}
 
-			// HERE
-			match, err := regexp.Match(regex, []byte(name))
+			// compressed files are matched without their compression
+			// suffix so data.csv.gz matches a regex written for csv
+			match, err := regexp.Match(regex, []byte(archive.StripCompression(name)))
 			if err != nil {
 				log.Error().Stack().Err(err).Msg("error in regexp match" + regex)
 				return
@@ -642,13 +705,17 @@ func (s *Server) watchEventsFor(dir, regex string) {
 				fmt.Printf("DELETE %v\n", name)
 			case e.Mask&unix.IN_CLOSE_WRITE == unix.IN_CLOSE_WRITE:
 				fmt.Printf("CLOSE_WRITE %v\n", dir+"/"+name)
-				if match {
+				if archive.IsArchive(name) {
+					s.queueArchive(dir, name, regex)
+				} else if match {
 					fmt.Printf("adding f " + dir + "/" + name + " to processing queue\n")
 					s.QueueOfFiles <- QueueEntry{dirPath: dir, filePath: dir + "/" + name, regex: regex}
 				}
 			case e.Mask&unix.IN_MOVED_TO == unix.IN_MOVED_TO:
 				fmt.Printf("IN_MOVED_TO [%v] %v\n", e.Cookie, name)
-				if match {
+				if archive.IsArchive(name) {
+					s.queueArchive(dir, name, regex)
+				} else if match {
 					fmt.Printf("adding " + dir + "/" + name + " to processing queue\n")
 					s.QueueOfFiles <- QueueEntry{dirPath: dir, filePath: dir + "/" + name, regex: regex}
 				}
@@ -661,3 +728,49 @@ func (s *Server) watchEventsFor(dir, regex string) {
 		}
 	}
 }
+
+// queueArchive queues one extract job per archive member that matches
+// the regex of an extract source watching dir.  The archive is renamed
+// with the processed suffix once its members are queued so it is not
+// queued again, the watcher that renames it queues the members of
+// every extract source watching dir.
+func (s *Server) queueArchive(dir, name, regex string) {
+	archivePath := dir + "/" + name
+	regexes := s.watched.of(dir)
+	if len(regexes) == 0 {
+		regexes = []string{regex}
+	}
+
+	entries := make([]QueueEntry, 0)
+	for _, r := range regexes {
+		members, err := archive.Members(archivePath, r)
+		if os.IsNotExist(err) {
+			log.Info().Msg("archive " + archivePath + " was queued by another watcher")
+			return
+		}
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("error reading archive " + archivePath)
+			return
+		}
+		for _, m := range members {
+			entries = append(entries, QueueEntry{dirPath: dir, regex: r, member: m})
+		}
+	}
+	if len(entries) == 0 {
+		log.Info().Msg("no archive members match the regex in " + archivePath)
+		return
+	}
+
+	processedPath := archivePath + archive.ProcessedSuffix
+	err := os.Rename(archivePath, processedPath)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in renaming archive " + archivePath)
+		return
+	}
+
+	for _, e := range entries {
+		e.filePath = processedPath
+		log.Info().Msg("adding archive member " + e.member + " of " + processedPath + " to processing queue")
+		s.QueueOfFiles <- e
+	}
+}
@@ -1,12 +1,15 @@
 package extractsource
 
 import (
+	"archive/zip"
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/churrodata/churro/api/v1alpha1"
+	"github.com/churrodata/churro/internal/archive"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -43,7 +46,7 @@ func TestCreateExtractPod(t *testing.T) {
 		t.Fatalf("operator.CreateExtractPod Error: %v", err)
 	}
 
-	err = s.createExtractPod(otherClient, scheme, filePath, cfg, tableName, extractSourceName)
+	err = s.createExtractPod(otherClient, scheme, filePath, "", cfg, tableName, extractSourceName)
 	if err != nil {
 		t.Fatalf("operator.CreateExtractPod Error: %v", err)
 	}
@@ -71,3 +74,56 @@ func TestGetExtractCount(t *testing.T) {
 	fmt.Printf("count is %d\n", count)
 
 }
+
+func TestQueueArchive(t *testing.T) {
+	dir := t.TempDir()
+	f, err := os.Create(filepath.Join(dir, "data.zip"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	for _, name := range []string{"a.csv", "b.json", "c.txt"} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte("x\n")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	// two extract sources watch the directory, the watcher of the csv
+	// source queues the json member too
+	s := Server{QueueOfFiles: make(chan QueueEntry, 4), watched: &watchedPaths{}}
+	s.watched.add(dir, `.*\.csv`)
+	s.watched.add(dir, `.*\.json`)
+	s.watched.add(dir, `.*\.csv`)
+	s.queueArchive(dir, "data.zip", `.*\.csv`)
+	close(s.QueueOfFiles)
+
+	processed := filepath.Join(dir, "data.zip") + archive.ProcessedSuffix
+	got := make(map[string]string)
+	for e := range s.QueueOfFiles {
+		if e.filePath != processed || e.dirPath != dir {
+			t.Fatalf("queued %+v", e)
+		}
+		got[e.member] = e.regex
+	}
+	if len(got) != 2 || got["a.csv"] != `.*\.csv` || got["b.json"] != `.*\.json` {
+		t.Fatalf("queued members %v", got)
+	}
+	if _, err := os.Stat(processed); err != nil {
+		t.Fatalf("archive was not renamed %v", err)
+	}
+
+	// the watcher of the json source finds the archive gone
+	s.QueueOfFiles = make(chan QueueEntry, 4)
+	s.queueArchive(dir, "data.zip", `.*\.json`)
+	if len(s.QueueOfFiles) != 0 {
+		t.Fatalf("queued %d members of a processed archive", len(s.QueueOfFiles))
+	}
+}
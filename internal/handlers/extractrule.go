@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"time"
 
+	extractapi "github.com/churrodata/churro/api/extract"
 	"github.com/churrodata/churro/internal/domain"
 	pb "github.com/churrodata/churro/rpc/ctl"
 )
@@ -39,9 +40,20 @@ type ExtractRuleForm struct {
 	ColumnName        string
 	ColumnPath        string
 	ColumnType        string
+	ColumnFormat      string
 	MatchValues       string
+	MatchRegex        string
+	NotNull           bool
+	MinValue          string
+	MaxValue          string
+	Unique            bool
+	PrivacyPolicy     string
+	KeyColumn         bool
 	Initialized       bool
+	TransformFunction string
 	Functions         []FunctionFormValue
+	ColumnTypes       []string
+	ColumnFormats     []string
 }
 
 // UpdateExtractRule ...
@@ -84,12 +96,21 @@ func (u *HandlerWrapper) UpdateExtractRule(w http.ResponseWriter, r *http.Reques
 
 	//  update the rule with the form contents
 	rule := domain.ExtractRule{
-		ID:              ruleID,
-		ExtractSourceID: extractSourceID,
-		ColumnName:      r.Form["columnname"][0],
-		ColumnPath:      r.Form["columnpath"][0],
-		ColumnType:      r.Form["columntype"][0],
-		MatchValues:     r.Form["matchvalues"][0],
+		ID:                ruleID,
+		ExtractSourceID:   extractSourceID,
+		ColumnName:        r.Form["columnname"][0],
+		ColumnPath:        r.Form["columnpath"][0],
+		ColumnType:        r.Form["columntype"][0],
+		ColumnFormat:      r.FormValue("columnformat"),
+		MatchValues:       r.Form["matchvalues"][0],
+		TransformFunction: r.FormValue("transformfunctionname"),
+		KeyColumn:         r.FormValue("keycolumn") == "true",
+		MatchRegex:        r.FormValue("matchregex"),
+		NotNull:           r.FormValue("notnull") == "true",
+		MinValue:          r.FormValue("minvalue"),
+		MaxValue:          r.FormValue("maxvalue"),
+		Unique:            r.FormValue("unique") == "true",
+		PrivacyPolicy:     r.FormValue("privacypolicy"),
 	}
 
 	req := pb.UpdateExtractRuleRequest{
@@ -158,12 +179,8 @@ func (u *HandlerWrapper) ShowCreateExtractRule(w http.ResponseWriter, r *http.Re
 		PipelineID:      vars["id"],
 		ExtractSourceID: vars["extractsourceid"],
 		Functions:       make([]FunctionFormValue, 0),
-	}
-
-	// allow extract rules to not specify a transform function
-	f := FunctionFormValue{
-		FunctionName: "None",
-		Selected:     "",
+		ColumnTypes:     extractapi.ColumnTypes,
+		ColumnFormats:   extractapi.ColumnFormats,
 	}
 
 	x, err := getPipelineCR(extractRuleForm.PipelineID)
@@ -206,15 +223,7 @@ func (u *HandlerWrapper) ShowCreateExtractRule(w http.ResponseWriter, r *http.Re
 	}
 	extractRuleForm.ExtractSourceName = extractSource.Name
 
-	extractRuleForm.Functions = append(extractRuleForm.Functions, f)
-
-	for i := 0; i < len(functions); i++ {
-		f := FunctionFormValue{
-			FunctionName: functions[i].Name,
-			Selected:     "",
-		}
-		extractRuleForm.Functions = append(extractRuleForm.Functions, f)
-	}
+	extractRuleForm.Functions = functionFormValues(functions)
 
 	tmpl, err := template.ParseFiles("pages/extract-rules-create.html", "pages/navbar.html")
 	if err != nil {
@@ -243,8 +252,16 @@ func (u *HandlerWrapper) CreateExtractRule(w http.ResponseWriter, r *http.Reques
 		ColumnName:        r.Form["columnname"][0],
 		ColumnPath:        r.Form["columnpath"][0],
 		ColumnType:        r.Form["columntype"][0],
+		ColumnFormat:      r.FormValue("columnformat"),
 		MatchValues:       r.Form["matchvalues"][0],
 		TransformFunction: r.Form["transformfunctionname"][0],
+		KeyColumn:         r.FormValue("keycolumn") == "true",
+		MatchRegex:        r.FormValue("matchregex"),
+		NotNull:           r.FormValue("notnull") == "true",
+		MinValue:          r.FormValue("minvalue"),
+		MaxValue:          r.FormValue("maxvalue"),
+		Unique:            r.FormValue("unique") == "true",
+		PrivacyPolicy:     r.FormValue("privacypolicy"),
 		LastUpdated:       time.Now(),
 	}
 	pipelineName := r.Form["pipelinename"][0]
@@ -373,7 +390,17 @@ func (u *HandlerWrapper) ExtractRule(w http.ResponseWriter, r *http.Request) {
 		MatchValues:       rule.MatchValues,
 		ColumnPath:        rule.ColumnPath,
 		ColumnType:        rule.ColumnType,
+		ColumnFormat:      rule.ColumnFormat,
+		MatchRegex:        rule.MatchRegex,
+		NotNull:           rule.NotNull,
+		MinValue:          rule.MinValue,
+		MaxValue:          rule.MaxValue,
+		Unique:            rule.Unique,
+		PrivacyPolicy:     rule.PrivacyPolicy,
+		KeyColumn:         rule.KeyColumn,
 		Initialized:       extractSource.Initialized,
+		ColumnTypes:       extractapi.ColumnTypes,
+		ColumnFormats:     extractapi.ColumnFormats,
 	}
 
 	var functions []domain.TransformFunction
@@ -384,21 +411,10 @@ func (u *HandlerWrapper) ExtractRule(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	noneValue := domain.TransformFunction{Name: ""}
-	functions = append(functions, noneValue)
-
-	extractRuleForm.Functions = make([]FunctionFormValue, 0)
-	for i := 0; i < len(functions); i++ {
-		x := FunctionFormValue{
-			FunctionName: functions[i].Name,
-		}
-		if rule.TransformFunction == functions[i].Name {
-			x.Selected = "selected"
-		} else {
-			x.Selected = ""
-		}
-		extractRuleForm.Functions = append(extractRuleForm.Functions, x)
+	if rule.TransformFunction != "None" {
+		extractRuleForm.TransformFunction = rule.TransformFunction
 	}
+	extractRuleForm.Functions = functionFormValues(functions)
 
 	tmpl, err := template.ParseFiles("pages/extract-rule.html", "pages/navbar.html")
 	if err != nil {
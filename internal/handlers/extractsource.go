@@ -22,6 +22,7 @@ import (
 	"html/template"
 	"io"
 	"net/http"
+	"sort"
 	"time"
 
 	extractapi "github.com/churrodata/churro/api/extract"
@@ -46,7 +47,10 @@ type ExtractSourceForm struct {
 	ExtractSource   domain.ExtractSource
 	ExtractRules    []domain.ExtractRule
 	Extensions      []domain.Extension
+	Lookups         []domain.Lookup
+	Routes          []domain.Route
 	Metrics         []domain.ExtractSourceMetric
+	FailedFiles     []domain.FailedFile
 }
 
 // ShowCreateExtractSource ...
@@ -140,6 +144,36 @@ func (u *HandlerWrapper) PipelineExtractSource(w http.ResponseWriter, r *http.Re
 	for _, v := range value.Extensions {
 		wdf.Extensions = append(wdf.Extensions, v)
 	}
+	for _, v := range value.Lookups {
+		wdf.Lookups = append(wdf.Lookups, v)
+	}
+	sort.Slice(wdf.Lookups, func(i, j int) bool {
+		return wdf.Lookups[i].LookupName < wdf.Lookups[j].LookupName
+	})
+	for _, v := range value.Routes {
+		wdf.Routes = append(wdf.Routes, v)
+	}
+	sort.Slice(wdf.Routes, func(i, j int) bool {
+		if wdf.Routes[i].Position != wdf.Routes[j].Position {
+			return wdf.Routes[i].Position < wdf.Routes[j].Position
+		}
+		return wdf.Routes[i].RouteName < wdf.Routes[j].RouteName
+	})
+
+	// the page is still shown when the extractsource service is down
+	wdf.FailedFiles = make([]domain.FailedFile, 0)
+	failedResponse, err := client.GetFailedFiles(context.Background(), &pb.GetFailedFilesRequest{
+		Namespace:       x.Name,
+		ExtractSourceID: wdf.ExtractSourceID,
+	})
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error getting failed files")
+	} else {
+		err = json.Unmarshal([]byte(failedResponse.FailedFilesString), &wdf.FailedFiles)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("error reading failed files")
+		}
+	}
 
 	tmpl, err := template.ParseFiles("pages/extractsource.html", "pages/navbar.html")
 	if err != nil {
@@ -186,6 +220,41 @@ func (u *HandlerWrapper) CreateExtractSource(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	lazyQuotes, err := strconv.ParseBool(r.Form["lazyquotes"][0])
+	if err != nil {
+		a := u.Copy("lazyquotes is blank or not a valid boolean")
+		a.ShowCreateExtractSource(w, r)
+		return
+	}
+
+	batchRows, err := strconv.Atoi(r.Form["batchrows"][0])
+	if err != nil {
+		a := u.Copy("batchrows is blank or not a valid integer")
+		a.ShowCreateExtractSource(w, r)
+		return
+	}
+
+	batchBytes, err := strconv.Atoi(r.Form["batchbytes"][0])
+	if err != nil {
+		a := u.Copy("batchbytes is blank or not a valid integer")
+		a.ShowCreateExtractSource(w, r)
+		return
+	}
+
+	dedupWindow, err := strconv.Atoi(r.FormValue("dedupwindow"))
+	if err != nil || dedupWindow < 0 {
+		a := u.Copy("dedupwindow is required to be an integer >= 0")
+		a.ShowCreateExtractSource(w, r)
+		return
+	}
+
+	copyThreshold, err := strconv.Atoi(r.FormValue("copythreshold"))
+	if err != nil || copyThreshold < 0 {
+		a := u.Copy("copythreshold is required to be an integer >= 0")
+		a.ShowCreateExtractSource(w, r)
+		return
+	}
+
 	d := domain.ExtractSource{
 		ID:             xid.New().String(),
 		Name:           r.Form["extractsourcename"][0],
@@ -201,9 +270,21 @@ func (u *HandlerWrapper) CreateExtractSource(w http.ResponseWriter, r *http.Requ
 		Encoding:       r.Form["encoding"][0],
 		Transport:      r.Form["transport"][0],
 		Servicetype:    r.Form["servicetype"][0],
+		Delimiter:      r.Form["delimiter"][0],
+		Quote:          r.Form["quote"][0],
+		Comment:        r.Form["comment"][0],
+		LazyQuotes:     lazyQuotes,
+		Charset:        r.Form["charset"][0],
+		BatchRows:      batchRows,
+		BatchBytes:     batchBytes,
+		LoadMode:       r.FormValue("loadmode"),
 		LastUpdated:    time.Now(),
 		ExtractRules:   make(map[string]domain.ExtractRule),
 	}
+	d.ReloadDuplicates = r.FormValue("reloadduplicates") == "true"
+	d.RowHash = r.FormValue("rowhash") == "true"
+	d.DedupWindow = dedupWindow
+	d.CopyThreshold = copyThreshold
 	pipelineName := r.Form["pipelinename"][0]
 
 	if d.Path == "" {
@@ -236,6 +317,11 @@ func (u *HandlerWrapper) CreateExtractSource(w http.ResponseWriter, r *http.Requ
 		a.ShowCreateExtractSource(w, r)
 		return
 	}
+	if d.BatchRows < 0 || d.BatchBytes < 0 {
+		a := u.Copy("batchrows and batchbytes are required to be >= 0")
+		a.ShowCreateExtractSource(w, r)
+		return
+	}
 	if d.Servicetype == "" && (d.Scheme == extractapi.HTTPPostScheme) {
 		a := u.Copy("servicetype is required")
 		a.ShowCreateExtractSource(w, r)
@@ -251,6 +337,14 @@ func (u *HandlerWrapper) CreateExtractSource(w http.ResponseWriter, r *http.Requ
 		a.ShowCreateExtractSource(w, r)
 		return
 	}
+	if d.Scheme == extractapi.CSVScheme {
+		_, err = extractapi.GetCSVDialect(d.Delimiter, d.Quote, d.Comment, d.LazyQuotes, d.Charset)
+		if err != nil {
+			a := u.Copy(err.Error())
+			a.ShowCreateExtractSource(w, r)
+			return
+		}
+	}
 
 	log.Info().Msg(fmt.Sprintf("adding new extract source %+v\n", d))
 
@@ -349,6 +443,53 @@ func (u *HandlerWrapper) UpdateExtractSource(w http.ResponseWriter, r *http.Requ
 		a.PipelineExtractSource(w, r)
 		return
 	}
+	wdir.LoadMode = r.FormValue("loadmode")
+	wdir.ReloadDuplicates = r.FormValue("reloadduplicates") == "true"
+	wdir.RowHash = r.FormValue("rowhash") == "true"
+	wdir.DedupWindow, err = strconv.Atoi(r.FormValue("dedupwindow"))
+	if err != nil || wdir.DedupWindow < 0 {
+		a := u.Copy("dedupwindow is required to be an integer >= 0")
+		a.PipelineExtractSource(w, r)
+		return
+	}
+	wdir.CopyThreshold, err = strconv.Atoi(r.FormValue("copythreshold"))
+	if err != nil || wdir.CopyThreshold < 0 {
+		a := u.Copy("copythreshold is required to be an integer >= 0")
+		a.PipelineExtractSource(w, r)
+		return
+	}
+	if wdir.Scheme == extractapi.CSVScheme {
+		wdir.Delimiter = r.Form["delimiter"][0]
+		wdir.Quote = r.Form["quote"][0]
+		wdir.Comment = r.Form["comment"][0]
+		wdir.LazyQuotes, err = strconv.ParseBool(r.Form["lazyquotes"][0])
+		if err != nil {
+			a := u.Copy("lazyquotes is blank or not a valid boolean")
+			a.PipelineExtractSource(w, r)
+			return
+		}
+		wdir.Charset = r.Form["charset"][0]
+		_, err = extractapi.GetCSVDialect(wdir.Delimiter, wdir.Quote, wdir.Comment, wdir.LazyQuotes, wdir.Charset)
+		if err != nil {
+			a := u.Copy(err.Error())
+			a.PipelineExtractSource(w, r)
+			return
+		}
+	}
+	if wdir.Scheme == extractapi.CSVScheme || wdir.Scheme == extractapi.NDJSONScheme || wdir.Scheme == extractapi.FixedWidthScheme {
+		wdir.BatchRows, err = strconv.Atoi(r.Form["batchrows"][0])
+		if err != nil || wdir.BatchRows < 0 {
+			a := u.Copy("batchrows is required to be an integer >= 0")
+			a.PipelineExtractSource(w, r)
+			return
+		}
+		wdir.BatchBytes, err = strconv.Atoi(r.Form["batchbytes"][0])
+		if err != nil || wdir.BatchBytes < 0 {
+			a := u.Copy("batchbytes is required to be an integer >= 0")
+			a.PipelineExtractSource(w, r)
+			return
+		}
+	}
 
 	b, _ := json.Marshal(&wdir)
 	wreq := pb.UpdateExtractSourceRequest{
@@ -547,3 +688,43 @@ func (u *HandlerWrapper) UploadToExtractSource(w http.ResponseWriter, r *http.Re
 	j.PipelineExtractSource(w, r)
 	return
 }
+
+// RequeueFailedFiles extracts a failed file of an extract source again,
+// or all of its failed files when no file is given
+func (u *HandlerWrapper) RequeueFailedFiles(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	req := pb.RequeueFailedFilesRequest{
+		ExtractSourceID: vars["extractsourceid"],
+	}
+	if vars["fid"] != "" {
+		req.IDs = []string{vars["fid"]}
+	}
+
+	pipelineID := vars["id"]
+	log.Info().Msg(fmt.Sprintf("ui RequeueFailedFiles with extractsourceid=[%s] fid=[%s] id=[%s]\n", req.ExtractSourceID, vars["fid"], pipelineID))
+
+	x, err := getPipelineCR(pipelineID)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	client, err := GetServiceConnection(x.Name)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "error RequeueFailedFiles "+err.Error())
+		return
+	}
+
+	req.Namespace = x.Name
+
+	_, err = client.RequeueFailedFiles(context.Background(), &req)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "error RequeueFailedFiles "+err.Error())
+		return
+	}
+
+	targetURL := fmt.Sprintf("/pipelines/%s/extractsources/%s",
+		pipelineID, req.ExtractSourceID)
+	http.Redirect(w, r, targetURL, 302)
+}
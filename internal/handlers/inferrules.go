@@ -0,0 +1,167 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog/log"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/domain"
+	pb "github.com/churrodata/churro/rpc/ctl"
+)
+
+// maxSampleBytes limits the part of a sample file sent to infer the
+// extract rules from
+const maxSampleBytes = 32 << 20
+
+// InferRulesForm holds the extract rules inferred from a sample file
+// while they are reviewed
+type InferRulesForm struct {
+	UserEmail         string
+	ErrorText         string
+	PipelineID        string
+	PipelineName      string
+	ExtractSourceID   string
+	ExtractSourceName string
+	Rules             []domain.ExtractRule
+	ColumnTypes       []string
+	ColumnFormats     []string
+}
+
+// showInferredRules infers the extract rules of a sample file and
+// shows them for review before they are created
+func (u *HandlerWrapper) showInferredRules(w http.ResponseWriter, r *http.Request, sample io.Reader, client pb.CtlClient, form InferRulesForm) {
+	b, err := ioutil.ReadAll(io.LimitReader(sample, maxSampleBytes))
+	if err != nil {
+		a := u.Copy(err.Error())
+		a.PipelineExtractSource(w, r)
+		return
+	}
+
+	req := pb.InferExtractRulesRequest{
+		Namespace:       form.PipelineName,
+		ExtractSourceID: form.ExtractSourceID,
+		Sample:          b,
+	}
+	response, err := client.InferExtractRules(context.Background(), &req)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in inferring extract rules")
+		a := u.Copy(err.Error())
+		a.PipelineExtractSource(w, r)
+		return
+	}
+
+	err = json.Unmarshal([]byte(response.ExtractRulesString), &form.Rules)
+	if err != nil {
+		a := u.Copy(err.Error())
+		a.PipelineExtractSource(w, r)
+		return
+	}
+
+	u.renderInferredRules(w, form)
+}
+
+// CreateInferredExtractRules creates the reviewed extract rules that
+// were inferred from a sample file
+func (u *HandlerWrapper) CreateInferredExtractRules(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	r.ParseForm()
+
+	form := InferRulesForm{
+		UserEmail:         u.UserEmail,
+		PipelineID:        vars["id"],
+		PipelineName:      r.FormValue("pipelinename"),
+		ExtractSourceID:   vars["extractsourceid"],
+		ExtractSourceName: r.FormValue("extractsourcename"),
+	}
+
+	names := r.Form["columnname"]
+	paths := r.Form["columnpath"]
+	types := r.Form["columntype"]
+	formats := r.Form["columnformat"]
+	if len(paths) != len(names) || len(types) != len(names) || len(formats) != len(names) {
+		form.ErrorText = "the extract rules form is not complete"
+		u.renderInferredRules(w, form)
+		return
+	}
+
+	included := make(map[int]bool)
+	for _, v := range r.Form["include"] {
+		i, err := strconv.Atoi(v)
+		if err == nil {
+			included[i] = true
+		}
+	}
+
+	rules := make([]domain.ExtractRule, 0)
+	for i := range names {
+		rule := domain.ExtractRule{
+			ExtractSourceID: form.ExtractSourceID,
+			ColumnName:      names[i],
+			ColumnPath:      paths[i],
+			ColumnType:      types[i],
+			ColumnFormat:    formats[i],
+		}
+		form.Rules = append(form.Rules, rule)
+		if included[i] {
+			rules = append(rules, rule)
+		}
+	}
+
+	client, err := GetServiceConnection(form.PipelineName)
+	if err != nil {
+		form.ErrorText = err.Error()
+		u.renderInferredRules(w, form)
+		return
+	}
+
+	b, _ := json.Marshal(rules)
+	req := pb.CreateExtractRulesRequest{
+		Namespace:          form.PipelineName,
+		ExtractSourceID:    form.ExtractSourceID,
+		ExtractRulesString: string(b),
+	}
+	_, err = client.CreateExtractRules(context.Background(), &req)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in creating extract rules")
+		form.ErrorText = err.Error()
+		u.renderInferredRules(w, form)
+		return
+	}
+
+	targetURL := fmt.Sprintf("/pipelines/%s/extractsources/%s", form.PipelineID, form.ExtractSourceID)
+	http.Redirect(w, r, targetURL, 302)
+}
+
+func (u *HandlerWrapper) renderInferredRules(w http.ResponseWriter, form InferRulesForm) {
+	form.ColumnTypes = extractapi.ColumnTypes
+	form.ColumnFormats = extractapi.ColumnFormats
+
+	tmpl, err := template.ParseFiles("pages/extract-rules-review.html", "pages/navbar.html")
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+	err = tmpl.ExecuteTemplate(w, "layout", form)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in template")
+	}
+}
@@ -0,0 +1,363 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
+
+	"net/http"
+	"time"
+
+	"github.com/churrodata/churro/internal/domain"
+	pb "github.com/churrodata/churro/rpc/ctl"
+)
+
+// LookupForm ...
+type LookupForm struct {
+	UserEmail         string
+	ErrorText         string
+	PipelineID        string
+	PipelineName      string
+	ExtractSourceID   string
+	ExtractSourceName string
+	LookupID          string
+	LookupName        string
+	ReferenceTable    string
+	ReferenceFile     string
+	MatchColumns      string
+	Columns           string
+}
+
+// lookupFromForm builds a lookup from the posted form values
+func lookupFromForm(r *http.Request, lookupID, extractSourceID string) domain.Lookup {
+	return domain.Lookup{
+		ID:              lookupID,
+		ExtractSourceID: extractSourceID,
+		LookupName:      r.FormValue("lookupname"),
+		ReferenceTable:  r.FormValue("referencetable"),
+		ReferenceFile:   r.FormValue("referencefile"),
+		MatchColumns:    r.FormValue("matchcolumns"),
+		Columns:         r.FormValue("columns"),
+		LastUpdated:     time.Now(),
+	}
+}
+
+// UpdateLookup ...
+func (u *HandlerWrapper) UpdateLookup(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	pipelineID := vars["id"]
+	if pipelineID == "" {
+		a := u.Copy("invalid pipeline id")
+		a.ShowCreateLookup(w, r)
+		return
+	}
+	extractSourceID := vars["extractsourceid"]
+	if extractSourceID == "" {
+		a := u.Copy("invalid extract source id")
+		a.ShowCreateLookup(w, r)
+		return
+	}
+	lookupID := vars["lid"]
+	if lookupID == "" {
+		a := u.Copy("invalid lookup id")
+		a.ShowCreateLookup(w, r)
+		return
+	}
+
+	r.ParseForm()
+
+	x, err := getPipelineCR(pipelineID)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	client, err := GetServiceConnection(x.Name)
+	if err != nil {
+		a := u.Copy(err.Error())
+		a.Lookup(w, r)
+		return
+	}
+
+	//  update the lookup with the form contents
+	l := lookupFromForm(r, lookupID, extractSourceID)
+
+	req := pb.UpdateLookupRequest{
+		Namespace:       x.Name,
+		ExtractSourceID: extractSourceID,
+	}
+
+	b, _ := json.Marshal(&l)
+	req.LookupString = string(b)
+
+	_, err = client.UpdateLookup(context.Background(), &req)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		a := u.Copy(err.Error())
+		a.Lookup(w, r)
+		return
+	}
+
+	targetURL := fmt.Sprintf("/pipelines/%s/extractsources/%s", pipelineID, extractSourceID)
+	http.Redirect(w, r, targetURL, 302)
+
+}
+
+// DeleteLookup ...
+func (u *HandlerWrapper) DeleteLookup(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	req := pb.DeleteLookupRequest{
+		ExtractSourceID: vars["extractsourceid"],
+		LookupID:        vars["lid"],
+	}
+
+	pipelineID := vars["id"]
+	log.Info().Msg(fmt.Sprintf("ui DeleteLookup with extractsourceid=[%s] lid=[%s] id=[%s]\n", req.ExtractSourceID, req.LookupID, pipelineID))
+
+	x, err := getPipelineCR(pipelineID)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	client, err := GetServiceConnection(x.Name)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "error DeleteLookup "+err.Error())
+		return
+	}
+
+	req.Namespace = x.Name
+
+	_, err = client.DeleteLookup(context.Background(), &req)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "error DeleteLookup "+err.Error())
+		return
+	}
+
+	targetURL := fmt.Sprintf("/pipelines/%s/extractsources/%s",
+		pipelineID, req.ExtractSourceID)
+	http.Redirect(w, r, targetURL, 302)
+}
+
+// ShowCreateLookup ...
+func (u *HandlerWrapper) ShowCreateLookup(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	lForm := LookupForm{
+		UserEmail:       u.UserEmail,
+		PipelineID:      vars["id"],
+		ExtractSourceID: vars["extractsourceid"],
+	}
+
+	// keep whatever the user entered when redisplaying after an error
+	if r.Method == http.MethodPost {
+		l := lookupFromForm(r, "", lForm.ExtractSourceID)
+		lForm.LookupName = l.LookupName
+		lForm.ReferenceTable = l.ReferenceTable
+		lForm.ReferenceFile = l.ReferenceFile
+		lForm.MatchColumns = l.MatchColumns
+		lForm.Columns = l.Columns
+	}
+
+	x, err := getPipelineCR(lForm.PipelineID)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	lForm.PipelineName = x.Name
+
+	client, err := GetServiceConnection(lForm.PipelineName)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	req := pb.GetExtractSourceRequest{
+		Namespace:       lForm.PipelineName,
+		ExtractSourceID: vars["extractsourceid"],
+	}
+
+	response, err := client.GetExtractSource(context.Background(), &req)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	var extractSource domain.ExtractSource
+	err = json.Unmarshal([]byte(response.ExtractSourceString), &extractSource)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+	lForm.ExtractSourceName = extractSource.Name
+
+	tmpl, err := template.ParseFiles("pages/lookup-create.html", "pages/navbar.html")
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+	lForm.ErrorText = u.ErrorText
+	err = tmpl.ExecuteTemplate(w, "layout", lForm)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in template")
+	}
+}
+
+// CreateLookup ...
+func (u *HandlerWrapper) CreateLookup(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	pipelineID := vars["id"]
+	extractSourceID := vars["extractsourceid"]
+
+	r.ParseForm()
+
+	p := lookupFromForm(r, xid.New().String(), extractSourceID)
+
+	if p.LookupName == "" {
+		a := u.Copy("lookup name is blank")
+		a.ShowCreateLookup(w, r)
+		return
+	}
+
+	x, err := getPipelineCR(pipelineID)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	client, err := GetServiceConnection(x.Name)
+	if err != nil {
+		a := u.Copy(err.Error())
+		a.ShowCreateLookup(w, r)
+		return
+	}
+
+	b, _ := json.Marshal(&p)
+	req := pb.CreateLookupRequest{
+		Namespace:    x.Name,
+		LookupString: string(b),
+	}
+
+	_, err = client.CreateLookup(context.Background(), &req)
+	if err != nil {
+		a := u.Copy(err.Error())
+		a.ShowCreateLookup(w, r)
+		return
+	}
+	targetURL := fmt.Sprintf("/pipelines/%s/extractsources/%s?pipelinename=%s", pipelineID, extractSourceID, x.Name)
+	http.Redirect(w, r, targetURL, 302)
+
+}
+
+// Lookup ...
+func (u *HandlerWrapper) Lookup(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	pipelineID := vars["id"]
+	extractSourceID := vars["extractsourceid"]
+	lookupID := vars["lid"]
+
+	x, err := getPipelineCR(pipelineID)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	client, err := GetServiceConnection(x.Name)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	wreq := pb.GetExtractSourceRequest{
+		Namespace:       x.Name,
+		ExtractSourceID: extractSourceID,
+	}
+	wresponse, err := client.GetExtractSource(context.Background(), &wreq)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in getextractsource")
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	var extractSource domain.ExtractSource
+	err = json.Unmarshal([]byte(wresponse.ExtractSourceString), &extractSource)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	req := pb.GetLookupRequest{
+		Namespace:       x.Name,
+		ExtractSourceID: extractSourceID,
+		LookupID:        lookupID,
+	}
+
+	response, err := client.GetLookup(context.Background(), &req)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in getlookup")
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	var l domain.Lookup
+	err = json.Unmarshal([]byte(response.LookupString), &l)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in lookup unmarshal")
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	lForm := LookupForm{
+		UserEmail:         u.UserEmail,
+		PipelineID:        pipelineID,
+		PipelineName:      x.Name,
+		ExtractSourceID:   extractSourceID,
+		ExtractSourceName: extractSource.Name,
+		LookupID:          lookupID,
+		LookupName:        l.LookupName,
+		ReferenceTable:    l.ReferenceTable,
+		ReferenceFile:     l.ReferenceFile,
+		MatchColumns:      l.MatchColumns,
+		Columns:           l.Columns,
+	}
+
+	// keep whatever the user entered when redisplaying after an error
+	if r.Method == http.MethodPost {
+		f := lookupFromForm(r, lookupID, extractSourceID)
+		lForm.LookupName = f.LookupName
+		lForm.ReferenceTable = f.ReferenceTable
+		lForm.ReferenceFile = f.ReferenceFile
+		lForm.MatchColumns = f.MatchColumns
+		lForm.Columns = f.Columns
+	}
+
+	tmpl, err := template.ParseFiles("pages/lookup.html", "pages/navbar.html")
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in template")
+		w.Write([]byte(err.Error()))
+		return
+	}
+	lForm.ErrorText = u.ErrorText
+	err = tmpl.ExecuteTemplate(w, "layout", lForm)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in template")
+	}
+}
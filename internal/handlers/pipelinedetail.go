@@ -184,14 +184,16 @@ func (u *HandlerWrapper) PipelineDetailHandler(w http.ResponseWriter, r *http.Re
 	pipelineDetail.Jobs = make([]domain.JobProfile, 0)
 	for i := 0; i < len(piResponse.Jobs); i++ {
 		pm := domain.JobProfile{
-			JobName:       piResponse.Jobs[i].Name,
-			Status:        piResponse.Jobs[i].Status,
-			DataSource:    piResponse.Jobs[i].Datasource,
-			FileName:      piResponse.Jobs[i].FileName,
-			TableName:     piResponse.Jobs[i].TableName,
-			RecordsLoaded: int(piResponse.Jobs[i].RecordsLoaded),
-			CompletedDate: piResponse.Jobs[i].CompletedDate,
-			StartDate:     piResponse.Jobs[i].StartDate,
+			JobName:          piResponse.Jobs[i].Name,
+			Status:           piResponse.Jobs[i].Status,
+			DataSource:       piResponse.Jobs[i].Datasource,
+			FileName:         piResponse.Jobs[i].FileName,
+			TableName:        piResponse.Jobs[i].TableName,
+			RecordsLoaded:    int(piResponse.Jobs[i].RecordsLoaded),
+			ConversionErrors: int(piResponse.Jobs[i].ConversionErrors),
+			RecordsRejected:  int(piResponse.Jobs[i].RecordsRejected),
+			CompletedDate:    piResponse.Jobs[i].CompletedDate,
+			StartDate:        piResponse.Jobs[i].StartDate,
 		}
 		pipelineDetail.Jobs = append(pipelineDetail.Jobs, pm)
 	}
@@ -331,6 +333,16 @@ func (u *HandlerWrapper) CreatePipelineDetail(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if dbType == domain.DatabasePostgres || dbType == domain.DatabaseClickhouse {
+		src, err := getExternalSource(r, dbType)
+		if err != nil {
+			a := u.Copy(err.Error())
+			a.ShowCreatePipeline(w, r)
+			return
+		}
+		p.Spec.AdminDataSource = src
+	}
+
 	if p.ObjectMeta.Name == "" {
 		a := u.Copy("pipeline name is blank")
 		a.ShowCreatePipeline(w, r)
@@ -599,3 +611,26 @@ func getSelectedPipelines(form url.Values) (values []string) {
 
 	return values
 }
+
+// getExternalSource returns the externally provided postgres or
+// clickhouse server entered on the create pipeline form, only postgres
+// needs the name of a database on the server
+func getExternalSource(r *http.Request, dbType string) (src v1alpha1.Source, err error) {
+	src.Host = r.Form["dbhost"][0]
+	src.Externaldatabase = r.Form["dbname"][0]
+	src.Username = r.Form["dbuser"][0]
+	if src.Host == "" {
+		return src, fmt.Errorf("database host is required")
+	}
+	if dbType == domain.DatabasePostgres && src.Externaldatabase == "" {
+		return src, fmt.Errorf("database name is required")
+	}
+	if src.Username == "" {
+		return src, fmt.Errorf("database user is required")
+	}
+	src.Port, err = strconv.Atoi(r.Form["dbport"][0])
+	if err != nil || src.Port <= 0 {
+		return src, fmt.Errorf("database port needs to be a number greater than 0")
+	}
+	return src, nil
+}
@@ -103,14 +103,16 @@ func (u *HandlerWrapper) PipelineStatusHandler(w http.ResponseWriter, r *http.Re
 		pipelineDetail.Jobs = make([]domain.JobProfile, 0)
 		for i := 0; i < len(piResponse.Jobs); i++ {
 			pm := domain.JobProfile{
-				JobName:       piResponse.Jobs[i].Name,
-				Status:        piResponse.Jobs[i].Status,
-				DataSource:    piResponse.Jobs[i].Datasource,
-				FileName:      piResponse.Jobs[i].FileName,
-				TableName:     piResponse.Jobs[i].TableName,
-				RecordsLoaded: int(piResponse.Jobs[i].RecordsLoaded),
-				CompletedDate: piResponse.Jobs[i].CompletedDate,
-				StartDate:     piResponse.Jobs[i].StartDate,
+				JobName:          piResponse.Jobs[i].Name,
+				Status:           piResponse.Jobs[i].Status,
+				DataSource:       piResponse.Jobs[i].Datasource,
+				FileName:         piResponse.Jobs[i].FileName,
+				TableName:        piResponse.Jobs[i].TableName,
+				RecordsLoaded:    int(piResponse.Jobs[i].RecordsLoaded),
+				ConversionErrors: int(piResponse.Jobs[i].ConversionErrors),
+				RecordsRejected:  int(piResponse.Jobs[i].RecordsRejected),
+				CompletedDate:    piResponse.Jobs[i].CompletedDate,
+				StartDate:        piResponse.Jobs[i].StartDate,
 			}
 			pipelineDetail.Jobs = append(pipelineDetail.Jobs, pm)
 		}
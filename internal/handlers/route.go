@@ -0,0 +1,377 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
+
+	"net/http"
+	"time"
+
+	"github.com/churrodata/churro/internal/domain"
+	pb "github.com/churrodata/churro/rpc/ctl"
+)
+
+// RouteForm ...
+type RouteForm struct {
+	UserEmail         string
+	ErrorText         string
+	PipelineID        string
+	PipelineName      string
+	ExtractSourceID   string
+	ExtractSourceName string
+	RouteID           string
+	RouteName         string
+	Position          int
+	Predicate         string
+	TargetTable       string
+	Drop              bool
+}
+
+// routeFromForm builds a route from the posted form values
+func routeFromForm(r *http.Request, routeID, extractSourceID string) (domain.Route, error) {
+	route := domain.Route{
+		ID:              routeID,
+		ExtractSourceID: extractSourceID,
+		RouteName:       r.FormValue("routename"),
+		Predicate:       r.FormValue("predicate"),
+		TargetTable:     r.FormValue("targettable"),
+		Drop:            r.FormValue("drop") == "true",
+		LastUpdated:     time.Now(),
+	}
+	position, err := strconv.Atoi(r.FormValue("position"))
+	if err != nil {
+		return route, errors.New("position is blank or not a valid integer")
+	}
+	route.Position = position
+	return route, nil
+}
+
+// setRoute copies a route into the form
+func (f *RouteForm) setRoute(route domain.Route) {
+	f.RouteName = route.RouteName
+	f.Position = route.Position
+	f.Predicate = route.Predicate
+	f.TargetTable = route.TargetTable
+	f.Drop = route.Drop
+}
+
+// UpdateRoute ...
+func (u *HandlerWrapper) UpdateRoute(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	pipelineID := vars["id"]
+	if pipelineID == "" {
+		a := u.Copy("invalid pipeline id")
+		a.ShowCreateRoute(w, r)
+		return
+	}
+	extractSourceID := vars["extractsourceid"]
+	if extractSourceID == "" {
+		a := u.Copy("invalid extract source id")
+		a.ShowCreateRoute(w, r)
+		return
+	}
+	routeID := vars["rid"]
+	if routeID == "" {
+		a := u.Copy("invalid route id")
+		a.ShowCreateRoute(w, r)
+		return
+	}
+
+	r.ParseForm()
+
+	x, err := getPipelineCR(pipelineID)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	client, err := GetServiceConnection(x.Name)
+	if err != nil {
+		a := u.Copy(err.Error())
+		a.Route(w, r)
+		return
+	}
+
+	//  update the route with the form contents
+	rt, err := routeFromForm(r, routeID, extractSourceID)
+	if err != nil {
+		a := u.Copy(err.Error())
+		a.Route(w, r)
+		return
+	}
+
+	req := pb.UpdateRouteRequest{
+		Namespace:       x.Name,
+		ExtractSourceID: extractSourceID,
+	}
+
+	b, _ := json.Marshal(&rt)
+	req.RouteString = string(b)
+
+	_, err = client.UpdateRoute(context.Background(), &req)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("some error")
+		a := u.Copy(err.Error())
+		a.Route(w, r)
+		return
+	}
+
+	targetURL := fmt.Sprintf("/pipelines/%s/extractsources/%s", pipelineID, extractSourceID)
+	http.Redirect(w, r, targetURL, 302)
+
+}
+
+// DeleteRoute ...
+func (u *HandlerWrapper) DeleteRoute(w http.ResponseWriter, r *http.Request) {
+
+	vars := mux.Vars(r)
+	req := pb.DeleteRouteRequest{
+		ExtractSourceID: vars["extractsourceid"],
+		RouteID:         vars["rid"],
+	}
+
+	pipelineID := vars["id"]
+	log.Info().Msg(fmt.Sprintf("ui DeleteRoute with extractsourceid=[%s] rid=[%s] id=[%s]\n", req.ExtractSourceID, req.RouteID, pipelineID))
+
+	x, err := getPipelineCR(pipelineID)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	client, err := GetServiceConnection(x.Name)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "error DeleteRoute "+err.Error())
+		return
+	}
+
+	req.Namespace = x.Name
+
+	_, err = client.DeleteRoute(context.Background(), &req)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "error DeleteRoute "+err.Error())
+		return
+	}
+
+	targetURL := fmt.Sprintf("/pipelines/%s/extractsources/%s",
+		pipelineID, req.ExtractSourceID)
+	http.Redirect(w, r, targetURL, 302)
+}
+
+// ShowCreateRoute ...
+func (u *HandlerWrapper) ShowCreateRoute(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	rForm := RouteForm{
+		UserEmail:       u.UserEmail,
+		PipelineID:      vars["id"],
+		ExtractSourceID: vars["extractsourceid"],
+	}
+
+	// keep whatever the user entered when redisplaying after an error
+	if r.Method == http.MethodPost {
+		rt, _ := routeFromForm(r, "", rForm.ExtractSourceID)
+		rForm.setRoute(rt)
+	}
+
+	x, err := getPipelineCR(rForm.PipelineID)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	rForm.PipelineName = x.Name
+
+	client, err := GetServiceConnection(rForm.PipelineName)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	req := pb.GetExtractSourceRequest{
+		Namespace:       rForm.PipelineName,
+		ExtractSourceID: vars["extractsourceid"],
+	}
+
+	response, err := client.GetExtractSource(context.Background(), &req)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	var extractSource domain.ExtractSource
+	err = json.Unmarshal([]byte(response.ExtractSourceString), &extractSource)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+	rForm.ExtractSourceName = extractSource.Name
+
+	tmpl, err := template.ParseFiles("pages/route-create.html", "pages/navbar.html")
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+	rForm.ErrorText = u.ErrorText
+	err = tmpl.ExecuteTemplate(w, "layout", rForm)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in template")
+	}
+}
+
+// CreateRoute ...
+func (u *HandlerWrapper) CreateRoute(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	pipelineID := vars["id"]
+	extractSourceID := vars["extractsourceid"]
+
+	r.ParseForm()
+
+	p, err := routeFromForm(r, xid.New().String(), extractSourceID)
+	if err != nil {
+		a := u.Copy(err.Error())
+		a.ShowCreateRoute(w, r)
+		return
+	}
+
+	if p.RouteName == "" {
+		a := u.Copy("route name is blank")
+		a.ShowCreateRoute(w, r)
+		return
+	}
+
+	x, err := getPipelineCR(pipelineID)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	client, err := GetServiceConnection(x.Name)
+	if err != nil {
+		a := u.Copy(err.Error())
+		a.ShowCreateRoute(w, r)
+		return
+	}
+
+	b, _ := json.Marshal(&p)
+	req := pb.CreateRouteRequest{
+		Namespace:   x.Name,
+		RouteString: string(b),
+	}
+
+	_, err = client.CreateRoute(context.Background(), &req)
+	if err != nil {
+		a := u.Copy(err.Error())
+		a.ShowCreateRoute(w, r)
+		return
+	}
+	targetURL := fmt.Sprintf("/pipelines/%s/extractsources/%s?pipelinename=%s", pipelineID, extractSourceID, x.Name)
+	http.Redirect(w, r, targetURL, 302)
+
+}
+
+// Route ...
+func (u *HandlerWrapper) Route(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	pipelineID := vars["id"]
+	extractSourceID := vars["extractsourceid"]
+	routeID := vars["rid"]
+
+	x, err := getPipelineCR(pipelineID)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	client, err := GetServiceConnection(x.Name)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	wreq := pb.GetExtractSourceRequest{
+		Namespace:       x.Name,
+		ExtractSourceID: extractSourceID,
+	}
+	wresponse, err := client.GetExtractSource(context.Background(), &wreq)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in getextractsource")
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	var extractSource domain.ExtractSource
+	err = json.Unmarshal([]byte(wresponse.ExtractSourceString), &extractSource)
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	req := pb.GetRouteRequest{
+		Namespace:       x.Name,
+		ExtractSourceID: extractSourceID,
+		RouteID:         routeID,
+	}
+
+	response, err := client.GetRoute(context.Background(), &req)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in getroute")
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	var rt domain.Route
+	err = json.Unmarshal([]byte(response.RouteString), &rt)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in route unmarshal")
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	rForm := RouteForm{
+		UserEmail:         u.UserEmail,
+		PipelineID:        pipelineID,
+		PipelineName:      x.Name,
+		ExtractSourceID:   extractSourceID,
+		ExtractSourceName: extractSource.Name,
+		RouteID:           routeID,
+	}
+	rForm.setRoute(rt)
+
+	// keep whatever the user entered when redisplaying after an error
+	if r.Method == http.MethodPost {
+		f, _ := routeFromForm(r, routeID, extractSourceID)
+		rForm.setRoute(f)
+	}
+
+	tmpl, err := template.ParseFiles("pages/route.html", "pages/navbar.html")
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in template")
+		w.Write([]byte(err.Error()))
+		return
+	}
+	rForm.ErrorText = u.ErrorText
+	err = tmpl.ExecuteTemplate(w, "layout", rForm)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in template")
+	}
+}
@@ -22,11 +22,10 @@ import (
 
 	"github.com/churrodata/churro/internal/authorization"
 	"github.com/churrodata/churro/internal/domain"
+	"github.com/churrodata/churro/internal/transform"
 	pb "github.com/churrodata/churro/rpc/ctl"
 	"github.com/gorilla/mux"
 	"github.com/rs/xid"
-	"github.com/traefik/yaegi/interp"
-	"github.com/traefik/yaegi/stdlib"
 )
 
 // CreateTransformFunction ...
@@ -66,9 +65,7 @@ func (u *HandlerWrapper) CreateTransformFunction(w http.ResponseWriter, r *http.
 		return
 	}
 
-	i := interp.New(interp.Options{})
-	i.Use(stdlib.Symbols)
-	_, err := i.Eval(p.Source)
+	_, err := transform.Shape(p)
 	if err != nil {
 		a := u.Copy(err.Error())
 		a.ErrorText = err.Error()
@@ -105,6 +102,28 @@ func (u *HandlerWrapper) CreateTransformFunction(w http.ResponseWriter, r *http.
 type FunctionFormValue struct {
 	FunctionName string
 	Selected     string
+	Description  string
+}
+
+// functionFormValues returns the transform functions of a pipeline
+// followed by the built-in functions an extract rule can reference,
+// a built-in function is shown with example parameters
+func functionFormValues(functions []domain.TransformFunction) []FunctionFormValue {
+	values := make([]FunctionFormValue, 0, len(functions)+len(transform.Builtins))
+	for _, f := range functions {
+		v := FunctionFormValue{FunctionName: f.Name}
+		if f.Shape != "" {
+			v.Description = f.Shape + " transform function"
+		}
+		values = append(values, v)
+	}
+	for _, b := range transform.Builtins {
+		values = append(values, FunctionFormValue{
+			FunctionName: b.Example,
+			Description:  b.Description,
+		})
+	}
+	return values
 }
 
 // TransformFunctionForm ...
@@ -114,6 +133,8 @@ type TransformFunctionForm struct {
 	PipelineID   string
 	Functions    []FunctionFormValue
 	ErrorText    string
+	Function     domain.TransformFunction
+	Test         FunctionTest
 }
 
 // ShowCreateTransformFunction ...
@@ -135,7 +156,7 @@ func (u *HandlerWrapper) ShowCreateTransformFunction(w http.ResponseWriter, r *h
 
 	transformFunctionForm.PipelineName = x.Name
 
-	tmpl, err := template.ParseFiles("pages/transform-function-create.html", "pages/navbar.html")
+	tmpl, err := template.ParseFiles("pages/transform-function-create.html", "pages/transform-function-test.html", "pages/navbar.html")
 	if err != nil {
 		w.Write([]byte(err.Error()))
 		return
@@ -156,6 +177,7 @@ type FunctionForm struct {
 	PipelineID   string
 	PipelineName string
 	Function     domain.TransformFunction
+	Test         FunctionTest
 }
 
 // TransformFunction ...
@@ -205,7 +227,7 @@ func (u *HandlerWrapper) TransformFunction(w http.ResponseWriter, r *http.Reques
 	}
 	ff.Function = function
 
-	tmpl, err := template.ParseFiles("pages/transform-function.html", "pages/navbar.html")
+	tmpl, err := template.ParseFiles("pages/transform-function.html", "pages/transform-function-test.html", "pages/navbar.html")
 	if err != nil {
 		a := u.Copy(err.Error())
 		a.PipelineDetailHandler(w, r)
@@ -236,9 +258,7 @@ func (u *HandlerWrapper) UpdateTransformFunction(w http.ResponseWriter, r *http.
 		return
 	}
 
-	i := interp.New(interp.Options{})
-	i.Use(stdlib.Symbols)
-	_, err := i.Eval(f.Source)
+	_, err := transform.Shape(f)
 	if err != nil {
 		a := u.Copy(err.Error())
 		a.ErrorText = err.Error()
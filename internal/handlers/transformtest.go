@@ -0,0 +1,171 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/churrodata/churro/internal/authorization"
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/churrodata/churro/internal/transform"
+	pb "github.com/churrodata/churro/rpc/ctl"
+	"github.com/gorilla/mux"
+)
+
+// FunctionTest holds the sample values a transform function is tested
+// with and the results of the test
+type FunctionTest struct {
+	Samples      string
+	Shape        string
+	CompileError string
+	Results      []FunctionTestResult
+}
+
+// FunctionTestResult is the result of a sample, the output of a row
+// function is shown as JSON
+type FunctionTestResult struct {
+	Sample  string
+	Output  string
+	Dropped bool
+	Error   string
+}
+
+// TestTransformFunction compiles the transform function of the create
+// or edit form and runs it on the sample values, the form is shown
+// again with the results and the function is not saved
+func (u *HandlerWrapper) TestTransformFunction(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	vars := mux.Vars(r)
+	pipelineID := vars["id"]
+	pipelineName := r.FormValue("pipelinename")
+
+	f := domain.TransformFunction{
+		ID:     r.FormValue("functionid"),
+		Name:   r.FormValue("transformname"),
+		Source: r.FormValue("transformsource"),
+	}
+	test := FunctionTest{Samples: r.FormValue("samples")}
+
+	errorText := ""
+	m := authorization.AuthMap{
+		Subject:    u.UserEmail,
+		PipelineID: pipelineID,
+		Object:     authorization.ObjectPipeline,
+		Action:     authorization.ActionWrite,
+	}
+	if !m.Authorized(u.DatabaseType) {
+		errorText = "user not authorized to test transform functions"
+	} else {
+		errorText = runFunctionTest(pipelineName, f, &test)
+	}
+	f.Shape = test.Shape
+
+	page := "pages/transform-function-create.html"
+	var form interface{} = TransformFunctionForm{
+		UserEmail:    u.UserEmail,
+		PipelineName: pipelineName,
+		PipelineID:   pipelineID,
+		ErrorText:    errorText,
+		Function:     f,
+		Test:         test,
+	}
+	if f.ID != "" {
+		page = "pages/transform-function.html"
+		form = FunctionForm{
+			ErrorText:    errorText,
+			UserEmail:    u.UserEmail,
+			PipelineID:   pipelineID,
+			PipelineName: pipelineName,
+			Function:     f,
+			Test:         test,
+		}
+	}
+
+	tmpl, err := template.ParseFiles(page, "pages/transform-function-test.html", "pages/navbar.html")
+	if err != nil {
+		w.Write([]byte(err.Error()))
+		return
+	}
+	err = tmpl.ExecuteTemplate(w, "layout", form)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("error in template")
+	}
+}
+
+// runFunctionTest tests a transform function with the samples of the
+// test, one sample per line, and returns the text of an error that
+// kept the test from running
+func runFunctionTest(pipelineName string, f domain.TransformFunction, test *FunctionTest) string {
+	if f.Name == "" {
+		return "transform name is blank"
+	}
+	if f.Source == "" {
+		return "transform source is blank"
+	}
+
+	samples := make([]string, 0)
+	for _, line := range strings.Split(test.Samples, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line != "" {
+			samples = append(samples, line)
+		}
+	}
+
+	client, err := GetServiceConnection(pipelineName)
+	if err != nil {
+		return err.Error()
+	}
+
+	b, _ := json.Marshal(&f)
+	req := pb.TestTransformFunctionRequest{
+		Namespace:      pipelineName,
+		FunctionString: string(b),
+		Samples:        samples,
+	}
+	response, err := client.TestTransformFunction(context.Background(), &req)
+	if err != nil {
+		return err.Error()
+	}
+
+	test.Shape = response.Shape
+	test.CompileError = response.CompileError
+	if response.ResultsString == "" {
+		return ""
+	}
+	var results []transform.DryRunResult
+	err = json.Unmarshal([]byte(response.ResultsString), &results)
+	if err != nil {
+		return err.Error()
+	}
+	for _, result := range results {
+		x := FunctionTestResult{
+			Sample:  result.Sample,
+			Dropped: result.Dropped,
+			Error:   result.Error,
+		}
+		switch v := result.Output.(type) {
+		case nil:
+		case string:
+			x.Output = v
+		default:
+			b, _ := json.Marshal(v)
+			x.Output = string(b)
+		}
+		test.Results = append(test.Results, x)
+	}
+	return ""
+}
@@ -52,7 +52,7 @@ func (u *HandlerWrapper) UploadSchema(w http.ResponseWriter, r *http.Request) {
 
 	vars := mux.Vars(r)
 	pipelineID := vars["id"]
-	extractSourceID := vars["wdid"]
+	extractSourceID := vars["extractsourceid"]
 
 	x, err := getPipelineCR(pipelineID)
 	if err != nil {
@@ -98,6 +98,20 @@ func (u *HandlerWrapper) UploadSchema(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// a sample file gives the extract rules to review instead of a
+	// schema
+	if r.FormValue("infer") == "true" {
+		form := InferRulesForm{
+			UserEmail:         u.UserEmail,
+			PipelineID:        pipelineID,
+			PipelineName:      x.Name,
+			ExtractSourceID:   extractSourceID,
+			ExtractSourceName: value.Name,
+		}
+		u.showInferredRules(w, r, file, client, form)
+		return
+	}
+
 	reader := bufio.NewReader(file)
 	buffer := make([]byte, 8096)
 
@@ -0,0 +1,420 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package infer suggests the extract rules of a sample file, the
+// column names come from headers or leaf paths and the column types
+// from the values found under each column
+package infer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/xuri/excelize/v2"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/db/sqlsafe"
+	"github.com/churrodata/churro/internal/domain"
+)
+
+// MaxSampleRows is the number of rows of a sample whose values are
+// used to suggest column types
+const MaxSampleRows = 1000
+
+// Column is a column found in a sample file with the values sampled
+// from it
+type Column struct {
+	Name   string
+	Path   string
+	Values []string
+}
+
+// Rules returns the extract rules suggested for a sample file of the
+// scheme, in the order the columns are found.  The rules have no ID or
+// extract source, they are reviewed before they are created.
+func Rules(scheme, sheetName string, sample []byte) ([]domain.ExtractRule, error) {
+	var columns []Column
+	var err error
+	switch scheme {
+	case extractapi.CSVScheme:
+		columns, err = CSVColumns(sample)
+	case extractapi.XLSXScheme:
+		columns, err = XLSXColumns(sample, sheetName)
+	case extractapi.JSONPathScheme:
+		columns, err = JSONColumns(sample)
+	case extractapi.NDJSONScheme:
+		columns, err = NDJSONColumns(sample)
+	case extractapi.XMLScheme:
+		columns, err = XMLColumns(sample)
+	default:
+		return nil, fmt.Errorf("schema inference does not support the %s scheme", scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]domain.ExtractRule, 0, len(columns))
+	names := make(map[string]bool)
+	for _, c := range columns {
+		colType, format := ColumnType(c.Values)
+		rules = append(rules, domain.ExtractRule{
+			ColumnName:   uniqueName(ColumnName(c.Name), names),
+			ColumnPath:   c.Path,
+			ColumnType:   colType,
+			ColumnFormat: format,
+		})
+	}
+	return rules, nil
+}
+
+// CSVColumns returns the columns of a CSV sample named by its header
+func CSVColumns(sample []byte) ([]Column, error) {
+	r := csv.NewReader(bytes.NewReader(sample))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("could not read the CSV header %v", err)
+	}
+	rows := make([][]string, 0)
+	for len(rows) < MaxSampleRows {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// a sample cut short can end in a partial record
+			break
+		}
+		rows = append(rows, record)
+	}
+	return tableColumns(header, rows), nil
+}
+
+// XLSXColumns returns the columns of a sheet of a XLSX sample named by
+// its header row, the first sheet is used when sheetName is blank
+func XLSXColumns(sample []byte, sheetName string) ([]Column, error) {
+	f, err := excelize.OpenReader(bytes.NewReader(sample))
+	if err != nil {
+		return nil, fmt.Errorf("could not open the XLSX sample %v", err)
+	}
+	if sheetName == "" {
+		sheetName = f.GetSheetName(0)
+	}
+	rows, err := f.GetRows(sheetName)
+	if err != nil {
+		return nil, fmt.Errorf("could not read sheet %s %v", sheetName, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("sheet %s has no header row", sheetName)
+	}
+	if len(rows) > MaxSampleRows+1 {
+		rows = rows[:MaxSampleRows+1]
+	}
+	return tableColumns(rows[0], rows[1:]), nil
+}
+
+// tableColumns returns a column for each header, the path of a column
+// is its position
+func tableColumns(header []string, rows [][]string) []Column {
+	columns := make([]Column, 0, len(header))
+	for i, h := range header {
+		c := Column{Name: h, Path: strconv.Itoa(i)}
+		if strings.TrimSpace(h) == "" {
+			c.Name = "column" + strconv.Itoa(i)
+		}
+		for _, row := range rows {
+			if i < len(row) {
+				c.Values = append(c.Values, row[i])
+			}
+		}
+		columns = append(columns, c)
+	}
+	return columns
+}
+
+// JSONColumns returns a column for each leaf path of a JSON document,
+// the elements of an array share the [*] path
+func JSONColumns(sample []byte) ([]Column, error) {
+	var doc interface{}
+	if err := json.Unmarshal(sample, &doc); err != nil {
+		return nil, fmt.Errorf("could not parse the JSON sample %v", err)
+	}
+	l := newLeaves()
+	l.walkJSON("$", "", doc, true)
+	return l.columns, nil
+}
+
+// NDJSONColumns returns a column for each leaf path of the objects of
+// a newline delimited JSON sample, an array is a single JSONB column
+func NDJSONColumns(sample []byte) ([]Column, error) {
+	l := newLeaves()
+	scanner := bufio.NewScanner(bytes.NewReader(sample))
+	scanner.Buffer(make([]byte, 64*1024), len(sample)+1)
+	rows := 0
+	for scanner.Scan() && rows < MaxSampleRows {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var obj interface{}
+		if err := json.Unmarshal(line, &obj); err != nil {
+			// a sample cut short can end in a partial line
+			if rows > 0 {
+				break
+			}
+			return nil, fmt.Errorf("could not parse the NDJSON sample %v", err)
+		}
+		l.walkJSON("$", "", obj, false)
+		rows++
+	}
+	if rows == 0 {
+		return nil, fmt.Errorf("the NDJSON sample has no objects")
+	}
+	return l.columns, nil
+}
+
+// leaves collects the columns of leaf paths in the order found
+type leaves struct {
+	columns []Column
+	index   map[string]int
+}
+
+func newLeaves() *leaves {
+	return &leaves{index: make(map[string]int)}
+}
+
+func (l *leaves) add(name, path, value string) {
+	i, ok := l.index[path]
+	if !ok {
+		i = len(l.columns)
+		l.index[path] = i
+		l.columns = append(l.columns, Column{Name: name, Path: path})
+	}
+	if len(l.columns[i].Values) < MaxSampleRows {
+		l.columns[i].Values = append(l.columns[i].Values, value)
+	}
+}
+
+var jsonKeyRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// walkJSON adds the leaves below v, arrays are walked into when
+// intoArrays is set and are a JSON valued leaf otherwise
+func (l *leaves) walkJSON(path, name string, v interface{}, intoArrays bool) {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			e := t[k]
+			p := path + "." + k
+			if !jsonKeyRegex.MatchString(k) {
+				p = path + "['" + strings.ReplaceAll(k, "'", "\\'") + "']"
+			}
+			l.walkJSON(p, k, e, intoArrays)
+		}
+	case []interface{}:
+		if !intoArrays {
+			b, _ := json.Marshal(t)
+			l.add(name, path, string(b))
+			return
+		}
+		for _, e := range t {
+			l.walkJSON(path+"[*]", name, e, intoArrays)
+		}
+	case nil:
+		l.add(name, path, "")
+	default:
+		l.add(name, path, fmt.Sprintf("%v", t))
+	}
+}
+
+// XMLColumns returns a column for each path of an element of a XML
+// sample that holds text and no other elements
+func XMLColumns(sample []byte) ([]Column, error) {
+	l := newLeaves()
+	d := xml.NewDecoder(bytes.NewReader(sample))
+	d.Strict = false
+	d.CharsetReader = func(label string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	type element struct {
+		name     string
+		text     strings.Builder
+		children bool
+	}
+	stack := make([]*element, 0)
+	path := func() string {
+		names := make([]string, 0, len(stack))
+		for _, e := range stack {
+			names = append(names, e.name)
+		}
+		return "/" + strings.Join(names, "/")
+	}
+
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// a sample cut short ends inside an element
+			if len(l.columns) > 0 {
+				break
+			}
+			return nil, fmt.Errorf("could not parse the XML sample %v", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if len(stack) > 0 {
+				stack[len(stack)-1].children = true
+			}
+			stack = append(stack, &element{name: t.Name.Local})
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		case xml.EndElement:
+			if len(stack) == 0 {
+				continue
+			}
+			e := stack[len(stack)-1]
+			if !e.children {
+				l.add(e.name, path(), strings.TrimSpace(e.text.String()))
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	if len(l.columns) == 0 {
+		return nil, fmt.Errorf("the XML sample has no elements holding values")
+	}
+	return l.columns, nil
+}
+
+var nameRegex = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// ColumnName returns a column name made of lower case letters, digits
+// and underscores from a header or element name
+func ColumnName(name string) string {
+	n := nameRegex.ReplaceAllString(strings.ToLower(strings.TrimSpace(name)), "_")
+	n = strings.Trim(n, "_")
+	if n == "" {
+		n = "column"
+	}
+	if n[0] >= '0' && n[0] <= '9' {
+		n = "c" + n
+	}
+	if len(n) > sqlsafe.MaxIdentifierLength-4 {
+		n = n[:sqlsafe.MaxIdentifierLength-4]
+	}
+	return n
+}
+
+// uniqueName returns name, or name with a numbered suffix when an
+// earlier column already has it
+func uniqueName(name string, names map[string]bool) string {
+	unique := name
+	for i := 2; names[unique]; i++ {
+		unique = name + "_" + strconv.Itoa(i)
+	}
+	names[unique] = true
+	return unique
+}
+
+// ColumnType returns the narrowest column type, and the format of a
+// DATE or TIMESTAMP, that all of the non blank values convert to.
+// TEXT is returned when there are no such values.
+func ColumnType(values []string) (colType, format string) {
+	present := make([]string, 0, len(values))
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v != "" && !strings.EqualFold(v, "null") {
+			present = append(present, v)
+		}
+	}
+	if len(present) == 0 {
+		return extractapi.COLTYPE_TEXT, ""
+	}
+
+	for _, t := range []string{extractapi.COLTYPE_INT, extractapi.COLTYPE_BIGINT, extractapi.COLTYPE_DECIMAL} {
+		if converts(t, "", present) {
+			// a leading zero is kept as text, e.g. a zip code
+			if t != extractapi.COLTYPE_DECIMAL && leadingZeros(present) {
+				break
+			}
+			return t, ""
+		}
+	}
+	if words(present) && converts(extractapi.COLTYPE_BOOLEAN, "", present) {
+		return extractapi.COLTYPE_BOOLEAN, ""
+	}
+	for _, f := range extractapi.ColumnFormats {
+		if f == extractapi.FormatUnix || f == extractapi.FormatUnixMilli {
+			continue
+		}
+		t := extractapi.COLTYPE_TIMESTAMP
+		if !strings.Contains(f, "15") {
+			t = extractapi.COLTYPE_DATE
+		}
+		if converts(t, f, present) {
+			return t, f
+		}
+	}
+	if (strings.HasPrefix(present[0], "{") || strings.HasPrefix(present[0], "[")) && converts(extractapi.COLTYPE_JSONB, "", present) {
+		return extractapi.COLTYPE_JSONB, ""
+	}
+	return extractapi.COLTYPE_TEXT, ""
+}
+
+// converts reports whether each value converts to the column type
+func converts(colType, format string, values []string) bool {
+	for _, v := range values {
+		if _, err := extractapi.ConvertValue(colType, format, v); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// leadingZeros reports whether a value is a number with a leading zero
+func leadingZeros(values []string) bool {
+	for _, v := range values {
+		v = strings.TrimLeft(v, "+-")
+		if len(v) > 1 && v[0] == '0' {
+			return true
+		}
+	}
+	return false
+}
+
+// words reports whether each value has a letter, 1 and 0 are numbers
+// rather than booleans
+func words(values []string) bool {
+	for _, v := range values {
+		if strings.IndexFunc(v, unicode.IsLetter) < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,134 @@
+package infer
+
+import (
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+
+	extractapi "github.com/churrodata/churro/api/extract"
+	"github.com/churrodata/churro/internal/domain"
+)
+
+func checkRules(t *testing.T, got []domain.ExtractRule, want []domain.ExtractRule) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d rules %+v expected %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("rule %d got %+v expected %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestColumnType(t *testing.T) {
+	tests := []struct {
+		values []string
+		want   string
+		format string
+	}{
+		{[]string{"1", "-42", "", "null"}, extractapi.COLTYPE_INT, ""},
+		{[]string{"9000000000", "7"}, extractapi.COLTYPE_BIGINT, ""},
+		{[]string{"1.5", "7", ".25"}, extractapi.COLTYPE_DECIMAL, ""},
+		{[]string{"02134", "78006"}, extractapi.COLTYPE_TEXT, ""},
+		{[]string{"true", "No", "Y"}, extractapi.COLTYPE_BOOLEAN, ""},
+		{[]string{"1", "0"}, extractapi.COLTYPE_INT, ""},
+		{[]string{"2021-03-15", "2020-12-31"}, extractapi.COLTYPE_DATE, "2006-01-02"},
+		{[]string{"03/15/2021", "12/31/2020"}, extractapi.COLTYPE_DATE, "01/02/2006"},
+		{[]string{"2021-03-15 10:04:05"}, extractapi.COLTYPE_TIMESTAMP, "2006-01-02 15:04:05"},
+		{[]string{`{"a":1}`, `[1,2]`}, extractapi.COLTYPE_JSONB, ""},
+		{[]string{"boerne", "1"}, extractapi.COLTYPE_TEXT, ""},
+		{[]string{"", "null"}, extractapi.COLTYPE_TEXT, ""},
+	}
+	for _, tt := range tests {
+		got, format := ColumnType(tt.values)
+		if got != tt.want || format != tt.format {
+			t.Fatalf("ColumnType %v got %s %q expected %s %q", tt.values, got, format, tt.want, tt.format)
+		}
+	}
+}
+
+func TestCSVRules(t *testing.T) {
+	sample := []byte("City Name,population,founded,zip,City Name\nBoerne,18000,1852-01-01,78006,x\nComfort,2300,1854-08-18,78013,y\nKerr")
+	rules, err := Rules(extractapi.CSVScheme, "", sample)
+	if err != nil {
+		t.Fatalf("Rules Error: %v", err)
+	}
+	checkRules(t, rules, []domain.ExtractRule{
+		{ColumnName: "city_name", ColumnPath: "0", ColumnType: extractapi.COLTYPE_TEXT},
+		{ColumnName: "population", ColumnPath: "1", ColumnType: extractapi.COLTYPE_INT},
+		{ColumnName: "founded", ColumnPath: "2", ColumnType: extractapi.COLTYPE_DATE, ColumnFormat: "2006-01-02"},
+		{ColumnName: "zip", ColumnPath: "3", ColumnType: extractapi.COLTYPE_INT},
+		{ColumnName: "city_name_2", ColumnPath: "4", ColumnType: extractapi.COLTYPE_TEXT},
+	})
+
+	if _, err := Rules(extractapi.ParquetScheme, "", sample); err == nil {
+		t.Fatal("Rules expected an error for an unsupported scheme")
+	}
+}
+
+func TestXLSXRules(t *testing.T) {
+	f := excelize.NewFile()
+	f.SetSheetRow("Sheet1", "A1", &[]interface{}{"sku", "price", "in stock"})
+	f.SetSheetRow("Sheet1", "A2", &[]interface{}{"a1", "9.99", "yes"})
+	f.SetSheetRow("Sheet1", "A3", &[]interface{}{"a2", "12", "no"})
+	b, err := f.WriteToBuffer()
+	if err != nil {
+		t.Fatalf("WriteToBuffer Error: %v", err)
+	}
+
+	rules, err := Rules(extractapi.XLSXScheme, "", b.Bytes())
+	if err != nil {
+		t.Fatalf("Rules Error: %v", err)
+	}
+	checkRules(t, rules, []domain.ExtractRule{
+		{ColumnName: "sku", ColumnPath: "0", ColumnType: extractapi.COLTYPE_TEXT},
+		{ColumnName: "price", ColumnPath: "1", ColumnType: extractapi.COLTYPE_DECIMAL},
+		{ColumnName: "in_stock", ColumnPath: "2", ColumnType: extractapi.COLTYPE_BOOLEAN},
+	})
+
+	if _, err := Rules(extractapi.XLSXScheme, "missing", b.Bytes()); err == nil {
+		t.Fatal("Rules expected an error for a missing sheet")
+	}
+}
+
+func TestJSONRules(t *testing.T) {
+	sample := []byte(`{"store": {"name": "acme", "books": [{"title": "a", "price": 8.95}, {"title": "b", "price": 12}], "open date": "2021-03-15"}}`)
+	rules, err := Rules(extractapi.JSONPathScheme, "", sample)
+	if err != nil {
+		t.Fatalf("Rules Error: %v", err)
+	}
+	checkRules(t, rules, []domain.ExtractRule{
+		{ColumnName: "price", ColumnPath: "$.store.books[*].price", ColumnType: extractapi.COLTYPE_DECIMAL},
+		{ColumnName: "title", ColumnPath: "$.store.books[*].title", ColumnType: extractapi.COLTYPE_TEXT},
+		{ColumnName: "name", ColumnPath: "$.store.name", ColumnType: extractapi.COLTYPE_TEXT},
+		{ColumnName: "open_date", ColumnPath: "$.store['open date']", ColumnType: extractapi.COLTYPE_DATE, ColumnFormat: "2006-01-02"},
+	})
+
+	sample = []byte("{\"id\": 1, \"tags\": [\"x\"], \"ok\": true}\n{\"id\": 2, \"tags\": [], \"ok\": false}\n{\"id\": 3, \"ta")
+	rules, err = Rules(extractapi.NDJSONScheme, "", sample)
+	if err != nil {
+		t.Fatalf("Rules Error: %v", err)
+	}
+	checkRules(t, rules, []domain.ExtractRule{
+		{ColumnName: "id", ColumnPath: "$.id", ColumnType: extractapi.COLTYPE_INT},
+		{ColumnName: "ok", ColumnPath: "$.ok", ColumnType: extractapi.COLTYPE_BOOLEAN},
+		{ColumnName: "tags", ColumnPath: "$.tags", ColumnType: extractapi.COLTYPE_JSONB},
+	})
+}
+
+func TestXMLRules(t *testing.T) {
+	sample := []byte(`<?xml version="1.0"?>
+<library>
+  <book><author><name>king</name></author><pages>1138</pages></book>
+  <book><author><name>brown</name></author><pages>689</pages></book>
+</library>`)
+	rules, err := Rules(extractapi.XMLScheme, "", sample)
+	if err != nil {
+		t.Fatalf("Rules Error: %v", err)
+	}
+	checkRules(t, rules, []domain.ExtractRule{
+		{ColumnName: "name", ColumnPath: "/library/book/author/name", ColumnType: extractapi.COLTYPE_TEXT},
+		{ColumnName: "pages", ColumnPath: "/library/book/pages", ColumnType: extractapi.COLTYPE_INT},
+	})
+}
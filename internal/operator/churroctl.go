@@ -14,6 +14,7 @@ import (
 	"os"
 
 	"github.com/churrodata/churro/api/v1alpha1"
+	"github.com/churrodata/churro/internal/domain"
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -64,6 +65,10 @@ func (r PipelineReconciler) processCtl(req ctrl.Request, pipeline v1alpha1.Pipel
 		pEnv := v1.EnvVar{Name: "CHURRO_PIPELINE", Value: pipeline.ObjectMeta.Name}
 		pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, pEnv)
 
+		if pipeline.Spec.DatabaseType == domain.DatabaseSqlite {
+			addSqliteVolume(&pod)
+		}
+
 		if err := ctrl.SetControllerReference(&pipeline, &pod, r.Scheme); err != nil {
 			r.Log.Error(err, "error setting controller reference")
 			return err
@@ -134,6 +134,21 @@ func (r PipelineReconciler) processPipeline(req ctrl.Request, pipeline v1alpha1.
 			//return err
 			r.Log.Error(err, "error in Singlestore client")
 		}
+	case domain.DatabasePostgres:
+		err = r.processPostgres(pipeline)
+		if err != nil {
+			return err
+		}
+	case domain.DatabaseSqlite:
+		err = r.processSqlite(pipeline)
+		if err != nil {
+			return err
+		}
+	case domain.DatabaseClickhouse:
+		err = r.processClickhouse(pipeline)
+		if err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("unsupported databasetype %s", pipeline.Spec.DatabaseType)
 	}
@@ -0,0 +1,41 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package operator
+
+import (
+	"fmt"
+
+	"github.com/churrodata/churro/api/v1alpha1"
+)
+
+// processPostgres checks the pipeline points at an externally provided
+// postgres server, nothing is provisioned for it in the pipeline
+// namespace, churro-ctl creates the pipeline schema and user
+func (r PipelineReconciler) processPostgres(pipeline v1alpha1.Pipeline) error {
+	names := []string{"adminDataSource", "dataSource"}
+	for i, src := range []v1alpha1.Source{pipeline.Spec.AdminDataSource, pipeline.Spec.DataSource} {
+		name := names[i]
+		if src.Host == "" {
+			return fmt.Errorf("postgres %s host is required", name)
+		}
+		if src.Port <= 0 {
+			return fmt.Errorf("postgres %s port is required", name)
+		}
+		if src.Externaldatabase == "" {
+			return fmt.Errorf("postgres %s externaldatabase is required", name)
+		}
+		if src.Username == "" {
+			return fmt.Errorf("postgres %s username is required", name)
+		}
+	}
+	r.Log.Info("using external postgres database", "host", pipeline.Spec.DataSource.Host, "database", pipeline.Spec.DataSource.Externaldatabase)
+	return nil
+}
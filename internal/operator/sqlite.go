@@ -0,0 +1,53 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package operator
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/churrodata/churro/api/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+)
+
+// the churrodata PVC is mounted here in churro-ctl and the extract
+// jobs, sqlite database files are kept beneath it
+const sqliteMountPath = "/churro"
+
+// processSqlite checks the pipeline sqlite files are kept on the
+// churrodata PVC, nothing is provisioned for sqlite itself
+func (r PipelineReconciler) processSqlite(pipeline v1alpha1.Pipeline) error {
+	names := []string{"adminDataSource", "dataSource"}
+	for i, src := range []v1alpha1.Source{pipeline.Spec.AdminDataSource, pipeline.Spec.DataSource} {
+		p := filepath.Clean(src.Path)
+		if src.Path == "" || !strings.HasPrefix(p, sqliteMountPath+"/") {
+			return fmt.Errorf("sqlite %s path %q needs to be beneath %s", names[i], src.Path, sqliteMountPath)
+		}
+	}
+	return nil
+}
+
+// addSqliteVolume mounts the churrodata PVC holding the sqlite files
+func addSqliteVolume(pod *v1.Pod) {
+	pod.Spec.Volumes = append(pod.Spec.Volumes, v1.Volume{
+		Name: churroDataPVC,
+		VolumeSource: v1.VolumeSource{
+			PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+				ClaimName: churroDataPVC,
+			},
+		},
+	})
+	pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, v1.VolumeMount{
+		Name:      churroDataPVC,
+		MountPath: sqliteMountPath,
+	})
+}
@@ -171,6 +171,9 @@ func fillCRDefaults(cr *v1alpha1.Pipeline, pipelineName string) {
 	cr.Status.Active = "true"
 	cr.Status.Standby = []string{"one", "two"}
 
+	// an external database is entered when the pipeline is created
+	external := cr.Spec.AdminDataSource
+
 	/**
 	cr.Spec.Functions = make([]v1alpha1.TransformFunction, 0)
 	sample := v1alpha1.TransformFunction{}
@@ -249,5 +252,27 @@ func fillCRDefaults(cr *v1alpha1.Pipeline, pipelineName string) {
 		cr.Spec.DataSource.Port = 3306
 		cr.Spec.AdminDataSource.Username = "admin"
 		cr.Spec.AdminDataSource.Database = "churro"
+	case domain.DatabasePostgres:
+		// the admin user creates the churro and pipeline schemas
+		// and the pipeline user within the external database
+		cr.Spec.AdminDataSource.Host = external.Host
+		cr.Spec.AdminDataSource.Port = external.Port
+		cr.Spec.AdminDataSource.Externaldatabase = external.Externaldatabase
+		cr.Spec.AdminDataSource.Username = external.Username
+		cr.Spec.DataSource.Host = external.Host
+		cr.Spec.DataSource.Port = external.Port
+		cr.Spec.DataSource.Externaldatabase = external.Externaldatabase
+	case domain.DatabaseClickhouse:
+		// the admin user creates the churro and pipeline databases
+		// and the pipeline user on the external server
+		cr.Spec.AdminDataSource.Host = external.Host
+		cr.Spec.AdminDataSource.Port = external.Port
+		cr.Spec.AdminDataSource.Username = external.Username
+		cr.Spec.DataSource.Host = external.Host
+		cr.Spec.DataSource.Port = external.Port
+	case domain.DatabaseSqlite:
+		// the database files are kept on the churrodata PVC
+		cr.Spec.AdminDataSource.Path = "/churro/db"
+		cr.Spec.DataSource.Path = "/churro/db"
 	}
 }
@@ -0,0 +1,370 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package predicate evaluates the conditions routing rules match rows
+// with.  A predicate is a Go expression over the column values of a
+// row, for example
+//
+//	type == "refund" && amount > 100
+//	hasprefix(sku, "VOID-") || status == nil
+//
+// Identifiers are column names, Go keywords included, a column the row does not have is nil.
+// Values compare as numbers when both sides are numbers and as strings
+// otherwise.  The functions contains, hasprefix, hassuffix, matches,
+// lower and upper are available.
+package predicate
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// functions are the functions a predicate can call and the number of
+// arguments they take
+var functions = map[string]int{
+	"contains":  2,
+	"hasprefix": 2,
+	"hassuffix": 2,
+	"matches":   2,
+	"lower":     1,
+	"upper":     1,
+}
+
+// Predicate is a compiled predicate
+type Predicate struct {
+	source  string
+	expr    ast.Expr
+	regexes map[ast.Expr]*regexp.Regexp
+}
+
+// Compile parses a predicate, it fails on syntax the predicate does not
+// support, unknown functions and invalid regular expressions
+func Compile(source string) (*Predicate, error) {
+	if strings.TrimSpace(source) == "" {
+		return nil, fmt.Errorf("predicate is blank")
+	}
+	expr, err := parser.ParseExpr(escapeKeywords(source))
+	if err != nil {
+		return nil, fmt.Errorf("predicate %s is not valid %v", source, err)
+	}
+	p := &Predicate{
+		source:  source,
+		expr:    expr,
+		regexes: make(map[ast.Expr]*regexp.Regexp),
+	}
+	if err := p.check(expr); err != nil {
+		return nil, fmt.Errorf("predicate %s is not valid, %v", source, err)
+	}
+	return p, nil
+}
+
+// keywordPrefix is put before column names that are Go keywords, like
+// type, so they parse as identifiers
+const keywordPrefix = "_keyword_"
+
+// escapeKeywords prefixes the Go keywords of a predicate
+func escapeKeywords(source string) string {
+	var s scanner.Scanner
+	fset := token.NewFileSet()
+	s.Init(fset.AddFile("", fset.Base(), len(source)), []byte(source), nil, 0)
+
+	var b strings.Builder
+	last := 0
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok.IsKeyword() {
+			offset := fset.Position(pos).Offset
+			b.WriteString(source[last:offset])
+			b.WriteString(keywordPrefix + lit)
+			last = offset + len(lit)
+		}
+	}
+	b.WriteString(source[last:])
+	return b.String()
+}
+
+// String returns the source of the predicate
+func (p *Predicate) String() string {
+	return p.source
+}
+
+// check walks the expression rejecting what eval does not support
+func (p *Predicate) check(expr ast.Expr) error {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return nil
+	case *ast.BasicLit:
+		_, err := literal(e)
+		return err
+	case *ast.ParenExpr:
+		return p.check(e.X)
+	case *ast.UnaryExpr:
+		if e.Op != token.NOT && e.Op != token.SUB {
+			return fmt.Errorf("operator %s is not supported", e.Op)
+		}
+		return p.check(e.X)
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case token.LAND, token.LOR, token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+		default:
+			return fmt.Errorf("operator %s is not supported", e.Op)
+		}
+		if err := p.check(e.X); err != nil {
+			return err
+		}
+		return p.check(e.Y)
+	case *ast.CallExpr:
+		ident, ok := e.Fun.(*ast.Ident)
+		if !ok {
+			return fmt.Errorf("only the functions %s can be called", functionNames())
+		}
+		n, ok := functions[ident.Name]
+		if !ok {
+			return fmt.Errorf("unknown function %s, the functions are %s", ident.Name, functionNames())
+		}
+		if len(e.Args) != n {
+			return fmt.Errorf("function %s takes %d arguments", ident.Name, n)
+		}
+		for _, arg := range e.Args {
+			if err := p.check(arg); err != nil {
+				return err
+			}
+		}
+		if ident.Name == "matches" {
+			lit, ok := e.Args[1].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return fmt.Errorf("function matches takes a string regular expression")
+			}
+			pattern, _ := strconv.Unquote(lit.Value)
+			regex, err := regexp.Compile(pattern)
+			if err != nil {
+				return fmt.Errorf("regular expression %s is not valid %v", lit.Value, err)
+			}
+			p.regexes[e] = regex
+		}
+		return nil
+	}
+	return fmt.Errorf("expression %T is not supported", expr)
+}
+
+// functionNames returns the names of the functions, sorted
+func functionNames() string {
+	names := make([]string, 0, len(functions))
+	for name := range functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// Match evaluates the predicate against the column values of a row
+func (p *Predicate) Match(row map[string]interface{}) (bool, error) {
+	v, err := p.eval(p.expr, row)
+	if err != nil {
+		return false, fmt.Errorf("predicate %s %v", p.source, err)
+	}
+	b, err := truth(v)
+	if err != nil {
+		return false, fmt.Errorf("predicate %s %v", p.source, err)
+	}
+	return b, nil
+}
+
+func (p *Predicate) eval(expr ast.Expr, row map[string]interface{}) (interface{}, error) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		switch e.Name {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "nil":
+			return nil, nil
+		}
+		return row[strings.TrimPrefix(e.Name, keywordPrefix)], nil
+	case *ast.BasicLit:
+		return literal(e)
+	case *ast.ParenExpr:
+		return p.eval(e.X, row)
+	case *ast.UnaryExpr:
+		v, err := p.eval(e.X, row)
+		if err != nil {
+			return nil, err
+		}
+		if e.Op == token.NOT {
+			b, err := truth(v)
+			return !b, err
+		}
+		f, ok := number(v)
+		if !ok {
+			return nil, fmt.Errorf("value %v is not a number", v)
+		}
+		return -f, nil
+	case *ast.BinaryExpr:
+		x, err := p.eval(e.X, row)
+		if err != nil {
+			return nil, err
+		}
+		switch e.Op {
+		case token.LAND, token.LOR:
+			b, err := truth(x)
+			if err != nil {
+				return nil, err
+			}
+			if (e.Op == token.LAND && !b) || (e.Op == token.LOR && b) {
+				return b, nil
+			}
+			y, err := p.eval(e.Y, row)
+			if err != nil {
+				return nil, err
+			}
+			return truth(y)
+		}
+		y, err := p.eval(e.Y, row)
+		if err != nil {
+			return nil, err
+		}
+		return compare(e.Op, x, y), nil
+	case *ast.CallExpr:
+		args := make([]string, 0, len(e.Args))
+		for _, arg := range e.Args {
+			v, err := p.eval(arg, row)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, text(v))
+		}
+		switch e.Fun.(*ast.Ident).Name {
+		case "contains":
+			return strings.Contains(args[0], args[1]), nil
+		case "hasprefix":
+			return strings.HasPrefix(args[0], args[1]), nil
+		case "hassuffix":
+			return strings.HasSuffix(args[0], args[1]), nil
+		case "matches":
+			return p.regexes[e].MatchString(args[0]), nil
+		case "lower":
+			return strings.ToLower(args[0]), nil
+		case "upper":
+			return strings.ToUpper(args[0]), nil
+		}
+	}
+	return nil, fmt.Errorf("expression %T is not supported", expr)
+}
+
+// literal returns the value of a string or number literal
+func literal(lit *ast.BasicLit) (interface{}, error) {
+	switch lit.Kind {
+	case token.STRING:
+		return strconv.Unquote(lit.Value)
+	case token.INT, token.FLOAT:
+		return strconv.ParseFloat(lit.Value, 64)
+	}
+	return nil, fmt.Errorf("literal %s is not supported", lit.Value)
+}
+
+// compare compares two values, nil only equals nil and is neither less
+// nor greater than another value
+func compare(op token.Token, x, y interface{}) bool {
+	if x == nil || y == nil {
+		switch op {
+		case token.EQL:
+			return x == nil && y == nil
+		case token.NEQ:
+			return x != nil || y != nil
+		}
+		return false
+	}
+
+	c := 0
+	fx, okx := number(x)
+	fy, oky := number(y)
+	switch {
+	case okx && oky:
+		if fx < fy {
+			c = -1
+		} else if fx > fy {
+			c = 1
+		}
+	default:
+		c = strings.Compare(text(x), text(y))
+	}
+
+	switch op {
+	case token.EQL:
+		return c == 0
+	case token.NEQ:
+		return c != 0
+	case token.LSS:
+		return c < 0
+	case token.LEQ:
+		return c <= 0
+	case token.GTR:
+		return c > 0
+	}
+	return c >= 0
+}
+
+// number returns a value as a number, strings holding a number are
+// numbers
+func number(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// truth returns a value as a bool, strings holding a bool are bools
+func truth(v interface{}) (bool, error) {
+	switch b := v.(type) {
+	case bool:
+		return b, nil
+	case string:
+		if parsed, err := strconv.ParseBool(strings.TrimSpace(b)); err == nil {
+			return parsed, nil
+		}
+	}
+	return false, fmt.Errorf("value %v is not a bool", v)
+}
+
+// text returns a value as a string, nil is blank
+func text(v interface{}) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	case float64:
+		return strconv.FormatFloat(s, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%v", v)
+}
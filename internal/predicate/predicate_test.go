@@ -0,0 +1,77 @@
+package predicate
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	row := map[string]interface{}{
+		"type":   "refund",
+		"amount": "150.50",
+		"sku":    "VOID-1234",
+		"count":  int64(3),
+		"status": nil,
+	}
+
+	tests := []struct {
+		source string
+		want   bool
+	}{
+		{`type == "refund"`, true},
+		{`type != "refund"`, false},
+		{`amount > 100`, true},
+		{`amount > 100 && type == "sale"`, false},
+		{`type == "sale" || amount >= 150.5`, true},
+		{`!(amount < 100)`, true},
+		{`count == 3`, true},
+		{`count > -1`, true},
+		{`hasprefix(sku, "VOID-")`, true},
+		{`hassuffix(sku, "99")`, false},
+		{`contains(lower(type), "fun")`, true},
+		{`upper(type) == "REFUND"`, true},
+		{`matches(sku, "^VOID-[0-9]+$")`, true},
+		{`status == nil`, true},
+		{`missing == nil`, true},
+		{`status > 1`, false},
+		{`type < "sale"`, true},
+	}
+	for _, tc := range tests {
+		p, err := Compile(tc.source)
+		if err != nil {
+			t.Fatalf("Compile(%s) %v", tc.source, err)
+		}
+		got, err := p.Match(row)
+		if err != nil {
+			t.Fatalf("Match(%s) %v", tc.source, err)
+		}
+		if got != tc.want {
+			t.Errorf("Match(%s) got %v want %v", tc.source, got, tc.want)
+		}
+	}
+
+	p, err := Compile(`type && amount > 1`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Match(row); err == nil {
+		t.Error("Match of a non bool value should fail")
+	}
+}
+
+func TestCompile(t *testing.T) {
+	invalid := []string{
+		"",
+		`type ==`,
+		`amount + 1 > 2`,
+		`os.Exit(1)`,
+		`split(sku, "-")`,
+		`contains(sku)`,
+		`matches(sku, "[")`,
+		`matches(sku, pattern)`,
+		`sku[0] == "V"`,
+		`'V' == sku`,
+	}
+	for _, source := range invalid {
+		if _, err := Compile(source); err == nil {
+			t.Errorf("Compile(%q) should fail", source)
+		}
+	}
+}
@@ -0,0 +1,273 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package privacy holds the column privacy policies of extract rules,
+// a policy redacts, partially masks, hashes or tokenizes the values of
+// a column before they are loaded
+package privacy
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// the privacy policies
+const (
+	PolicyRedact   = "redact"
+	PolicyMask     = "mask"
+	PolicyHash     = "hash"
+	PolicyTokenize = "tokenize"
+)
+
+// SecretName is the Kubernetes secret holding the salt and token key,
+// the extract pod gets its SaltKey and TokenKeyKey values in the
+// SaltEnv and TokenKeyEnv environment variables
+const (
+	SecretName  = "churro.privacy"
+	SaltKey     = "salt"
+	TokenKeyKey = "tokenkey"
+	SaltEnv     = "CHURRO_PRIVACY_SALT"
+	TokenKeyEnv = "CHURRO_PRIVACY_TOKEN_KEY"
+)
+
+// tokenPrefix starts every token, telling a token from a raw value
+const tokenPrefix = "tok_"
+
+// ErrNoKey is returned when a policy needs a salt or token key that
+// is not set
+var ErrNoKey = errors.New("privacy key is not set")
+
+// Keys are the secret values of the hash and tokenize policies
+type Keys struct {
+	Salt     []byte
+	TokenKey []byte
+}
+
+// KeysFromEnv returns the keys of the environment of the extract pod
+func KeysFromEnv() Keys {
+	return Keys{
+		Salt:     []byte(os.Getenv(SaltEnv)),
+		TokenKey: []byte(os.Getenv(TokenKeyEnv)),
+	}
+}
+
+// Policy is a parsed privacy policy, written like a built-in transform
+// function: redact, mask(4), mask(4,"#"), hash or tokenize
+type Policy struct {
+	Name string
+	// Keep is the number of trailing characters mask leaves visible
+	Keep int
+	// MaskChar replaces the masked characters, * unless given
+	MaskChar string
+}
+
+// String returns the policy as written
+func (p Policy) String() string {
+	if p.Name != PolicyMask {
+		return p.Name
+	}
+	if p.MaskChar == "*" {
+		return fmt.Sprintf("mask(%d)", p.Keep)
+	}
+	return fmt.Sprintf("mask(%d,%q)", p.Keep, p.MaskChar)
+}
+
+// TextOnly reports whether the policy changes the value to a string,
+// it can then only be used on a TEXT column
+func (p Policy) TextOnly() bool {
+	return p.Name != PolicyRedact
+}
+
+// Parse parses a privacy policy
+func Parse(source string) (Policy, error) {
+	expr, err := parser.ParseExpr(strings.TrimSpace(source))
+	if err != nil {
+		return Policy{}, fmt.Errorf("privacy policy %s is not valid %v", source, err)
+	}
+	var name string
+	var args []ast.Expr
+	switch e := expr.(type) {
+	case *ast.Ident:
+		name = e.Name
+	case *ast.CallExpr:
+		ident, ok := e.Fun.(*ast.Ident)
+		if !ok {
+			return Policy{}, fmt.Errorf("privacy policy %s is not valid", source)
+		}
+		name = ident.Name
+		args = e.Args
+	default:
+		return Policy{}, fmt.Errorf("privacy policy %s is not valid", source)
+	}
+
+	switch name {
+	case PolicyRedact, PolicyHash, PolicyTokenize:
+		if len(args) > 0 {
+			return Policy{}, fmt.Errorf("privacy policy %s takes no parameters", name)
+		}
+		return Policy{Name: name}, nil
+	case PolicyMask:
+		return parseMask(source, args)
+	}
+	return Policy{}, fmt.Errorf("privacy policy %s is not one of redact, mask, hash or tokenize", name)
+}
+
+// parseMask parses the parameters of mask, the number of characters
+// kept and an optional mask character
+func parseMask(source string, args []ast.Expr) (Policy, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return Policy{}, fmt.Errorf("privacy policy mask takes the parameters (keep, maskchar)")
+	}
+	p := Policy{Name: PolicyMask, MaskChar: "*"}
+	lit, ok := args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.INT {
+		return Policy{}, fmt.Errorf("privacy policy %s keep must be a number", source)
+	}
+	keep, err := strconv.Atoi(lit.Value)
+	if err != nil {
+		return Policy{}, fmt.Errorf("privacy policy %s keep is not valid %v", source, err)
+	}
+	p.Keep = keep
+	if len(args) == 2 {
+		lit, ok = args[1].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return Policy{}, fmt.Errorf("privacy policy %s mask character must be a string", source)
+		}
+		p.MaskChar, err = strconv.Unquote(lit.Value)
+		if err != nil {
+			return Policy{}, fmt.Errorf("privacy policy %s mask character is not valid %v", source, err)
+		}
+		if utf8.RuneCountInString(p.MaskChar) != 1 {
+			return Policy{}, fmt.Errorf("privacy policy %s mask character must be one character", source)
+		}
+	}
+	return p, nil
+}
+
+// Check returns an error when the keys lack the salt or token key the
+// policy needs
+func (p Policy) Check(keys Keys) error {
+	switch {
+	case p.Name == PolicyHash && len(keys.Salt) == 0:
+		return fmt.Errorf("%w: hash needs %s in secret %s", ErrNoKey, SaltKey, SecretName)
+	case p.Name == PolicyTokenize && len(keys.TokenKey) == 0:
+		return fmt.Errorf("%w: tokenize needs %s in secret %s", ErrNoKey, TokenKeyKey, SecretName)
+	}
+	return nil
+}
+
+// Apply returns the value with the policy applied, a nil value stays
+// nil
+func (p Policy) Apply(value interface{}, keys Keys) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if err := p.Check(keys); err != nil {
+		return nil, err
+	}
+	s, ok := value.(string)
+	if !ok {
+		s = fmt.Sprintf("%v", value)
+	}
+
+	switch p.Name {
+	case PolicyRedact:
+		return nil, nil
+	case PolicyMask:
+		return mask(s, p.Keep, p.MaskChar), nil
+	case PolicyHash:
+		mac := hmac.New(sha256.New, keys.Salt)
+		mac.Write([]byte(s))
+		return hex.EncodeToString(mac.Sum(nil)), nil
+	case PolicyTokenize:
+		return Tokenize(s, keys.TokenKey)
+	}
+	return nil, fmt.Errorf("privacy policy %s is not valid", p.Name)
+}
+
+// mask replaces all but the last keep characters of s, a value no
+// longer than keep would be left as it is so all of it is replaced
+func mask(s string, keep int, maskChar string) string {
+	runes := []rune(s)
+	n := len(runes) - keep
+	if n <= 0 {
+		return strings.Repeat(maskChar, len(runes))
+	}
+	return strings.Repeat(maskChar, n) + string(runes[n:])
+}
+
+// tokenCipher returns the AES-256-GCM cipher of a token key, the key
+// is hashed so a secret of any length can be used
+func tokenCipher(key []byte) (cipher.AEAD, error) {
+	sum := sha256.Sum256(key)
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Tokenize returns the token of a value.  The nonce is derived from the
+// value so a value always has the same token, tokenized columns can
+// still be joined and counted, and Detokenize returns the value.
+func Tokenize(s string, key []byte) (string, error) {
+	if len(key) == 0 {
+		return "", ErrNoKey
+	}
+	aead, err := tokenCipher(key)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(s))
+	nonce := mac.Sum(nil)[:aead.NonceSize()]
+	sealed := aead.Seal(nonce, nonce, []byte(s), nil)
+	return tokenPrefix + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Detokenize returns the value of a token made with the same key
+func Detokenize(tok string, key []byte) (string, error) {
+	if len(key) == 0 {
+		return "", ErrNoKey
+	}
+	if !strings.HasPrefix(tok, tokenPrefix) {
+		return "", fmt.Errorf("%q is not a token", tok)
+	}
+	sealed, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(tok, tokenPrefix))
+	if err != nil {
+		return "", fmt.Errorf("%q is not a token %v", tok, err)
+	}
+	aead, err := tokenCipher(key)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("%q is not a token", tok)
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("token could not be decrypted %v", err)
+	}
+	return string(plain), nil
+}
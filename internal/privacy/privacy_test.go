@@ -0,0 +1,127 @@
+package privacy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+		valid  bool
+	}{
+		{"redact", "redact", true},
+		{"hash", "hash", true},
+		{"tokenize", "tokenize", true},
+		{"mask(4)", "mask(4)", true},
+		{` mask(2, "#") `, `mask(2,"#")`, true},
+		{"mask", "", false},
+		{"mask(-1)", "", false},
+		{`mask(4, "##")`, "", false},
+		{`mask("4")`, "", false},
+		{"hash(1)", "", false},
+		{"encrypt", "", false},
+		{"redact(", "", false},
+	}
+	for _, tt := range tests {
+		p, err := Parse(tt.source)
+		if tt.valid && err != nil {
+			t.Errorf("Parse(%q) error %v", tt.source, err)
+			continue
+		}
+		if !tt.valid {
+			if err == nil {
+				t.Errorf("Parse(%q) should fail", tt.source)
+			}
+			continue
+		}
+		if p.String() != tt.want {
+			t.Errorf("Parse(%q) = %s, want %s", tt.source, p.String(), tt.want)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	keys := Keys{Salt: []byte("pepper"), TokenKey: []byte("secret")}
+
+	tests := []struct {
+		policy string
+		value  interface{}
+		want   interface{}
+	}{
+		{"redact", "123-45-6789", nil},
+		{"mask(4)", "4111111111111111", "************1111"},
+		{`mask(2,"#")`, "héllo", "###lo"},
+		{"mask(4)", "abc", "***"},
+		{"mask(4)", "1234", "****"},
+		{`mask(2,"#")`, "é", "#"},
+		{"mask(0)", "abc", "***"},
+		{"mask(4)", nil, nil},
+		{"hash", nil, nil},
+	}
+	for _, tt := range tests {
+		p, err := Parse(tt.policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := p.Apply(tt.value, keys)
+		if err != nil {
+			t.Errorf("%s(%v) error %v", tt.policy, tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s(%v) = %v, want %v", tt.policy, tt.value, got, tt.want)
+		}
+	}
+
+	hash, _ := Parse("hash")
+	a, _ := hash.Apply("jane@example.com", keys)
+	b, _ := hash.Apply("jane@example.com", keys)
+	c, _ := hash.Apply("jane@example.com", Keys{Salt: []byte("salt")})
+	if a != b || a == c || len(a.(string)) != 64 {
+		t.Errorf("salted hash should be repeatable and depend on the salt, got %v %v %v", a, b, c)
+	}
+
+	_, err := hash.Apply("jane@example.com", Keys{})
+	if !errors.Is(err, ErrNoKey) {
+		t.Errorf("hash without a salt should fail with ErrNoKey, got %v", err)
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	key := []byte("secret")
+	p, _ := Parse("tokenize")
+
+	tok, err := p.Apply("123-45-6789", Keys{TokenKey: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := tok.(string)
+	if !strings.HasPrefix(s, tokenPrefix) || strings.Contains(s, "6789") {
+		t.Fatalf("token %q is not valid", s)
+	}
+	again, _ := p.Apply("123-45-6789", Keys{TokenKey: key})
+	if again != tok {
+		t.Errorf("a value should always have the same token, got %v and %v", tok, again)
+	}
+	other, _ := p.Apply("123-45-6780", Keys{TokenKey: key})
+	if other == tok {
+		t.Errorf("different values should have different tokens")
+	}
+
+	value, err := Detokenize(s, key)
+	if err != nil || value != "123-45-6789" {
+		t.Errorf("Detokenize = %q, %v", value, err)
+	}
+	if _, err := Detokenize(s, []byte("other")); err == nil {
+		t.Errorf("Detokenize with another key should fail")
+	}
+	if _, err := Detokenize("123-45-6789", key); err == nil {
+		t.Errorf("Detokenize of a value that is not a token should fail")
+	}
+	if _, err := p.Apply("x", Keys{}); !errors.Is(err, ErrNoKey) {
+		t.Errorf("tokenize without a key should fail with ErrNoKey, got %v", err)
+	}
+}
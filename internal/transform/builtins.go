@@ -0,0 +1,317 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package transform
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/mail"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// cellFunc transforms the value of a column, a nil value loads a null
+type cellFunc func(string) (interface{}, error)
+
+// Builtin is a transform function shipped with churro.  An extract rule
+// references it by name, followed by its parameters as Go string
+// literals when it has any, for example
+// dateformat("02/01/2006","2006-01-02")
+type Builtin struct {
+	Name        string
+	Params      []string
+	Optional    int
+	Description string
+	Example     string
+	build       func(params []string) (cellFunc, error)
+}
+
+// Builtins are the built-in transform functions, extract rules use them
+// without a TransformFunction being created in the pipeline
+var Builtins = []Builtin{
+	{
+		Name:        "trim",
+		Description: "removes leading and trailing white space",
+		Example:     "trim",
+		build: func(params []string) (cellFunc, error) {
+			return func(s string) (interface{}, error) { return strings.TrimSpace(s), nil }, nil
+		},
+	},
+	{
+		Name:        "upper",
+		Description: "converts to upper case",
+		Example:     "upper",
+		build: func(params []string) (cellFunc, error) {
+			return func(s string) (interface{}, error) { return strings.ToUpper(s), nil }, nil
+		},
+	},
+	{
+		Name:        "lower",
+		Description: "converts to lower case",
+		Example:     "lower",
+		build: func(params []string) (cellFunc, error) {
+			return func(s string) (interface{}, error) { return strings.ToLower(s), nil }, nil
+		},
+	},
+	{
+		Name:        "nullifempty",
+		Description: "loads a null for a blank value",
+		Example:     "nullifempty",
+		build: func(params []string) (cellFunc, error) {
+			return func(s string) (interface{}, error) {
+				if strings.TrimSpace(s) == "" {
+					return nil, nil
+				}
+				return s, nil
+			}, nil
+		},
+	},
+	{
+		Name:        "dateformat",
+		Params:      []string{"from", "to"},
+		Description: "reformats a date from one Go time layout to another",
+		Example:     `dateformat("02/01/2006","2006-01-02")`,
+		build: func(params []string) (cellFunc, error) {
+			from, to := params[0], params[1]
+			return func(s string) (interface{}, error) {
+				s = strings.TrimSpace(s)
+				if s == "" {
+					return nil, nil
+				}
+				t, err := time.Parse(from, s)
+				if err != nil {
+					return nil, err
+				}
+				return t.Format(to), nil
+			}, nil
+		},
+	},
+	{
+		Name:        "sha256",
+		Description: "replaces the value with its hex encoded SHA-256 hash",
+		Example:     "sha256",
+		build: func(params []string) (cellFunc, error) {
+			return func(s string) (interface{}, error) {
+				sum := sha256.Sum256([]byte(s))
+				return hex.EncodeToString(sum[:]), nil
+			}, nil
+		},
+	},
+	{
+		Name:        "regexreplace",
+		Params:      []string{"pattern", "replacement"},
+		Description: "replaces the matches of a regular expression, the replacement may use $1 for a submatch",
+		Example:     `regexreplace("\\s+"," ")`,
+		build: func(params []string) (cellFunc, error) {
+			regex, err := regexp.Compile(params[0])
+			if err != nil {
+				return nil, err
+			}
+			replacement := params[1]
+			return func(s string) (interface{}, error) {
+				return regex.ReplaceAllString(s, replacement), nil
+			}, nil
+		},
+	},
+	{
+		Name:        "phone",
+		Params:      []string{"countrycode"},
+		Optional:    1,
+		Description: "keeps the digits of a phone number, a number without a leading + is given the country code when there is one",
+		Example:     `phone("1")`,
+		build: func(params []string) (cellFunc, error) {
+			countryCode := ""
+			if len(params) > 0 {
+				countryCode = strings.TrimPrefix(params[0], "+")
+			}
+			return func(s string) (interface{}, error) {
+				s = strings.TrimSpace(s)
+				if s == "" {
+					return nil, nil
+				}
+				digits := strings.Map(func(r rune) rune {
+					if unicode.IsDigit(r) {
+						return r
+					}
+					return -1
+				}, s)
+				if digits == "" {
+					return nil, fmt.Errorf("phone number %q has no digits", s)
+				}
+				if strings.HasPrefix(s, "+") {
+					return "+" + digits, nil
+				}
+				if countryCode != "" {
+					return "+" + countryCode + digits, nil
+				}
+				return digits, nil
+			}, nil
+		},
+	},
+	{
+		Name:        "email",
+		Description: "trims and lower cases an e-mail address, dropping a display name",
+		Example:     "email",
+		build: func(params []string) (cellFunc, error) {
+			return func(s string) (interface{}, error) {
+				s = strings.TrimSpace(s)
+				if s == "" {
+					return nil, nil
+				}
+				a, err := mail.ParseAddress(s)
+				if err != nil {
+					return nil, fmt.Errorf("e-mail address %q is not valid %v", s, err)
+				}
+				return strings.ToLower(a.Address), nil
+			}, nil
+		},
+	},
+	{
+		Name:        "currency",
+		Params:      []string{"decimalseparator"},
+		Optional:    1,
+		Description: "parses an amount such as $1,234.50 or (12.00) into a decimal, the decimal separator is . unless given",
+		Example:     `currency(",")`,
+		build: func(params []string) (cellFunc, error) {
+			separator := "."
+			if len(params) > 0 {
+				separator = params[0]
+			}
+			if separator != "." && separator != "," {
+				return nil, fmt.Errorf("decimal separator %q must be . or ,", separator)
+			}
+			return func(s string) (interface{}, error) {
+				return parseCurrency(s, separator)
+			}, nil
+		},
+	},
+}
+
+// parseCurrency returns an amount as a decimal string, dropping the
+// currency symbols and thousands separators, an amount in parentheses
+// is negative
+func parseCurrency(s, separator string) (interface{}, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = s[1 : len(s)-1]
+	}
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case unicode.IsDigit(r):
+			b.WriteRune(r)
+		case string(r) == separator:
+			b.WriteRune('.')
+		case r == '-':
+			negative = !negative
+		}
+	}
+	amount := b.String()
+	if _, err := strconv.ParseFloat(amount, 64); err != nil {
+		return nil, fmt.Errorf("amount %q is not a number", s)
+	}
+	if negative {
+		amount = "-" + amount
+	}
+	return amount, nil
+}
+
+// IsBuiltin returns true when a transform function reference names a
+// built-in function
+func IsBuiltin(ref string) bool {
+	name := ref
+	if i := strings.Index(ref, "("); i >= 0 {
+		name = ref[:i]
+	}
+	_, ok := findBuiltin(strings.TrimSpace(name))
+	return ok
+}
+
+// ValidateBuiltin checks the parameters of a reference to a built-in
+// function, a reference to another function is not checked
+func ValidateBuiltin(ref string) error {
+	if !IsBuiltin(ref) {
+		return nil
+	}
+	_, err := parseBuiltin(ref)
+	return err
+}
+
+func findBuiltin(name string) (Builtin, bool) {
+	for _, b := range Builtins {
+		if b.Name == name {
+			return b, true
+		}
+	}
+	return Builtin{}, false
+}
+
+// parseBuiltin parses a reference to a built-in function, the
+// parameters are Go string or number literals
+func parseBuiltin(ref string) (cellFunc, error) {
+	expr, err := parser.ParseExpr(ref)
+	if err != nil {
+		return nil, fmt.Errorf("transform function %s is not valid %v", ref, err)
+	}
+	var name string
+	params := make([]string, 0)
+	switch e := expr.(type) {
+	case *ast.Ident:
+		name = e.Name
+	case *ast.CallExpr:
+		ident, ok := e.Fun.(*ast.Ident)
+		if !ok {
+			return nil, fmt.Errorf("transform function %s is not valid", ref)
+		}
+		name = ident.Name
+		for _, arg := range e.Args {
+			lit, ok := arg.(*ast.BasicLit)
+			if !ok {
+				return nil, fmt.Errorf("transform function %s parameters must be literals", ref)
+			}
+			value := lit.Value
+			if lit.Kind == token.STRING {
+				value, err = strconv.Unquote(lit.Value)
+				if err != nil {
+					return nil, fmt.Errorf("transform function %s parameter %s is not valid %v", ref, lit.Value, err)
+				}
+			}
+			params = append(params, value)
+		}
+	default:
+		return nil, fmt.Errorf("transform function %s is not valid", ref)
+	}
+
+	b, ok := findBuiltin(name)
+	if !ok {
+		return nil, fmt.Errorf("could not find built-in function %s", name)
+	}
+	if len(params) > len(b.Params) || len(params) < len(b.Params)-b.Optional {
+		return nil, fmt.Errorf("built-in function %s takes the parameters (%s)", name, strings.Join(b.Params, ", "))
+	}
+	fn, err := b.build(params)
+	if err != nil {
+		return nil, fmt.Errorf("built-in function %s %v", ref, err)
+	}
+	return fn, nil
+}
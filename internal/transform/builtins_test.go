@@ -0,0 +1,78 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/churrodata/churro/internal/domain"
+)
+
+func TestBuiltins(t *testing.T) {
+	tests := []struct {
+		ref   string
+		value string
+		want  interface{}
+	}{
+		{"trim", "  boerne ", "boerne"},
+		{"upper", "boerne", "BOERNE"},
+		{"lower", "BOERNE", "boerne"},
+		{"nullifempty", "  ", nil},
+		{"nullifempty", "x", "x"},
+		{`dateformat("02/01/2006","2006-01-02")`, "31/12/2021", "2021-12-31"},
+		{`dateformat("02/01/2006", "2006-01-02")`, "", nil},
+		{"sha256", "abc", "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{`regexreplace("\\s+", " ")`, "a   b\tc", "a b c"},
+		{`regexreplace("(\\d+)-(\\d+)", "$2-$1")`, "12-34", "34-12"},
+		{"phone", "(830) 555-0100", "8305550100"},
+		{`phone("1")`, "(830) 555-0100", "+18305550100"},
+		{`phone("1")`, "+44 20 7946 0958", "+442079460958"},
+		{"email", " Ann Lee <Ann.Lee@Example.COM> ", "ann.lee@example.com"},
+		{"currency", "$1,234.50", "1234.50"},
+		{"currency", "(12.00)", "-12.00"},
+		{`currency(",")`, "1.234,50 €", "1234.50"},
+	}
+	for _, tt := range tests {
+		fn, err := parseBuiltin(tt.ref)
+		if err != nil {
+			t.Fatalf("parseBuiltin %s Error: %v", tt.ref, err)
+		}
+		got, err := fn(tt.value)
+		if err != nil {
+			t.Fatalf("%s of %q Error: %v", tt.ref, tt.value, err)
+		}
+		if got != tt.want {
+			t.Fatalf("%s of %q got %v want %v", tt.ref, tt.value, got, tt.want)
+		}
+	}
+
+	for _, ref := range []string{`dateformat("02/01/2006")`, `trim("x")`, `regexreplace("(", "")`, `currency(";")`, `upper(x)`} {
+		if err := ValidateBuiltin(ref); err == nil {
+			t.Fatalf("ValidateBuiltin %s expected an error", ref)
+		}
+	}
+	if IsBuiltin("transforms.MyUppercase") || ValidateBuiltin("transforms.MyUppercase") != nil {
+		t.Fatal("a transform function is not a built-in function")
+	}
+
+	// a value a built-in function can not transform is an error
+	fn, _ := parseBuiltin("email")
+	if _, err := fn("not an address"); err == nil {
+		t.Fatal("email expected an error")
+	}
+}
+
+func TestRunRulesBuiltins(t *testing.T) {
+	f := Compile([]domain.TransformFunction{{Name: "transforms.MyUppercase", Source: upperSource}})
+	cols := []string{"name", "joined", "note"}
+	rules := map[string]domain.ExtractRule{
+		"1": {ColumnName: "name", TransformFunction: "transforms.MyUppercase"},
+		"2": {ColumnName: "joined", TransformFunction: `dateformat("01/02/2006","2006-01-02")`},
+		"3": {ColumnName: "note", TransformFunction: "nullifempty"},
+	}
+	record := []interface{}{"ann", "12/31/2021", ""}
+	if err := f.RunRules(cols, record, rules); err != nil {
+		t.Fatalf("RunRules Error: %v", err)
+	}
+	if record[0] != "ANN" || record[1] != "2021-12-31" || record[2] != nil {
+		t.Fatalf("RunRules got %v", record)
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright 2021 The churrodata Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package transform
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/churrodata/churro/internal/domain"
+	"github.com/traefik/yaegi/interp"
+	"github.com/traefik/yaegi/stdlib"
+)
+
+// dryRunTimeout is how long a dry run waits for a function to compile
+// and for it to return for each sample
+var dryRunTimeout = 5 * time.Second
+
+// SandboxEnv is set in the environment of the process a dry run starts
+// to run the interpreter, see ServeSandbox
+const SandboxEnv = "CHURRO_TRANSFORM_SANDBOX"
+
+// sandboxDenied are the standard library packages a dry run does not
+// give functions, they reach the file system, network or process
+var sandboxDenied = []string{
+	"io/ioutil",
+	"log/syslog",
+	"net",
+	"os",
+	"path/filepath",
+	"plugin",
+	"runtime",
+	"syscall",
+	"unsafe",
+}
+
+// sandboxAllowed are packages under a denied package that only parse
+// values
+var sandboxAllowed = []string{
+	"net/mail",
+	"net/url",
+}
+
+// DryRunResult is the result of running a transform function on a
+// sample, Dropped is set when a row function drops the row
+type DryRunResult struct {
+	Sample  string      `json:"sample"`
+	Output  interface{} `json:"output"`
+	Dropped bool        `json:"dropped,omitempty"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// sandboxSymbols returns the standard library symbols less the denied
+// packages
+func sandboxSymbols() interp.Exports {
+	symbols := make(interp.Exports)
+	for key, values := range stdlib.Symbols {
+		path := key
+		if i := strings.LastIndex(key, "/"); i > 0 {
+			path = key[:i]
+		}
+		if sandboxDeniedPath(path) {
+			continue
+		}
+		symbols[key] = values
+	}
+	return symbols
+}
+
+func sandboxDeniedPath(path string) bool {
+	for _, allowed := range sandboxAllowed {
+		if path == allowed {
+			return false
+		}
+	}
+	for _, denied := range sandboxDenied {
+		if path == denied || strings.HasPrefix(path, denied+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// sandboxRequest is what a dry run sends the sandbox process
+type sandboxRequest struct {
+	Function domain.TransformFunction `json:"function"`
+	Samples  []string                 `json:"samples"`
+	Timeout  time.Duration            `json:"timeout"`
+}
+
+// sandboxResponse is what the sandbox process sends back
+type sandboxResponse struct {
+	Shape   string         `json:"shape"`
+	Results []DryRunResult `json:"results"`
+	Error   string         `json:"error,omitempty"`
+}
+
+// DryRun compiles a transform function in a sandboxed interpreter and
+// runs it on each sample, a sample is the value of a cell function or
+// the JSON object of the row of a row function.  The error is set when
+// the function does not compile or has neither shape, a sample the
+// function fails on has the error in its result.
+//
+// The interpreter runs in a process of its own, started from this
+// executable with SandboxEnv set, and the process is killed when the
+// compile and the samples do not finish in time.
+func DryRun(fn domain.TransformFunction, samples []string) (shape string, results []DryRunResult, err error) {
+	request, err := json.Marshal(sandboxRequest{Function: fn, Samples: samples, Timeout: dryRunTimeout})
+	if err != nil {
+		return "", nil, err
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return "", nil, err
+	}
+
+	// the compile and each sample have dryRunTimeout, the process has
+	// a second more to start and to answer
+	limit := dryRunTimeout*time.Duration(len(samples)+1) + time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), limit)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, executable)
+	cmd.Env = []string{SandboxEnv + "=1"}
+	cmd.Dir = os.TempDir()
+	cmd.Stdin = bytes.NewReader(request)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", nil, fmt.Errorf("function did not return within %v", limit)
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("error in running the sandbox %v %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var response sandboxResponse
+	err = json.Unmarshal(stdout.Bytes(), &response)
+	if err != nil {
+		return "", nil, fmt.Errorf("error in reading the sandbox response %v", err)
+	}
+	if response.Error != "" {
+		return "", nil, errors.New(response.Error)
+	}
+	return response.Shape, response.Results, nil
+}
+
+// Check compiles a transform function in the sandbox a dry run uses
+// and returns its shape, a function that does not compile there or
+// uses a denied package is an error
+func Check(fn domain.TransformFunction) (string, error) {
+	shape, _, err := DryRun(fn, nil)
+	return shape, err
+}
+
+// ServeSandbox runs a dry run when the process was started by DryRun,
+// it reads the request from stdin, writes the response to stdout and
+// exits.  It returns without doing anything in any other process, so
+// a command that runs dry runs calls it first thing in main.
+func ServeSandbox() {
+	if os.Getenv(SandboxEnv) == "" {
+		return
+	}
+	var request sandboxRequest
+	err := json.NewDecoder(os.Stdin).Decode(&request)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error in reading the sandbox request %v\n", err)
+		os.Exit(1)
+	}
+	if request.Timeout > 0 {
+		dryRunTimeout = request.Timeout
+	}
+
+	var response sandboxResponse
+	response.Shape, response.Results, err = dryRun(request.Function, request.Samples)
+	if err != nil {
+		response.Error = err.Error()
+	}
+	err = json.NewEncoder(os.Stdout).Encode(response)
+	if err != nil {
+		os.Exit(1)
+	}
+	// a function still running from a sample that timed out ends here
+	os.Exit(0)
+}
+
+// dryRun is DryRun in the sandbox process
+func dryRun(fn domain.TransformFunction, samples []string) (shape string, results []DryRunResult, err error) {
+	var compiled interface{}
+	// package initializers run while compiling, so the compile has the
+	// same deadline as a sample
+	_, err = runSample(func() (interface{}, error) {
+		var err error
+		shape, compiled, err = compileFunction(fn, sandboxSymbols())
+		return nil, err
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	results = make([]DryRunResult, 0, len(samples))
+	timedOut := false
+	for _, sample := range samples {
+		result := DryRunResult{Sample: sample}
+		// the interpreter is still busy with the sample that timed out
+		if timedOut {
+			result.Error = "not run, an earlier sample did not return"
+			results = append(results, result)
+			continue
+		}
+		var output interface{}
+		switch shape {
+		case ShapeCell:
+			f := compiled.(func(string) string)
+			output, err = runSample(func() (interface{}, error) { return f(sample), nil })
+		case ShapeRow:
+			row := make(map[string]interface{})
+			err = json.Unmarshal([]byte(sample), &row)
+			if err != nil {
+				err = fmt.Errorf("sample is not a JSON object %v", err)
+				break
+			}
+			f := compiled.(RowFunc)
+			output, err = runSample(func() (interface{}, error) {
+				out, err := f(row)
+				if out == nil {
+					return nil, err
+				}
+				return out, err
+			})
+			result.Dropped = err == nil && output == nil
+		}
+		if err != nil {
+			result.Error = err.Error()
+			timedOut = errors.Is(err, errTimeout)
+		} else {
+			result.Output = output
+		}
+		results = append(results, result)
+	}
+	return shape, results, nil
+}
+
+// errTimeout is the error of a function that does not return in time
+var errTimeout = errors.New("function did not return in time")
+
+// runSample runs a function on a sample, a panic or a function that
+// does not return in time is an error
+func runSample(run func() (interface{}, error)) (interface{}, error) {
+	type outcome struct {
+		output interface{}
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- outcome{err: fmt.Errorf("function panicked %v", r)}
+			}
+		}()
+		output, err := run()
+		done <- outcome{output: output, err: err}
+	}()
+
+	select {
+	case o := <-done:
+		return o.output, o.err
+	case <-time.After(dryRunTimeout):
+		return nil, fmt.Errorf("%w, the limit is %v", errTimeout, dryRunTimeout)
+	}
+}
@@ -11,7 +11,15 @@
 package transform
 
 import (
+	"errors"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/churrodata/churro/internal/domain"
 	"github.com/rs/zerolog/log"
@@ -19,63 +27,262 @@ import (
 	"github.com/traefik/yaegi/stdlib"
 )
 
-// RunRules ...
-func RunRules(cols []string, record []interface{}, rules map[string]domain.ExtractRule, functions []domain.TransformFunction) error {
-	log.Info().Msg(fmt.Sprintf("functions entering RunRules %v", functions))
-	log.Info().Msg(fmt.Sprintf("records entering RunRules %v", record))
+// the shapes of a transform function, a cell function sees the value
+// of its column and a row function sees the whole row
+const (
+	ShapeCell = "cell"
+	ShapeRow  = "row"
+)
+
+// RowFunc is a row transform function, it returns the row to load
+// which may hold derived columns, or nil to drop the row
+type RowFunc func(map[string]interface{}) (map[string]interface{}, error)
+
+// Functions holds transform functions compiled once, by function
+// name, so rows of a job reuse them instead of interpreting the
+// source of a function for every row
+type Functions struct {
+	mu       sync.Mutex
+	compiled map[string]cellFunc
+	rows     map[string]RowFunc
+	errs     map[string]error
+	// builtins are the built-in functions by reference, they are
+	// parsed the first time a rule uses them
+	builtins map[string]cellFunc
+}
+
+// Compile interprets the source of each transform function, a
+// function that does not compile gives its error when a rule uses it
+func Compile(functions []domain.TransformFunction) *Functions {
+	f := &Functions{
+		compiled: make(map[string]cellFunc),
+		rows:     make(map[string]RowFunc),
+		errs:     make(map[string]error),
+		builtins: make(map[string]cellFunc),
+	}
+	for _, fn := range functions {
+		shape, compiled, err := CompileFunction(fn)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("error compiling transform function " + fn.Name)
+			f.errs[fn.Name] = err
+			continue
+		}
+		switch shape {
+		case ShapeCell:
+			cell := compiled.(func(string) string)
+			f.compiled[fn.Name] = func(s string) (interface{}, error) { return cell(s), nil }
+		case ShapeRow:
+			f.rows[fn.Name] = compiled.(RowFunc)
+		}
+	}
+	log.Info().Msg(fmt.Sprintf("compiled %d cell and %d row transform functions", len(f.compiled), len(f.rows)))
+	return f
+}
+
+// CompileFunction interprets the source of a transform function and
+// returns its shape and the function, which is a func(string) string
+// for a cell function and a RowFunc for a row function
+func CompileFunction(fn domain.TransformFunction) (shape string, compiled interface{}, err error) {
+	return compileFunction(fn, stdlib.Symbols)
+}
+
+// compileFunction interprets the source of a transform function with
+// the symbols given to the interpreter
+func compileFunction(fn domain.TransformFunction, symbols interp.Exports) (shape string, compiled interface{}, err error) {
+	i := interp.New(interp.Options{})
+	i.Use(symbols)
+	_, err = i.Eval(fn.Source)
+	if err != nil {
+		return "", nil, fmt.Errorf("error in interpreting source %v", err)
+	}
+	v, err := i.Eval(fn.Name)
+	if err != nil {
+		return "", nil, fmt.Errorf("error in interpreting function %v", err)
+	}
+	switch f := v.Interface().(type) {
+	case func(string) string:
+		return ShapeCell, f, nil
+	case func(map[string]interface{}) (map[string]interface{}, error):
+		return ShapeRow, RowFunc(f), nil
+	}
+	return "", nil, fmt.Errorf("function %s must be a func(string) string or a func(map[string]interface{}) (map[string]interface{}, error)", fn.Name)
+}
+
+// Shape returns the shape of a transform function from the type of
+// its declaration, the source is parsed and type checked but nothing
+// in it is run
+func Shape(fn domain.TransformFunction) (string, error) {
+	dot := strings.LastIndex(fn.Name, ".")
+	if dot < 1 || dot == len(fn.Name)-1 {
+		return "", fmt.Errorf("function name %s must be package.Function", fn.Name)
+	}
+	pkgName, funcName := fn.Name[:dot], fn.Name[dot+1:]
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", fn.Source, 0)
+	if err != nil {
+		return "", fmt.Errorf("error in parsing source %v", err)
+	}
+	if file.Name.Name != pkgName {
+		return "", fmt.Errorf("source is package %s not package %s", file.Name.Name, pkgName)
+	}
+
+	// imports are not resolved, the declaration of a transform function
+	// only has predeclared types so errors in the bodies are ignored
+	conf := types.Config{
+		Importer: noImporter{},
+		Error:    func(error) {},
+	}
+	pkg, _ := conf.Check(pkgName, fset, []*ast.File{file}, nil)
+	f, ok := pkg.Scope().Lookup(funcName).(*types.Func)
+	if !ok {
+		return "", fmt.Errorf("could not find function %s", fn.Name)
+	}
+	sig := f.Type().(*types.Signature)
+	switch {
+	case types.Identical(sig, cellSignature):
+		return ShapeCell, nil
+	case types.Identical(sig, rowSignature):
+		return ShapeRow, nil
+	}
+	return "", fmt.Errorf("function %s must be a func(string) string or a func(map[string]interface{}) (map[string]interface{}, error)", fn.Name)
+}
+
+// the signatures of a cell function and of a row function
+var (
+	cellSignature = types.NewSignature(nil,
+		types.NewTuple(types.NewVar(token.NoPos, nil, "", types.Typ[types.String])),
+		types.NewTuple(types.NewVar(token.NoPos, nil, "", types.Typ[types.String])), false)
+	rowType      = types.NewMap(types.Typ[types.String], types.NewInterfaceType(nil, nil).Complete())
+	rowSignature = types.NewSignature(nil,
+		types.NewTuple(types.NewVar(token.NoPos, nil, "", rowType)),
+		types.NewTuple(types.NewVar(token.NoPos, nil, "", rowType), types.NewVar(token.NoPos, nil, "", types.Universe.Lookup("error").Type())), false)
+)
+
+// noImporter refuses every import, Shape does not need the imported
+// packages
+type noImporter struct{}
+
+func (noImporter) Import(path string) (*types.Package, error) {
+	return nil, errors.New("imports are not resolved")
+}
+
+// RunRules runs the transform function of each extract rule on the
+// value of its column in record
+func (f *Functions) RunRules(cols []string, record []interface{}, rules map[string]domain.ExtractRule) error {
 	for _, rule := range rules {
 		if rule.TransformFunction == "" || rule.TransformFunction == "None" {
 			continue
 		}
+		// row functions run on the whole row, see RunRow
+		if _, ok := f.rows[rule.TransformFunction]; ok {
+			continue
+		}
 
-		log.Info().Msg(fmt.Sprintf("cols coming into to transform %v\n", cols))
-		var recordIndex int
-		//var err error
-		for i := 0; i < len(cols); i++ {
+		recordIndex := -1
+		for i := 0; i < len(cols) && i < len(record); i++ {
 			if cols[i] == rule.ColumnName {
-				log.Info().Msg(fmt.Sprintf("rule column name found in cols list at %d col at this index is %s value is %s\n", i, cols[i], record[i].(string)))
 				recordIndex = i
 			}
 		}
-		/**
-		recordIndex, err := strconv.Atoi(rule.ColumnPath)
-		if err != nil {
-			// assume an error means we have a string
-			recordIndex, err = findColumn(cols, rule.ColumnPath)
-			if err != nil {
-				return err
-			}
+		if recordIndex < 0 {
+			continue
 		}
-		*/
-		var fn domain.TransformFunction
-		log.Info().Msg(fmt.Sprintf("looking for function %v\n", rule.TransformFunction))
-		fn, err := findFunction(functions, rule.TransformFunction)
+
+		fn, err := f.function(rule.TransformFunction)
 		if err != nil {
-			log.Error().Stack().Err(err).Msg("some error")
 			return err
 		}
-		i := interp.New(interp.Options{})
-		i.Use(stdlib.Symbols)
-		log.Info().Msg("function source is " + fn.Source)
-		_, err = i.Eval(fn.Source)
-		if err != nil {
-			log.Error().Stack().Err(err).Msg("some error")
-			return fmt.Errorf("error in interpreting source %v", err)
+
+		value, ok := record[recordIndex].(string)
+		if !ok && record[recordIndex] != nil {
+			value = fmt.Sprintf("%v", record[recordIndex])
 		}
-		log.Info().Msg("function is " + rule.TransformFunction)
-		v, err := i.Eval(fn.Name)
+		// the interpreter is not shared between goroutines
+		f.mu.Lock()
+		v, err := fn(value)
+		f.mu.Unlock()
 		if err != nil {
-			log.Error().Stack().Err(err).Msg("some error")
-			return fmt.Errorf("error in interpreting function %v", err)
+			return fmt.Errorf("column %s transform function %s %v", rule.ColumnName, rule.TransformFunction, err)
 		}
-		//tmp := v.Interface().(func(string) string)(record[recordIndex].(string))
-		tmp := v.Interface().(func(string) string)
-		log.Info().Msg(fmt.Sprintf("value to convert is index is %d  value is %s ", recordIndex, record[recordIndex].(string)))
-		record[recordIndex] = tmp(record[recordIndex].(string))
+		record[recordIndex] = v
 	}
 	return nil
 }
 
+// function returns the compiled transform function of the name, or the
+// built-in function the name references
+func (f *Functions) function(name string) (cellFunc, error) {
+	if err, ok := f.errs[name]; ok {
+		return nil, err
+	}
+	if fn, ok := f.compiled[name]; ok {
+		return fn, nil
+	}
+	if !IsBuiltin(name) {
+		return nil, fmt.Errorf("could not find function %s", name)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if fn, ok := f.builtins[name]; ok {
+		return fn, nil
+	}
+	fn, err := parseBuiltin(name)
+	if err != nil {
+		return nil, err
+	}
+	if f.builtins == nil {
+		f.builtins = make(map[string]cellFunc)
+	}
+	f.builtins[name] = fn
+	return fn, nil
+}
+
+// RowFunctions returns the names of the row functions the extract
+// rules use, sorted so rows run them in the same order
+func (f *Functions) RowFunctions(rules map[string]domain.ExtractRule) []string {
+	names := make([]string, 0)
+	found := make(map[string]bool)
+	for _, rule := range rules {
+		if _, ok := f.rows[rule.TransformFunction]; ok && !found[rule.TransformFunction] {
+			found[rule.TransformFunction] = true
+			names = append(names, rule.TransformFunction)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RunRow runs the named row functions in turn on a row, each function
+// is given the row the previous one returned.  A nil row is returned
+// when a function drops the row.
+func (f *Functions) RunRow(names []string, row map[string]interface{}) (map[string]interface{}, error) {
+	for _, name := range names {
+		fn, ok := f.rows[name]
+		if !ok {
+			return nil, fmt.Errorf("could not find row function %s", name)
+		}
+		f.mu.Lock()
+		out, err := fn(row)
+		f.mu.Unlock()
+		if err != nil {
+			return nil, fmt.Errorf("transform function %s %v", name, err)
+		}
+		if out == nil {
+			return nil, nil
+		}
+		row = out
+	}
+	return row, nil
+}
+
+// RunRules compiles the transform functions and runs them on a single
+// record, a job reuses the Functions returned by Compile instead
+func RunRules(cols []string, record []interface{}, rules map[string]domain.ExtractRule, functions []domain.TransformFunction) error {
+	return Compile(functions).RunRules(cols, record, rules)
+}
+
 func findColumn(cols []string, path string) (int, error) {
 	for i := 0; i < len(cols); i++ {
 		if cols[i] == path {
@@ -84,12 +291,3 @@ func findColumn(cols []string, path string) (int, error) {
 	}
 	return 0, fmt.Errorf("could not find column that matches path %s", path)
 }
-
-func findFunction(functions []domain.TransformFunction, functionName string) (domain.TransformFunction, error) {
-	for i := 0; i < len(functions); i++ {
-		if functions[i].Name == functionName {
-			return functions[i], nil
-		}
-	}
-	return domain.TransformFunction{}, fmt.Errorf("could not find function %s", functionName)
-}
@@ -2,11 +2,32 @@ package transform
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/churrodata/churro/internal/domain"
 )
 
+// TestMain serves the sandbox when a dry run starts the test binary as
+// its sandbox process
+func TestMain(m *testing.M) {
+	ServeSandbox()
+	os.Exit(m.Run())
+}
+
+const upperSource = `package transforms
+
+      import "strings"
+
+      func MyUppercase(s string) string {
+
+        return strings.ToUpper(s)
+
+      }`
+
 func TestRunRules(t *testing.T) {
 
 	cols := []string{"one", "two"}
@@ -23,17 +44,9 @@ func TestRunRules(t *testing.T) {
 	rules[r.ID] = r
 	functions := []domain.TransformFunction{
 		{
-			ID:   "one",
-			Name: "transforms.MyUppercase",
-			Source: `package transforms
-
-      import "strings"
-
-      func MyUppercase(s string) string {
-
-        return strings.ToUpper(s)
-
-      }`,
+			ID:     "one",
+			Name:   "transforms.MyUppercase",
+			Source: upperSource,
 		},
 	}
 	err := RunRules(cols, record, rules, functions)
@@ -46,3 +59,250 @@ func TestRunRules(t *testing.T) {
 	}
 
 }
+
+func TestCompile(t *testing.T) {
+	functions := []domain.TransformFunction{
+		{Name: "transforms.MyUppercase", Source: upperSource},
+		{Name: "broken.Fn", Source: "package broken\n\nfunc Fn(s string) string { return s + 1 }"},
+		{Name: "wrong.Fn", Source: "package wrong\n\nfunc Fn(i int) int { return i }"},
+	}
+	f := Compile(functions)
+
+	cols := []string{"name", "qty"}
+	rules := map[string]domain.ExtractRule{
+		"1": {ColumnName: "name", TransformFunction: "transforms.MyUppercase"},
+		"2": {ColumnName: "qty", TransformFunction: "transforms.MyUppercase"},
+	}
+	record := []interface{}{"boerne", 7}
+	if err := f.RunRules(cols, record, rules); err != nil {
+		t.Fatalf("RunRules Error: %v", err)
+	}
+	if record[0] != "BOERNE" || record[1] != "7" {
+		t.Fatalf("RunRules got %v", record)
+	}
+
+	for _, name := range []string{"broken.Fn", "wrong.Fn", "missing.Fn"} {
+		rules := map[string]domain.ExtractRule{"1": {ColumnName: "name", TransformFunction: name}}
+		if err := f.RunRules(cols, []interface{}{"a", "b"}, rules); err == nil {
+			t.Fatalf("RunRules expected an error for %s", name)
+		}
+	}
+}
+
+func benchmarkRecords(b *testing.B) ([]string, map[string]domain.ExtractRule, []domain.TransformFunction) {
+	b.Helper()
+	cols := []string{"one", "two"}
+	rules := map[string]domain.ExtractRule{
+		"one": {ColumnName: "one", TransformFunction: "transforms.MyUppercase"},
+	}
+	functions := []domain.TransformFunction{{Name: "transforms.MyUppercase", Source: upperSource}}
+	return cols, rules, functions
+}
+
+// BenchmarkRunRulesPerRow interprets the function source for each row
+func BenchmarkRunRulesPerRow(b *testing.B) {
+	cols, rules, functions := benchmarkRecords(b)
+	for i := 0; i < b.N; i++ {
+		if err := RunRules(cols, []interface{}{"a", "b"}, rules, functions); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkRunRulesCompiled reuses the functions compiled for the job
+func BenchmarkRunRulesCompiled(b *testing.B) {
+	cols, rules, functions := benchmarkRecords(b)
+	f := Compile(functions)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := f.RunRules(cols, []interface{}{"a", "b"}, rules); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+const fullNameSource = `package rows
+
+      import "errors"
+
+      func FullName(row map[string]interface{}) (map[string]interface{}, error) {
+        first, _ := row["first"].(string)
+        last, _ := row["last"].(string)
+        if last == "" {
+          return nil, nil
+        }
+        if last == "bad" {
+          return nil, errors.New("bad row")
+        }
+        row["fullname"] = first + " " + last
+        delete(row, "first")
+        return row, nil
+      }`
+
+func TestRunRow(t *testing.T) {
+	functions := []domain.TransformFunction{
+		{Name: "transforms.MyUppercase", Source: upperSource},
+		{Name: "rows.FullName", Source: fullNameSource},
+	}
+	for _, fn := range functions {
+		shape, err := Shape(fn)
+		if err != nil {
+			t.Fatalf("Shape Error: %v", err)
+		}
+		want := ShapeCell
+		if fn.Name == "rows.FullName" {
+			want = ShapeRow
+		}
+		if shape != want {
+			t.Fatalf("Shape of %s got %s want %s", fn.Name, shape, want)
+		}
+	}
+
+	f := Compile(functions)
+	rules := map[string]domain.ExtractRule{
+		"1": {ColumnName: "first", TransformFunction: "transforms.MyUppercase"},
+		"2": {ColumnName: "last", TransformFunction: "rows.FullName"},
+		"3": {ColumnName: "fullname", TransformFunction: "rows.FullName"},
+	}
+	names := f.RowFunctions(rules)
+	if len(names) != 1 || names[0] != "rows.FullName" {
+		t.Fatalf("RowFunctions got %v", names)
+	}
+
+	// the cell functions skip the row functions
+	record := []interface{}{"ann", "lee"}
+	if err := f.RunRules([]string{"first", "last"}, record, rules); err != nil {
+		t.Fatalf("RunRules Error: %v", err)
+	}
+	if record[0] != "ANN" || record[1] != "lee" {
+		t.Fatalf("RunRules got %v", record)
+	}
+
+	row, err := f.RunRow(names, map[string]interface{}{"first": "ANN", "last": "lee"})
+	if err != nil {
+		t.Fatalf("RunRow Error: %v", err)
+	}
+	if len(row) != 2 || row["fullname"] != "ANN lee" || row["last"] != "lee" {
+		t.Fatalf("RunRow got %v", row)
+	}
+
+	row, err = f.RunRow(names, map[string]interface{}{"first": "ANN", "last": ""})
+	if err != nil || row != nil {
+		t.Fatalf("RunRow expected a dropped row got %v %v", row, err)
+	}
+
+	if _, err = f.RunRow(names, map[string]interface{}{"first": "ANN", "last": "bad"}); err == nil {
+		t.Fatal("RunRow expected an error")
+	}
+
+	if _, err = Shape(domain.TransformFunction{Name: "wrong.Fn", Source: "package wrong\n\nfunc Fn(i int) int { return i }"}); err == nil {
+		t.Fatal("Shape expected an error for an unknown shape")
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	shape, results, err := DryRun(domain.TransformFunction{Name: "transforms.MyUppercase", Source: upperSource}, []string{"ann", ""})
+	if err != nil || shape != ShapeCell {
+		t.Fatalf("DryRun got %s %v", shape, err)
+	}
+	if len(results) != 2 || results[0].Output != "ANN" || results[1].Output != "" || results[0].Error != "" {
+		t.Fatalf("DryRun results %+v", results)
+	}
+
+	_, results, err = DryRun(domain.TransformFunction{Name: "rows.FullName", Source: fullNameSource}, []string{`{"first":"ann","last":"lee"}`, `{"first":"ann"}`, `{"last":"bad"}`, `not json`})
+	if err != nil {
+		t.Fatalf("DryRun Error: %v", err)
+	}
+	row, ok := results[0].Output.(map[string]interface{})
+	if !ok || row["fullname"] != "ann lee" {
+		t.Fatalf("DryRun row result %+v", results[0])
+	}
+	if !results[1].Dropped || results[2].Error == "" || results[3].Error == "" {
+		t.Fatalf("DryRun row results %+v", results)
+	}
+
+	panics := domain.TransformFunction{Name: "p.Fn", Source: "package p\n\nfunc Fn(s string) string { return s[10:] }"}
+	_, results, err = DryRun(panics, []string{"short"})
+	if err != nil || results[0].Error == "" {
+		t.Fatalf("DryRun expected a runtime error got %+v %v", results, err)
+	}
+
+	for _, fn := range []domain.TransformFunction{
+		{Name: "broken.Fn", Source: "package broken\n\nfunc Fn(s string) string { return s + 1 }"},
+		{Name: "wrong.Fn", Source: "package wrong\n\nfunc Fn(i int) int { return i }"},
+		{Name: "files.Fn", Source: "package files\n\nimport \"os\"\n\nfunc Fn(s string) string { os.Remove(s); return s }"},
+	} {
+		if _, _, err := DryRun(fn, []string{"x"}); err == nil {
+			t.Fatalf("DryRun expected a compile error for %s", fn.Name)
+		}
+	}
+}
+
+func TestDryRunTimeout(t *testing.T) {
+	saved := dryRunTimeout
+	dryRunTimeout = 200 * time.Millisecond
+	defer func() { dryRunTimeout = saved }()
+
+	// a package initializer that never returns is stopped while compiling
+	hangs := domain.TransformFunction{Name: "hangs.Fn", Source: "package hangs\n\nvar _ = func() int { for {} ; return 0 }()\n\nfunc Fn(s string) string { return s }"}
+	start := time.Now()
+	if _, _, err := DryRun(hangs, []string{"x"}); err == nil {
+		t.Fatal("DryRun expected an error for a package initializer that does not return")
+	}
+	if _, err := Check(hangs); err == nil {
+		t.Fatal("Check expected an error for a package initializer that does not return")
+	}
+
+	// the samples after one that does not return are not run
+	loops := domain.TransformFunction{Name: "loops.Fn", Source: "package loops\n\nfunc Fn(s string) string { for s == \"loop\" {} ; return s }"}
+	_, results, err := DryRun(loops, []string{"ok", "loop", "ok"})
+	if err != nil {
+		t.Fatalf("DryRun Error: %v", err)
+	}
+	if results[0].Output != "ok" || results[1].Error == "" || results[2].Error == "" {
+		t.Fatalf("DryRun results %+v", results)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Fatalf("DryRun took %v", time.Since(start))
+	}
+}
+
+func TestCheck(t *testing.T) {
+	shape, err := Check(domain.TransformFunction{Name: "rows.FullName", Source: fullNameSource})
+	if err != nil || shape != ShapeRow {
+		t.Fatalf("Check got %s %v", shape, err)
+	}
+
+	// the sandbox refuses an initializer that writes a file
+	probe := filepath.Join(t.TempDir(), "probe-check")
+	source := "package probe\n\nimport \"os\"\n\nvar _ = func() int { os.WriteFile(" + strconv.Quote(probe) + ", nil, 0600); return 0 }()\n\nfunc Fn(s string) string { return s }"
+	if _, err := Check(domain.TransformFunction{Name: "probe.Fn", Source: source}); err == nil {
+		t.Fatal("Check expected an error for a function using os")
+	}
+	if _, err := os.Stat(probe); err == nil {
+		t.Fatal("Check ran the package initializer with os")
+	}
+}
+
+func TestShapeRunsNothing(t *testing.T) {
+	probe := filepath.Join(t.TempDir(), "probe-shape")
+	source := "package probe\n\nimport \"os\"\n\nvar _ = func() int { os.WriteFile(" + strconv.Quote(probe) + ", nil, 0600); return 0 }()\n\nfunc Fn(s string) string { return s }"
+	shape, err := Shape(domain.TransformFunction{Name: "probe.Fn", Source: source})
+	if err != nil || shape != ShapeCell {
+		t.Fatalf("Shape got %s %v", shape, err)
+	}
+	if _, err := os.Stat(probe); err == nil {
+		t.Fatal("Shape ran the package initializer")
+	}
+
+	for _, fn := range []domain.TransformFunction{
+		{Name: "probe.Fn", Source: "package other\n\nfunc Fn(s string) string { return s }"},
+		{Name: "probe.Missing", Source: "package probe\n\nfunc Fn(s string) string { return s }"},
+		{Name: "Fn", Source: "package probe\n\nfunc Fn(s string) string { return s }"},
+		{Name: "probe.Fn", Source: "package probe\n\nfunc Fn(s string) string {"},
+	} {
+		if _, err := Shape(fn); err == nil {
+			t.Fatalf("Shape expected an error for %s %q", fn.Name, fn.Source)
+		}
+	}
+}
@@ -24,16 +24,27 @@ type CtlClient interface {
 	UpdateTransformFunction(ctx context.Context, in *UpdateTransformFunctionRequest, opts ...grpc.CallOption) (*UpdateTransformFunctionResponse, error)
 	GetTransformFunction(ctx context.Context, in *GetTransformFunctionRequest, opts ...grpc.CallOption) (*GetTransformFunctionResponse, error)
 	GetTransformFunctions(ctx context.Context, in *GetTransformFunctionsRequest, opts ...grpc.CallOption) (*GetTransformFunctionsResponse, error)
+	TestTransformFunction(ctx context.Context, in *TestTransformFunctionRequest, opts ...grpc.CallOption) (*TestTransformFunctionResponse, error)
 	UpdateExtractRule(ctx context.Context, in *UpdateExtractRuleRequest, opts ...grpc.CallOption) (*UpdateExtractRuleResponse, error)
 	DeleteExtractRule(ctx context.Context, in *DeleteExtractRuleRequest, opts ...grpc.CallOption) (*DeleteExtractRuleResponse, error)
 	CreateExtractRule(ctx context.Context, in *CreateExtractRuleRequest, opts ...grpc.CallOption) (*CreateExtractRuleResponse, error)
 	GetExtractRule(ctx context.Context, in *GetExtractRuleRequest, opts ...grpc.CallOption) (*GetExtractRuleResponse, error)
 	GetExtractRules(ctx context.Context, in *GetExtractRulesRequest, opts ...grpc.CallOption) (*GetExtractRulesResponse, error)
+	InferExtractRules(ctx context.Context, in *InferExtractRulesRequest, opts ...grpc.CallOption) (*InferExtractRulesResponse, error)
+	CreateExtractRules(ctx context.Context, in *CreateExtractRulesRequest, opts ...grpc.CallOption) (*CreateExtractRulesResponse, error)
 	UpdateExtension(ctx context.Context, in *UpdateExtensionRequest, opts ...grpc.CallOption) (*UpdateExtensionResponse, error)
 	DeleteExtension(ctx context.Context, in *DeleteExtensionRequest, opts ...grpc.CallOption) (*DeleteExtensionResponse, error)
 	CreateExtension(ctx context.Context, in *CreateExtensionRequest, opts ...grpc.CallOption) (*CreateExtensionResponse, error)
 	GetExtension(ctx context.Context, in *GetExtensionRequest, opts ...grpc.CallOption) (*GetExtensionResponse, error)
 	GetExtensions(ctx context.Context, in *GetExtensionsRequest, opts ...grpc.CallOption) (*GetExtensionsResponse, error)
+	UpdateLookup(ctx context.Context, in *UpdateLookupRequest, opts ...grpc.CallOption) (*UpdateLookupResponse, error)
+	DeleteLookup(ctx context.Context, in *DeleteLookupRequest, opts ...grpc.CallOption) (*DeleteLookupResponse, error)
+	CreateLookup(ctx context.Context, in *CreateLookupRequest, opts ...grpc.CallOption) (*CreateLookupResponse, error)
+	GetLookup(ctx context.Context, in *GetLookupRequest, opts ...grpc.CallOption) (*GetLookupResponse, error)
+	UpdateRoute(ctx context.Context, in *UpdateRouteRequest, opts ...grpc.CallOption) (*UpdateRouteResponse, error)
+	DeleteRoute(ctx context.Context, in *DeleteRouteRequest, opts ...grpc.CallOption) (*DeleteRouteResponse, error)
+	CreateRoute(ctx context.Context, in *CreateRouteRequest, opts ...grpc.CallOption) (*CreateRouteResponse, error)
+	GetRoute(ctx context.Context, in *GetRouteRequest, opts ...grpc.CallOption) (*GetRouteResponse, error)
 	UpdateExtractSource(ctx context.Context, in *UpdateExtractSourceRequest, opts ...grpc.CallOption) (*UpdateExtractSourceResponse, error)
 	DeleteExtractSource(ctx context.Context, in *DeleteExtractSourceRequest, opts ...grpc.CallOption) (*DeleteExtractSourceResponse, error)
 	GetExtractSource(ctx context.Context, in *GetExtractSourceRequest, opts ...grpc.CallOption) (*GetExtractSourceResponse, error)
@@ -44,6 +55,8 @@ type CtlClient interface {
 	DeleteJobs(ctx context.Context, in *DeleteJobsRequest, opts ...grpc.CallOption) (*DeleteJobsResponse, error)
 	GetPipelineJobLog(ctx context.Context, in *GetPipelineJobLogRequest, opts ...grpc.CallOption) (*GetPipelineJobLogResponse, error)
 	GetExtractData(ctx context.Context, in *GetExtractDataRequest, opts ...grpc.CallOption) (*GetExtractDataResponse, error)
+	GetFailedFiles(ctx context.Context, in *GetFailedFilesRequest, opts ...grpc.CallOption) (*GetFailedFilesResponse, error)
+	RequeueFailedFiles(ctx context.Context, in *RequeueFailedFilesRequest, opts ...grpc.CallOption) (*RequeueFailedFilesResponse, error)
 }
 
 type ctlClient struct {
@@ -108,6 +121,15 @@ func (c *ctlClient) GetTransformFunctions(ctx context.Context, in *GetTransformF
 	return out, nil
 }
 
+func (c *ctlClient) TestTransformFunction(ctx context.Context, in *TestTransformFunctionRequest, opts ...grpc.CallOption) (*TestTransformFunctionResponse, error) {
+	out := new(TestTransformFunctionResponse)
+	err := c.cc.Invoke(ctx, "/ctl.Ctl/TestTransformFunction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *ctlClient) UpdateExtractRule(ctx context.Context, in *UpdateExtractRuleRequest, opts ...grpc.CallOption) (*UpdateExtractRuleResponse, error) {
 	out := new(UpdateExtractRuleResponse)
 	err := c.cc.Invoke(ctx, "/ctl.Ctl/UpdateExtractRule", in, out, opts...)
@@ -153,6 +175,24 @@ func (c *ctlClient) GetExtractRules(ctx context.Context, in *GetExtractRulesRequ
 	return out, nil
 }
 
+func (c *ctlClient) InferExtractRules(ctx context.Context, in *InferExtractRulesRequest, opts ...grpc.CallOption) (*InferExtractRulesResponse, error) {
+	out := new(InferExtractRulesResponse)
+	err := c.cc.Invoke(ctx, "/ctl.Ctl/InferExtractRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlClient) CreateExtractRules(ctx context.Context, in *CreateExtractRulesRequest, opts ...grpc.CallOption) (*CreateExtractRulesResponse, error) {
+	out := new(CreateExtractRulesResponse)
+	err := c.cc.Invoke(ctx, "/ctl.Ctl/CreateExtractRules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *ctlClient) UpdateExtension(ctx context.Context, in *UpdateExtensionRequest, opts ...grpc.CallOption) (*UpdateExtensionResponse, error) {
 	out := new(UpdateExtensionResponse)
 	err := c.cc.Invoke(ctx, "/ctl.Ctl/UpdateExtension", in, out, opts...)
@@ -198,6 +238,78 @@ func (c *ctlClient) GetExtensions(ctx context.Context, in *GetExtensionsRequest,
 	return out, nil
 }
 
+func (c *ctlClient) UpdateLookup(ctx context.Context, in *UpdateLookupRequest, opts ...grpc.CallOption) (*UpdateLookupResponse, error) {
+	out := new(UpdateLookupResponse)
+	err := c.cc.Invoke(ctx, "/ctl.Ctl/UpdateLookup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlClient) DeleteLookup(ctx context.Context, in *DeleteLookupRequest, opts ...grpc.CallOption) (*DeleteLookupResponse, error) {
+	out := new(DeleteLookupResponse)
+	err := c.cc.Invoke(ctx, "/ctl.Ctl/DeleteLookup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlClient) CreateLookup(ctx context.Context, in *CreateLookupRequest, opts ...grpc.CallOption) (*CreateLookupResponse, error) {
+	out := new(CreateLookupResponse)
+	err := c.cc.Invoke(ctx, "/ctl.Ctl/CreateLookup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlClient) GetLookup(ctx context.Context, in *GetLookupRequest, opts ...grpc.CallOption) (*GetLookupResponse, error) {
+	out := new(GetLookupResponse)
+	err := c.cc.Invoke(ctx, "/ctl.Ctl/GetLookup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlClient) UpdateRoute(ctx context.Context, in *UpdateRouteRequest, opts ...grpc.CallOption) (*UpdateRouteResponse, error) {
+	out := new(UpdateRouteResponse)
+	err := c.cc.Invoke(ctx, "/ctl.Ctl/UpdateRoute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlClient) DeleteRoute(ctx context.Context, in *DeleteRouteRequest, opts ...grpc.CallOption) (*DeleteRouteResponse, error) {
+	out := new(DeleteRouteResponse)
+	err := c.cc.Invoke(ctx, "/ctl.Ctl/DeleteRoute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlClient) CreateRoute(ctx context.Context, in *CreateRouteRequest, opts ...grpc.CallOption) (*CreateRouteResponse, error) {
+	out := new(CreateRouteResponse)
+	err := c.cc.Invoke(ctx, "/ctl.Ctl/CreateRoute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlClient) GetRoute(ctx context.Context, in *GetRouteRequest, opts ...grpc.CallOption) (*GetRouteResponse, error) {
+	out := new(GetRouteResponse)
+	err := c.cc.Invoke(ctx, "/ctl.Ctl/GetRoute", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *ctlClient) UpdateExtractSource(ctx context.Context, in *UpdateExtractSourceRequest, opts ...grpc.CallOption) (*UpdateExtractSourceResponse, error) {
 	out := new(UpdateExtractSourceResponse)
 	err := c.cc.Invoke(ctx, "/ctl.Ctl/UpdateExtractSource", in, out, opts...)
@@ -288,6 +400,24 @@ func (c *ctlClient) GetExtractData(ctx context.Context, in *GetExtractDataReques
 	return out, nil
 }
 
+func (c *ctlClient) GetFailedFiles(ctx context.Context, in *GetFailedFilesRequest, opts ...grpc.CallOption) (*GetFailedFilesResponse, error) {
+	out := new(GetFailedFilesResponse)
+	err := c.cc.Invoke(ctx, "/ctl.Ctl/GetFailedFiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ctlClient) RequeueFailedFiles(ctx context.Context, in *RequeueFailedFilesRequest, opts ...grpc.CallOption) (*RequeueFailedFilesResponse, error) {
+	out := new(RequeueFailedFilesResponse)
+	err := c.cc.Invoke(ctx, "/ctl.Ctl/RequeueFailedFiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CtlServer is the server API for Ctl service.
 // All implementations should embed UnimplementedCtlServer
 // for forward compatibility
@@ -298,16 +428,27 @@ type CtlServer interface {
 	UpdateTransformFunction(context.Context, *UpdateTransformFunctionRequest) (*UpdateTransformFunctionResponse, error)
 	GetTransformFunction(context.Context, *GetTransformFunctionRequest) (*GetTransformFunctionResponse, error)
 	GetTransformFunctions(context.Context, *GetTransformFunctionsRequest) (*GetTransformFunctionsResponse, error)
+	TestTransformFunction(context.Context, *TestTransformFunctionRequest) (*TestTransformFunctionResponse, error)
 	UpdateExtractRule(context.Context, *UpdateExtractRuleRequest) (*UpdateExtractRuleResponse, error)
 	DeleteExtractRule(context.Context, *DeleteExtractRuleRequest) (*DeleteExtractRuleResponse, error)
 	CreateExtractRule(context.Context, *CreateExtractRuleRequest) (*CreateExtractRuleResponse, error)
 	GetExtractRule(context.Context, *GetExtractRuleRequest) (*GetExtractRuleResponse, error)
 	GetExtractRules(context.Context, *GetExtractRulesRequest) (*GetExtractRulesResponse, error)
+	InferExtractRules(context.Context, *InferExtractRulesRequest) (*InferExtractRulesResponse, error)
+	CreateExtractRules(context.Context, *CreateExtractRulesRequest) (*CreateExtractRulesResponse, error)
 	UpdateExtension(context.Context, *UpdateExtensionRequest) (*UpdateExtensionResponse, error)
 	DeleteExtension(context.Context, *DeleteExtensionRequest) (*DeleteExtensionResponse, error)
 	CreateExtension(context.Context, *CreateExtensionRequest) (*CreateExtensionResponse, error)
 	GetExtension(context.Context, *GetExtensionRequest) (*GetExtensionResponse, error)
 	GetExtensions(context.Context, *GetExtensionsRequest) (*GetExtensionsResponse, error)
+	UpdateLookup(context.Context, *UpdateLookupRequest) (*UpdateLookupResponse, error)
+	DeleteLookup(context.Context, *DeleteLookupRequest) (*DeleteLookupResponse, error)
+	CreateLookup(context.Context, *CreateLookupRequest) (*CreateLookupResponse, error)
+	GetLookup(context.Context, *GetLookupRequest) (*GetLookupResponse, error)
+	UpdateRoute(context.Context, *UpdateRouteRequest) (*UpdateRouteResponse, error)
+	DeleteRoute(context.Context, *DeleteRouteRequest) (*DeleteRouteResponse, error)
+	CreateRoute(context.Context, *CreateRouteRequest) (*CreateRouteResponse, error)
+	GetRoute(context.Context, *GetRouteRequest) (*GetRouteResponse, error)
 	UpdateExtractSource(context.Context, *UpdateExtractSourceRequest) (*UpdateExtractSourceResponse, error)
 	DeleteExtractSource(context.Context, *DeleteExtractSourceRequest) (*DeleteExtractSourceResponse, error)
 	GetExtractSource(context.Context, *GetExtractSourceRequest) (*GetExtractSourceResponse, error)
@@ -318,6 +459,8 @@ type CtlServer interface {
 	DeleteJobs(context.Context, *DeleteJobsRequest) (*DeleteJobsResponse, error)
 	GetPipelineJobLog(context.Context, *GetPipelineJobLogRequest) (*GetPipelineJobLogResponse, error)
 	GetExtractData(context.Context, *GetExtractDataRequest) (*GetExtractDataResponse, error)
+	GetFailedFiles(context.Context, *GetFailedFilesRequest) (*GetFailedFilesResponse, error)
+	RequeueFailedFiles(context.Context, *RequeueFailedFilesRequest) (*RequeueFailedFilesResponse, error)
 }
 
 // UnimplementedCtlServer should be embedded to have forward compatible implementations.
@@ -342,6 +485,9 @@ func (UnimplementedCtlServer) GetTransformFunction(context.Context, *GetTransfor
 func (UnimplementedCtlServer) GetTransformFunctions(context.Context, *GetTransformFunctionsRequest) (*GetTransformFunctionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTransformFunctions not implemented")
 }
+func (UnimplementedCtlServer) TestTransformFunction(context.Context, *TestTransformFunctionRequest) (*TestTransformFunctionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TestTransformFunction not implemented")
+}
 func (UnimplementedCtlServer) UpdateExtractRule(context.Context, *UpdateExtractRuleRequest) (*UpdateExtractRuleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateExtractRule not implemented")
 }
@@ -357,6 +503,12 @@ func (UnimplementedCtlServer) GetExtractRule(context.Context, *GetExtractRuleReq
 func (UnimplementedCtlServer) GetExtractRules(context.Context, *GetExtractRulesRequest) (*GetExtractRulesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetExtractRules not implemented")
 }
+func (UnimplementedCtlServer) InferExtractRules(context.Context, *InferExtractRulesRequest) (*InferExtractRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InferExtractRules not implemented")
+}
+func (UnimplementedCtlServer) CreateExtractRules(context.Context, *CreateExtractRulesRequest) (*CreateExtractRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateExtractRules not implemented")
+}
 func (UnimplementedCtlServer) UpdateExtension(context.Context, *UpdateExtensionRequest) (*UpdateExtensionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateExtension not implemented")
 }
@@ -372,6 +524,30 @@ func (UnimplementedCtlServer) GetExtension(context.Context, *GetExtensionRequest
 func (UnimplementedCtlServer) GetExtensions(context.Context, *GetExtensionsRequest) (*GetExtensionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetExtensions not implemented")
 }
+func (UnimplementedCtlServer) UpdateLookup(context.Context, *UpdateLookupRequest) (*UpdateLookupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLookup not implemented")
+}
+func (UnimplementedCtlServer) DeleteLookup(context.Context, *DeleteLookupRequest) (*DeleteLookupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLookup not implemented")
+}
+func (UnimplementedCtlServer) CreateLookup(context.Context, *CreateLookupRequest) (*CreateLookupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLookup not implemented")
+}
+func (UnimplementedCtlServer) GetLookup(context.Context, *GetLookupRequest) (*GetLookupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLookup not implemented")
+}
+func (UnimplementedCtlServer) UpdateRoute(context.Context, *UpdateRouteRequest) (*UpdateRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRoute not implemented")
+}
+func (UnimplementedCtlServer) DeleteRoute(context.Context, *DeleteRouteRequest) (*DeleteRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRoute not implemented")
+}
+func (UnimplementedCtlServer) CreateRoute(context.Context, *CreateRouteRequest) (*CreateRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRoute not implemented")
+}
+func (UnimplementedCtlServer) GetRoute(context.Context, *GetRouteRequest) (*GetRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoute not implemented")
+}
 func (UnimplementedCtlServer) UpdateExtractSource(context.Context, *UpdateExtractSourceRequest) (*UpdateExtractSourceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateExtractSource not implemented")
 }
@@ -402,6 +578,12 @@ func (UnimplementedCtlServer) GetPipelineJobLog(context.Context, *GetPipelineJob
 func (UnimplementedCtlServer) GetExtractData(context.Context, *GetExtractDataRequest) (*GetExtractDataResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetExtractData not implemented")
 }
+func (UnimplementedCtlServer) GetFailedFiles(context.Context, *GetFailedFilesRequest) (*GetFailedFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFailedFiles not implemented")
+}
+func (UnimplementedCtlServer) RequeueFailedFiles(context.Context, *RequeueFailedFilesRequest) (*RequeueFailedFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequeueFailedFiles not implemented")
+}
 
 // UnsafeCtlServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to CtlServer will
@@ -522,6 +704,24 @@ func _Ctl_GetTransformFunctions_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Ctl_TestTransformFunction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TestTransformFunctionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlServer).TestTransformFunction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.Ctl/TestTransformFunction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlServer).TestTransformFunction(ctx, req.(*TestTransformFunctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Ctl_UpdateExtractRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateExtractRuleRequest)
 	if err := dec(in); err != nil {
@@ -612,6 +812,42 @@ func _Ctl_GetExtractRules_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Ctl_InferExtractRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InferExtractRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlServer).InferExtractRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.Ctl/InferExtractRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlServer).InferExtractRules(ctx, req.(*InferExtractRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ctl_CreateExtractRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateExtractRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlServer).CreateExtractRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.Ctl/CreateExtractRules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlServer).CreateExtractRules(ctx, req.(*CreateExtractRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Ctl_UpdateExtension_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateExtensionRequest)
 	if err := dec(in); err != nil {
@@ -702,6 +938,150 @@ func _Ctl_GetExtensions_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Ctl_UpdateLookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLookupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlServer).UpdateLookup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.Ctl/UpdateLookup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlServer).UpdateLookup(ctx, req.(*UpdateLookupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ctl_DeleteLookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLookupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlServer).DeleteLookup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.Ctl/DeleteLookup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlServer).DeleteLookup(ctx, req.(*DeleteLookupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ctl_CreateLookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLookupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlServer).CreateLookup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.Ctl/CreateLookup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlServer).CreateLookup(ctx, req.(*CreateLookupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ctl_GetLookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLookupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlServer).GetLookup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.Ctl/GetLookup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlServer).GetLookup(ctx, req.(*GetLookupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ctl_UpdateRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlServer).UpdateRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.Ctl/UpdateRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlServer).UpdateRoute(ctx, req.(*UpdateRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ctl_DeleteRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlServer).DeleteRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.Ctl/DeleteRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlServer).DeleteRoute(ctx, req.(*DeleteRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ctl_CreateRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlServer).CreateRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.Ctl/CreateRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlServer).CreateRoute(ctx, req.(*CreateRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ctl_GetRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlServer).GetRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.Ctl/GetRoute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlServer).GetRoute(ctx, req.(*GetRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Ctl_UpdateExtractSource_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateExtractSourceRequest)
 	if err := dec(in); err != nil {
@@ -882,6 +1262,42 @@ func _Ctl_GetExtractData_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Ctl_GetFailedFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFailedFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlServer).GetFailedFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.Ctl/GetFailedFiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlServer).GetFailedFiles(ctx, req.(*GetFailedFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ctl_RequeueFailedFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequeueFailedFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CtlServer).RequeueFailedFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ctl.Ctl/RequeueFailedFiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CtlServer).RequeueFailedFiles(ctx, req.(*RequeueFailedFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Ctl_ServiceDesc is the grpc.ServiceDesc for Ctl service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -913,6 +1329,10 @@ var Ctl_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTransformFunctions",
 			Handler:    _Ctl_GetTransformFunctions_Handler,
 		},
+		{
+			MethodName: "TestTransformFunction",
+			Handler:    _Ctl_TestTransformFunction_Handler,
+		},
 		{
 			MethodName: "UpdateExtractRule",
 			Handler:    _Ctl_UpdateExtractRule_Handler,
@@ -933,6 +1353,14 @@ var Ctl_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetExtractRules",
 			Handler:    _Ctl_GetExtractRules_Handler,
 		},
+		{
+			MethodName: "InferExtractRules",
+			Handler:    _Ctl_InferExtractRules_Handler,
+		},
+		{
+			MethodName: "CreateExtractRules",
+			Handler:    _Ctl_CreateExtractRules_Handler,
+		},
 		{
 			MethodName: "UpdateExtension",
 			Handler:    _Ctl_UpdateExtension_Handler,
@@ -953,6 +1381,38 @@ var Ctl_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetExtensions",
 			Handler:    _Ctl_GetExtensions_Handler,
 		},
+		{
+			MethodName: "UpdateLookup",
+			Handler:    _Ctl_UpdateLookup_Handler,
+		},
+		{
+			MethodName: "DeleteLookup",
+			Handler:    _Ctl_DeleteLookup_Handler,
+		},
+		{
+			MethodName: "CreateLookup",
+			Handler:    _Ctl_CreateLookup_Handler,
+		},
+		{
+			MethodName: "GetLookup",
+			Handler:    _Ctl_GetLookup_Handler,
+		},
+		{
+			MethodName: "UpdateRoute",
+			Handler:    _Ctl_UpdateRoute_Handler,
+		},
+		{
+			MethodName: "DeleteRoute",
+			Handler:    _Ctl_DeleteRoute_Handler,
+		},
+		{
+			MethodName: "CreateRoute",
+			Handler:    _Ctl_CreateRoute_Handler,
+		},
+		{
+			MethodName: "GetRoute",
+			Handler:    _Ctl_GetRoute_Handler,
+		},
 		{
 			MethodName: "UpdateExtractSource",
 			Handler:    _Ctl_UpdateExtractSource_Handler,
@@ -993,6 +1453,14 @@ var Ctl_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetExtractData",
 			Handler:    _Ctl_GetExtractData_Handler,
 		},
+		{
+			MethodName: "GetFailedFiles",
+			Handler:    _Ctl_GetFailedFiles_Handler,
+		},
+		{
+			MethodName: "RequeueFailedFiles",
+			Handler:    _Ctl_RequeueFailedFiles_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "rpc/ctl/ctl.proto",
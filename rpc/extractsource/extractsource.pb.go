@@ -818,6 +818,202 @@ func (x *StopAPIResponse) GetStatusCode() int32 {
 	return 0
 }
 
+type GetFailedFilesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExtractSourceID string `protobuf:"bytes,1,opt,name=extractSourceID,proto3" json:"extractSourceID,omitempty"`
+}
+
+func (x *GetFailedFilesRequest) Reset() {
+	*x = GetFailedFilesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_extractsource_extractsource_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetFailedFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFailedFilesRequest) ProtoMessage() {}
+
+func (x *GetFailedFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_extractsource_extractsource_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFailedFilesRequest.ProtoReflect.Descriptor instead.
+func (*GetFailedFilesRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_extractsource_extractsource_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetFailedFilesRequest) GetExtractSourceID() string {
+	if x != nil {
+		return x.ExtractSourceID
+	}
+	return ""
+}
+
+type GetFailedFilesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FailedFilesString string `protobuf:"bytes,1,opt,name=failedFilesString,proto3" json:"failedFilesString,omitempty"`
+}
+
+func (x *GetFailedFilesResponse) Reset() {
+	*x = GetFailedFilesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_extractsource_extractsource_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetFailedFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFailedFilesResponse) ProtoMessage() {}
+
+func (x *GetFailedFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_extractsource_extractsource_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFailedFilesResponse.ProtoReflect.Descriptor instead.
+func (*GetFailedFilesResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_extractsource_extractsource_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetFailedFilesResponse) GetFailedFilesString() string {
+	if x != nil {
+		return x.FailedFilesString
+	}
+	return ""
+}
+
+type RequeueFailedFilesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExtractSourceID string   `protobuf:"bytes,1,opt,name=extractSourceID,proto3" json:"extractSourceID,omitempty"`
+	IDs             []string `protobuf:"bytes,2,rep,name=IDs,proto3" json:"IDs,omitempty"`
+}
+
+func (x *RequeueFailedFilesRequest) Reset() {
+	*x = RequeueFailedFilesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_extractsource_extractsource_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequeueFailedFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequeueFailedFilesRequest) ProtoMessage() {}
+
+func (x *RequeueFailedFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_extractsource_extractsource_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequeueFailedFilesRequest.ProtoReflect.Descriptor instead.
+func (*RequeueFailedFilesRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_extractsource_extractsource_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RequeueFailedFilesRequest) GetExtractSourceID() string {
+	if x != nil {
+		return x.ExtractSourceID
+	}
+	return ""
+}
+
+func (x *RequeueFailedFilesRequest) GetIDs() []string {
+	if x != nil {
+		return x.IDs
+	}
+	return nil
+}
+
+type RequeueFailedFilesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IDs []string `protobuf:"bytes,1,rep,name=IDs,proto3" json:"IDs,omitempty"`
+}
+
+func (x *RequeueFailedFilesResponse) Reset() {
+	*x = RequeueFailedFilesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_extractsource_extractsource_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequeueFailedFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequeueFailedFilesResponse) ProtoMessage() {}
+
+func (x *RequeueFailedFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_extractsource_extractsource_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequeueFailedFilesResponse.ProtoReflect.Descriptor instead.
+func (*RequeueFailedFilesResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_extractsource_extractsource_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RequeueFailedFilesResponse) GetIDs() []string {
+	if x != nil {
+		return x.IDs
+	}
+	return nil
+}
+
 var File_rpc_extractsource_extractsource_proto protoreflect.FileDescriptor
 
 var file_rpc_extractsource_extractsource_proto_rawDesc = []byte{
@@ -899,50 +1095,80 @@ var file_rpc_extractsource_extractsource_proto_rawDesc = []byte{
 	0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x75,
 	0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74,
 	0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x32, 0x8f, 0x05, 0x0a, 0x0d, 0x45, 0x78, 0x74,
-	0x72, 0x61, 0x63, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x3f, 0x0a, 0x04, 0x50, 0x69,
-	0x6e, 0x67, 0x12, 0x1a, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x2e, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
-	0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x50,
-	0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6c, 0x0a, 0x13, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x53, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x12, 0x29, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74,
-	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e,
-	0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6c, 0x0a, 0x13, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x12, 0x29, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x53, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2a, 0x2e, 0x65, 0x78,
-	0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x74, 0x0a, 0x15, 0x55, 0x70, 0x6c, 0x6f, 0x61,
-	0x64, 0x54, 0x6f, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x12, 0x2b, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
-	0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x6f, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74,
-	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c, 0x2e,
-	0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x55, 0x70,
-	0x6c, 0x6f, 0x61, 0x64, 0x54, 0x6f, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x53, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x12, 0x54, 0x0a,
-	0x0b, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x79, 0x55, 0x52, 0x4c, 0x12, 0x21, 0x2e, 0x65,
-	0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x55, 0x70, 0x6c,
-	0x6f, 0x61, 0x64, 0x42, 0x79, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x22, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e,
-	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x79, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x08, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x50, 0x49, 0x12,
-	0x1e, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e,
-	0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x50, 0x49, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1f, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e,
-	0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x50, 0x49, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x48, 0x0a, 0x07, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x50, 0x49, 0x12, 0x1d, 0x2e, 0x65, 0x78,
-	0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x53, 0x74, 0x6f, 0x70,
-	0x41, 0x50, 0x49, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x65, 0x78, 0x74,
-	0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x41,
-	0x50, 0x49, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x13, 0x5a, 0x11, 0x72, 0x70,
-	0x63, 0x2f, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x41, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x46,
+	0x61, 0x69, 0x6c, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x28, 0x0a, 0x0f, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x78, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x44, 0x22, 0x46, 0x0a, 0x16, 0x47,
+	0x65, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x11, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x46,
+	0x69, 0x6c, 0x65, 0x73, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x11, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x53, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x22, 0x57, 0x0a, 0x19, 0x52, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65, 0x46, 0x61,
+	0x69, 0x6c, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x28, 0x0a, 0x0f, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x78, 0x74, 0x72, 0x61,
+	0x63, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x44, 0x12, 0x10, 0x0a, 0x03, 0x49, 0x44,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x49, 0x44, 0x73, 0x22, 0x2e, 0x0a, 0x1a,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x46, 0x69, 0x6c,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x49, 0x44,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x49, 0x44, 0x73, 0x32, 0xd9, 0x06, 0x0a,
+	0x0d, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x3f,
+	0x0a, 0x04, 0x50, 0x69, 0x6e, 0x67, 0x12, 0x1a, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x2e, 0x50, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x6c, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x29, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2a, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6c, 0x0a,
+	0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x12, 0x29, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x78, 0x74, 0x72, 0x61,
+	0x63, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2a, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x74, 0x0a, 0x15, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x6f, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x12, 0x2b, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x6f, 0x45, 0x78, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x2c, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x6f, 0x45, 0x78, 0x74, 0x72, 0x61, 0x63,
+	0x74, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28,
+	0x01, 0x12, 0x54, 0x0a, 0x0b, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x79, 0x55, 0x52, 0x4c,
+	0x12, 0x21, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x79, 0x55, 0x52, 0x4c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x79, 0x55, 0x52, 0x4c, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x08, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x41, 0x50, 0x49, 0x12, 0x1e, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x50, 0x49, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x41, 0x50, 0x49, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x07, 0x53, 0x74, 0x6f, 0x70, 0x41, 0x50, 0x49, 0x12,
+	0x1d, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e,
+	0x53, 0x74, 0x6f, 0x70, 0x41, 0x50, 0x49, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e,
+	0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x53,
+	0x74, 0x6f, 0x70, 0x41, 0x50, 0x49, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5d,
+	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73,
+	0x12, 0x24, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x2e, 0x47, 0x65, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64,
+	0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x69, 0x0a,
+	0x12, 0x52, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x46, 0x69,
+	0x6c, 0x65, 0x73, 0x12, 0x28, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e,
+	0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x2e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x75, 0x65, 0x46, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x13, 0x5a, 0x11, 0x72, 0x70, 0x63, 0x2f,
+	0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -957,7 +1183,7 @@ func file_rpc_extractsource_extractsource_proto_rawDescGZIP() []byte {
 	return file_rpc_extractsource_extractsource_proto_rawDescData
 }
 
-var file_rpc_extractsource_extractsource_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_rpc_extractsource_extractsource_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_rpc_extractsource_extractsource_proto_goTypes = []interface{}{
 	(*UploadInfo)(nil),                    // 0: extractsource.UploadInfo
 	(*UploadToExtractSourceRequest)(nil),  // 1: extractsource.UploadToExtractSourceRequest
@@ -974,6 +1200,10 @@ var file_rpc_extractsource_extractsource_proto_goTypes = []interface{}{
 	(*StartAPIResponse)(nil),              // 12: extractsource.StartAPIResponse
 	(*StopAPIRequest)(nil),                // 13: extractsource.StopAPIRequest
 	(*StopAPIResponse)(nil),               // 14: extractsource.StopAPIResponse
+	(*GetFailedFilesRequest)(nil),         // 15: extractsource.GetFailedFilesRequest
+	(*GetFailedFilesResponse)(nil),        // 16: extractsource.GetFailedFilesResponse
+	(*RequeueFailedFilesRequest)(nil),     // 17: extractsource.RequeueFailedFilesRequest
+	(*RequeueFailedFilesResponse)(nil),    // 18: extractsource.RequeueFailedFilesResponse
 }
 var file_rpc_extractsource_extractsource_proto_depIdxs = []int32{
 	0,  // 0: extractsource.UploadToExtractSourceRequest.info:type_name -> extractsource.UploadInfo
@@ -984,15 +1214,19 @@ var file_rpc_extractsource_extractsource_proto_depIdxs = []int32{
 	9,  // 5: extractsource.ExtractSource.UploadByURL:input_type -> extractsource.UploadByURLRequest
 	11, // 6: extractsource.ExtractSource.StartAPI:input_type -> extractsource.StartAPIRequest
 	13, // 7: extractsource.ExtractSource.StopAPI:input_type -> extractsource.StopAPIRequest
-	4,  // 8: extractsource.ExtractSource.Ping:output_type -> extractsource.PingResponse
-	6,  // 9: extractsource.ExtractSource.CreateExtractSource:output_type -> extractsource.CreateExtractSourceResponse
-	8,  // 10: extractsource.ExtractSource.DeleteExtractSource:output_type -> extractsource.DeleteExtractSourceResponse
-	2,  // 11: extractsource.ExtractSource.UploadToExtractSource:output_type -> extractsource.UploadToExtractSourceResponse
-	10, // 12: extractsource.ExtractSource.UploadByURL:output_type -> extractsource.UploadByURLResponse
-	12, // 13: extractsource.ExtractSource.StartAPI:output_type -> extractsource.StartAPIResponse
-	14, // 14: extractsource.ExtractSource.StopAPI:output_type -> extractsource.StopAPIResponse
-	8,  // [8:15] is the sub-list for method output_type
-	1,  // [1:8] is the sub-list for method input_type
+	15, // 8: extractsource.ExtractSource.GetFailedFiles:input_type -> extractsource.GetFailedFilesRequest
+	17, // 9: extractsource.ExtractSource.RequeueFailedFiles:input_type -> extractsource.RequeueFailedFilesRequest
+	4,  // 10: extractsource.ExtractSource.Ping:output_type -> extractsource.PingResponse
+	6,  // 11: extractsource.ExtractSource.CreateExtractSource:output_type -> extractsource.CreateExtractSourceResponse
+	8,  // 12: extractsource.ExtractSource.DeleteExtractSource:output_type -> extractsource.DeleteExtractSourceResponse
+	2,  // 13: extractsource.ExtractSource.UploadToExtractSource:output_type -> extractsource.UploadToExtractSourceResponse
+	10, // 14: extractsource.ExtractSource.UploadByURL:output_type -> extractsource.UploadByURLResponse
+	12, // 15: extractsource.ExtractSource.StartAPI:output_type -> extractsource.StartAPIResponse
+	14, // 16: extractsource.ExtractSource.StopAPI:output_type -> extractsource.StopAPIResponse
+	16, // 17: extractsource.ExtractSource.GetFailedFiles:output_type -> extractsource.GetFailedFilesResponse
+	18, // 18: extractsource.ExtractSource.RequeueFailedFiles:output_type -> extractsource.RequeueFailedFilesResponse
+	10, // [10:19] is the sub-list for method output_type
+	1,  // [1:10] is the sub-list for method input_type
 	1,  // [1:1] is the sub-list for extension type_name
 	1,  // [1:1] is the sub-list for extension extendee
 	0,  // [0:1] is the sub-list for field type_name
@@ -1184,6 +1418,54 @@ func file_rpc_extractsource_extractsource_proto_init() {
 				return nil
 			}
 		}
+		file_rpc_extractsource_extractsource_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFailedFilesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_extractsource_extractsource_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetFailedFilesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_extractsource_extractsource_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequeueFailedFilesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_extractsource_extractsource_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequeueFailedFilesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_rpc_extractsource_extractsource_proto_msgTypes[1].OneofWrappers = []interface{}{
 		(*UploadToExtractSourceRequest_Info)(nil),
@@ -1195,7 +1477,7 @@ func file_rpc_extractsource_extractsource_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_rpc_extractsource_extractsource_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   15,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
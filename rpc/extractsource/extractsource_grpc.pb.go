@@ -25,6 +25,8 @@ type ExtractSourceClient interface {
 	UploadByURL(ctx context.Context, in *UploadByURLRequest, opts ...grpc.CallOption) (*UploadByURLResponse, error)
 	StartAPI(ctx context.Context, in *StartAPIRequest, opts ...grpc.CallOption) (*StartAPIResponse, error)
 	StopAPI(ctx context.Context, in *StopAPIRequest, opts ...grpc.CallOption) (*StopAPIResponse, error)
+	GetFailedFiles(ctx context.Context, in *GetFailedFilesRequest, opts ...grpc.CallOption) (*GetFailedFilesResponse, error)
+	RequeueFailedFiles(ctx context.Context, in *RequeueFailedFilesRequest, opts ...grpc.CallOption) (*RequeueFailedFilesResponse, error)
 }
 
 type extractSourceClient struct {
@@ -123,6 +125,24 @@ func (c *extractSourceClient) StopAPI(ctx context.Context, in *StopAPIRequest, o
 	return out, nil
 }
 
+func (c *extractSourceClient) GetFailedFiles(ctx context.Context, in *GetFailedFilesRequest, opts ...grpc.CallOption) (*GetFailedFilesResponse, error) {
+	out := new(GetFailedFilesResponse)
+	err := c.cc.Invoke(ctx, "/extractsource.ExtractSource/GetFailedFiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extractSourceClient) RequeueFailedFiles(ctx context.Context, in *RequeueFailedFilesRequest, opts ...grpc.CallOption) (*RequeueFailedFilesResponse, error) {
+	out := new(RequeueFailedFilesResponse)
+	err := c.cc.Invoke(ctx, "/extractsource.ExtractSource/RequeueFailedFiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ExtractSourceServer is the server API for ExtractSource service.
 // All implementations should embed UnimplementedExtractSourceServer
 // for forward compatibility
@@ -134,6 +154,8 @@ type ExtractSourceServer interface {
 	UploadByURL(context.Context, *UploadByURLRequest) (*UploadByURLResponse, error)
 	StartAPI(context.Context, *StartAPIRequest) (*StartAPIResponse, error)
 	StopAPI(context.Context, *StopAPIRequest) (*StopAPIResponse, error)
+	GetFailedFiles(context.Context, *GetFailedFilesRequest) (*GetFailedFilesResponse, error)
+	RequeueFailedFiles(context.Context, *RequeueFailedFilesRequest) (*RequeueFailedFilesResponse, error)
 }
 
 // UnimplementedExtractSourceServer should be embedded to have forward compatible implementations.
@@ -161,6 +183,12 @@ func (UnimplementedExtractSourceServer) StartAPI(context.Context, *StartAPIReque
 func (UnimplementedExtractSourceServer) StopAPI(context.Context, *StopAPIRequest) (*StopAPIResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StopAPI not implemented")
 }
+func (UnimplementedExtractSourceServer) GetFailedFiles(context.Context, *GetFailedFilesRequest) (*GetFailedFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFailedFiles not implemented")
+}
+func (UnimplementedExtractSourceServer) RequeueFailedFiles(context.Context, *RequeueFailedFilesRequest) (*RequeueFailedFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequeueFailedFiles not implemented")
+}
 
 // UnsafeExtractSourceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to ExtractSourceServer will
@@ -307,6 +335,42 @@ func _ExtractSource_StopAPI_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ExtractSource_GetFailedFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFailedFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtractSourceServer).GetFailedFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extractsource.ExtractSource/GetFailedFiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtractSourceServer).GetFailedFiles(ctx, req.(*GetFailedFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtractSource_RequeueFailedFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequeueFailedFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtractSourceServer).RequeueFailedFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/extractsource.ExtractSource/RequeueFailedFiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtractSourceServer).RequeueFailedFiles(ctx, req.(*RequeueFailedFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ExtractSource_ServiceDesc is the grpc.ServiceDesc for ExtractSource service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -338,6 +402,14 @@ var ExtractSource_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StopAPI",
 			Handler:    _ExtractSource_StopAPI_Handler,
 		},
+		{
+			MethodName: "GetFailedFiles",
+			Handler:    _ExtractSource_GetFailedFiles_Handler,
+		},
+		{
+			MethodName: "RequeueFailedFiles",
+			Handler:    _ExtractSource_RequeueFailedFiles_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
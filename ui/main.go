@@ -1,10 +1,12 @@
 package main
 
 import (
+	b64 "encoding/base64"
 	"flag"
 	"fmt"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/churrodata/churro/api/v1alpha1"
@@ -62,6 +64,35 @@ func main() {
 		src.Host = "svc-memsql-cluster-ddl"
 		src.Port = 3306
 		src.Database = "memsql"
+	case domain.DatabasePostgres:
+		src.Username = os.Getenv("POSTGRES_USER")
+		// source passwords are base64 encoded as they are in the
+		// pipeline CR
+		src.Password = b64.StdEncoding.EncodeToString([]byte(os.Getenv("POSTGRES_PASSWORD")))
+		src.Host = os.Getenv("POSTGRES_HOST")
+		src.Port, err = strconv.Atoi(os.Getenv("POSTGRES_PORT"))
+		if err != nil {
+			src.Port = 5432
+		}
+		src.Externaldatabase = os.Getenv("POSTGRES_DB")
+		src.Database = "public"
+	case domain.DatabaseClickhouse:
+		src.Username = os.Getenv("CLICKHOUSE_USER")
+		// source passwords are base64 encoded as they are in the
+		// pipeline CR
+		src.Password = b64.StdEncoding.EncodeToString([]byte(os.Getenv("CLICKHOUSE_PASSWORD")))
+		src.Host = os.Getenv("CLICKHOUSE_HOST")
+		src.Port, err = strconv.Atoi(os.Getenv("CLICKHOUSE_PORT"))
+		if err != nil {
+			src.Port = 9000
+		}
+		src.Database = "default"
+	case domain.DatabaseSqlite:
+		src.Path = os.Getenv("SQLITE_PATH")
+		if src.Path == "" {
+			src.Path = "/admindb"
+		}
+		src.Database = "churro"
 	default:
 		fmt.Printf("error:  unsupported DATABASE_TYPE env var  value\n")
 		os.Exit(1)
@@ -169,11 +200,13 @@ func main() {
 	r.HandleFunc("/pipelines/{id}/tfunctions/{tfid}/tfunction", u.UpdateTransformFunction).Methods("POST")
 	r.HandleFunc("/pipelines/{id}/deletetfunctions/{tfid}", u.DeleteTransformFunction).Methods("GET")
 	r.HandleFunc("/pipelines/{id}/tfunction", u.CreateTransformFunction).Methods("POST")
+	r.HandleFunc("/pipelines/{id}/tfunction/test", u.TestTransformFunction).Methods("POST")
 
 	r.HandleFunc("/pipelines/{id}/extractsources/show-create", u.ShowCreateExtractSource).Methods("GET")
 	r.HandleFunc("/pipelines/{id}/extractsource/{extractsourceid}/uploadfile", u.UploadToExtractSource).Methods("POST")
 	r.HandleFunc("/pipelines/{id}/extractsource/{extractsourceid}/uploadurl", u.UploadURLToExtractSource).Methods("POST")
 	r.HandleFunc("/pipelines/{id}/extractsource/{extractsourceid}/uploadschema", u.UploadSchema).Methods("POST")
+	r.HandleFunc("/pipelines/{id}/extractsource/{extractsourceid}/inferredrules", u.CreateInferredExtractRules).Methods("POST")
 	r.Path("/pipelines/{id}/startextractsource/{extractsourceid}").HandlerFunc(u.StartExtractSource).Methods("GET")
 	r.Path("/pipelines/{id}/stopextractsource/{extractsourceid}").HandlerFunc(u.StopExtractSource).Methods("GET")
 	r.Path("/pipelines/{id}/extractsources/{extractsourceid}").HandlerFunc(u.PipelineExtractSource).Methods("GET")
@@ -192,6 +225,18 @@ func main() {
 	r.Path("/pipelines/{id}/extractsources/{extractsourceid}/extension/{eid}").HandlerFunc(u.Extension).Methods("GET")
 	r.HandleFunc("/pipelines/{id}/extractsources/{extractsourceid}/updateextension/{eid}", u.UpdateExtension).Methods("POST")
 	r.Path("/pipelines/{id}/extractsources/{extractsourceid}/deleteextension/{eid}").HandlerFunc(u.DeleteExtension).Methods("GET")
+	r.HandleFunc("/pipelines/{id}/extractsources/{extractsourceid}/lookup", u.CreateLookup).Methods("POST")
+	r.Path("/pipelines/{id}/extractsources/{extractsourceid}/lookup/show-create").HandlerFunc(u.ShowCreateLookup).Methods("GET")
+	r.Path("/pipelines/{id}/extractsources/{extractsourceid}/lookup/{lid}").HandlerFunc(u.Lookup).Methods("GET")
+	r.HandleFunc("/pipelines/{id}/extractsources/{extractsourceid}/updatelookup/{lid}", u.UpdateLookup).Methods("POST")
+	r.Path("/pipelines/{id}/extractsources/{extractsourceid}/deletelookup/{lid}").HandlerFunc(u.DeleteLookup).Methods("GET")
+	r.HandleFunc("/pipelines/{id}/extractsources/{extractsourceid}/route", u.CreateRoute).Methods("POST")
+	r.Path("/pipelines/{id}/extractsources/{extractsourceid}/route/show-create").HandlerFunc(u.ShowCreateRoute).Methods("GET")
+	r.Path("/pipelines/{id}/extractsources/{extractsourceid}/route/{rid}").HandlerFunc(u.Route).Methods("GET")
+	r.HandleFunc("/pipelines/{id}/extractsources/{extractsourceid}/updateroute/{rid}", u.UpdateRoute).Methods("POST")
+	r.Path("/pipelines/{id}/extractsources/{extractsourceid}/deleteroute/{rid}").HandlerFunc(u.DeleteRoute).Methods("GET")
+	r.Path("/pipelines/{id}/extractsources/{extractsourceid}/requeuefailed").HandlerFunc(u.RequeueFailedFiles).Methods("GET")
+	r.Path("/pipelines/{id}/extractsources/{extractsourceid}/requeuefailed/{fid}").HandlerFunc(u.RequeueFailedFiles).Methods("GET")
 
 	r.Path("/pipelines/{id}/users/show").HandlerFunc(u.ShowPipelineUsers).Methods("GET")
 	r.HandleFunc("/pipelines/{id}/users/add", u.UpdatePipelineUsers).Methods("POST")